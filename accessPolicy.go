@@ -0,0 +1,107 @@
+package srclient
+
+import (
+	"fmt"
+	"path"
+)
+
+// AccessPolicy restricts the subjects a client may read and write, as a
+// second line of defense when the registry ACLs are coarse. Patterns
+// are globs as understood by path.Match, such as "orders-*", invalid
+// patterns match no subject.
+type AccessPolicy struct {
+	// Read lists the subjects the client may read, empty allows all.
+	Read []string
+	// Write lists the subjects the client may write, empty allows all.
+	Write []string
+	// Deny lists the subjects the client may neither read nor write, it
+	// overrides Read and Write.
+	Deny []string
+}
+
+// PolicyError is returned when a subject operation isn't allowed by the
+// access policy of the client. No request is sent to the registry.
+// Subject is empty for the global settings, which the clients confined
+// to some subjects, by a subject prefix or the Write list of their
+// policy, may not change as they apply to the subjects of every tenant.
+type PolicyError struct {
+	Subject string
+	Write   bool
+}
+
+func (e *PolicyError) Error() string {
+	access := "reading"
+	if e.Write {
+		access = "writing"
+	}
+	if e.Subject == "" {
+		return fmt.Sprintf("access policy doesn't allow %s the global settings", access)
+	}
+	return fmt.Sprintf("access policy doesn't allow %s subject %s", access, e.Subject)
+}
+
+// WithAccessPolicy enforces the policy on every subject operation of
+// the client. Subject listings and searches leave out the subjects it
+// may not read. Schemas read by ID aren't restricted.
+func WithAccessPolicy(policy AccessPolicy) Option {
+	return func(options *clientOptions) {
+		options.accessPolicy = &policy
+	}
+}
+
+// allows tells if the policy allows reading or writing the subject.
+func (policy *AccessPolicy) allows(subject string, write bool) bool {
+	if policy == nil {
+		return true
+	}
+	if matchesAny(policy.Deny, subject) {
+		return false
+	}
+	allowed := policy.Read
+	if write {
+		allowed = policy.Write
+	}
+	return len(allowed) == 0 || matchesAny(allowed, subject)
+}
+
+func matchesAny(patterns []string, subject string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := path.Match(pattern, subject); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// authorize returns a PolicyError if the access policy doesn't allow
+// reading or writing the subject.
+func (client *SchemaRegistryClient) authorize(subject string, write bool) error {
+	if !client.accessPolicy.allows(subject, write) {
+		return &PolicyError{Subject: subject, Write: write}
+	}
+	return nil
+}
+
+// authorizeGlobal returns a PolicyError with an empty subject if the
+// client is confined to some subjects, by a subject prefix or the Write
+// list of its access policy, and may then not change global settings.
+func (client *SchemaRegistryClient) authorizeGlobal() error {
+	if client.subjectPrefix != "" || (client.accessPolicy != nil && len(client.accessPolicy.Write) > 0) {
+		return &PolicyError{Write: true}
+	}
+	return nil
+}
+
+// readableSubjects leaves out the subjects the client may not read.
+func (client *SchemaRegistryClient) readableSubjects(subjects []string) []string {
+	if client.accessPolicy == nil {
+		return subjects
+	}
+	readable := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		if client.accessPolicy.allows(subject, false) {
+			readable = append(readable, subject)
+		}
+	}
+	return readable
+}
@@ -0,0 +1,138 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAccessPolicy_Allows(t *testing.T) {
+	t.Parallel()
+	policy := &AccessPolicy{
+		Read:  []string{"orders-*", "shared-*"},
+		Write: []string{"orders-*"},
+		Deny:  []string{"orders-internal-*"},
+	}
+	tests := map[string]struct {
+		subject  string
+		write    bool
+		noPolicy bool
+		expected bool
+	}{
+		"read allowed":         {subject: "orders-value", expected: true},
+		"write allowed":        {subject: "orders-value", write: true, expected: true},
+		"read only":            {subject: "shared-value", expected: true},
+		"write not allowed":    {subject: "shared-value", write: true, expected: false},
+		"read not allowed":     {subject: "payments-value", expected: false},
+		"denied read":          {subject: "orders-internal-value", expected: false},
+		"denied write":         {subject: "orders-internal-value", write: true, expected: false},
+		"invalid pattern":      {subject: "[", expected: false},
+		"no policy allows all": {subject: "payments-value", write: true, noPolicy: true, expected: true},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			tested := policy
+			if testData.noPolicy {
+				tested = nil
+			}
+
+			assert.Equal(t, testData.expected, tested.allows(testData.subject, testData.write))
+		})
+	}
+}
+
+func TestSchemaRegistryClient_AccessPolicy(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		if req.URL.Path == "/subjects" {
+			_ = json.NewEncoder(rw).Encode([]string{"orders-value", "payments-value", "shared-value"})
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Version: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithAccessPolicy(AccessPolicy{
+		Read:  []string{"orders-*", "shared-*"},
+		Write: []string{"orders-*"},
+	}))
+
+	// Act
+	subjects, subjectsErr := srClient.GetSubjects(context.Background())
+	_, readErr := srClient.GetLatestSchema(context.Background(), "shared-value")
+	_, writeErr := srClient.CreateSchema(context.Background(), "shared-value", testSchema1, Avro)
+	_, deleteErr := srClient.DeleteSubject(context.Background(), "payments-value", false)
+
+	// Assert
+	require.NoError(t, subjectsErr)
+	require.NoError(t, readErr)
+	assert.Equal(t, []string{"orders-value", "shared-value"}, subjects)
+	var policyErr *PolicyError
+	require.True(t, errors.As(writeErr, &policyErr))
+	assert.Equal(t, PolicyError{Subject: "shared-value", Write: true}, *policyErr)
+	require.True(t, errors.As(deleteErr, &policyErr))
+	assert.Equal(t, PolicyError{Subject: "payments-value", Write: true}, *policyErr)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestSchemaRegistryClient_ChangeGlobalCompatibilityLevelConfined(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		options []Option
+		refused bool
+	}{
+		"unconfined": {},
+		"subject prefix": {
+			options: []Option{WithSubjectPrefix("team-a.")},
+			refused: true,
+		},
+		"write allowlist": {
+			options: []Option{WithAccessPolicy(AccessPolicy{Write: []string{"orders-*"}})},
+			refused: true,
+		},
+		"read allowlist": {
+			options: []Option{WithAccessPolicy(AccessPolicy{Read: []string{"orders-*"}})},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				_ = json.NewEncoder(rw).Encode(configChangeResponse{CompatibilityLevel: Full})
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL, testData.options...)
+
+			// Act
+			_, err := srClient.ChangeGlobalCompatibilityLevel(context.Background(), Full)
+
+			// Assert
+			if !testData.refused {
+				assert.NoError(t, err)
+				return
+			}
+			var policyErr *PolicyError
+			require.True(t, errors.As(err, &policyErr))
+			assert.Equal(t, PolicyError{Write: true}, *policyErr)
+			assert.Equal(t, "access policy doesn't allow writing the global settings", err.Error())
+			assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+)
+
+// ErrNotApproved is returned when the approver denied an operation.
+var ErrNotApproved = errors.New("operation not approved")
+
+// ApprovalRequest describes a destructive operation waiting for approval.
+type ApprovalRequest struct {
+	Operation AuditOperation
+	Subject   string
+	// Version is the version to delete, zero for whole subjects.
+	Version       int
+	Compatibility CompatibilityLevel
+	Permanent     bool
+}
+
+// Approver decides if a destructive operation may go ahead, returning
+// nil to approve it or an error explaining the denial. It may block
+// until an external approval system answers, within the context.
+type Approver func(ctx context.Context, request ApprovalRequest) error
+
+// WithApprover gates the destructive operations, permanent deletes and
+// setting the compatibility of a subject to NONE, behind the approver:
+// they fail with ErrNotApproved before any request is sent to the
+// registry unless it approves them.
+func WithApprover(approver Approver) Option {
+	return func(options *clientOptions) {
+		options.approver = approver
+	}
+}
+
+// approve asks the approver, if any, for the permission to go ahead.
+func (client *SchemaRegistryClient) approve(ctx context.Context, request ApprovalRequest) error {
+	if client.approver == nil {
+		return nil
+	}
+	if err := client.approver(ctx, request); err != nil {
+		return fmt.Errorf("%w: %s", ErrNotApproved, err)
+	}
+	return nil
+}
+
+// approveDelete asks for the approval of permanent deletes.
+func (client *SchemaRegistryClient) approveDelete(ctx context.Context, request ApprovalRequest) error {
+	if !request.Permanent {
+		return nil
+	}
+	return client.approve(ctx, request)
+}
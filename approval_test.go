@@ -0,0 +1,123 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_Approver(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		call        func(client *SchemaRegistryClient) error
+		needsReview bool
+		expected    ApprovalRequest
+	}{
+		"permanent subject delete": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.DeleteSubject(context.Background(), "cupcakes-value", true)
+				return err
+			},
+			needsReview: true,
+			expected:    ApprovalRequest{Operation: AuditDeleteSubject, Subject: "cupcakes-value", Permanent: true},
+		},
+		"permanent version delete": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.DeleteSubjectByVersion(context.Background(), "cupcakes-value", 2, true)
+				return err
+			},
+			needsReview: true,
+			expected:    ApprovalRequest{Operation: AuditDeleteVersion, Subject: "cupcakes-value", Version: 2, Permanent: true},
+		},
+		"compatibility set to none": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.ChangeSubjectCompatibilityLevel(context.Background(), "cupcakes-value", None)
+				return err
+			},
+			needsReview: true,
+			expected:    ApprovalRequest{Operation: AuditChangeCompatibility, Subject: "cupcakes-value", Compatibility: None},
+		},
+		"global compatibility set to none": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.ChangeGlobalCompatibilityLevel(context.Background(), None)
+				return err
+			},
+			needsReview: true,
+			expected:    ApprovalRequest{Operation: AuditChangeCompatibility, Compatibility: None},
+		},
+		"soft delete": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.DeleteSubject(context.Background(), "cupcakes-value", false)
+				return err
+			},
+		},
+		"compatibility set to full": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.ChangeSubjectCompatibilityLevel(context.Background(), "cupcakes-value", Full)
+				return err
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				if req.Method == http.MethodPut {
+					_ = json.NewEncoder(rw).Encode(configChangeResponse{CompatibilityLevel: None})
+					return
+				}
+				_ = json.NewEncoder(rw).Encode([]int{1})
+			}))
+			defer server.Close()
+			var requests []ApprovalRequest
+			srClient := NewClient(server.URL, WithApprover(func(ctx context.Context, request ApprovalRequest) error {
+				requests = append(requests, request)
+				return errors.New("rejected by the on-call reviewer")
+			}))
+
+			// Act
+			err := testData.call(srClient)
+
+			// Assert
+			if !testData.needsReview {
+				assert.NoError(t, err)
+				assert.Empty(t, requests)
+				return
+			}
+			require.ErrorIs(t, err, ErrNotApproved)
+			assert.Contains(t, err.Error(), "rejected by the on-call reviewer")
+			assert.Equal(t, []ApprovalRequest{testData.expected}, requests)
+			assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestSchemaRegistryClient_ApproverApproves(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(rw).Encode([]int{1})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithApprover(func(ctx context.Context, request ApprovalRequest) error {
+		return nil
+	}))
+
+	_, err := srClient.DeleteSubject(context.Background(), "cupcakes-value", true)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,126 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+)
+
+// AuditOperation is the kind of change made to the registry.
+type AuditOperation string
+
+const (
+	AuditRegisterSchema      AuditOperation = "REGISTER_SCHEMA"
+	AuditDeleteSubject       AuditOperation = "DELETE_SUBJECT"
+	AuditDeleteVersion       AuditOperation = "DELETE_VERSION"
+	AuditChangeCompatibility AuditOperation = "CHANGE_COMPATIBILITY"
+	AuditResetConfig         AuditOperation = "RESET_CONFIG"
+	AuditChangeMode          AuditOperation = "CHANGE_MODE"
+)
+
+// AuditEvent describes a change made to the registry through the client.
+type AuditEvent struct {
+	Time      time.Time
+	Operation AuditOperation
+	// Principal is the username the client authenticates with, empty
+	// with bearer tokens or without authentication.
+	Principal string
+	Subject   string
+	// Version is the version deleted or registered, when known.
+	Version int
+	// SchemaID is the ID of the schema registered.
+	SchemaID      int
+	Compatibility CompatibilityLevel
+	Mode          Mode
+	Permanent     bool
+	// Err is the error the change failed with, nil on success.
+	Err error
+}
+
+// AuditSink records the changes made to the registry, it may log them,
+// write them to a file or produce them to a Kafka topic. Record is
+// called after every change, successful or not, and must not block
+// for long.
+type AuditSink interface {
+	Record(ctx context.Context, event AuditEvent)
+}
+
+// AuditSinkFunc adapts a function to the AuditSink interface.
+type AuditSinkFunc func(ctx context.Context, event AuditEvent)
+
+func (f AuditSinkFunc) Record(ctx context.Context, event AuditEvent) {
+	f(ctx, event)
+}
+
+// WithAuditSink records every schema registration, deletion and
+// configuration change made through the client in the sink.
+func WithAuditSink(sink AuditSink) Option {
+	return func(options *clientOptions) {
+		options.auditSink = sink
+	}
+}
+
+type jsonAuditSink struct {
+	lock    sync.Mutex
+	encoder *json.Encoder
+}
+
+type jsonAuditEvent struct {
+	Time          time.Time          `json:"time"`
+	Operation     AuditOperation     `json:"operation"`
+	Principal     string             `json:"principal,omitempty"`
+	Subject       string             `json:"subject,omitempty"`
+	Version       int                `json:"version,omitempty"`
+	SchemaID      int                `json:"id,omitempty"`
+	Compatibility CompatibilityLevel `json:"compatibility,omitempty"`
+	Mode          Mode               `json:"mode,omitempty"`
+	Permanent     bool               `json:"permanent,omitempty"`
+	Outcome       string             `json:"outcome"`
+	Error         string             `json:"error,omitempty"`
+}
+
+// NewJSONAuditSink returns a sink writing the events to w as JSON, one
+// per line. Write errors are ignored.
+func NewJSONAuditSink(w io.Writer) AuditSink {
+	return &jsonAuditSink{encoder: json.NewEncoder(w)}
+}
+
+func (sink *jsonAuditSink) Record(ctx context.Context, event AuditEvent) {
+	line := jsonAuditEvent{
+		Time:          event.Time,
+		Operation:     event.Operation,
+		Principal:     event.Principal,
+		Subject:       event.Subject,
+		Version:       event.Version,
+		SchemaID:      event.SchemaID,
+		Compatibility: event.Compatibility,
+		Mode:          event.Mode,
+		Permanent:     event.Permanent,
+		Outcome:       "SUCCESS",
+	}
+	if event.Err != nil {
+		line.Outcome = "FAILURE"
+		line.Error = event.Err.Error()
+	}
+
+	sink.lock.Lock()
+	defer sink.lock.Unlock()
+	_ = sink.encoder.Encode(line)
+}
+
+// audit records a change made to the registry, if there is a sink.
+func (client *SchemaRegistryClient) audit(ctx context.Context, event AuditEvent, err error) {
+	if client.auditSink == nil {
+		return
+	}
+	event.Time = client.now()
+	event.Err = err
+	client.credsLock.RLock()
+	if client.credentials != nil {
+		event.Principal = client.credentials.username
+	}
+	client.credsLock.RUnlock()
+	client.auditSink.Record(ctx, event)
+}
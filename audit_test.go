@@ -0,0 +1,95 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_AuditSink(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, "/subjects/forbidden"):
+			rw.WriteHeader(http.StatusForbidden)
+			_ = json.NewEncoder(rw).Encode(Error{Code: 40301, Message: "forbidden"})
+		case req.Method == http.MethodDelete && strings.HasPrefix(req.URL.Path, "/config/"):
+			_ = json.NewEncoder(rw).Encode(Config{CompatibilityLevel: Full})
+		case req.Method == http.MethodDelete && strings.Contains(req.URL.Path, "/versions/"):
+			_ = json.NewEncoder(rw).Encode(2)
+		case req.Method == http.MethodDelete:
+			_ = json.NewEncoder(rw).Encode([]int{1})
+		case req.Method == http.MethodPut:
+			_ = json.NewEncoder(rw).Encode(configChangeResponse{CompatibilityLevel: Full})
+		default:
+			_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 3, Version: 4, Schema: testSchema1})
+		}
+	}))
+	defer server.Close()
+
+	var lock sync.Mutex
+	var events []AuditEvent
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	srClient := NewClient(server.URL,
+		WithBasicAuth("admin", "secret"),
+		WithClock(clock),
+		WithAuditSink(AuditSinkFunc(func(ctx context.Context, event AuditEvent) {
+			lock.Lock()
+			defer lock.Unlock()
+			events = append(events, event)
+		})),
+	)
+
+	// Act
+	_, err := srClient.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	_, err = srClient.ChangeSubjectCompatibilityLevel(context.Background(), "cupcakes-value", Full)
+	require.NoError(t, err)
+	_, err = srClient.ResetSubjectConfig(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	_, err = srClient.DeleteSubjectByVersion(context.Background(), "cupcakes-value", 2, false)
+	require.NoError(t, err)
+	_, err = srClient.DeleteSubject(context.Background(), "cupcakes-value", true)
+	require.NoError(t, err)
+	_, deleteErr := srClient.DeleteSubject(context.Background(), "forbidden", false)
+	_, err = srClient.GetSchema(context.Background(), 3)
+	require.NoError(t, err)
+
+	// Assert
+	require.Error(t, deleteErr)
+	start := clock.Now()
+	assert.Equal(t, []AuditEvent{
+		{Time: start, Operation: AuditRegisterSchema, Principal: "admin", Subject: "cupcakes-value", SchemaID: 3, Version: 4},
+		{Time: start, Operation: AuditChangeCompatibility, Principal: "admin", Subject: "cupcakes-value", Compatibility: Full},
+		{Time: start, Operation: AuditResetConfig, Principal: "admin", Subject: "cupcakes-value"},
+		{Time: start, Operation: AuditDeleteVersion, Principal: "admin", Subject: "cupcakes-value", Version: 2},
+		{Time: start, Operation: AuditDeleteSubject, Principal: "admin", Subject: "cupcakes-value", Permanent: true},
+		{Time: start, Operation: AuditDeleteSubject, Principal: "admin", Subject: "forbidden", Err: deleteErr},
+	}, events)
+}
+
+func TestJSONAuditSink(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	sink := NewJSONAuditSink(&out)
+	at := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	sink.Record(context.Background(), AuditEvent{Time: at, Operation: AuditDeleteSubject, Principal: "admin", Subject: "cupcakes-value", Permanent: true})
+	sink.Record(context.Background(), AuditEvent{Time: at, Operation: AuditRegisterSchema, Subject: "cupcakes-value", Err: errors.New("incompatible")})
+
+	lines := strings.Split(strings.TrimSpace(out.String()), "\n")
+	require.Len(t, lines, 2)
+	assert.JSONEq(t, `{"time": "2024-01-01T00:00:00Z", "operation": "DELETE_SUBJECT", "principal": "admin", "subject": "cupcakes-value", "permanent": true, "outcome": "SUCCESS"}`, lines[0])
+	assert.JSONEq(t, `{"time": "2024-01-01T00:00:00Z", "operation": "REGISTER_SCHEMA", "subject": "cupcakes-value", "outcome": "FAILURE", "error": "incompatible"}`, lines[1])
+}
@@ -0,0 +1,873 @@
+package srclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"path"
+	"strings"
+	"unicode"
+)
+
+// AvroIDLSchema is a named type declared by an Avro IDL file, Schema is
+// its JSON schema with the types it uses inlined.
+type AvroIDLSchema struct {
+	Name   string
+	Schema string
+}
+
+// CompileAvroIDL translates an Avro IDL protocol, or an Avro IDL schema
+// file with a namespace and schema declaration, into the JSON schemas of
+// its named types, like the idl2schemata command of the Avro tools, in
+// the order they are declared. Messages are ignored.
+//
+// importFile reads the files imported by import idl, import schema and
+// import protocol statements, given their path relative to the file
+// compiled. Imports fail when it is nil.
+func CompileAvroIDL(idl string, importFile func(path string) (string, error)) ([]AvroIDLSchema, error) {
+	compiler := &avroIDLCompiler{
+		importFile: importFile,
+		byName:     make(map[string]*avroIDLType),
+		imported:   make(map[string]bool),
+	}
+	if err := compiler.compile(idl, ""); err != nil {
+		return nil, err
+	}
+
+	schemas := make([]AvroIDLSchema, 0, len(compiler.types))
+	for _, named := range compiler.types {
+		emitted, err := compiler.emit(named, make(map[string]bool))
+		if err != nil {
+			return nil, err
+		}
+		var schema bytes.Buffer
+		encoder := json.NewEncoder(&schema)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(emitted); err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, AvroIDLSchema{Name: named.name, Schema: strings.TrimSuffix(schema.String(), "\n")})
+	}
+	return schemas, nil
+}
+
+// avroIDLCompiler holds the named types of an IDL file and of its imports.
+type avroIDLCompiler struct {
+	importFile func(path string) (string, error)
+	types      []*avroIDLType
+	byName     map[string]*avroIDLType
+	imported   map[string]bool
+}
+
+// avroIDLType is a named type, its schema is an avroObject for the types
+// declared in IDL, the decoded JSON for the imported ones.
+type avroIDLType struct {
+	name   string
+	schema interface{}
+	raw    bool
+}
+
+// avroIDLRef is a reference to a named type, resolved once every type
+// is declared.
+type avroIDLRef struct {
+	name      string
+	namespace string
+}
+
+// avroObject is a JSON object keeping the order of its members.
+type avroObject []avroMember
+
+type avroMember struct {
+	key   string
+	value interface{}
+}
+
+func (object avroObject) MarshalJSON() ([]byte, error) {
+	var buffer bytes.Buffer
+	buffer.WriteByte('{')
+	for i, member := range object {
+		if i > 0 {
+			buffer.WriteByte(',')
+		}
+		encoder := json.NewEncoder(&buffer)
+		encoder.SetEscapeHTML(false)
+		if err := encoder.Encode(member.key); err != nil {
+			return nil, err
+		}
+		buffer.Truncate(buffer.Len() - 1)
+		buffer.WriteByte(':')
+		if err := encoder.Encode(member.value); err != nil {
+			return nil, err
+		}
+		buffer.Truncate(buffer.Len() - 1)
+	}
+	buffer.WriteByte('}')
+	return buffer.Bytes(), nil
+}
+
+func (compiler *avroIDLCompiler) declare(named *avroIDLType) error {
+	if _, ok := compiler.byName[named.name]; ok {
+		return fmt.Errorf("avro idl: type %s declared twice", named.name)
+	}
+	compiler.byName[named.name] = named
+	compiler.types = append(compiler.types, named)
+	return nil
+}
+
+func (compiler *avroIDLCompiler) resolve(ref avroIDLRef) (*avroIDLType, error) {
+	candidates := []string{ref.name}
+	if !strings.Contains(ref.name, ".") && ref.namespace != "" {
+		candidates = []string{ref.namespace + "." + ref.name, ref.name}
+	}
+	for _, name := range candidates {
+		if named, ok := compiler.byName[name]; ok {
+			return named, nil
+		}
+	}
+	return nil, fmt.Errorf("avro idl: undefined type %s", ref.name)
+}
+
+// emit turns a type into its JSON schema, the named types being inlined
+// the first time they are used and referenced by name afterwards.
+func (compiler *avroIDLCompiler) emit(value interface{}, emitted map[string]bool) (interface{}, error) {
+	switch typed := value.(type) {
+	case avroIDLRef:
+		named, err := compiler.resolve(typed)
+		if err != nil {
+			return nil, err
+		}
+		return compiler.emit(named, emitted)
+	case *avroIDLType:
+		if emitted[typed.name] {
+			return typed.name, nil
+		}
+		emitted[typed.name] = true
+		if typed.raw {
+			nested := make(map[string]bool)
+			avroNamedTypes(typed.schema, "", nested, make(map[string]bool))
+			for name := range nested {
+				emitted[name] = true
+			}
+			return typed.schema, nil
+		}
+		return compiler.emit(typed.schema, emitted)
+	case avroObject:
+		object := make(avroObject, len(typed))
+		for i, member := range typed {
+			converted, err := compiler.emit(member.value, emitted)
+			if err != nil {
+				return nil, err
+			}
+			object[i] = avroMember{key: member.key, value: converted}
+		}
+		return object, nil
+	case []interface{}:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			converted, err := compiler.emit(item, emitted)
+			if err != nil {
+				return nil, err
+			}
+			items[i] = converted
+		}
+		return items, nil
+	}
+	return value, nil
+}
+
+// compile declares the named types of an IDL file, file being its path
+// relative to the file compiled first.
+func (compiler *avroIDLCompiler) compile(idl, file string) error {
+	tokens, err := tokenizeAvroIDL(idl)
+	if err != nil {
+		return err
+	}
+	parser := &avroIDLParser{compiler: compiler, tokens: tokens, file: file}
+	return parser.parseFile()
+}
+
+// importRaw declares the named types of an imported JSON schema or
+// protocol.
+func (compiler *avroIDLCompiler) importRaw(content string, protocol bool) error {
+	var document interface{}
+	if err := json.Unmarshal([]byte(content), &document); err != nil {
+		return fmt.Errorf("avro idl: %w", err)
+	}
+	schemas := []interface{}{document}
+	namespace := ""
+	if protocol {
+		object, _ := document.(map[string]interface{})
+		namespace, _ = object["namespace"].(string)
+		schemas, _ = object["types"].([]interface{})
+	}
+	for _, schema := range schemas {
+		object, ok := schema.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, ok := object["name"].(string)
+		if !ok {
+			continue
+		}
+		if ns, ok := object["namespace"].(string); ok {
+			namespace = ns
+		}
+		if err := compiler.declare(&avroIDLType{name: avroFullName(name, namespace), schema: schema, raw: true}); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+type avroIDLTokenKind int
+
+const (
+	idlEOF avroIDLTokenKind = iota
+	idlIdent
+	idlString
+	idlNumber
+	idlPunct
+)
+
+type avroIDLToken struct {
+	kind avroIDLTokenKind
+	text string
+	// doc is the doc comment preceding the token
+	doc  string
+	line int
+}
+
+func tokenizeAvroIDL(idl string) ([]avroIDLToken, error) {
+	var tokens []avroIDLToken
+	line, doc := 1, ""
+	for i := 0; i < len(idl); {
+		c := idl[i]
+		switch {
+		case c == '\n':
+			line++
+			i++
+		case c == ' ' || c == '\t' || c == '\r':
+			i++
+		case strings.HasPrefix(idl[i:], "//"):
+			for i < len(idl) && idl[i] != '\n' {
+				i++
+			}
+		case strings.HasPrefix(idl[i:], "/*"):
+			end := strings.Index(idl[i+2:], "*/")
+			if end < 0 {
+				return nil, fmt.Errorf("avro idl: line %d: unterminated comment", line)
+			}
+			comment := idl[i : i+2+end+2]
+			if strings.HasPrefix(comment, "/**") && comment != "/**/" {
+				doc = avroIDLDoc(comment)
+			}
+			line += strings.Count(comment, "\n")
+			i += len(comment)
+		case c == '"':
+			end := i + 1
+			for end < len(idl) && idl[end] != '"' {
+				if idl[end] == '\\' {
+					end++
+				}
+				end++
+			}
+			if end >= len(idl) {
+				return nil, fmt.Errorf("avro idl: line %d: unterminated string", line)
+			}
+			var text string
+			if err := json.Unmarshal([]byte(idl[i:end+1]), &text); err != nil {
+				return nil, fmt.Errorf("avro idl: line %d: %w", line, err)
+			}
+			tokens = append(tokens, avroIDLToken{kind: idlString, text: text, doc: doc, line: line})
+			doc = ""
+			i = end + 1
+		case c == '`':
+			end := strings.IndexByte(idl[i+1:], '`')
+			if end < 0 {
+				return nil, fmt.Errorf("avro idl: line %d: unterminated identifier", line)
+			}
+			tokens = append(tokens, avroIDLToken{kind: idlIdent, text: idl[i+1 : i+1+end], doc: doc, line: line})
+			doc = ""
+			i += end + 2
+		case c == '-' || c == '+' || (c >= '0' && c <= '9'):
+			end := i + 1
+			for end < len(idl) && strings.IndexByte("0123456789.eE+-", idl[end]) >= 0 {
+				end++
+			}
+			tokens = append(tokens, avroIDLToken{kind: idlNumber, text: idl[i:end], doc: doc, line: line})
+			doc = ""
+			i = end
+		case c == '_' || unicode.IsLetter(rune(c)):
+			end := i + 1
+			for end < len(idl) && (idl[end] == '_' || idl[end] == '.' || idl[end] == '-' ||
+				unicode.IsLetter(rune(idl[end])) || unicode.IsDigit(rune(idl[end]))) {
+				end++
+			}
+			tokens = append(tokens, avroIDLToken{kind: idlIdent, text: idl[i:end], doc: doc, line: line})
+			doc = ""
+			i = end
+		case strings.IndexByte("{}()[]<>,;=@?:", c) >= 0:
+			tokens = append(tokens, avroIDLToken{kind: idlPunct, text: string(c), doc: doc, line: line})
+			doc = ""
+			i++
+		default:
+			return nil, fmt.Errorf("avro idl: line %d: unexpected character %q", line, c)
+		}
+	}
+	return append(tokens, avroIDLToken{kind: idlEOF, line: line}), nil
+}
+
+// avroIDLDoc extracts the text of a doc comment.
+func avroIDLDoc(comment string) string {
+	lines := strings.Split(strings.TrimSuffix(strings.TrimPrefix(comment, "/**"), "*/"), "\n")
+	for i, line := range lines {
+		line = strings.TrimSpace(line)
+		lines[i] = strings.TrimSpace(strings.TrimPrefix(line, "*"))
+	}
+	return strings.TrimSpace(strings.Join(lines, "\n"))
+}
+
+type avroIDLParser struct {
+	compiler  *avroIDLCompiler
+	tokens    []avroIDLToken
+	pos       int
+	file      string
+	namespace string
+}
+
+func (parser *avroIDLParser) peek() avroIDLToken {
+	return parser.tokens[parser.pos]
+}
+
+func (parser *avroIDLParser) next() avroIDLToken {
+	token := parser.tokens[parser.pos]
+	if token.kind != idlEOF {
+		parser.pos++
+	}
+	return token
+}
+
+func (parser *avroIDLParser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("avro idl: line %d: %s", parser.peek().line, fmt.Sprintf(format, args...))
+}
+
+// accept consumes the next token if it is the punctuation or keyword.
+func (parser *avroIDLParser) accept(text string) bool {
+	token := parser.peek()
+	if (token.kind == idlPunct || token.kind == idlIdent) && token.text == text {
+		parser.pos++
+		return true
+	}
+	return false
+}
+
+func (parser *avroIDLParser) expect(text string) error {
+	if !parser.accept(text) {
+		return parser.errorf("expected %s, got %q", text, parser.peek().text)
+	}
+	return nil
+}
+
+func (parser *avroIDLParser) ident() (string, error) {
+	token := parser.next()
+	if token.kind != idlIdent {
+		return "", fmt.Errorf("avro idl: line %d: expected a name, got %q", token.line, token.text)
+	}
+	return token.text, nil
+}
+
+// parseFile parses a protocol, or the declarations of a schema file.
+func (parser *avroIDLParser) parseFile() error {
+	properties, err := parser.annotations()
+	if err != nil {
+		return err
+	}
+	if parser.accept("protocol") {
+		if namespace, ok := avroProperty(properties, "namespace").(string); ok {
+			parser.namespace = namespace
+		}
+		if _, err := parser.ident(); err != nil {
+			return err
+		}
+		if err := parser.expect("{"); err != nil {
+			return err
+		}
+		for !parser.accept("}") {
+			if parser.peek().kind == idlEOF {
+				return parser.errorf("unterminated protocol")
+			}
+			if err := parser.declaration(); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+
+	if len(properties) > 0 {
+		return parser.errorf("unexpected annotations")
+	}
+	if parser.accept("namespace") {
+		namespace, err := parser.ident()
+		if err != nil {
+			return err
+		}
+		parser.namespace = namespace
+		if err := parser.expect(";"); err != nil {
+			return err
+		}
+	}
+	if parser.accept("schema") {
+		// The main schema is one of the types declared, or a type using them
+		if _, err := parser.parseType(); err != nil {
+			return err
+		}
+		if err := parser.expect(";"); err != nil {
+			return err
+		}
+	}
+	for parser.peek().kind != idlEOF {
+		if err := parser.declaration(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// annotations parses the @name(value) annotations, in order.
+func (parser *avroIDLParser) annotations() (avroObject, error) {
+	var properties avroObject
+	for parser.accept("@") {
+		name, err := parser.ident()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expect("("); err != nil {
+			return nil, err
+		}
+		value, err := parser.jsonValue()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expect(")"); err != nil {
+			return nil, err
+		}
+		properties = append(properties, avroMember{key: name, value: value})
+	}
+	return properties, nil
+}
+
+func avroProperty(properties avroObject, key string) interface{} {
+	for _, member := range properties {
+		if member.key == key {
+			return member.value
+		}
+	}
+	return nil
+}
+
+func (parser *avroIDLParser) declaration() error {
+	doc := parser.peek().doc
+	properties, err := parser.annotations()
+	if err != nil {
+		return err
+	}
+	if doc == "" && len(properties) > 0 {
+		doc = parser.peek().doc
+	}
+	keyword := parser.peek()
+	if keyword.kind != idlIdent {
+		return parser.errorf("unexpected %q", keyword.text)
+	}
+	switch keyword.text {
+	case "import":
+		parser.next()
+		return parser.importFile()
+	case "record", "error":
+		parser.next()
+		return parser.record(keyword.text, doc, properties)
+	case "enum":
+		parser.next()
+		return parser.enum(doc, properties)
+	case "fixed":
+		parser.next()
+		return parser.fixed(doc, properties)
+	}
+	// Messages aren't part of the schemas
+	depth := 0
+	for {
+		token := parser.next()
+		switch {
+		case token.kind == idlEOF:
+			return parser.errorf("unterminated message")
+		case token.kind == idlPunct && (token.text == "(" || token.text == "{"):
+			depth++
+		case token.kind == idlPunct && (token.text == ")" || token.text == "}"):
+			depth--
+		case token.kind == idlPunct && token.text == ";" && depth == 0:
+			return nil
+		}
+	}
+}
+
+func (parser *avroIDLParser) importFile() error {
+	kind, err := parser.ident()
+	if err != nil {
+		return err
+	}
+	token := parser.next()
+	if token.kind != idlString {
+		return fmt.Errorf("avro idl: line %d: expected the path of the import", token.line)
+	}
+	if err := parser.expect(";"); err != nil {
+		return err
+	}
+	if parser.compiler.importFile == nil {
+		return fmt.Errorf("avro idl: line %d: import %s %q: imports aren't supported", token.line, kind, token.text)
+	}
+	file := path.Join(path.Dir(parser.file), token.text)
+	if parser.compiler.imported[file] {
+		return nil
+	}
+	parser.compiler.imported[file] = true
+	content, err := parser.compiler.importFile(file)
+	if err != nil {
+		return fmt.Errorf("avro idl: import %s: %w", token.text, err)
+	}
+	switch kind {
+	case "idl":
+		return parser.compiler.compile(content, file)
+	case "schema":
+		return parser.compiler.importRaw(content, false)
+	case "protocol":
+		return parser.compiler.importRaw(content, true)
+	}
+	return fmt.Errorf("avro idl: line %d: unknown import kind %s", token.line, kind)
+}
+
+// named starts the schema of a named type.
+func (parser *avroIDLParser) named(kind, doc string, properties avroObject) (avroObject, string, error) {
+	name, err := parser.ident()
+	if err != nil {
+		return nil, "", err
+	}
+	namespace := parser.namespace
+	if ns, ok := avroProperty(properties, "namespace").(string); ok {
+		namespace = ns
+	}
+	fullName := avroFullName(name, namespace)
+	schema := avroObject{{key: "type", value: kind}, {key: "name", value: fullName}}
+	if doc != "" {
+		schema = append(schema, avroMember{key: "doc", value: doc})
+	}
+	for _, property := range properties {
+		if property.key != "namespace" {
+			schema = append(schema, property)
+		}
+	}
+	return schema, fullName, nil
+}
+
+func (parser *avroIDLParser) record(kind, doc string, properties avroObject) error {
+	schema, name, err := parser.named(kind, doc, properties)
+	if err != nil {
+		return err
+	}
+	if err := parser.expect("{"); err != nil {
+		return err
+	}
+	fields := make([]interface{}, 0)
+	for !parser.accept("}") {
+		if parser.peek().kind == idlEOF {
+			return parser.errorf("unterminated record %s", name)
+		}
+		declared, err := parser.fields()
+		if err != nil {
+			return err
+		}
+		fields = append(fields, declared...)
+	}
+	schema = append(schema, avroMember{key: "fields", value: fields})
+	return parser.compiler.declare(&avroIDLType{name: name, schema: schema})
+}
+
+// fields parses a field declaration, which may declare several fields
+// of the same type.
+func (parser *avroIDLParser) fields() ([]interface{}, error) {
+	typeDoc := parser.peek().doc
+	fieldType, err := parser.parseType()
+	if err != nil {
+		return nil, err
+	}
+	var fields []interface{}
+	for {
+		doc := typeDoc
+		if fieldDoc := parser.peek().doc; fieldDoc != "" {
+			doc = fieldDoc
+		}
+		properties, err := parser.annotations()
+		if err != nil {
+			return nil, err
+		}
+		name, err := parser.ident()
+		if err != nil {
+			return nil, err
+		}
+		field := avroObject{{key: "name", value: name}, {key: "type", value: fieldType}}
+		if doc != "" {
+			field = append(field, avroMember{key: "doc", value: doc})
+		}
+		if parser.accept("=") {
+			value, err := parser.jsonValue()
+			if err != nil {
+				return nil, err
+			}
+			if union, ok := fieldType.([]interface{}); ok && len(union) == 2 && union[0] == "null" && value != nil {
+				// Optional types default to null, unless they are given
+				// another default
+				field[1].value = []interface{}{union[1], "null"}
+			}
+			field = append(field, avroMember{key: "default", value: value})
+		}
+		field = append(field, properties...)
+		fields = append(fields, field)
+		if !parser.accept(",") {
+			break
+		}
+	}
+	return fields, parser.expect(";")
+}
+
+func (parser *avroIDLParser) enum(doc string, properties avroObject) error {
+	schema, name, err := parser.named("enum", doc, properties)
+	if err != nil {
+		return err
+	}
+	if err := parser.expect("{"); err != nil {
+		return err
+	}
+	symbols := make([]interface{}, 0)
+	for !parser.accept("}") {
+		symbol, err := parser.ident()
+		if err != nil {
+			return err
+		}
+		symbols = append(symbols, symbol)
+		if !parser.accept(",") {
+			if err := parser.expect("}"); err != nil {
+				return err
+			}
+			break
+		}
+	}
+	schema = append(schema, avroMember{key: "symbols", value: symbols})
+	if parser.accept("=") {
+		symbol, err := parser.ident()
+		if err != nil {
+			return err
+		}
+		schema = append(schema, avroMember{key: "default", value: symbol})
+		if err := parser.expect(";"); err != nil {
+			return err
+		}
+	} else {
+		parser.accept(";")
+	}
+	return parser.compiler.declare(&avroIDLType{name: name, schema: schema})
+}
+
+func (parser *avroIDLParser) fixed(doc string, properties avroObject) error {
+	schema, name, err := parser.named("fixed", doc, properties)
+	if err != nil {
+		return err
+	}
+	size, err := parser.integer()
+	if err != nil {
+		return err
+	}
+	schema = append(schema, avroMember{key: "size", value: size})
+	if err := parser.expect(";"); err != nil {
+		return err
+	}
+	return parser.compiler.declare(&avroIDLType{name: name, schema: schema})
+}
+
+// integer parses a parenthesized integer, as the size of a fixed.
+func (parser *avroIDLParser) integer() (int, error) {
+	if err := parser.expect("("); err != nil {
+		return 0, err
+	}
+	token := parser.next()
+	var value int
+	if _, err := fmt.Sscan(token.text, &value); token.kind != idlNumber || err != nil {
+		return 0, fmt.Errorf("avro idl: line %d: expected an integer, got %q", token.line, token.text)
+	}
+	return value, parser.expect(")")
+}
+
+var avroIDLLogicalTypes = map[string]avroObject{
+	"date":               {{key: "type", value: "int"}, {key: "logicalType", value: "date"}},
+	"time_ms":            {{key: "type", value: "int"}, {key: "logicalType", value: "time-millis"}},
+	"timestamp_ms":       {{key: "type", value: "long"}, {key: "logicalType", value: "timestamp-millis"}},
+	"local_timestamp_ms": {{key: "type", value: "long"}, {key: "logicalType", value: "local-timestamp-millis"}},
+	"uuid":               {{key: "type", value: "string"}, {key: "logicalType", value: "uuid"}},
+}
+
+// parseType parses a type with its annotations, a trailing ? making it
+// optional.
+func (parser *avroIDLParser) parseType() (interface{}, error) {
+	properties, err := parser.annotations()
+	if err != nil {
+		return nil, err
+	}
+	token := parser.next()
+	if token.kind != idlIdent {
+		return nil, fmt.Errorf("avro idl: line %d: expected a type, got %q", token.line, token.text)
+	}
+
+	var schema interface{}
+	switch {
+	case avroPrimitives[token.text]:
+		schema = token.text
+	case avroIDLLogicalTypes[token.text] != nil:
+		schema = append(avroObject(nil), avroIDLLogicalTypes[token.text]...)
+	case token.text == "decimal":
+		if err := parser.expect("("); err != nil {
+			return nil, err
+		}
+		precision, scale := parser.next(), avroIDLToken{text: "0"}
+		if parser.accept(",") {
+			scale = parser.next()
+		}
+		if err := parser.expect(")"); err != nil {
+			return nil, err
+		}
+		var p, s int
+		if _, err := fmt.Sscan(precision.text, &p); err != nil {
+			return nil, fmt.Errorf("avro idl: line %d: invalid decimal precision %q", precision.line, precision.text)
+		}
+		if _, err := fmt.Sscan(scale.text, &s); err != nil {
+			return nil, fmt.Errorf("avro idl: line %d: invalid decimal scale %q", scale.line, scale.text)
+		}
+		schema = avroObject{{key: "type", value: "bytes"}, {key: "logicalType", value: "decimal"}, {key: "precision", value: p}, {key: "scale", value: s}}
+	case token.text == "array" || token.text == "map":
+		if err := parser.expect("<"); err != nil {
+			return nil, err
+		}
+		element, err := parser.parseType()
+		if err != nil {
+			return nil, err
+		}
+		if err := parser.expect(">"); err != nil {
+			return nil, err
+		}
+		key := "items"
+		if token.text == "map" {
+			key = "values"
+		}
+		schema = avroObject{{key: "type", value: token.text}, {key: key, value: element}}
+	case token.text == "union":
+		if err := parser.expect("{"); err != nil {
+			return nil, err
+		}
+		union := make([]interface{}, 0)
+		for {
+			member, err := parser.parseType()
+			if err != nil {
+				return nil, err
+			}
+			union = append(union, member)
+			if !parser.accept(",") {
+				break
+			}
+		}
+		if err := parser.expect("}"); err != nil {
+			return nil, err
+		}
+		schema = union
+	default:
+		schema = avroIDLRef{name: token.text, namespace: parser.namespace}
+	}
+
+	if len(properties) > 0 {
+		switch typed := schema.(type) {
+		case avroObject:
+			schema = append(typed, properties...)
+		case []interface{}:
+			return nil, fmt.Errorf("avro idl: line %d: unions can't be annotated", token.line)
+		default:
+			schema = append(avroObject{{key: "type", value: typed}}, properties...)
+		}
+	}
+	if parser.accept("?") {
+		schema = []interface{}{"null", schema}
+	}
+	return schema, nil
+}
+
+// jsonValue parses a JSON value, as the default of a field or the value
+// of an annotation.
+func (parser *avroIDLParser) jsonValue() (interface{}, error) {
+	token := parser.next()
+	switch token.kind {
+	case idlString:
+		return token.text, nil
+	case idlNumber:
+		var number json.Number
+		if err := json.Unmarshal([]byte(token.text), &number); err != nil {
+			return nil, fmt.Errorf("avro idl: line %d: invalid number %q", token.line, token.text)
+		}
+		return number, nil
+	case idlIdent:
+		switch token.text {
+		case "true":
+			return true, nil
+		case "false":
+			return false, nil
+		case "null":
+			return nil, nil
+		}
+	case idlPunct:
+		switch token.text {
+		case "[":
+			values := make([]interface{}, 0)
+			for !parser.accept("]") {
+				value, err := parser.jsonValue()
+				if err != nil {
+					return nil, err
+				}
+				values = append(values, value)
+				if !parser.accept(",") {
+					if err := parser.expect("]"); err != nil {
+						return nil, err
+					}
+					break
+				}
+			}
+			return values, nil
+		case "{":
+			object := make(map[string]interface{})
+			for !parser.accept("}") {
+				key := parser.next()
+				if key.kind != idlString {
+					return nil, fmt.Errorf("avro idl: line %d: expected a string key, got %q", key.line, key.text)
+				}
+				if err := parser.expect(":"); err != nil {
+					return nil, err
+				}
+				value, err := parser.jsonValue()
+				if err != nil {
+					return nil, err
+				}
+				object[key.text] = value
+				if !parser.accept(",") {
+					if err := parser.expect("}"); err != nil {
+						return nil, err
+					}
+					break
+				}
+			}
+			return object, nil
+		}
+	}
+	return nil, fmt.Errorf("avro idl: line %d: expected a JSON value, got %q", token.line, token.text)
+}
@@ -0,0 +1,138 @@
+package srclient
+
+import (
+	"errors"
+	"path/filepath"
+	"testing"
+
+	"github.com/crxfoz/goavro/v2"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testAvroIDL = `
+/** Shop events. */
+@namespace("com.shop")
+protocol Shop {
+	import schema "address.avsc";
+
+	/** Payment state. */
+	enum Status { PENDING, PAID } = PENDING;
+
+	fixed Hash(16);
+
+	record Customer {
+		/** Customer id. */
+		long id;
+		string @aliases(["mail"]) email, ` + "`error`" + `;
+		com.shop.Address? address = null;
+	}
+
+	record Order {
+		Customer customer;
+		array<Customer> others = [];
+		map<decimal(9, 2)> totals;
+		union { null, string, Hash } note = null;
+		timestamp_ms placedAt;
+		@logicalType("timestamp-micros") long updatedAt;
+		Status status = "PAID";
+		int? quantity = 1;
+	}
+
+	Order place(Customer customer) throws Fault;
+	void ping() oneway;
+}
+`
+
+func TestCompileAvroIDL(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	importFile := func(path string) (string, error) {
+		if path != "address.avsc" {
+			return "", errors.New("not found")
+		}
+		return `{"type": "record", "name": "Address", "namespace": "com.shop", "fields": [{"name": "street", "type": "string"}]}`, nil
+	}
+
+	// Act
+	schemas, err := CompileAvroIDL(testAvroIDL, importFile)
+
+	// Assert
+	require.NoError(t, err)
+	names := make([]string, len(schemas))
+	for i, schema := range schemas {
+		names[i] = schema.Name
+		_, err := goavro.NewCodec(schema.Schema)
+		assert.NoError(t, err, schema.Name)
+	}
+	assert.Equal(t, []string{"com.shop.Address", "com.shop.Status", "com.shop.Hash", "com.shop.Customer", "com.shop.Order"}, names)
+	assert.Equal(t, `{"type":"enum","name":"com.shop.Status","doc":"Payment state.","symbols":["PENDING","PAID"],"default":"PENDING"}`, schemas[1].Schema)
+	assert.JSONEq(t, `{"type":"record","name":"com.shop.Customer","fields":[
+		{"name":"id","type":"long","doc":"Customer id."},
+		{"name":"email","type":"string","aliases":["mail"]},
+		{"name":"error","type":"string"},
+		{"name":"address","type":["null",{"type":"record","name":"Address","namespace":"com.shop","fields":[{"name":"street","type":"string"}]}],"default":null}
+	]}`, schemas[3].Schema)
+	assert.Contains(t, schemas[4].Schema, `{"name":"others","type":{"type":"array","items":"com.shop.Customer"},"default":[]}`)
+	assert.Contains(t, schemas[4].Schema, `{"type":"bytes","logicalType":"decimal","precision":9,"scale":2}`)
+	assert.Contains(t, schemas[4].Schema, `{"name":"updatedAt","type":{"type":"long","logicalType":"timestamp-micros"}}`)
+	assert.Contains(t, schemas[4].Schema, `{"name":"quantity","type":["int","null"],"default":1}`)
+}
+
+func TestCompileAvroIDL_Errors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		idl      string
+		expected string
+	}{
+		"undefined type": {
+			idl:      `protocol P { record A { Missing b; } }`,
+			expected: "undefined type Missing",
+		},
+		"declared twice": {
+			idl:      `protocol P { record A {} enum A { X } }`,
+			expected: "type A declared twice",
+		},
+		"import": {
+			idl:      `protocol P { import idl "other.avdl"; }`,
+			expected: "imports aren't supported",
+		},
+		"syntax": {
+			idl:      "protocol P {\n record A { string } }",
+			expected: "line 2: expected a name",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := CompileAvroIDL(testData.idl, nil)
+
+			// Assert
+			assert.ErrorContains(t, err, testData.expected)
+		})
+	}
+}
+
+func TestLoadSchemaSpecs_AvroIDL(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := writeSchemaFiles(t, map[string]string{
+		"idl/shop.avdl":         "namespace com.shop;\nschema Order;\nimport idl \"common/money.avdl\";\nrecord Order { Money total; }\n",
+		"idl/common/money.avdl": "@namespace(\"com.shop\") protocol Common { record Money { long cents; } }",
+	})
+
+	// Act
+	specs, err := LoadSchemaSpecs(filepath.Join(dir, "idl", "shop.avdl"), nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, "com.shop.Money.avsc", specs[0].Subject)
+	assert.Equal(t, "com.shop.Order.avsc", specs[1].Subject)
+	assert.Equal(t, Avro, specs[1].SchemaType)
+	assert.Equal(t, `{"type":"record","name":"com.shop.Order","fields":[{"name":"total","type":{"type":"record","name":"com.shop.Money","fields":[{"name":"cents","type":"long"}]}}]}`, specs[1].Schema)
+}
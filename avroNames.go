@@ -0,0 +1,103 @@
+package srclient
+
+import "strings"
+
+// walkAvroNamedTypes walks an Avro schema, following the namespaces,
+// and calls define with the full name and the definition of each named
+// type the schema defines and use with the full name of each type it
+// refers to by name. Either function may be nil.
+func walkAvroNamedTypes(schema interface{}, namespace string, define func(fullName string, definition map[string]interface{}), use func(fullName string)) {
+	switch typed := schema.(type) {
+	case string:
+		if use != nil && !avroPrimitives[typed] {
+			use(avroFullName(typed, namespace))
+		}
+	case []interface{}:
+		for _, member := range typed {
+			walkAvroNamedTypes(member, namespace, define, use)
+		}
+	case map[string]interface{}:
+		typeName, ok := typed["type"].(string)
+		if !ok {
+			walkAvroNamedTypes(typed["type"], namespace, define, use)
+			return
+		}
+		switch typeName {
+		case "array":
+			walkAvroNamedTypes(typed["items"], namespace, define, use)
+		case "map":
+			walkAvroNamedTypes(typed["values"], namespace, define, use)
+		case "record", "error", "enum", "fixed":
+			fullName := avroDefinitionName(typed, namespace)
+			if define != nil {
+				define(fullName, typed)
+			}
+			fields, _ := typed["fields"].([]interface{})
+			for _, field := range fields {
+				if fieldMap, ok := field.(map[string]interface{}); ok {
+					walkAvroNamedTypes(fieldMap["type"], avroNamespaceOf(fullName), define, use)
+				}
+			}
+		default:
+			walkAvroNamedTypes(typeName, namespace, define, use)
+		}
+	}
+}
+
+// avroNamedTypes collects the full names of the named types an Avro
+// schema defines and of the ones it uses.
+func avroNamedTypes(schema interface{}, namespace string, defined, used map[string]bool) {
+	walkAvroNamedTypes(schema, namespace, func(fullName string, _ map[string]interface{}) {
+		defined[fullName] = true
+	}, func(fullName string) {
+		used[fullName] = true
+	})
+}
+
+// avroDefinitions maps the full names of the named types of an Avro
+// schema to their definitions.
+func avroDefinitions(schema interface{}) map[string]map[string]interface{} {
+	named := make(map[string]map[string]interface{})
+	walkAvroNamedTypes(schema, "", func(fullName string, definition map[string]interface{}) {
+		named[fullName] = definition
+	}, nil)
+	return named
+}
+
+// avroLookupName returns the full name of the named type a name used in
+// the namespace refers to. Like the Java parser, a name not found in the
+// namespace falls back to the null namespace.
+func avroLookupName(named map[string]map[string]interface{}, name, namespace string) (string, bool) {
+	for _, fullName := range []string{avroFullName(name, namespace), name} {
+		if _, ok := named[fullName]; ok {
+			return fullName, true
+		}
+	}
+	return "", false
+}
+
+// avroFullName qualifies the name with the namespace, unless it already is.
+func avroFullName(name, namespace string) string {
+	if namespace == "" || strings.Contains(name, ".") {
+		return name
+	}
+	return namespace + "." + name
+}
+
+// avroDefinitionName is the full name of a named type defined in the
+// namespace.
+func avroDefinitionName(definition map[string]interface{}, namespace string) string {
+	name, _ := definition["name"].(string)
+	if ns, ok := definition["namespace"].(string); ok {
+		namespace = ns
+	}
+	return avroFullName(name, namespace)
+}
+
+// avroNamespaceOf is the namespace the fields of a named type are in.
+func avroNamespaceOf(fullName string) string {
+	if idx := strings.LastIndex(fullName, "."); idx >= 0 {
+		return fullName[:idx]
+	}
+	return ""
+}
@@ -0,0 +1,470 @@
+package srclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"github.com/crxfoz/goavro/v2"
+)
+
+// avroProjection reads the values decoded with a writer schema as the
+// reader schema would, following the schema resolution rules of the
+// specification: fields are matched by name or alias, the fields the
+// writer doesn't have take their default, the ones the reader doesn't
+// have are dropped, numbers are promoted and union members are picked
+// again among the reader ones.
+type avroProjection struct {
+	readerNames    map[string]map[string]interface{}
+	writerNames    map[string]map[string]interface{}
+	reader, writer interface{}
+}
+
+func newAvroProjection(reader, writer *Schema) (*avroProjection, error) {
+	projection := &avroProjection{}
+	if err := json.Unmarshal([]byte(reader.Schema()), &projection.reader); err != nil {
+		return nil, fmt.Errorf("reader schema: %w", err)
+	}
+	if err := json.Unmarshal([]byte(writer.Schema()), &projection.writer); err != nil {
+		return nil, fmt.Errorf("writer schema: %w", err)
+	}
+	projection.readerNames = avroDefinitions(projection.reader)
+	projection.writerNames = avroDefinitions(projection.writer)
+	return projection, nil
+}
+
+// project converts a native value decoded by goavro with the writer
+// schema into the one goavro decodes with the reader schema.
+func (projection *avroProjection) project(native interface{}) (interface{}, error) {
+	resolver := &avroResolver{
+		readerNames: projection.readerNames,
+		writerNames: projection.writerNames,
+		resolving:   make(map[string]bool),
+	}
+	return resolver.project(projection.reader, projection.writer, "", "", "", native)
+}
+
+// avroProjectionKey identifies a projection by the IDs of its schemas.
+type avroProjectionKey struct {
+	reader, writer int
+}
+
+// avroProjections caches the projections of the writer schemas on the
+// reader schemas.
+type avroProjections struct {
+	lock        sync.RWMutex
+	projections map[avroProjectionKey]*avroProjection
+}
+
+// project reads a native value decoded with the writer schema as the
+// reader schema would.
+func (projections *avroProjections) project(reader, writer *Schema, native interface{}) (interface{}, error) {
+	key := avroProjectionKey{reader: reader.ID(), writer: writer.ID()}
+	projections.lock.RLock()
+	projection, ok := projections.projections[key]
+	projections.lock.RUnlock()
+	if !ok {
+		var err error
+		if projection, err = newAvroProjection(reader, writer); err != nil {
+			return nil, err
+		}
+		projections.lock.Lock()
+		if projections.projections == nil {
+			projections.projections = make(map[avroProjectionKey]*avroProjection)
+		}
+		projections.projections[key] = projection
+		projections.lock.Unlock()
+	}
+	return projection.project(native)
+}
+
+// goavroLogicalTypes are the logical types goavro decodes to other
+// values than their underlying type, their union members are named
+// after both.
+var goavroLogicalTypes = map[string]bool{
+	"string.uuid": true, "string.validated-string": true, "bytes.decimal": true,
+	"int.date": true, "int.time-millis": true, "long.time-micros": true,
+	"long.timestamp-millis": true, "long.timestamp-micros": true,
+}
+
+// project converts the value of a writer type into the value of the
+// reader type, the names they use being in their namespace.
+func (resolver *avroResolver) project(reader, writer interface{}, readerNamespace, writerNamespace, path string, value interface{}) (interface{}, error) {
+	at := path
+	if at == "" {
+		at = "schema"
+	}
+
+	if writerUnion, ok := writer.([]interface{}); ok {
+		// goavro wraps the values of the members but the null one
+		name := "null"
+		wrapped, _ := value.(map[string]interface{})
+		for member, memberValue := range wrapped {
+			name, value = member, memberValue
+		}
+		for _, member := range writerUnion {
+			if avroUnionName(member, writerNamespace, resolver.writerNames) == name {
+				return resolver.project(reader, member, readerNamespace, writerNamespace, path, value)
+			}
+		}
+		return nil, fmt.Errorf("%s: union has no member %s", at, name)
+	}
+	if readerUnion, ok := reader.([]interface{}); ok {
+		for _, member := range readerUnion {
+			if !resolver.matches(member, writer, readerNamespace, writerNamespace) {
+				continue
+			}
+			projected, err := resolver.project(member, writer, readerNamespace, writerNamespace, path, value)
+			if err != nil || projected == nil {
+				return projected, err
+			}
+			return goavro.Union(avroUnionName(member, readerNamespace, resolver.readerNames), projected), nil
+		}
+		writerKind, _, _ := avroKind(writer, writerNamespace, resolver.writerNames)
+		return nil, fmt.Errorf("%s: union has no member for %s", at, writerKind)
+	}
+
+	writerKind, writerDefinition, writerScope := avroKind(writer, writerNamespace, resolver.writerNames)
+	readerKind, readerDefinition, readerScope := avroKind(reader, readerNamespace, resolver.readerNames)
+	if !avroKnownKinds[readerKind] || !avroKnownKinds[writerKind] {
+		return nil, fmt.Errorf("%s: unknown type %s read as %s", at, writerKind, readerKind)
+	}
+	if readerKind != writerKind {
+		return avroPromote(writerKind, readerKind, at, value)
+	}
+
+	switch readerKind {
+	case "array":
+		items, _ := value.([]interface{})
+		projected := make([]interface{}, len(items))
+		for i, item := range items {
+			var err error
+			if projected[i], err = resolver.project(readerDefinition["items"], writerDefinition["items"], readerScope, writerScope, path+"[]", item); err != nil {
+				return nil, err
+			}
+		}
+		return projected, nil
+	case "map":
+		values, _ := value.(map[string]interface{})
+		projected := make(map[string]interface{}, len(values))
+		for key, item := range values {
+			var err error
+			if projected[key], err = resolver.project(readerDefinition["values"], writerDefinition["values"], readerScope, writerScope, path+"{}", item); err != nil {
+				return nil, err
+			}
+		}
+		return projected, nil
+	case "record", "error", "enum":
+		readerName, _ := readerDefinition["name"].(string)
+		writerName, _ := writerDefinition["name"].(string)
+		if avroShortName(readerName) != avroShortName(writerName) && !avroHasAlias(readerDefinition, writerName) {
+			return nil, fmt.Errorf("%s: %s %s can't be read as %s", at, writerKind, writerName, readerName)
+		}
+		if readerKind == "enum" {
+			return avroProjectSymbol(readerDefinition, at, value)
+		}
+		return resolver.projectRecord(readerDefinition, writerDefinition, readerScope, writerScope, path, value)
+	case "fixed":
+		if fmt.Sprint(readerDefinition["size"]) != fmt.Sprint(writerDefinition["size"]) {
+			return nil, fmt.Errorf("%s: fixed changed size from %v to %v", at, writerDefinition["size"], readerDefinition["size"])
+		}
+		return avroProjectLogical(readerDefinition, writerDefinition, value)
+	default:
+		return avroProjectLogical(reader, writer, value)
+	}
+}
+
+func (resolver *avroResolver) projectRecord(reader, writer map[string]interface{}, readerNamespace, writerNamespace, path string, value interface{}) (interface{}, error) {
+	written, _ := value.(map[string]interface{})
+	writerFields := make(map[string]map[string]interface{})
+	fields, _ := writer["fields"].([]interface{})
+	for _, field := range fields {
+		if fieldMap, ok := field.(map[string]interface{}); ok {
+			name, _ := fieldMap["name"].(string)
+			writerFields[name] = fieldMap
+		}
+	}
+
+	fields, _ = reader["fields"].([]interface{})
+	projected := make(map[string]interface{}, len(fields))
+	for _, field := range fields {
+		fieldMap, ok := field.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := fieldMap["name"].(string)
+		writerName := name
+		writerField, ok := writerFields[name]
+		if !ok {
+			aliases, _ := fieldMap["aliases"].([]interface{})
+			for _, alias := range aliases {
+				writerName = fmt.Sprint(alias)
+				if writerField, ok = writerFields[writerName]; ok {
+					break
+				}
+			}
+		}
+
+		var err error
+		switch defaultValue, hasDefault := fieldMap["default"]; {
+		case ok:
+			projected[name], err = resolver.project(fieldMap["type"], writerField["type"], readerNamespace, writerNamespace, joinFieldPath(path, name), written[writerName])
+		case hasDefault:
+			projected[name], err = resolver.defaultValue(fieldMap["type"], readerNamespace, joinFieldPath(path, name), defaultValue)
+		default:
+			err = fmt.Errorf("%s: field is missing from the written data and has no default", joinFieldPath(path, name))
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	return projected, nil
+}
+
+// defaultValue converts the JSON default of a field of the reader
+// schema into the native value goavro decodes.
+func (resolver *avroResolver) defaultValue(schema interface{}, namespace, path string, value interface{}) (interface{}, error) {
+	if union, ok := schema.([]interface{}); ok {
+		if len(union) == 0 {
+			return nil, fmt.Errorf("%s: empty union", path)
+		}
+		// The default of a union is a value of its first member
+		converted, err := resolver.defaultValue(union[0], namespace, path, value)
+		if err != nil || converted == nil {
+			return converted, err
+		}
+		return goavro.Union(avroUnionName(union[0], namespace, resolver.readerNames), converted), nil
+	}
+
+	kind, definition, scope := avroKind(schema, namespace, resolver.readerNames)
+	if logical := avroLogicalType(schema, definition); logical != "" {
+		// Only goavro knows the values of its logical types
+		leaf := schema
+		if definition != nil {
+			leaf = definition
+		}
+		leafSchema, err := json.Marshal(leaf)
+		if err != nil {
+			return nil, err
+		}
+		codec, err := goavro.NewCodec(string(leafSchema))
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+		textual, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		converted, _, err := codec.NativeFromTextual(textual)
+		return converted, err
+	}
+
+	switch kind {
+	case "null":
+		return nil, nil
+	case "int", "long", "float", "double":
+		number, ok := value.(float64)
+		if !ok {
+			return nil, fmt.Errorf("%s: default %v is not a number", path, value)
+		}
+		return avroNumber(number, int64(number), kind), nil
+	case "string", "enum", "boolean":
+		return value, nil
+	case "bytes", "fixed":
+		text, ok := value.(string)
+		if !ok {
+			return nil, fmt.Errorf("%s: default %v is not a string", path, value)
+		}
+		return []byte(text), nil
+	case "array":
+		items, _ := value.([]interface{})
+		converted := make([]interface{}, len(items))
+		for i, item := range items {
+			var err error
+			if converted[i], err = resolver.defaultValue(definition["items"], scope, path+"[]", item); err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	case "map":
+		values, _ := value.(map[string]interface{})
+		converted := make(map[string]interface{}, len(values))
+		for key, item := range values {
+			var err error
+			if converted[key], err = resolver.defaultValue(definition["values"], scope, path+"{}", item); err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	case "record", "error":
+		values, _ := value.(map[string]interface{})
+		converted := make(map[string]interface{}, len(values))
+		fields, _ := definition["fields"].([]interface{})
+		for _, field := range fields {
+			fieldMap, ok := field.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			name, _ := fieldMap["name"].(string)
+			item, ok := values[name]
+			if !ok {
+				if item, ok = fieldMap["default"]; !ok {
+					return nil, fmt.Errorf("%s: default has no value for field %s", path, name)
+				}
+			}
+			var err error
+			if converted[name], err = resolver.defaultValue(fieldMap["type"], scope, joinFieldPath(path, name), item); err != nil {
+				return nil, err
+			}
+		}
+		return converted, nil
+	default:
+		return nil, fmt.Errorf("%s: unknown type %s", path, kind)
+	}
+}
+
+// avroPromote converts a number, a string or bytes of the writer kind
+// into the reader kind, as the schema resolution allows.
+func avroPromote(writerKind, readerKind, at string, value interface{}) (interface{}, error) {
+	var promoted interface{}
+	switch number := value.(type) {
+	case int32:
+		promoted = avroNumber(float64(number), int64(number), readerKind)
+	case int64:
+		promoted = avroNumber(float64(number), number, readerKind)
+	case float32:
+		promoted = avroNumber(float64(number), int64(number), readerKind)
+	case float64:
+		promoted = avroNumber(number, int64(number), readerKind)
+	case string:
+		if readerKind == "bytes" {
+			promoted = []byte(number)
+		}
+	case []byte:
+		if readerKind == "string" {
+			promoted = string(number)
+		}
+	}
+	allowed := writerKind == readerKind
+	for _, kind := range avroPromotions[writerKind] {
+		allowed = allowed || kind == readerKind
+	}
+	if !allowed || promoted == nil {
+		return nil, fmt.Errorf("%s: %s can't be read as %s", at, writerKind, readerKind)
+	}
+	return promoted, nil
+}
+
+func avroNumber(float float64, integer int64, kind string) interface{} {
+	switch kind {
+	case "int":
+		return int32(integer)
+	case "long":
+		return integer
+	case "float":
+		return float32(float)
+	case "double":
+		return float
+	default:
+		return nil
+	}
+}
+
+// avroProjectSymbol reads an enum symbol, the symbols the reader doesn't
+// know take the default of the reader enum.
+func avroProjectSymbol(reader map[string]interface{}, at string, value interface{}) (interface{}, error) {
+	symbols, _ := reader["symbols"].([]interface{})
+	for _, symbol := range symbols {
+		if fmt.Sprint(symbol) == fmt.Sprint(value) {
+			return value, nil
+		}
+	}
+	if defaultSymbol, ok := reader["default"].(string); ok {
+		return defaultSymbol, nil
+	}
+	name, _ := reader["name"].(string)
+	return nil, fmt.Errorf("%s: enum %s has no symbol %v", at, name, value)
+}
+
+// avroProjectLogical reads a value of the same underlying type, going
+// through its binary encoding when the logical types differ, as goavro
+// decodes them to different values.
+func avroProjectLogical(readerLeaf, writerLeaf interface{}, value interface{}) (interface{}, error) {
+	if avroLogicalType(readerLeaf, nil) == avroLogicalType(writerLeaf, nil) {
+		return value, nil
+	}
+	writerSchema, err := json.Marshal(writerLeaf)
+	if err != nil {
+		return nil, err
+	}
+	readerSchema, err := json.Marshal(readerLeaf)
+	if err != nil {
+		return nil, err
+	}
+	writerCodec, err := goavro.NewCodec(string(writerSchema))
+	if err != nil {
+		return nil, err
+	}
+	readerCodec, err := goavro.NewCodec(string(readerSchema))
+	if err != nil {
+		return nil, err
+	}
+	binary, err := writerCodec.BinaryFromNative(nil, value)
+	if err != nil {
+		return nil, err
+	}
+	projected, _, err := readerCodec.NativeFromBinary(binary)
+	return projected, err
+}
+
+// avroLogicalType returns the logical type of a type goavro decodes to
+// other values than its underlying type, if any.
+func avroLogicalType(schema interface{}, definition map[string]interface{}) string {
+	typed, ok := schema.(map[string]interface{})
+	if definition != nil {
+		typed, ok = definition, true
+	}
+	if !ok {
+		return ""
+	}
+	if nested, ok := typed["type"].(map[string]interface{}); ok {
+		return avroLogicalType(nested, nil)
+	}
+	typeName, _ := typed["type"].(string)
+	logicalType, _ := typed["logicalType"].(string)
+	if typeName == "fixed" && logicalType == "decimal" {
+		return "fixed.decimal"
+	}
+	if goavroLogicalTypes[typeName+"."+logicalType] {
+		return typeName + "." + logicalType
+	}
+	return ""
+}
+
+// avroUnionName is the name goavro gives the values of a union member.
+func avroUnionName(member interface{}, namespace string, names map[string]map[string]interface{}) string {
+	switch typed := member.(type) {
+	case string:
+		if fullName, ok := avroLookupName(names, typed, namespace); ok {
+			return fullName
+		}
+		return typed
+	case map[string]interface{}:
+		typeName, ok := typed["type"].(string)
+		if !ok {
+			return avroUnionName(typed["type"], namespace, names)
+		}
+		switch typeName {
+		case "record", "error", "enum", "fixed":
+			return avroDefinitionName(typed, namespace)
+		case "array", "map":
+			return typeName
+		}
+		if logicalType := avroLogicalType(typed, nil); logicalType != "" {
+			return logicalType
+		}
+		return avroUnionName(typeName, namespace, names)
+	default:
+		return ""
+	}
+}
@@ -0,0 +1,123 @@
+package srclient
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAvroProjection(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		writer      string
+		reader      string
+		value       interface{}
+		expected    interface{}
+		expectedErr string
+	}{
+		"nullable widening": {
+			writer:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}]}`,
+			reader:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": ["null", "string"], "default": null}]}`,
+			value:    map[string]interface{}{"flavor": "vanilla"},
+			expected: map[string]interface{}{"flavor": map[string]interface{}{"string": "vanilla"}},
+		},
+		"reader only fields with defaults": {
+			writer: `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}]}`,
+			reader: `{"type": "record", "name": "cupcake", "namespace": "com.bakery", "fields": [
+				{"name": "flavor", "type": "string"},
+				{"name": "size", "type": "int", "default": 1},
+				{"name": "topping", "type": ["null", "string"], "default": null},
+				{"name": "price", "type": ["double", "null"], "default": 2.5},
+				{"name": "box", "type": {"type": "record", "name": "box", "fields": [{"name": "width", "type": "long"}]}, "default": {"width": 2}},
+				{"name": "gift", "type": ["null", "box"], "default": null},
+				{"name": "tags", "type": {"type": "array", "items": "string"}, "default": ["new"]}
+			]}`,
+			value: map[string]interface{}{"flavor": "vanilla"},
+			expected: map[string]interface{}{
+				"flavor":  "vanilla",
+				"size":    int32(1),
+				"topping": nil,
+				"price":   map[string]interface{}{"double": 2.5},
+				"box":     map[string]interface{}{"width": int64(2)},
+				"gift":    nil,
+				"tags":    []interface{}{"new"},
+			},
+		},
+		"union narrowing": {
+			writer:   `["null", "string"]`,
+			reader:   `"string"`,
+			value:    map[string]interface{}{"string": "vanilla"},
+			expected: "vanilla",
+		},
+		"union narrowing of null": {
+			writer:      `["null", "string"]`,
+			reader:      `"string"`,
+			value:       nil,
+			expectedErr: "schema: null can't be read as string",
+		},
+		"promotion into union": {
+			writer:   `{"type": "record", "name": "cupcake", "fields": [{"name": "size", "type": "int"}]}`,
+			reader:   `{"type": "record", "name": "cupcake", "fields": [{"name": "size", "type": ["null", "long"]}]}`,
+			value:    map[string]interface{}{"size": int32(3)},
+			expected: map[string]interface{}{"size": map[string]interface{}{"long": int64(3)}},
+		},
+		"named union members": {
+			writer:   `{"type": "record", "name": "order", "namespace": "com.bakery", "fields": [{"name": "item", "type": ["null", {"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}]}]}]}`,
+			reader:   `{"type": "record", "name": "order", "namespace": "com.shop", "fields": [{"name": "item", "type": ["null", {"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int", "default": 1}]}]}]}`,
+			value:    map[string]interface{}{"item": map[string]interface{}{"com.bakery.cupcake": map[string]interface{}{"flavor": "vanilla"}}},
+			expected: map[string]interface{}{"item": map[string]interface{}{"com.shop.cupcake": map[string]interface{}{"flavor": "vanilla", "size": int32(1)}}},
+		},
+		"field alias and enum default": {
+			writer: `{"type": "record", "name": "cupcake", "fields": [{"name": "taste", "type": {"type": "enum", "name": "flavor", "symbols": ["VANILLA", "MATCHA"]}}]}`,
+			reader: `{"type": "record", "name": "cupcake", "fields": [
+				{"name": "flavor", "aliases": ["taste"], "type": {"type": "enum", "name": "flavor", "symbols": ["VANILLA", "OTHER"], "default": "OTHER"}}
+			]}`,
+			value:    map[string]interface{}{"taste": "MATCHA"},
+			expected: map[string]interface{}{"flavor": "OTHER"},
+		},
+		"logical type": {
+			writer:   `"long"`,
+			reader:   `{"type": "long", "logicalType": "timestamp-millis"}`,
+			value:    int64(1500),
+			expected: time.Unix(1, 500*int64(time.Millisecond)).UTC(),
+		},
+		"missing field without default": {
+			writer:      `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}]}`,
+			reader:      `{"type": "record", "name": "cupcake", "fields": [{"name": "size", "type": "int"}]}`,
+			value:       map[string]interface{}{"flavor": "vanilla"},
+			expectedErr: "size: field is missing from the written data and has no default",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			writer, err := NewSchema(1, testData.writer, Avro, 1, nil, nil, nil)
+			require.NoError(t, err)
+			reader, err := NewSchema(2, testData.reader, Avro, 2, nil, nil, nil)
+			require.NoError(t, err)
+			binary, err := writer.Codec().BinaryFromNative(nil, testData.value)
+			require.NoError(t, err)
+			native, _, err := writer.Codec().NativeFromBinary(binary)
+			require.NoError(t, err)
+			projections := &avroProjections{}
+
+			// Act
+			projected, err := projections.project(reader, writer, native)
+
+			// Assert
+			if testData.expectedErr != "" {
+				assert.EqualError(t, err, testData.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, projected)
+			_, err = reader.Codec().BinaryFromNative(nil, projected)
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,101 @@
+package srclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+)
+
+// ErrBudgetExceeded is returned instead of sending a request once the
+// hard limit of the request budget is reached for the current window.
+var ErrBudgetExceeded = errors.New("schema registry request budget exceeded")
+
+// RequestBudget caps the requests a client sends to the registry per
+// time window, to keep the services sharing a Confluent Cloud API quota
+// within it. Windows are consecutive and the first one starts with the
+// first request.
+type RequestBudget struct {
+	Window time.Duration
+	// Warn is the number of requests in a window after which OnWarn is
+	// called, zero disables the warning.
+	Warn int
+	// Limit is the number of requests allowed in a window, the next ones
+	// fail with ErrBudgetExceeded until the window ends. Zero means no
+	// limit.
+	Limit int
+	// OnWarn and OnLimit are called at most once per window, when the
+	// warning threshold and the limit are reached.
+	OnWarn  func(usage BudgetUsage)
+	OnLimit func(usage BudgetUsage)
+}
+
+// BudgetUsage is the number of requests sent in the current window.
+type BudgetUsage struct {
+	WindowStart time.Time
+	Window      time.Duration
+	Requests    int
+	// Rejected is the number of requests which failed with
+	// ErrBudgetExceeded in the window.
+	Rejected int
+}
+
+type requestBudget struct {
+	lock   sync.Mutex
+	budget RequestBudget
+	usage  BudgetUsage
+}
+
+// WithRequestBudget counts the requests sent to the registry per window
+// of the budget, warning and rejecting requests past its thresholds.
+func WithRequestBudget(budget RequestBudget) Option {
+	return func(options *clientOptions) {
+		options.requestBudget = &budget
+	}
+}
+
+// BudgetUsage returns the usage of the current window of the request
+// budget, the zero value without a budget.
+func (client *SchemaRegistryClient) BudgetUsage() BudgetUsage {
+	if client.budget == nil {
+		return BudgetUsage{}
+	}
+	client.budget.lock.Lock()
+	defer client.budget.lock.Unlock()
+	client.budget.roll(client.now())
+	return client.budget.usage
+}
+
+// roll starts a new window when the current one is over.
+func (b *requestBudget) roll(now time.Time) {
+	if b.usage.WindowStart.IsZero() || !now.Before(b.usage.WindowStart.Add(b.budget.Window)) {
+		b.usage = BudgetUsage{WindowStart: now, Window: b.budget.Window}
+	}
+}
+
+// spend counts a request, unless the limit is reached.
+func (b *requestBudget) spend(now time.Time) error {
+	b.lock.Lock()
+	b.roll(now)
+	var callback func(BudgetUsage)
+	var err error
+	if b.budget.Limit > 0 && b.usage.Requests >= b.budget.Limit {
+		b.usage.Rejected++
+		if b.usage.Rejected == 1 {
+			callback = b.budget.OnLimit
+		}
+		err = fmt.Errorf("%w: %d requests since %s", ErrBudgetExceeded, b.usage.Requests, b.usage.WindowStart.Format(time.RFC3339))
+	} else {
+		b.usage.Requests++
+		if b.usage.Requests == b.budget.Warn {
+			callback = b.budget.OnWarn
+		}
+	}
+	usage := b.usage
+	b.lock.Unlock()
+
+	if callback != nil {
+		callback(usage)
+	}
+	return err
+}
@@ -0,0 +1,64 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaRegistryClient_RequestBudget(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+	var warnings, limits []BudgetUsage
+	srClient := NewClient(server.URL, WithClock(clock), WithCaching(false), WithRequestBudget(RequestBudget{
+		Window:  time.Minute,
+		Warn:    2,
+		Limit:   3,
+		OnWarn:  func(usage BudgetUsage) { warnings = append(warnings, usage) },
+		OnLimit: func(usage BudgetUsage) { limits = append(limits, usage) },
+	}))
+
+	// Act
+	var errs []error
+	for i := 0; i < 5; i++ {
+		_, err := srClient.GetSchema(context.Background(), 1)
+		errs = append(errs, err)
+	}
+	usage := srClient.BudgetUsage()
+	clock.Advance(time.Minute)
+	_, nextWindowErr := srClient.GetSchema(context.Background(), 1)
+
+	// Assert
+	assert.NoError(t, errs[0])
+	assert.NoError(t, errs[1])
+	assert.NoError(t, errs[2])
+	assert.ErrorIs(t, errs[3], ErrBudgetExceeded)
+	assert.ErrorIs(t, errs[4], ErrBudgetExceeded)
+	assert.NoError(t, nextWindowErr)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	assert.Equal(t, BudgetUsage{WindowStart: start, Window: time.Minute, Requests: 3, Rejected: 2}, usage)
+	assert.Equal(t, []BudgetUsage{{WindowStart: start, Window: time.Minute, Requests: 2}}, warnings)
+	assert.Equal(t, []BudgetUsage{{WindowStart: start, Window: time.Minute, Requests: 3, Rejected: 1}}, limits)
+	assert.Equal(t, BudgetUsage{WindowStart: start.Add(time.Minute), Window: time.Minute, Requests: 1}, srClient.BudgetUsage())
+}
+
+func TestSchemaRegistryClient_BudgetUsageWithoutBudget(t *testing.T) {
+	t.Parallel()
+	srClient := NewClient("http://localhost:8081")
+
+	assert.Equal(t, BudgetUsage{}, srClient.BudgetUsage())
+}
@@ -0,0 +1,261 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"gopkg.in/yaml.v3"
+)
+
+const bufDownload = "/buf.registry.module.v1.DownloadService/Download"
+
+// ProtoModule holds the .proto files of a Buf module, keyed by their
+// import path.
+type ProtoModule map[string]string
+
+type bufWorkspaceConfig struct {
+	Directories []string `yaml:"directories"`
+	Modules     []struct {
+		Path string `yaml:"path"`
+	} `yaml:"modules"`
+}
+
+// LoadBufWorkspace reads the .proto files of a local buf workspace. The
+// module roots are taken from buf.work.yaml, or from the modules of a
+// v2 buf.yaml, and default to the directory itself.
+func LoadBufWorkspace(dir string) (ProtoModule, error) {
+	roots, err := bufModuleRoots(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	module := make(ProtoModule)
+	for _, root := range roots {
+		root = filepath.Join(dir, root)
+		err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+			if err != nil {
+				return err
+			}
+			if info.IsDir() {
+				if path != root && strings.HasPrefix(info.Name(), ".") {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if filepath.Ext(path) != ".proto" {
+				return nil
+			}
+
+			content, err := readSchemaFile(path)
+			if err != nil {
+				return err
+			}
+			name, err := filepath.Rel(root, path)
+			if err != nil {
+				return err
+			}
+			module[filepath.ToSlash(name)] = content
+			return nil
+		})
+		if err != nil {
+			return nil, err
+		}
+	}
+	return module, nil
+}
+
+func bufModuleRoots(dir string) ([]string, error) {
+	for _, file := range []string{"buf.work.yaml", "buf.yaml"} {
+		content, err := os.ReadFile(filepath.Join(dir, file))
+		if os.IsNotExist(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		var config bufWorkspaceConfig
+		if err := yaml.Unmarshal(content, &config); err != nil {
+			return nil, fmt.Errorf("%s: %w", file, err)
+		}
+		roots := config.Directories
+		for _, module := range config.Modules {
+			roots = append(roots, module.Path)
+		}
+		if len(roots) > 0 {
+			return roots, nil
+		}
+	}
+	return []string{"."}, nil
+}
+
+type bufDownloadRequest struct {
+	Values []bufDownloadValue `json:"values"`
+}
+
+type bufDownloadValue struct {
+	ResourceRef struct {
+		Name struct {
+			Owner  string `json:"owner"`
+			Module string `json:"module"`
+			Ref    string `json:"ref,omitempty"`
+		} `json:"name"`
+	} `json:"resourceRef"`
+}
+
+type bufDownloadResponse struct {
+	Contents []struct {
+		Files []struct {
+			Path    string `json:"path"`
+			Content []byte `json:"content"`
+		} `json:"files"`
+	} `json:"contents"`
+}
+
+// FetchBufModule downloads the .proto files of a module from a Buf
+// Schema Registry such as https://buf.build. The module is given as
+// owner/name, ref selects a label or commit and defaults to the latest
+// one. The dependencies of the module are not part of the download,
+// fetch them too and merge the modules before syncing them.
+func FetchBufModule(ctx context.Context, httpClient *http.Client, remote, module, ref, token string) (ProtoModule, error) {
+	owner := strings.SplitN(module, "/", 2)
+	if len(owner) != 2 {
+		return nil, fmt.Errorf("invalid Buf module %q, expected owner/name", module)
+	}
+
+	value := bufDownloadValue{}
+	value.ResourceRef.Name.Owner = owner[0]
+	value.ResourceRef.Name.Module = owner[1]
+	value.ResourceRef.Name.Ref = ref
+	requestBytes, err := json.Marshal(bufDownloadRequest{Values: []bufDownloadValue{value}})
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, "POST", strings.TrimSuffix(remote, "/")+bufDownload, bytes.NewBuffer(requestBytes))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("downloading Buf module %s: %s: %s", module, resp.Status, body)
+	}
+
+	var downloaded bufDownloadResponse
+	if err := json.Unmarshal(body, &downloaded); err != nil {
+		return nil, err
+	}
+
+	files := make(ProtoModule)
+	for _, content := range downloaded.Contents {
+		for _, file := range content.Files {
+			if filepath.Ext(file.Path) == ".proto" {
+				files[file.Path] = string(file.Content)
+			}
+		}
+	}
+	return files, nil
+}
+
+// SyncProtoModule registers every file of the module as a Protobuf
+// schema, dependencies first so that the imports are registered as
+// references. Files already registered are left untouched, which keeps
+// the registry in sync when run repeatedly. subjectFor names the subject
+// of each import path and defaults to the import path itself, following
+// the naming of references used by the Confluent serializers.
+func SyncProtoModule(ctx context.Context, client ISchemaRegistryClient, module ProtoModule, subjectFor func(path string) string) ([]*Schema, error) {
+	if subjectFor == nil {
+		subjectFor = func(path string) string { return path }
+	}
+
+	paths := make([]string, 0, len(module))
+	for path := range module {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(module)}
+	files, err := parser.ParseFiles(paths...)
+	if err != nil {
+		return nil, err
+	}
+
+	synced := make(map[string]*Schema)
+	var registered []*Schema
+	var sync func(file *desc.FileDescriptor) error
+	sync = func(file *desc.FileDescriptor) error {
+		if _, ok := synced[file.GetName()]; ok {
+			return nil
+		}
+
+		var references []Reference
+		for _, dependency := range file.GetDependencies() {
+			if _, inModule := module[dependency.GetName()]; !inModule {
+				continue
+			}
+			if err := sync(dependency); err != nil {
+				return err
+			}
+			references = append(references, Reference{
+				Name:    dependency.GetName(),
+				Subject: subjectFor(dependency.GetName()),
+				Version: synced[dependency.GetName()].Version(),
+			})
+		}
+
+		schema, err := syncProtoFile(ctx, client, subjectFor(file.GetName()), module[file.GetName()], references)
+		if err != nil {
+			return fmt.Errorf("%s: %w", file.GetName(), err)
+		}
+		synced[file.GetName()] = schema
+		registered = append(registered, schema)
+		return nil
+	}
+
+	for _, file := range files {
+		if err := sync(file); err != nil {
+			return nil, err
+		}
+	}
+	return registered, nil
+}
+
+// syncProtoFile registers the file unless it is already registered, the
+// schema is looked up afterwards to learn its version.
+func syncProtoFile(ctx context.Context, client ISchemaRegistryClient, subject, content string, references []Reference) (*Schema, error) {
+	schema, err := client.LookupSchema(ctx, subject, content, Protobuf, references...)
+	if err == nil {
+		return schema, nil
+	}
+	if !isNotFoundError(err) {
+		return nil, err
+	}
+
+	if _, err := client.CreateSchema(ctx, subject, content, Protobuf, references...); err != nil {
+		return nil, err
+	}
+	return client.LookupSchema(ctx, subject, content, Protobuf, references...)
+}
@@ -0,0 +1,116 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	bufCupcakeProto = `syntax = "proto3";
+package shop;
+message Cupcake {
+  string flavor = 1;
+}`
+	bufOrderProto = `syntax = "proto3";
+package shop;
+import "shop/cupcake.proto";
+import "google/protobuf/timestamp.proto";
+message Order {
+  Cupcake cupcake = 1;
+  google.protobuf.Timestamp placed_at = 2;
+}`
+)
+
+func TestLoadBufWorkspace(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir, err := os.MkdirTemp("", "buf")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	write := func(name, content string) {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0755))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	write("buf.work.yaml", "version: v1\ndirectories:\n  - proto\n")
+	write("proto/shop/cupcake.proto", bufCupcakeProto)
+	write("proto/shop/order.proto", bufOrderProto)
+	write("proto/README.md", "not a proto file")
+	write("other/ignored.proto", bufCupcakeProto)
+
+	// Act
+	module, err := LoadBufWorkspace(dir)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ProtoModule{"shop/cupcake.proto": bufCupcakeProto, "shop/order.proto": bufOrderProto}, module)
+}
+
+func TestSyncProtoModule(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	module := ProtoModule{"shop/order.proto": bufOrderProto, "shop/cupcake.proto": bufCupcakeProto}
+	subjectFor := func(path string) string { return "buf." + path }
+
+	// Act
+	first, err := SyncProtoModule(context.Background(), registry, module, subjectFor)
+	require.NoError(t, err)
+	second, err := SyncProtoModule(context.Background(), registry, module, subjectFor)
+	require.NoError(t, err)
+
+	// Assert
+	require.Len(t, first, 2)
+	assert.Equal(t, bufCupcakeProto, first[0].Schema())
+	assert.Equal(t, bufOrderProto, first[1].Schema())
+	assert.Equal(t, first[0].ID(), second[0].ID())
+	assert.Equal(t, first[1].ID(), second[1].ID())
+
+	versions, err := registry.GetSchemaVersions(context.Background(), "buf.shop/order.proto")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, versions)
+
+	_, err = SyncProtoModule(context.Background(), registry, ProtoModule{"broken.proto": "message {"}, nil)
+	assert.Error(t, err)
+}
+
+func TestFetchBufModule(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, bufDownload, req.URL.Path)
+		require.Equal(t, "Bearer secret", req.Header.Get("Authorization"))
+
+		var request bufDownloadRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&request))
+		require.Len(t, request.Values, 1)
+		assert.Equal(t, "acme", request.Values[0].ResourceRef.Name.Owner)
+		assert.Equal(t, "shop", request.Values[0].ResourceRef.Name.Module)
+		assert.Equal(t, "main", request.Values[0].ResourceRef.Name.Ref)
+
+		_, _ = rw.Write([]byte(`{"contents": [{"files": [
+			{"path": "shop/cupcake.proto", "content": "c3ludGF4ID0gInByb3RvMyI7"},
+			{"path": "buf.yaml", "content": "dmVyc2lvbjogdjI="}
+		]}]}`))
+	}))
+	defer server.Close()
+
+	// Act
+	module, err := FetchBufModule(context.Background(), server.Client(), server.URL, "acme/shop", "main", "secret")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, ProtoModule{"shop/cupcake.proto": `syntax = "proto3";`}, module)
+
+	_, err = FetchBufModule(context.Background(), server.Client(), server.URL, "shop", "", "")
+	assert.Error(t, err)
+}
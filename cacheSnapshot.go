@@ -0,0 +1,140 @@
+package srclient
+
+import (
+	"encoding/json"
+	"io"
+	"sort"
+	"strconv"
+
+	"github.com/crxfoz/goavro/v2"
+)
+
+// cacheSnapshot is the content of the caches written by ExportCache.
+type cacheSnapshot struct {
+	// Schemas are the entries of the ID cache.
+	Schemas []cacheEntry `json:"schemas"`
+	// Subjects are the entries of the subject cache.
+	Subjects []cacheEntry `json:"subjects"`
+}
+
+type cacheEntry struct {
+	schemaResponse
+	// ByID tells if the entry of the subject cache is keyed by ID
+	// rather than by version.
+	ByID bool `json:"byId,omitempty"`
+}
+
+// ExportCache writes the content of the schema caches as JSON, for
+// ImportCache to warm up the caches of another client, so replicas
+// scaling out can start from a peer's or a sidecar's snapshot rather
+// than from the registry. Latest versions aren't exported, as they may
+// be outdated by the time the snapshot is imported.
+func (client *SchemaRegistryClient) ExportCache(w io.Writer) error {
+	var snapshot cacheSnapshot
+
+	client.idSchemaCache.each(func(_ interface{}, cached schemaCacheEntry) {
+		snapshot.Schemas = append(snapshot.Schemas, newCacheEntry("", cached.schema))
+	})
+
+	client.subjectSchemaCache.each(func(cacheKey interface{}, cached schemaCacheEntry) {
+		key := cacheKey.(subjectCacheKey)
+		if key.version == "latest" {
+			return
+		}
+		entry := newCacheEntry(key.subject, cached.schema)
+		entry.ByID = key.version == ""
+		snapshot.Subjects = append(snapshot.Subjects, entry)
+	})
+
+	sort.Slice(snapshot.Schemas, func(i, j int) bool {
+		return snapshot.Schemas[i].ID < snapshot.Schemas[j].ID
+	})
+	sort.Slice(snapshot.Subjects, func(i, j int) bool {
+		left, right := snapshot.Subjects[i], snapshot.Subjects[j]
+		if left.Subject != right.Subject {
+			return left.Subject < right.Subject
+		}
+		if left.Version != right.Version {
+			return left.Version < right.Version
+		}
+		return !left.ByID && right.ByID
+	})
+
+	return json.NewEncoder(w).Encode(snapshot)
+}
+
+// ImportCache adds the schemas of a snapshot written by ExportCache to
+// the caches, codecs are created as when fetching the schemas.
+func (client *SchemaRegistryClient) ImportCache(r io.Reader) error {
+	var snapshot cacheSnapshot
+	if err := json.NewDecoder(r).Decode(&snapshot); err != nil {
+		return err
+	}
+
+	schemas := make(map[int]*Schema, len(snapshot.Schemas))
+	for _, entry := range snapshot.Schemas {
+		schema, err := client.cachedSchema(entry)
+		if err != nil {
+			return err
+		}
+		schemas[entry.ID] = schema
+	}
+	subjectSchemas := make(map[subjectCacheKey]*Schema, len(snapshot.Subjects))
+	for _, entry := range snapshot.Subjects {
+		schema, err := client.cachedSchema(entry)
+		if err != nil {
+			return err
+		}
+		key := versionCacheKey(entry.Subject, strconv.Itoa(entry.Version))
+		if entry.ByID {
+			key = idCacheKey(entry.Subject, entry.ID)
+		}
+		subjectSchemas[key] = schema
+	}
+
+	for id, schema := range schemas {
+		client.idSchemaCache.set(id, client.newSchemaCacheEntry(schema))
+	}
+	for key, schema := range subjectSchemas {
+		client.subjectSchemaCache.set(key, client.newSchemaCacheEntry(schema))
+	}
+	return nil
+}
+
+func newCacheEntry(subject string, schema *Schema) cacheEntry {
+	entry := cacheEntry{schemaResponse: schemaResponse{
+		Subject:    subject,
+		Version:    schema.version,
+		Schema:     schema.schema,
+		SchemaType: schema.schemaType,
+		ID:         schema.id,
+		References: schema.references,
+		Metadata:   schema.metadata,
+	}}
+	if !schema.registeredAt.IsZero() {
+		entry.Timestamp = schema.registeredAt.UnixNano() / 1e6
+	}
+	return entry
+}
+
+// cachedSchema builds the schema of a snapshot entry.
+func (client *SchemaRegistryClient) cachedSchema(entry cacheEntry) (*Schema, error) {
+	schema := &Schema{
+		id:           entry.ID,
+		schema:       entry.Schema,
+		schemaType:   entry.SchemaType,
+		version:      entry.Version,
+		references:   entry.References,
+		metadata:     entry.Metadata,
+		registeredAt: registrationTime(entry.Timestamp),
+		subject:      entry.Subject,
+	}
+	if client.getCodecCreationEnabled() && schemaTypeOf(schema) == Avro {
+		codec, err := goavro.NewCodec(entry.Schema)
+		if err != nil {
+			return nil, err
+		}
+		schema.codec = codec
+	}
+	return schema, nil
+}
@@ -0,0 +1,77 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_ExportImportCache(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	response := schemaResponse{Subject: "cupcakes-value", Version: 2, ID: 7, Schema: testSchema1, Timestamp: 1700000000000}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(rw).Encode(response)
+	}))
+	defer server.Close()
+
+	peer := CreateSchemaRegistryClient(server.URL)
+	peer.CacheLatest(true)
+	_, err := peer.GetSchema(context.Background(), 7)
+	require.NoError(t, err)
+	_, err = peer.GetSchemaByVersion(context.Background(), "cupcakes-value", 2)
+	require.NoError(t, err)
+	_, err = peer.GetSchemaBySubjectAndID(context.Background(), "cupcakes-value", 7)
+	require.NoError(t, err)
+	_, err = peer.GetLatestSchema(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	require.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+	// Act
+	var snapshot bytes.Buffer
+	exportErr := peer.ExportCache(&snapshot)
+	replica := CreateSchemaRegistryClient(server.URL)
+	replica.CodecCreationEnabled(true)
+	importErr := replica.ImportCache(bytes.NewReader(snapshot.Bytes()))
+
+	// Assert
+	require.NoError(t, exportErr)
+	require.NoError(t, importErr)
+	byID, err := replica.GetSchema(context.Background(), 7)
+	require.NoError(t, err)
+	byVersion, err := replica.GetSchemaByVersion(context.Background(), "cupcakes-value", 2)
+	require.NoError(t, err)
+	bySubjectAndID, err := replica.GetSchemaBySubjectAndID(context.Background(), "cupcakes-value", 7)
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+
+	assert.Equal(t, testSchema1, byID.Schema())
+	assert.Equal(t, 2, byVersion.Version())
+	assert.Equal(t, int64(1700000000), bySubjectAndID.RegisteredAt().Unix())
+	assert.NotNil(t, byVersion.Codec())
+
+	// Latest versions aren't exported
+	replica.CacheLatest(true)
+	_, err = replica.GetLatestSchema(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	assert.Equal(t, int32(5), atomic.LoadInt32(&calls))
+}
+
+func TestSchemaRegistryClient_ImportCacheRejectsInvalidSnapshots(t *testing.T) {
+	t.Parallel()
+	srClient := CreateSchemaRegistryClient("http://localhost:8081")
+	srClient.CodecCreationEnabled(true)
+
+	assert.Error(t, srClient.ImportCache(strings.NewReader("not json")))
+	assert.Error(t, srClient.ImportCache(strings.NewReader(`{"schemas": [{"id": 1, "schema": "{"}]}`)))
+}
@@ -0,0 +1,107 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// CanarySuffix is appended to a subject to name its canary subject.
+const CanarySuffix = ".canary"
+
+// CanarySubject returns the shadow subject holding the candidate schema
+// of a staged rollout of the subject.
+func CanarySubject(subject string) string {
+	return subject + CanarySuffix
+}
+
+// CanaryReport is the result of CheckCanary.
+type CanaryReport struct {
+	// Schema is the candidate schema of the canary subject.
+	Schema *Schema
+	// Compatible tells if the registry accepts the candidate as the next
+	// version of the subject.
+	Compatible bool
+	// Evolution is the result of the corpus checks, nil without corpus.
+	Evolution *EvolutionReport
+}
+
+// Passed tells if the candidate passed every check.
+func (report *CanaryReport) Passed() bool {
+	return report.Compatible && (report.Evolution == nil || report.Evolution.Compatible())
+}
+
+// RegisterCanary registers a candidate schema under the canary subject
+// of the subject, for consumers and producers taking part in the staged
+// rollout to use it before it is promoted. The candidate replaces the
+// previous one, which is soft deleted. Nothing is registered unless the
+// registry accepts the candidate as the next version of the subject.
+func RegisterCanary(ctx context.Context, client ISchemaRegistryClient, subject, schema string, schemaType SchemaType, references ...Reference) (*Schema, error) {
+	compatible, err := client.IsSchemaCompatible(ctx, subject, schema, "latest", schemaType, references...)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil && !compatible {
+		return nil, fmt.Errorf("candidate schema isn't compatible with subject %s", subject)
+	}
+
+	canary := CanarySubject(subject)
+	if _, err := client.DeleteSubject(ctx, canary, false); err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+	return client.CreateSchema(ctx, canary, schema, schemaType, references...)
+}
+
+// CheckCanary checks the candidate of the canary subject against the
+// subject: with the compatibility checks of the registry and, when a
+// golden corpus of the subject is given, by reading its records with
+// the candidate and samples of the candidate with the previous versions,
+// as required by the compatibility level of the subject.
+func CheckCanary(ctx context.Context, client ISchemaRegistryClient, subject string, corpus *GoldenCorpus, samples int) (*CanaryReport, error) {
+	candidate, err := client.GetLatestSchema(ctx, CanarySubject(subject))
+	if err != nil {
+		return nil, err
+	}
+	schemaType := schemaTypeOf(candidate)
+
+	report := &CanaryReport{Schema: candidate, Compatible: true}
+	compatible, err := client.IsSchemaCompatible(ctx, subject, candidate.Schema(), "latest", schemaType, candidate.References()...)
+	switch {
+	case err == nil:
+		report.Compatible = compatible
+	case !isNotFoundError(err):
+		return nil, err
+	}
+
+	if corpus != nil {
+		level, err := client.GetCompatibilityLevel(ctx, subject, true)
+		if err != nil {
+			return nil, err
+		}
+		report.Evolution, err = SimulateEvolution(ctx, client, corpus, candidate.Schema(), schemaType, *level, samples)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return report, nil
+}
+
+// PromoteCanary registers the candidate of the canary subject as the
+// next version of the subject in a single registration, so consumers
+// of the subject either see the previous version or the candidate as
+// its latest, then soft deletes the canary subject.
+func PromoteCanary(ctx context.Context, client ISchemaRegistryClient, subject string) (*Schema, error) {
+	canary := CanarySubject(subject)
+	candidate, err := client.GetLatestSchema(ctx, canary)
+	if err != nil {
+		return nil, err
+	}
+
+	promoted, err := client.CreateSchemaWithMetadata(ctx, subject, candidate.Schema(), schemaTypeOf(candidate), candidate.Metadata(), candidate.References()...)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := client.DeleteSubject(ctx, canary, false); err != nil {
+		return promoted, fmt.Errorf("schema promoted but canary subject %s not deleted: %w", canary, err)
+	}
+	return promoted, nil
+}
@@ -0,0 +1,69 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const canaryCandidate = `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int", "default": 1}]}`
+
+func TestCanary_RegisterCheckAndPromote(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	corpus, err := BuildGoldenCorpus(context.Background(), registry, "cupcakes-value", 3, 42)
+	require.NoError(t, err)
+
+	// Act
+	candidate, registerErr := RegisterCanary(context.Background(), registry, "cupcakes-value", canaryCandidate, Avro)
+	report, checkErr := CheckCanary(context.Background(), registry, "cupcakes-value", corpus, 3)
+	promoted, promoteErr := PromoteCanary(context.Background(), registry, "cupcakes-value")
+
+	// Assert
+	require.NoError(t, registerErr)
+	require.NoError(t, checkErr)
+	require.NoError(t, promoteErr)
+	assert.Equal(t, 1, candidate.Version())
+	assert.True(t, report.Passed())
+	assert.Equal(t, 3, report.Evolution.Checked)
+	assert.Equal(t, 2, promoted.Version())
+	latest, err := registry.GetLatestSchema(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	assert.Equal(t, promoted.ID(), latest.ID())
+	softDeleted, err := registry.IsSubjectSoftDeleted(context.Background(), "cupcakes-value.canary")
+	require.NoError(t, err)
+	assert.True(t, softDeleted)
+}
+
+func TestRegisterCanary_ReplacesPreviousCandidate(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := RegisterCanary(context.Background(), registry, "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+
+	_, err = RegisterCanary(context.Background(), registry, "cupcakes-value", testSchema2, Avro)
+
+	require.NoError(t, err)
+	versions, err := registry.GetSchemaVersions(context.Background(), CanarySubject("cupcakes-value"))
+	require.NoError(t, err)
+	assert.Equal(t, []int{2}, versions)
+}
+
+func TestRegisterCanary_Incompatible(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+
+	_, err = RegisterCanary(context.Background(), registry, "cupcakes-value", testSchema2, Avro)
+
+	assert.ErrorContains(t, err, "isn't compatible")
+	exists, err := registry.SubjectExists(context.Background(), CanarySubject("cupcakes-value"))
+	require.NoError(t, err)
+	assert.False(t, exists)
+}
@@ -0,0 +1,93 @@
+package srclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"sync"
+)
+
+// SchemaMutationError is returned when the registry serves a schema
+// whose content differs from the one it served earlier under the same
+// ID or subject version, for instance after a registry was restored
+// from an old backup and reused IDs.
+type SchemaMutationError struct {
+	// Subject and Version are empty when the schema was read by ID.
+	Subject  string
+	Version  int
+	ID       int
+	Expected string
+	Actual   string
+}
+
+func (e *SchemaMutationError) Error() string {
+	if e.Subject == "" {
+		return fmt.Sprintf("schema id %d changed in the registry: fingerprint %s, was %s", e.ID, e.Actual, e.Expected)
+	}
+	return fmt.Sprintf("schema of subject %s version %d changed in the registry: fingerprint %s, was %s", e.Subject, e.Version, e.Actual, e.Expected)
+}
+
+// checksumPins keeps the fingerprints of the schemas served by the client.
+// Unlike the caches, it survives ResetCache, as rereading the schemas is
+// what it guards.
+type checksumPins struct {
+	lock      sync.Mutex
+	enabled   bool
+	byID      map[int]string
+	byVersion map[subjectCacheKey]string
+}
+
+// WithChecksumPinning makes the client record the fingerprint of every
+// schema it reads from the registry, by ID and by subject version, and
+// fail with a SchemaMutationError when the registry later returns a
+// different schema for the same ID or subject version.
+func WithChecksumPinning(enabled bool) Option {
+	return func(options *clientOptions) {
+		options.checksumPinning = enabled
+	}
+}
+
+// verify pins the fingerprint of a schema read from the registry or
+// returns an error if it differs from the pinned one. The schema
+// isn't pinned by version when subject is empty.
+func (pins *checksumPins) verify(subject string, schema *Schema) error {
+	if !pins.enabled {
+		return nil
+	}
+	fingerprint := schemaFingerprint(schema)
+
+	pins.lock.Lock()
+	defer pins.lock.Unlock()
+	if pins.byID == nil {
+		pins.byID = make(map[int]string)
+		pins.byVersion = make(map[subjectCacheKey]string)
+	}
+
+	key := versionCacheKey(subject, strconv.Itoa(schema.version))
+	pinVersion := subject != "" && schema.version > 0
+	if pinned, ok := pins.byVersion[key]; pinVersion && ok && pinned != fingerprint {
+		return &SchemaMutationError{Subject: subject, Version: schema.version, ID: schema.id, Expected: pinned, Actual: fingerprint}
+	}
+	if pinned, ok := pins.byID[schema.id]; ok && pinned != fingerprint {
+		return &SchemaMutationError{ID: schema.id, Expected: pinned, Actual: fingerprint}
+	}
+	if pinVersion {
+		pins.byVersion[key] = fingerprint
+	}
+	pins.byID[schema.id] = fingerprint
+	return nil
+}
+
+// schemaFingerprint is the SHA-256 of the type, text and references of
+// a schema.
+func schemaFingerprint(schema *Schema) string {
+	hash := sha256.New()
+	_, _ = fmt.Fprintf(hash, "%s\n%s\n", schemaTypeOf(schema), schema.schema)
+	if len(schema.references) > 0 {
+		references, _ := json.Marshal(schema.references)
+		hash.Write(references)
+	}
+	return hex.EncodeToString(hash.Sum(nil))
+}
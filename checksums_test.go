@@ -0,0 +1,97 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_ChecksumPinning(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		fetch    func(client *SchemaRegistryClient) (*Schema, error)
+		expected SchemaMutationError
+	}{
+		"by id": {
+			fetch: func(client *SchemaRegistryClient) (*Schema, error) {
+				return client.GetSchema(context.Background(), 1)
+			},
+			expected: SchemaMutationError{ID: 1},
+		},
+		"by version": {
+			fetch: func(client *SchemaRegistryClient) (*Schema, error) {
+				return client.GetSchemaByVersion(context.Background(), "test1", 1)
+			},
+			expected: SchemaMutationError{Subject: "test1", Version: 1, ID: 1},
+		},
+		"latest": {
+			fetch: func(client *SchemaRegistryClient) (*Schema, error) {
+				return client.GetLatestSchema(context.Background(), "test1")
+			},
+			expected: SchemaMutationError{Subject: "test1", Version: 1, ID: 1},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var restored int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				schema := testSchema1
+				if atomic.LoadInt32(&restored) == 1 {
+					schema = testSchema2
+				}
+				_ = json.NewEncoder(rw).Encode(schemaResponse{Subject: "test1", Version: 1, ID: 1, Schema: schema})
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL, WithCaching(false), WithChecksumPinning(true))
+			_, err := testData.fetch(srClient)
+			require.NoError(t, err)
+			_, err = testData.fetch(srClient)
+			require.NoError(t, err)
+
+			// Act
+			atomic.StoreInt32(&restored, 1)
+			schema, err := testData.fetch(srClient)
+
+			// Assert
+			assert.Nil(t, schema)
+			var mutationErr *SchemaMutationError
+			require.True(t, errors.As(err, &mutationErr))
+			assert.Equal(t, testData.expected.Subject, mutationErr.Subject)
+			assert.Equal(t, testData.expected.Version, mutationErr.Version)
+			assert.Equal(t, testData.expected.ID, mutationErr.ID)
+			assert.NotEqual(t, mutationErr.Expected, mutationErr.Actual)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_ChecksumPinningDisabled(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		schema := testSchema1
+		if atomic.AddInt32(&calls, 1) > 1 {
+			schema = testSchema2
+		}
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: schema})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithCaching(false))
+
+	_, err := srClient.GetSchema(context.Background(), 1)
+	require.NoError(t, err)
+	schema, err := srClient.GetSchema(context.Background(), 1)
+
+	require.NoError(t, err)
+	assert.Equal(t, testSchema2, schema.Schema())
+}
@@ -0,0 +1,113 @@
+package srclient
+
+import (
+	"sync"
+	"time"
+)
+
+// Clock provides the time to the client. The system clock is used by
+// default, tests can swap it for a ManualClock to drive time dependent
+// behaviors, such as staleness or polling backoff, without sleeping.
+type Clock interface {
+	Now() time.Time
+	After(d time.Duration) <-chan time.Time
+}
+
+type systemClock struct{}
+
+func (systemClock) Now() time.Time {
+	return time.Now()
+}
+
+func (systemClock) After(d time.Duration) <-chan time.Time {
+	return time.After(d)
+}
+
+// ManualClock is a Clock which only moves when told to, for tests of
+// the client and of code built on top of it.
+type ManualClock struct {
+	lock    sync.Mutex
+	now     time.Time
+	waiters []manualWaiter
+}
+
+type manualWaiter struct {
+	deadline time.Time
+	ch       chan time.Time
+}
+
+// NewManualClock creates a ManualClock set at the given time.
+func NewManualClock(start time.Time) *ManualClock {
+	return &ManualClock{now: start}
+}
+
+// Now returns the current time of the clock.
+func (c *ManualClock) Now() time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return c.now
+}
+
+// After returns a channel receiving the time once the clock has been
+// advanced by at least d.
+func (c *ManualClock) After(d time.Duration) <-chan time.Time {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	ch := make(chan time.Time, 1)
+	if d <= 0 {
+		ch <- c.now
+		return ch
+	}
+	c.waiters = append(c.waiters, manualWaiter{deadline: c.now.Add(d), ch: ch})
+	return ch
+}
+
+// Advance moves the clock forward and fires the channels whose
+// deadline has been reached.
+func (c *ManualClock) Advance(d time.Duration) {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	c.now = c.now.Add(d)
+
+	pending := c.waiters[:0]
+	for _, waiter := range c.waiters {
+		if waiter.deadline.After(c.now) {
+			pending = append(pending, waiter)
+			continue
+		}
+		waiter.ch <- c.now
+	}
+	c.waiters = pending
+}
+
+// Waiters returns the number of channels waiting for the clock to be
+// advanced, letting tests synchronize with goroutines blocked on it.
+func (c *ManualClock) Waiters() int {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	return len(c.waiters)
+}
+
+// SetClock replaces the clock used by the client, nil restores the
+// system clock.
+func (client *SchemaRegistryClient) SetClock(clock Clock) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	client.clockLock.Lock()
+	defer client.clockLock.Unlock()
+	client.clock = clock
+}
+
+func (client *SchemaRegistryClient) getClock() Clock {
+	client.clockLock.RLock()
+	defer client.clockLock.RUnlock()
+	if client.clock == nil {
+		return systemClock{}
+	}
+	return client.clock
+}
+
+func (client *SchemaRegistryClient) now() time.Time {
+	return client.getClock().Now()
+}
@@ -0,0 +1,75 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestManualClock(t *testing.T) {
+	t.Parallel()
+	start := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := NewManualClock(start)
+
+	immediate := clock.After(0)
+	later := clock.After(time.Second)
+	muchLater := clock.After(time.Minute)
+
+	assert.Equal(t, start, <-immediate)
+	assert.Equal(t, 2, clock.Waiters())
+
+	clock.Advance(time.Second)
+	assert.Equal(t, start.Add(time.Second), <-later)
+	assert.Equal(t, start.Add(time.Second), clock.Now())
+	assert.Equal(t, 1, clock.Waiters())
+
+	select {
+	case <-muchLater:
+		assert.Fail(t, "fired before its deadline")
+	default:
+	}
+	clock.Advance(time.Hour)
+	assert.Equal(t, start.Add(time.Hour+time.Second), <-muchLater)
+	assert.Equal(t, 0, clock.Waiters())
+}
+
+func TestSchemaRegistryClient_WaitForSubjectFollowsClock(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+	}))
+	defer server.Close()
+
+	clock := NewManualClock(time.Unix(0, 0))
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetClock(clock)
+
+	done := make(chan error)
+	go func() {
+		done <- srClient.WaitForSubject(context.Background(), "test1", time.Hour)
+	}()
+
+	// Each advance releases one backoff, 100ms, 200ms, ... capped to 2s
+	for i := 0; i < 5; i++ {
+		for clock.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(waitMaxBackoff)
+	}
+	for clock.Waiters() == 0 {
+		time.Sleep(time.Millisecond)
+	}
+	clock.Advance(time.Hour)
+
+	err := <-done
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, int32(7), atomic.LoadInt32(&calls))
+}
@@ -0,0 +1,93 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+)
+
+// ErrClientClosed is returned by the requests of a closed client.
+var ErrClientClosed = errors.New("schema registry client closed")
+
+// lifecycle counts the requests in flight so that closing the client
+// can wait for them.
+type lifecycle struct {
+	lock     sync.Mutex
+	closed   bool
+	inflight int
+	drained  chan struct{}
+	// background is cancelled when the client is closed, it is the
+	// context of the requests the client sends on its own
+	background context.Context
+	cancel     context.CancelFunc
+}
+
+func newLifecycle() *lifecycle {
+	background, cancel := context.WithCancel(context.Background())
+	return &lifecycle{drained: make(chan struct{}), background: background, cancel: cancel}
+}
+
+// begin registers a request, it fails once the client is closed.
+func (l *lifecycle) begin() error {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.closed {
+		return ErrClientClosed
+	}
+	l.inflight++
+	return nil
+}
+
+func (l *lifecycle) end() {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	l.inflight--
+	if l.closed && l.inflight == 0 {
+		close(l.drained)
+	}
+}
+
+// close refuses the next requests, it tells false when the client was
+// already closed.
+func (l *lifecycle) close() bool {
+	l.lock.Lock()
+	defer l.lock.Unlock()
+	if l.closed {
+		return false
+	}
+	l.closed = true
+	if l.inflight == 0 {
+		close(l.drained)
+	}
+	return true
+}
+
+// Close shuts the client down: the next requests fail with
+// ErrClientClosed, the background probes of WithLatencyAwareEndpoints
+// are stopped and the requests in flight are waited for until the
+// context is done, whose error is then returned. The shared cache is
+// flushed when it has a Flush(ctx) error method, and the idle connections
+// of the HTTP client are closed. A client returned by GetOrCreateClient
+// only releases a reference, it is closed and no longer shared once all
+// of them are released. Closing a closed client does nothing.
+func (client *SchemaRegistryClient) Close(ctx context.Context) error {
+	if !releaseSharedClient(client) || !client.lifecycle.close() {
+		return nil
+	}
+
+	var err error
+	select {
+	case <-client.lifecycle.drained:
+	case <-ctx.Done():
+		err = ctx.Err()
+	}
+	client.lifecycle.cancel()
+
+	if flusher, ok := client.getSharedCache().(interface{ Flush(context.Context) error }); ok {
+		if flushErr := flusher.Flush(ctx); flushErr != nil && err == nil {
+			err = flushErr
+		}
+	}
+	client.httpClient.CloseIdleConnections()
+	return err
+}
@@ -0,0 +1,127 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type flushedCache struct {
+	flushed chan struct{}
+}
+
+func (cache *flushedCache) Get(context.Context, string) ([]byte, bool, error) {
+	return nil, false, nil
+}
+
+func (cache *flushedCache) Set(context.Context, string, []byte) error {
+	return nil
+}
+
+func (cache *flushedCache) Flush(context.Context) error {
+	close(cache.flushed)
+	return nil
+}
+
+func TestSchemaRegistryClient_CloseDrainsRequests(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	received, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		close(received)
+		<-release
+		_, _ = rw.Write([]byte(`["cupcake"]`))
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+	cache := &flushedCache{flushed: make(chan struct{})}
+	srClient.SetSharedCache(cache)
+
+	subjects := make(chan error, 1)
+	go func() {
+		_, err := srClient.GetSubjects(context.Background())
+		subjects <- err
+	}()
+	<-received
+
+	// Act
+	closed := make(chan error, 1)
+	go func() {
+		closed <- srClient.Close(context.Background())
+	}()
+
+	// Assert
+	select {
+	case <-closed:
+		t.Fatal("closed before the request in flight ended")
+	case <-time.After(50 * time.Millisecond):
+	}
+	close(release)
+	assert.NoError(t, <-subjects)
+	assert.NoError(t, <-closed)
+	<-cache.flushed
+	_, err := srClient.GetSubjects(context.Background())
+	assert.ErrorIs(t, err, ErrClientClosed)
+	assert.NoError(t, srClient.Close(context.Background()))
+}
+
+func TestSchemaRegistryClient_CloseDeadline(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	received, release := make(chan struct{}), make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		close(received)
+		<-release
+	}))
+	defer server.Close()
+	defer close(release)
+	srClient := CreateSchemaRegistryClient(server.URL)
+	go func() {
+		_, _ = srClient.GetSubjects(context.Background())
+	}()
+	<-received
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	// Act
+	err := srClient.Close(ctx)
+
+	// Assert
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
+
+func TestSchemaRegistryClient_CloseSharedClient(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`["cupcake"]`))
+	}))
+	defer server.Close()
+	first := GetOrCreateClient(server.URL)
+	second := GetOrCreateClient(server.URL)
+	require.Same(t, first, second)
+
+	// Act
+	firstErr := first.Close(context.Background())
+	_, stillOpenErr := second.GetSubjects(context.Background())
+	third := GetOrCreateClient(server.URL)
+	secondErr := second.Close(context.Background())
+	_, stillOpenErr2 := third.GetSubjects(context.Background())
+	thirdErr := third.Close(context.Background())
+	_, closedErr := third.GetSubjects(context.Background())
+
+	// Assert
+	require.NoError(t, firstErr)
+	require.NoError(t, secondErr)
+	require.NoError(t, thirdErr)
+	assert.NoError(t, stillOpenErr)
+	assert.NoError(t, stillOpenErr2)
+	assert.Same(t, first, third)
+	assert.ErrorIs(t, closedErr, ErrClientClosed)
+	assert.NotSame(t, first, GetOrCreateClient(server.URL))
+}
@@ -0,0 +1,44 @@
+// Command srclient-check validates the schema files mapped by a
+// srclient.yaml without reaching the registry: every file must parse
+// and stay compatible with its previous git revision. It is meant to
+// run from pre-commit or lefthook hooks and exits with status 1 when a
+// schema fails a check.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/crxfoz/srclient"
+)
+
+func main() {
+	configPath := flag.String("config", srclient.SyncConfigFile, "configuration mapping schema files to subjects")
+	revision := flag.String("rev", "HEAD", "git revision to check compatibility against, empty to only parse")
+	flag.Parse()
+
+	config, err := srclient.LoadSyncConfig(*configPath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+
+	dir := filepath.Dir(*configPath)
+	var previous srclient.PreviousRevision
+	if *revision != "" {
+		previous = srclient.GitRevision(dir, *revision)
+	}
+	problems, err := srclient.CheckSchemaChanges(dir, config, previous)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(2)
+	}
+	for _, problem := range problems {
+		fmt.Fprintln(os.Stderr, problem)
+	}
+	if len(problems) > 0 {
+		os.Exit(1)
+	}
+}
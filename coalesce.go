@@ -0,0 +1,55 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+)
+
+// coalesce runs fetch once for the concurrent calls with the same key,
+// such as the goroutines decoding records of a schema not cached yet,
+// and gives its result to all of them. A call whose context is done
+// returns without waiting for the fetch, and the calls which were
+// waiting for a fetch cancelled by the context of another call fetch
+// again with theirs. Not found errors are remembered by the negative
+// cache.
+func (client *SchemaRegistryClient) coalesce(ctx context.Context, key string, fetch func(ctx context.Context) (*Schema, error)) (*Schema, error) {
+	if err := client.notFound.get(key, client.now()); err != nil {
+		return nil, err
+	}
+	for {
+		results := client.inflight.DoChan(key, func() (interface{}, error) {
+			schema, err := fetch(ctx)
+			if err != nil && ctx.Err() != nil {
+				return nil, &cancelledFetchError{err: err}
+			}
+			client.notFound.remember(key, err, client.now())
+			return schema, err
+		})
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case result := <-results:
+			var cancelled *cancelledFetchError
+			if errors.As(result.Err, &cancelled) {
+				if ctx.Err() == nil {
+					continue
+				}
+				return nil, cancelled.err
+			}
+			if result.Err != nil {
+				return nil, result.Err
+			}
+			return result.Val.(*Schema), nil
+		}
+	}
+}
+
+// cancelledFetchError is the error of a fetch cancelled by the context
+// of the call which started it.
+type cancelledFetchError struct {
+	err error
+}
+
+func (err *cancelledFetchError) Error() string {
+	return err.err.Error()
+}
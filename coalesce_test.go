@@ -0,0 +1,105 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func countingRegistry(t *testing.T, calls *int32, delay time.Duration) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(calls, 1)
+		time.Sleep(delay)
+		rw.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		fmt.Fprint(rw, `{"id": 1, "version": 1, "schema": "\"string\""}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestSchemaRegistryClient_CoalescesFetches(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		fetch func(client *SchemaRegistryClient) (*Schema, error)
+	}{
+		"by id": {
+			fetch: func(client *SchemaRegistryClient) (*Schema, error) {
+				return client.GetSchema(context.Background(), 1)
+			},
+		},
+		"by subject and id": {
+			fetch: func(client *SchemaRegistryClient) (*Schema, error) {
+				return client.GetSchemaBySubjectAndID(context.Background(), "cupcakes-value", 1)
+			},
+		},
+		"by version": {
+			fetch: func(client *SchemaRegistryClient) (*Schema, error) {
+				return client.GetSchemaByVersion(context.Background(), "cupcakes-value", 1)
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var calls int32
+			client := NewClient(countingRegistry(t, &calls, 100*time.Millisecond).URL, WithCaching(false))
+
+			// Act
+			var wg sync.WaitGroup
+			errs := make([]error, 10)
+			for i := range errs {
+				wg.Add(1)
+				go func(i int) {
+					defer wg.Done()
+					_, errs[i] = testData.fetch(client)
+				}(i)
+			}
+			wg.Wait()
+
+			// Assert
+			for _, err := range errs {
+				assert.NoError(t, err)
+			}
+			assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestSchemaRegistryClient_CoalescedFetchCancelled(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	client := NewClient(countingRegistry(t, &calls, 100*time.Millisecond).URL, WithCaching(false))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancelled := make(chan error)
+	go func() {
+		_, err := client.GetSchema(ctx, 1)
+		cancelled <- err
+	}()
+	require.Eventually(t, func() bool { return atomic.LoadInt32(&calls) == 1 }, time.Second, time.Millisecond)
+
+	// Act
+	fetched := make(chan error)
+	go func() {
+		_, err := client.GetSchema(context.Background(), 1)
+		fetched <- err
+	}()
+	time.Sleep(10 * time.Millisecond)
+	cancel()
+
+	// Assert
+	assert.ErrorIs(t, <-cancelled, context.Canceled)
+	assert.NoError(t, <-fetched)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
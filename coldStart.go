@@ -0,0 +1,71 @@
+package srclient
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// coldStartLimiter spaces out the requests fetching schemas missing from
+// the caches for a while after startup.
+type coldStartLimiter struct {
+	lock     sync.Mutex
+	interval time.Duration
+	until    time.Time
+	next     time.Time
+}
+
+// SetColdStartFetchRate limits the requests fetching schemas missing
+// from the caches to perSecond, for the given duration from now. Large
+// consumer groups restarting together all start with empty caches, the
+// limit keeps them from flooding the registry until their caches are
+// warm. It comes on top of the concurrent requests semaphore, which
+// bounds the requests in flight rather than their rate. Writes aren't
+// limited. A zero rate removes the limit.
+func (client *SchemaRegistryClient) SetColdStartFetchRate(perSecond float64, duration time.Duration) {
+	client.coldStart.lock.Lock()
+	defer client.coldStart.lock.Unlock()
+	client.coldStart.interval = 0
+	if perSecond > 0 {
+		client.coldStart.interval = time.Duration(float64(time.Second) / perSecond)
+	}
+	client.coldStart.until = client.now().Add(duration)
+	client.coldStart.next = time.Time{}
+}
+
+// wait blocks until the next fetch is allowed.
+func (limiter *coldStartLimiter) wait(ctx context.Context, clock Clock) error {
+	limiter.lock.Lock()
+	now := clock.Now()
+	if limiter.interval <= 0 || !now.Before(limiter.until) {
+		limiter.lock.Unlock()
+		return nil
+	}
+	slot := limiter.next
+	if slot.Before(now) {
+		slot = now
+	}
+	limiter.next = slot.Add(limiter.interval)
+	if slot.After(limiter.until) {
+		slot = limiter.until
+	}
+	limiter.lock.Unlock()
+
+	if !slot.After(now) {
+		return nil
+	}
+	select {
+	case <-clock.After(slot.Sub(now)):
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// fetchSchema reads a schema from the registry after a cache miss.
+func (client *SchemaRegistryClient) fetchSchema(ctx context.Context, uri string) ([]byte, error) {
+	if err := client.coldStart.wait(ctx, client.getClock()); err != nil {
+		return nil, err
+	}
+	return client.httpRequest(ctx, "GET", uri, nil)
+}
@@ -0,0 +1,83 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaRegistryClient_ColdStartFetchRate(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetClock(clock)
+	srClient.SetColdStartFetchRate(10, time.Minute)
+
+	// Act
+	var wg sync.WaitGroup
+	for id := 1; id <= 3; id++ {
+		wg.Add(1)
+		go func(id int) {
+			defer wg.Done()
+			_, err := srClient.GetSchema(context.Background(), id)
+			assert.NoError(t, err)
+		}(id)
+	}
+
+	// Assert
+	for clock.Waiters() < 2 || atomic.LoadInt32(&calls) < 1 {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, int32(1), atomic.LoadInt32(&calls))
+	clock.Advance(100 * time.Millisecond)
+	for atomic.LoadInt32(&calls) < 2 {
+		time.Sleep(time.Millisecond)
+	}
+	assert.Equal(t, 1, clock.Waiters())
+	clock.Advance(100 * time.Millisecond)
+	wg.Wait()
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+
+	// Cache hits and requests after the cold start aren't limited
+	_, err := srClient.GetSchema(context.Background(), 1)
+	assert.NoError(t, err)
+	clock.Advance(time.Minute)
+	for id := 4; id <= 6; id++ {
+		_, err := srClient.GetSchema(context.Background(), id)
+		assert.NoError(t, err)
+	}
+	assert.Equal(t, int32(6), atomic.LoadInt32(&calls))
+}
+
+func TestSchemaRegistryClient_ColdStartFetchRateHonorsContext(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetClock(NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
+	srClient.SetColdStartFetchRate(1, time.Minute)
+	_, err := srClient.GetSchema(context.Background(), 1)
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	_, err = srClient.GetSchema(ctx, 2)
+
+	assert.ErrorIs(t, err, context.Canceled)
+}
@@ -0,0 +1,155 @@
+package srclient
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// Compression is the algorithm a CompressionProcessor compresses bodies
+// with. Its value is the flag byte prepended to the compressed bodies.
+type Compression byte
+
+const (
+	NoCompression Compression = 0
+	Gzip          Compression = 1
+	Zstd          Compression = 2
+)
+
+func (compression Compression) String() string {
+	switch compression {
+	case NoCompression:
+		return "none"
+	case Gzip:
+		return "gzip"
+	case Zstd:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown (%d)", byte(compression))
+	}
+}
+
+var errEmptyCompressedBody = errors.New("missing compression flag")
+
+// CompressionProcessor is a PayloadProcessor compressing the bodies of
+// records. Compressed bodies start with a flag byte telling the
+// Compression they use, bodies smaller than the minimum size are left
+// uncompressed behind a NoCompression flag. Any flagged body can be
+// decompressed, whichever Compression the processor is configured with,
+// so producers can switch algorithms without breaking their consumers.
+// The zstd encoder is only created for Zstd and the decoder on the first
+// zstd body, Close releases them.
+type CompressionProcessor struct {
+	compression Compression
+	minSize     int
+	zstdEncoder *zstd.Encoder
+	zstdLock    sync.Mutex
+	zstdDecoder *zstd.Decoder
+}
+
+// NewCompressionProcessor creates a CompressionProcessor compressing the
+// bodies of at least minSize bytes.
+func NewCompressionProcessor(compression Compression, minSize int) (*CompressionProcessor, error) {
+	processor := &CompressionProcessor{compression: compression, minSize: minSize}
+	switch compression {
+	case NoCompression, Gzip:
+	case Zstd:
+		encoder, err := zstd.NewWriter(nil)
+		if err != nil {
+			return nil, err
+		}
+		processor.zstdEncoder = encoder
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", compression)
+	}
+	return processor, nil
+}
+
+// Close releases the zstd encoder and decoder of the processor once it
+// is no longer used.
+func (processor *CompressionProcessor) Close() error {
+	var err error
+	if processor.zstdEncoder != nil {
+		err = processor.zstdEncoder.Close()
+	}
+	processor.zstdLock.Lock()
+	defer processor.zstdLock.Unlock()
+	if processor.zstdDecoder != nil {
+		processor.zstdDecoder.Close()
+	}
+	return err
+}
+
+// decoder returns the zstd decoder, creating it the first time.
+func (processor *CompressionProcessor) decoder() (*zstd.Decoder, error) {
+	processor.zstdLock.Lock()
+	defer processor.zstdLock.Unlock()
+	if processor.zstdDecoder == nil {
+		decoder, err := zstd.NewReader(nil)
+		if err != nil {
+			return nil, err
+		}
+		processor.zstdDecoder = decoder
+	}
+	return processor.zstdDecoder, nil
+}
+
+// Process compresses the body and flags it with its compression.
+func (processor *CompressionProcessor) Process(_ context.Context, _ string, body []byte) ([]byte, error) {
+	compression := processor.compression
+	if len(body) < processor.minSize {
+		compression = NoCompression
+	}
+
+	compressed := []byte{byte(compression)}
+	switch compression {
+	case Gzip:
+		buffer := bytes.NewBuffer(compressed)
+		writer := gzip.NewWriter(buffer)
+		if _, err := writer.Write(body); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+		return buffer.Bytes(), nil
+	case Zstd:
+		return processor.zstdEncoder.EncodeAll(body, compressed), nil
+	default:
+		return append(compressed, body...), nil
+	}
+}
+
+// Unprocess decompresses a body according to its flag.
+func (processor *CompressionProcessor) Unprocess(_ context.Context, _ string, body []byte) ([]byte, error) {
+	if len(body) == 0 {
+		return nil, errEmptyCompressedBody
+	}
+
+	compression, compressed := Compression(body[0]), body[1:]
+	switch compression {
+	case NoCompression:
+		return compressed, nil
+	case Gzip:
+		reader, err := gzip.NewReader(bytes.NewReader(compressed))
+		if err != nil {
+			return nil, err
+		}
+		defer reader.Close()
+		return io.ReadAll(reader)
+	case Zstd:
+		decoder, err := processor.decoder()
+		if err != nil {
+			return nil, err
+		}
+		return decoder.DecodeAll(compressed, nil)
+	default:
+		return nil, fmt.Errorf("unsupported compression %s", compression)
+	}
+}
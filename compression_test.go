@@ -0,0 +1,123 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompressionProcessor(t *testing.T) {
+	t.Parallel()
+	large := bytes.Repeat([]byte("vanilla cupcake "), 256)
+	small := []byte("vanilla")
+
+	tests := map[string]struct {
+		compression  Compression
+		body         []byte
+		expectedFlag Compression
+	}{
+		"gzip":               {compression: Gzip, body: large, expectedFlag: Gzip},
+		"zstd":               {compression: Zstd, body: large, expectedFlag: Zstd},
+		"no compression":     {compression: NoCompression, body: large, expectedFlag: NoCompression},
+		"below minimum size": {compression: Zstd, body: small, expectedFlag: NoCompression},
+		"empty body":         {compression: Gzip, body: []byte{}, expectedFlag: NoCompression},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			processor, err := NewCompressionProcessor(testData.compression, 64)
+			require.NoError(t, err)
+
+			compressed, err := processor.Process(context.Background(), "cupcakes-value", testData.body)
+			require.NoError(t, err)
+			assert.Equal(t, byte(testData.expectedFlag), compressed[0])
+			if testData.expectedFlag != NoCompression {
+				assert.Less(t, len(compressed), len(testData.body))
+			}
+
+			decompressed, err := processor.Unprocess(context.Background(), "cupcakes-value", compressed)
+			require.NoError(t, err)
+			assert.Equal(t, testData.body, decompressed)
+		})
+	}
+}
+
+func TestCompressionProcessor_DecompressesAnyFlag(t *testing.T) {
+	t.Parallel()
+	body := bytes.Repeat([]byte("vanilla cupcake "), 256)
+	gzipProcessor, err := NewCompressionProcessor(Gzip, 0)
+	require.NoError(t, err)
+	zstdProcessor, err := NewCompressionProcessor(Zstd, 0)
+	require.NoError(t, err)
+
+	compressed, err := gzipProcessor.Process(context.Background(), "cupcakes-value", body)
+	require.NoError(t, err)
+	decompressed, err := zstdProcessor.Unprocess(context.Background(), "cupcakes-value", compressed)
+	require.NoError(t, err)
+	assert.Equal(t, body, decompressed)
+
+	_, err = zstdProcessor.Unprocess(context.Background(), "cupcakes-value", []byte{42, 1, 2})
+	assert.EqualError(t, err, "unsupported compression unknown (42)")
+	_, err = zstdProcessor.Unprocess(context.Background(), "cupcakes-value", nil)
+	assert.Error(t, err)
+	_, err = NewCompressionProcessor(Compression(42), 0)
+	assert.Error(t, err)
+}
+
+func TestCompressionProcessor_CreatesZstdCodersOnDemand(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	body := bytes.Repeat([]byte("vanilla cupcake "), 256)
+	gzipProcessor, err := NewCompressionProcessor(Gzip, 0)
+	require.NoError(t, err)
+	zstdProcessor, err := NewCompressionProcessor(Zstd, 0)
+	require.NoError(t, err)
+	compressed, err := zstdProcessor.Process(context.Background(), "cupcakes-value", body)
+	require.NoError(t, err)
+	assert.Nil(t, gzipProcessor.zstdEncoder)
+	assert.Nil(t, gzipProcessor.zstdDecoder)
+
+	// Act
+	decompressed, err := gzipProcessor.Unprocess(context.Background(), "cupcakes-value", compressed)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, body, decompressed)
+	assert.Nil(t, gzipProcessor.zstdEncoder)
+	assert.NotNil(t, gzipProcessor.zstdDecoder)
+	assert.NoError(t, gzipProcessor.Close())
+	assert.NoError(t, zstdProcessor.Close())
+}
+
+func TestCompressionProcessor_EncoderRegistry(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	processor, err := NewCompressionProcessor(Zstd, 0)
+	require.NoError(t, err)
+
+	encoders := NewEncoderRegistry(registry)
+	require.NoError(t, encoders.Register("cupcakes", EncoderConfig{Subject: "cupcakes-value"}))
+	encoders.SetProcessors("cupcakes-value", processor)
+	decoders := NewDecoderRegistry(registry)
+	require.NoError(t, decoders.Register("cupcakes", DecoderConfig{}))
+	decoders.SetProcessors("cupcakes-value", processor)
+
+	// Act
+	payload, err := encoders.Encode(context.Background(), "cupcakes", map[string]interface{}{"flavor": "vanilla"})
+	require.NoError(t, err)
+	record, err := decoders.Decode(context.Background(), "cupcakes", payload)
+
+	// Assert
+	assert.Equal(t, byte(Zstd), payload[wireHeaderLength])
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"flavor": "vanilla"}, record.Value)
+	}
+}
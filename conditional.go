@@ -0,0 +1,51 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"net/http"
+)
+
+// errNotModified is returned by conditional requests answered with 304
+// Not Modified, the cached schema being still current.
+var errNotModified = errors.New("not modified")
+
+type conditionalRequestKey struct{}
+
+// conditionalRequest carries the validator of a cached response to the
+// request revalidating it, and the validator of the new response back.
+type conditionalRequest struct {
+	ifNoneMatch string
+	etag        string
+}
+
+func withConditionalRequest(ctx context.Context, conditional *conditionalRequest) context.Context {
+	return context.WithValue(ctx, conditionalRequestKey{}, conditional)
+}
+
+func conditionalRequestOf(ctx context.Context) *conditionalRequest {
+	conditional, _ := ctx.Value(conditionalRequestKey{}).(*conditionalRequest)
+	return conditional
+}
+
+// setConditionalHeaders asks for the response only if it changed since
+// the cached one.
+func setConditionalHeaders(ctx context.Context, req *http.Request) {
+	if conditional := conditionalRequestOf(ctx); conditional != nil && conditional.ifNoneMatch != "" {
+		req.Header.Set("If-None-Match", conditional.ifNoneMatch)
+	}
+}
+
+// conditionalResponse keeps the validator of the response, it tells
+// whether the response is a 304 Not Modified.
+func conditionalResponse(ctx context.Context, resp *http.Response) bool {
+	conditional := conditionalRequestOf(ctx)
+	if conditional == nil {
+		return false
+	}
+	if resp.StatusCode == http.StatusNotModified {
+		return conditional.ifNoneMatch != ""
+	}
+	conditional.etag = resp.Header.Get("ETag")
+	return false
+}
@@ -0,0 +1,77 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGetLatestSchema_ETag(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var lock sync.Mutex
+	var conditions []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		conditions = append(conditions, req.Header.Get("If-None-Match"))
+		lock.Unlock()
+		if req.Header.Get("If-None-Match") == `"v1"` {
+			rw.WriteHeader(http.StatusNotModified)
+			return
+		}
+		rw.Header().Set("ETag", `"v1"`)
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Version: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	srClient := NewClient(server.URL, WithClock(clock), WithCacheLatest(true), WithCacheTTL(time.Minute))
+	first, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+	require.NoError(t, err)
+
+	// Act
+	clock.Advance(2 * time.Minute)
+	revalidated, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+	require.NoError(t, err)
+	clock.Advance(30 * time.Second)
+	cached, cachedErr := srClient.GetLatestSchema(context.Background(), "test1-value")
+
+	// Assert
+	assert.Same(t, first, revalidated)
+	assert.NoError(t, cachedErr)
+	assert.Same(t, first, cached)
+	lock.Lock()
+	defer lock.Unlock()
+	assert.Equal(t, []string{"", `"v1"`}, conditions)
+}
+
+func TestGetLatestSchema_ETagChanged(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	version := 1
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.Header().Set("ETag", fmt.Sprintf(`"v%d"`, version))
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: version, Version: version, Schema: testSchema1})
+		version++
+	}))
+	defer server.Close()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	srClient := NewClient(server.URL, WithClock(clock), WithCacheLatest(true), WithCacheTTL(time.Minute))
+	_, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+	require.NoError(t, err)
+
+	// Act
+	clock.Advance(2 * time.Minute)
+	latest, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 2, latest.Version())
+}
@@ -0,0 +1,182 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// ConfigSetting names a setting compared by CompareConfigs.
+type ConfigSetting string
+
+const (
+	CompatibilitySetting ConfigSetting = "compatibility"
+	ModeSetting          ConfigSetting = "mode"
+)
+
+// unknownMode is the mode of the registries without the /mode endpoints.
+const unknownMode = "unknown"
+
+// ConfigDifference is a setting which differs between two registries.
+// Subject is empty for the global settings. Values inherited from the
+// global settings are followed by " (global)".
+type ConfigDifference struct {
+	Subject    string
+	Setting    ConfigSetting
+	Value      string
+	OtherValue string
+}
+
+// ConfigDriftReport lists the settings differing between two registries,
+// the global ones first then the ones of the subjects in order.
+type ConfigDriftReport struct {
+	Differences []ConfigDifference
+}
+
+// Drifted tells whether the registries have different settings.
+func (report *ConfigDriftReport) Drifted() bool {
+	return len(report.Differences) > 0
+}
+
+// String prints one line per difference.
+func (report *ConfigDriftReport) String() string {
+	if !report.Drifted() {
+		return "no config drift"
+	}
+	lines := make([]string, 0, len(report.Differences))
+	for _, difference := range report.Differences {
+		scope := "global"
+		if difference.Subject != "" {
+			scope = "subject " + difference.Subject
+		}
+		lines = append(lines, fmt.Sprintf("%s %s: %s != %s", scope, difference.Setting, difference.Value, difference.OtherValue))
+	}
+	return strings.Join(lines, "\n")
+}
+
+// CompareConfigs diffs the global compatibility level and mode of two
+// registries, such as the staging and the production ones, and the
+// effective ones of every subject of either, to catch a subject checked
+// against NONE in one of them and BACKWARD in the other. The mode of
+// registries without the /mode endpoints is "unknown", their subjects'
+// modes are then not compared.
+func CompareConfigs(ctx context.Context, client, other ISchemaRegistryClient) (*ConfigDriftReport, error) {
+	report := &ConfigDriftReport{}
+	global, err := client.GetGlobalCompatibilityLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	otherGlobal, err := other.GetGlobalCompatibilityLevel(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if *global != *otherGlobal {
+		report.Differences = append(report.Differences, ConfigDifference{Setting: CompatibilitySetting, Value: global.String(), OtherValue: otherGlobal.String()})
+	}
+	globalMode, err := registryMode(ctx, client)
+	if err != nil {
+		return nil, err
+	}
+	otherGlobalMode, err := registryMode(ctx, other)
+	if err != nil {
+		return nil, err
+	}
+	if globalMode != otherGlobalMode {
+		report.Differences = append(report.Differences, ConfigDifference{Setting: ModeSetting, Value: globalMode, OtherValue: otherGlobalMode})
+	}
+	compareModes := globalMode != unknownMode && otherGlobalMode != unknownMode
+
+	subjects, err := client.GetSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	otherSubjects, err := other.GetSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, subject := range unionSubjects(subjects, otherSubjects) {
+		value, err := subjectCompatibility(ctx, client, subject, *global)
+		if err != nil {
+			return nil, err
+		}
+		otherValue, err := subjectCompatibility(ctx, other, subject, *otherGlobal)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSuffix(value, " (global)") != strings.TrimSuffix(otherValue, " (global)") {
+			report.Differences = append(report.Differences, ConfigDifference{Subject: subject, Setting: CompatibilitySetting, Value: value, OtherValue: otherValue})
+		}
+		if !compareModes {
+			continue
+		}
+		mode, err := subjectMode(ctx, client, subject, globalMode)
+		if err != nil {
+			return nil, err
+		}
+		otherMode, err := subjectMode(ctx, other, subject, otherGlobalMode)
+		if err != nil {
+			return nil, err
+		}
+		if strings.TrimSuffix(mode, " (global)") != strings.TrimSuffix(otherMode, " (global)") {
+			report.Differences = append(report.Differences, ConfigDifference{Subject: subject, Setting: ModeSetting, Value: mode, OtherValue: otherMode})
+		}
+	}
+	return report, nil
+}
+
+// registryMode returns the mode of the registry, unknownMode when it
+// has no /mode endpoints.
+func registryMode(ctx context.Context, client ISchemaRegistryClient) (string, error) {
+	mode, err := client.GetMode(ctx)
+	if errors.Is(err, ErrFeatureNotSupported) {
+		return unknownMode, nil
+	}
+	if err != nil {
+		return "", err
+	}
+	return string(*mode), nil
+}
+
+// subjectMode returns the mode set on the subject, or the global one
+// when it has none.
+func subjectMode(ctx context.Context, client ISchemaRegistryClient, subject string, global string) (string, error) {
+	mode, err := client.GetSubjectMode(ctx, subject, false)
+	if errors.Is(err, ErrFeatureNotSupported) {
+		return unknownMode, nil
+	}
+	if isNotFoundError(err) {
+		return global + " (global)", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("subject %s: %w", subject, err)
+	}
+	return string(*mode), nil
+}
+
+// subjectCompatibility returns the compatibility level set on the
+// subject, or the global one when it has none.
+func subjectCompatibility(ctx context.Context, client ISchemaRegistryClient, subject string, global CompatibilityLevel) (string, error) {
+	level, err := client.GetCompatibilityLevel(ctx, subject, false)
+	if isNotFoundError(err) {
+		return global.String() + " (global)", nil
+	}
+	if err != nil {
+		return "", fmt.Errorf("subject %s: %w", subject, err)
+	}
+	return level.String(), nil
+}
+
+func unionSubjects(subjects, others []string) []string {
+	union := make(map[string]bool, len(subjects)+len(others))
+	for _, subject := range append(append([]string{}, subjects...), others...) {
+		union[subject] = true
+	}
+	sorted := make([]string, 0, len(union))
+	for subject := range union {
+		sorted = append(sorted, subject)
+	}
+	sort.Strings(sorted)
+	return sorted
+}
@@ -0,0 +1,108 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareConfigs(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	stage := CreateMockSchemaRegistryClient("http://stage:8081")
+	prod := CreateMockSchemaRegistryClient("http://prod:8081")
+	prod.globalCompatibility = Full
+	for _, client := range []*MockSchemaRegistryClient{stage, prod} {
+		_, err := client.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+		require.NoError(t, err)
+		_, err = client.ChangeSubjectCompatibilityLevel(context.Background(), "orders-value", Backward)
+		require.NoError(t, err)
+	}
+	_, err := stage.ChangeSubjectCompatibilityLevel(context.Background(), "cupcakes-value", None)
+	require.NoError(t, err)
+
+	// Act
+	report, err := CompareConfigs(context.Background(), stage, prod)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, report.Drifted())
+	assert.Equal(t, []ConfigDifference{
+		{Setting: CompatibilitySetting, Value: "BACKWARD", OtherValue: "FULL"},
+		{Subject: "cupcakes-value", Setting: CompatibilitySetting, Value: "NONE", OtherValue: "FULL (global)"},
+	}, report.Differences)
+	assert.Equal(t, "global compatibility: BACKWARD != FULL\nsubject cupcakes-value compatibility: NONE != FULL (global)", report.String())
+}
+
+func TestCompareConfigs_NoDrift(t *testing.T) {
+	t.Parallel()
+	stage := CreateMockSchemaRegistryClient("http://stage:8081")
+	prod := CreateMockSchemaRegistryClient("http://prod:8081")
+	_, err := prod.ChangeSubjectCompatibilityLevel(context.Background(), "cupcakes-value", Backward)
+	require.NoError(t, err)
+
+	report, err := CompareConfigs(context.Background(), stage, prod)
+
+	require.NoError(t, err)
+	assert.False(t, report.Drifted())
+	assert.Equal(t, "no config drift", report.String())
+}
+
+func TestCompareConfigs_ModeDrift(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	stage := CreateMockSchemaRegistryClient("http://stage:8081")
+	prod := CreateMockSchemaRegistryClient("http://prod:8081")
+	for _, client := range []*MockSchemaRegistryClient{stage, prod} {
+		_, err := client.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+		require.NoError(t, err)
+	}
+	_, err := stage.SetMode(context.Background(), ReadOnly)
+	require.NoError(t, err)
+	_, err = prod.SetSubjectMode(context.Background(), "cupcakes-value", ReadOnly)
+	require.NoError(t, err)
+
+	// Act
+	report, err := CompareConfigs(context.Background(), stage, prod)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []ConfigDifference{
+		{Setting: ModeSetting, Value: "READONLY", OtherValue: "READWRITE"},
+	}, report.Differences)
+}
+
+// modelessRegistry is a registry without the /mode endpoints.
+type modelessRegistry struct {
+	*MockSchemaRegistryClient
+}
+
+func (modelessRegistry) GetMode(context.Context) (*Mode, error) {
+	return nil, ErrFeatureNotSupported
+}
+
+func (modelessRegistry) GetSubjectMode(context.Context, string, bool) (*Mode, error) {
+	return nil, ErrFeatureNotSupported
+}
+
+func TestCompareConfigs_ModeUnknown(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	stage := CreateMockSchemaRegistryClient("http://stage:8081")
+	prod := CreateMockSchemaRegistryClient("http://prod:8081")
+	for _, client := range []*MockSchemaRegistryClient{stage, prod} {
+		_, err := client.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+		require.NoError(t, err)
+	}
+
+	// Act
+	report, err := CompareConfigs(context.Background(), stage, modelessRegistry{prod})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []ConfigDifference{
+		{Setting: ModeSetting, Value: "READWRITE", OtherValue: "unknown"},
+	}, report.Differences)
+}
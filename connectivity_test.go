@@ -0,0 +1,79 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestValidateSchemaRegistryURL(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		url     string
+		message string
+	}{
+		"valid http":     {url: "http://localhost:8081"},
+		"valid https":    {url: "https://registry.example.com/path/"},
+		"empty":          {url: "", message: "the URL is empty"},
+		"missing scheme": {url: "localhost:8081", message: "missing scheme"},
+		"bad scheme":     {url: "ftp://localhost", message: "unsupported scheme ftp"},
+		"missing host":   {url: "http://", message: "missing host"},
+		"unparsable":     {url: "http://local host:80:80", message: "invalid"},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			err := ValidateSchemaRegistryURL(testData.url)
+			if testData.message == "" {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidURL)
+			assert.Contains(t, err.Error(), testData.message)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_InvalidURLFailsEveryCall(t *testing.T) {
+	t.Parallel()
+	srClient := CreateSchemaRegistryClient("localhost:8081")
+
+	_, err := srClient.GetSubjects(context.Background())
+
+	assert.ErrorIs(t, err, ErrInvalidURL)
+	assert.ErrorIs(t, srClient.Ping(context.Background()), ErrInvalidURL)
+}
+
+func TestSchemaRegistryClient_Ping(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.String() != "/config" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		if _, password, _ := req.BasicAuth(); password != "secret" {
+			rw.WriteHeader(http.StatusUnauthorized)
+			_, _ = rw.Write([]byte(`{"error_code":40101,"message":"Unauthorized"}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"compatibilityLevel":"BACKWARD"}`))
+	}))
+	defer server.Close()
+
+	// A trailing slash is tolerated
+	srClient := CreateSchemaRegistryClient(server.URL + "/")
+	err := srClient.Ping(context.Background())
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "Unauthorized")
+
+	srClient.SetCredentials("user", "secret")
+	assert.NoError(t, srClient.Ping(context.Background()))
+
+	server.Close()
+	assert.Error(t, srClient.Ping(context.Background()))
+}
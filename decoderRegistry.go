@@ -0,0 +1,240 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/crxfoz/goavro/v2"
+)
+
+var errUnsupportedSchemaType = errors.New("schema type not supported for this operation")
+
+// DecoderConfig describes how the records of a topic are decoded.
+type DecoderConfig struct {
+	// SchemaType is the type of schema the records are expected to
+	// be written with, records using another type are rejected.
+	// Avro is assumed when empty.
+	SchemaType SchemaType
+	// ReaderSchema is an optional Avro schema the decoded values are
+	// read with, following the schema resolution of the specification:
+	// missing fields get their default value, unknown fields are
+	// dropped and union members are picked again.
+	ReaderSchema string
+	// SubjectNameStrategy, when set, is used to check the writer schema
+	// is registered under the subject the topic is expected to use.
+	SubjectNameStrategy SubjectNameStrategy
+	// Key tells if the records decoded are keys rather than values,
+	// it is passed on to the SubjectNameStrategy.
+	Key bool
+
+	readerSchema *Schema
+	projections  *avroProjections
+}
+
+// DecodedRecord is a record decoded by a DecoderRegistry.
+type DecodedRecord struct {
+	Topic string
+	// Subject is only set when the topic uses a SubjectNameStrategy.
+	Subject string
+	// Schema is the schema the record was written with.
+	Schema *Schema
+	// Value is the native Go representation of the record.
+	Value interface{}
+}
+
+// DecoderRegistry dispatches records to the decoder configured for
+// their topic, so consumers of many topics can configure decoding
+// declaratively.
+type DecoderRegistry struct {
+	client         ISchemaRegistryClient
+	decoders       map[string]*DecoderConfig
+	defaultDecoder *DecoderConfig
+	lock           sync.RWMutex
+	processors     subjectProcessors
+}
+
+// NewDecoderRegistry creates an empty DecoderRegistry fetching the
+// writer schemas with the given client.
+func NewDecoderRegistry(client ISchemaRegistryClient) *DecoderRegistry {
+	return &DecoderRegistry{
+		client:   client,
+		decoders: make(map[string]*DecoderConfig),
+	}
+}
+
+// Register configures how the records of the given topic are decoded.
+func (registry *DecoderRegistry) Register(topic string, config DecoderConfig) error {
+	prepared, err := prepareDecoderConfig(config)
+	if err != nil {
+		return fmt.Errorf("decoder for topic %s: %w", topic, err)
+	}
+
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.decoders[topic] = prepared
+	return nil
+}
+
+// SetProcessors configures the processors reverted, in reverse order,
+// over the records of the subject before they are decoded. The subject
+// of a record is the one of its SubjectNameStrategy, or the one of the
+// TopicNameStrategy when its topic has no strategy. No processors
+// removes them.
+func (registry *DecoderRegistry) SetProcessors(subject string, processors ...PayloadProcessor) {
+	registry.processors.set(subject, processors)
+}
+
+// SetDefault configures how the records of topics which were not
+// registered are decoded. Without a default, they are rejected.
+func (registry *DecoderRegistry) SetDefault(config DecoderConfig) error {
+	prepared, err := prepareDecoderConfig(config)
+	if err != nil {
+		return fmt.Errorf("default decoder: %w", err)
+	}
+
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.defaultDecoder = prepared
+	return nil
+}
+
+// Decode decodes a wire format payload read from the given topic.
+func (registry *DecoderRegistry) Decode(ctx context.Context, topic string, payload []byte) (*DecodedRecord, error) {
+	registry.lock.RLock()
+	config, ok := registry.decoders[topic]
+	if !ok {
+		config = registry.defaultDecoder
+	}
+	registry.lock.RUnlock()
+	if config == nil {
+		return nil, fmt.Errorf("no decoder registered for topic %s", topic)
+	}
+
+	schemaID, body, err := parseWireFormat(payload)
+	if err != nil {
+		return nil, err
+	}
+
+	record := &DecodedRecord{Topic: topic}
+	if config.SubjectNameStrategy != nil {
+		schema, err := registry.client.GetSchema(ctx, schemaID)
+		if err != nil {
+			return nil, err
+		}
+		record.Subject = config.SubjectNameStrategy(topic, config.Key, schemaRecordName(schema))
+		record.Schema, err = registry.client.GetSchemaBySubjectAndID(ctx, record.Subject, schemaID)
+		if err != nil {
+			return nil, fmt.Errorf("schema id %d is not registered under subject %s: %w", schemaID, record.Subject, err)
+		}
+	} else {
+		record.Schema, err = registry.client.GetSchema(ctx, schemaID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if schemaTypeOf(record.Schema) != config.SchemaType {
+		return nil, fmt.Errorf("topic %s expects %s schemas, got %s", topic, config.SchemaType, schemaTypeOf(record.Schema))
+	}
+
+	subject := record.Subject
+	if subject == "" {
+		subject = TopicNameStrategy(topic, config.Key, "")
+	}
+	body, err = registry.processors.unprocess(ctx, subject, body)
+	if err != nil {
+		return nil, err
+	}
+
+	record.Value, err = config.decode(record.Schema, body)
+	if err != nil {
+		return nil, err
+	}
+	return record, nil
+}
+
+func prepareDecoderConfig(config DecoderConfig) (*DecoderConfig, error) {
+	if config.SchemaType == "" {
+		config.SchemaType = Avro
+	}
+
+	switch config.SchemaType {
+	case Avro:
+		if config.ReaderSchema != "" {
+			codec, err := goavro.NewCodec(config.ReaderSchema)
+			if err != nil {
+				return nil, err
+			}
+			config.readerSchema = &Schema{schema: config.ReaderSchema, codec: codec}
+			config.projections = &avroProjections{}
+		}
+	case Json:
+		if config.ReaderSchema != "" {
+			return nil, errors.New("reader schemas are only supported for Avro")
+		}
+	default:
+		return nil, errUnsupportedSchemaType
+	}
+
+	return &config, nil
+}
+
+func (config *DecoderConfig) decode(schema *Schema, body []byte) (interface{}, error) {
+	switch config.SchemaType {
+	case Avro:
+		codec := schema.Codec()
+		if codec == nil {
+			return nil, fmt.Errorf("invalid Avro schema with id %d", schema.ID())
+		}
+		native, _, err := codec.NativeFromBinary(body)
+		if err != nil || config.readerSchema == nil {
+			return native, err
+		}
+		return config.projections.project(config.readerSchema, schema, native)
+	case Json:
+		var native interface{}
+		if err := json.Unmarshal(body, &native); err != nil {
+			return nil, err
+		}
+		return native, nil
+	default:
+		return nil, errUnsupportedSchemaType
+	}
+}
+
+// schemaTypeOf returns the type of the schema, which is Avro when unset.
+func schemaTypeOf(schema *Schema) SchemaType {
+	if schema.SchemaType() == nil || *schema.SchemaType() == "" {
+		return Avro
+	}
+	return *schema.SchemaType()
+}
+
+// schemaRecordName returns the fully qualified name of the record
+// described by the schema, as used by the subject name strategies.
+func schemaRecordName(schema *Schema) string {
+	var named struct {
+		Name      string `json:"name"`
+		Namespace string `json:"namespace"`
+		Title     string `json:"title"`
+	}
+	if err := json.Unmarshal([]byte(schema.Schema()), &named); err != nil {
+		return ""
+	}
+
+	switch schemaTypeOf(schema) {
+	case Avro:
+		if named.Namespace == "" || strings.Contains(named.Name, ".") {
+			return named.Name
+		}
+		return named.Namespace + "." + named.Name
+	case Json:
+		return named.Title
+	default:
+		return ""
+	}
+}
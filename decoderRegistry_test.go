@@ -0,0 +1,87 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func encodeAvroTestRecord(t *testing.T, schema *Schema, native map[string]interface{}) []byte {
+	body, err := schema.Codec().BinaryFromNative(nil, native)
+	require.NoError(t, err)
+	return append(appendWireHeader(nil, schema.ID()), body...)
+}
+
+func TestDecoderRegistry_Decode(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	cupcake, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	bakery, err := registry.CreateSchema(context.Background(), "bakeries-value", testSchema2, Avro)
+	require.NoError(t, err)
+
+	decoders := NewDecoderRegistry(registry)
+	require.NoError(t, decoders.Register("cupcakes", DecoderConfig{SubjectNameStrategy: TopicNameStrategy}))
+	require.NoError(t, decoders.Register("bakeries", DecoderConfig{
+		ReaderSchema: `{"type": "record", "name": "bakery", "fields": [{"name": "open", "type": "boolean", "default": true}]}`,
+	}))
+
+	// Act
+	record, err := decoders.Decode(context.Background(), "cupcakes", encodeAvroTestRecord(t, cupcake, map[string]interface{}{"flavor": "vanilla"}))
+
+	// Assert
+	if assert.NoError(t, err) {
+		assert.Equal(t, "cupcakes-value", record.Subject)
+		assert.Equal(t, cupcake.ID(), record.Schema.ID())
+		assert.Equal(t, map[string]interface{}{"flavor": "vanilla"}, record.Value)
+	}
+
+	// Reader schema projection
+	record, err = decoders.Decode(context.Background(), "bakeries", encodeAvroTestRecord(t, bakery, map[string]interface{}{"number": 3}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"open": true}, record.Value)
+	}
+
+	// Reader schema wrapping a field in a union
+	require.NoError(t, decoders.Register("nullable-cupcakes", DecoderConfig{
+		ReaderSchema: `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": ["null", "string"]}]}`,
+	}))
+	record, err = decoders.Decode(context.Background(), "nullable-cupcakes", encodeAvroTestRecord(t, cupcake, map[string]interface{}{"flavor": "vanilla"}))
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"flavor": map[string]interface{}{"string": "vanilla"}}, record.Value)
+	}
+
+	// Schema registered under another subject
+	_, err = decoders.Decode(context.Background(), "cupcakes", encodeAvroTestRecord(t, bakery, map[string]interface{}{"number": 3}))
+	assert.Error(t, err)
+
+	// Unknown topic
+	_, err = decoders.Decode(context.Background(), "pies", encodeAvroTestRecord(t, cupcake, map[string]interface{}{"flavor": "vanilla"}))
+	assert.Error(t, err)
+
+	// Default decoder
+	require.NoError(t, decoders.SetDefault(DecoderConfig{}))
+	_, err = decoders.Decode(context.Background(), "pies", encodeAvroTestRecord(t, cupcake, map[string]interface{}{"flavor": "vanilla"}))
+	assert.NoError(t, err)
+
+	// Not in wire format
+	_, err = decoders.Decode(context.Background(), "cupcakes", []byte("cupcake"))
+	assert.ErrorIs(t, err, ErrInvalidWireFormat)
+}
+
+func TestDecoderRegistry_RejectsUnexpectedSchemaType(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	cupcake, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+
+	decoders := NewDecoderRegistry(registry)
+	require.NoError(t, decoders.Register("cupcakes", DecoderConfig{SchemaType: Json}))
+
+	_, err = decoders.Decode(context.Background(), "cupcakes", encodeAvroTestRecord(t, cupcake, map[string]interface{}{"flavor": "vanilla"}))
+	assert.Error(t, err)
+	assert.Error(t, decoders.Register("cupcakes", DecoderConfig{ReaderSchema: "not a schema"}))
+}
@@ -0,0 +1,92 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// ExportDescriptorSet builds a FileDescriptorSet from the latest schema
+// of the given Protobuf subjects and all the schemas they reference.
+// Marshalled, the set is a .desc file consumable by tools such as
+// grpcurl, buf or schema-aware gateways.
+func ExportDescriptorSet(ctx context.Context, client ISchemaRegistryClient, subjects ...string) (*descriptorpb.FileDescriptorSet, error) {
+	set := &descriptorpb.FileDescriptorSet{}
+	added := make(map[string]bool)
+	for _, subject := range subjects {
+		schema, err := client.GetLatestSchema(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		if schemaTypeOf(schema) != Protobuf {
+			return nil, fmt.Errorf("subject %s: %w", subject, errUnsupportedSchemaType)
+		}
+
+		file, err := protobufFileDescriptor(ctx, client, subject, schema)
+		if err != nil {
+			return nil, err
+		}
+		appendFileDescriptor(set, file, added)
+	}
+	return set, nil
+}
+
+// protobufFileDescriptor parses a Protobuf schema, the referenced
+// schemas are fetched from the registry and made available under
+// their import name.
+func protobufFileDescriptor(ctx context.Context, client ISchemaRegistryClient, subject string, schema *Schema) (*desc.FileDescriptor, error) {
+	name := protobufFileName(subject)
+	files := map[string]string{name: schema.Schema()}
+	if err := fetchProtobufReferences(ctx, client, schema.References(), files); err != nil {
+		return nil, err
+	}
+
+	parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(files)}
+	parsed, err := parser.ParseFiles(name)
+	if err != nil {
+		return nil, fmt.Errorf("subject %s: %w", subject, err)
+	}
+	return parsed[0], nil
+}
+
+func fetchProtobufReferences(ctx context.Context, client ISchemaRegistryClient, references []Reference, files map[string]string) error {
+	for _, reference := range references {
+		if _, ok := files[reference.Name]; ok {
+			continue
+		}
+
+		schema, err := client.GetSchemaByVersion(ctx, reference.Subject, reference.Version)
+		if err != nil {
+			return fmt.Errorf("reference %s: %w", reference.Name, err)
+		}
+		files[reference.Name] = schema.Schema()
+		if err := fetchProtobufReferences(ctx, client, schema.References(), files); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// appendFileDescriptor adds the file to the set after its dependencies,
+// as expected by the consumers of descriptor sets.
+func appendFileDescriptor(set *descriptorpb.FileDescriptorSet, file *desc.FileDescriptor, added map[string]bool) {
+	if added[file.GetName()] {
+		return
+	}
+	added[file.GetName()] = true
+	for _, dependency := range file.GetDependencies() {
+		appendFileDescriptor(set, dependency, added)
+	}
+	set.File = append(set.File, file.AsFileDescriptorProto())
+}
+
+func protobufFileName(subject string) string {
+	if strings.HasSuffix(subject, ".proto") {
+		return subject
+	}
+	return subject + ".proto"
+}
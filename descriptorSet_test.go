@@ -0,0 +1,84 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportDescriptorSet(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	protobuf := Protobuf
+	responses := map[string]schemaResponse{
+		"/subjects/orders-value/versions/latest": {
+			Subject:    "orders-value",
+			Version:    1,
+			ID:         2,
+			SchemaType: &protobuf,
+			Schema: `syntax = "proto3";
+package shop;
+import "shop/cupcake.proto";
+import "google/protobuf/timestamp.proto";
+message Order {
+  Cupcake cupcake = 1;
+  google.protobuf.Timestamp placed_at = 2;
+}`,
+			References: []Reference{{Name: "shop/cupcake.proto", Subject: "cupcake", Version: 1}},
+		},
+		"/subjects/cupcake/versions/1": {
+			Subject:    "cupcake",
+			Version:    1,
+			ID:         1,
+			SchemaType: &protobuf,
+			Schema: `syntax = "proto3";
+package shop;
+message Cupcake {
+  string flavor = 1;
+}`,
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		response, ok := responses[req.URL.String()]
+		if !ok {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40401, "message": "Subject not found"}`))
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(response)
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	set, err := ExportDescriptorSet(context.Background(), srClient, "orders-value")
+
+	// Assert
+	require.NoError(t, err)
+	var names []string
+	for _, file := range set.File {
+		names = append(names, file.GetName())
+	}
+	assert.Equal(t, []string{"shop/cupcake.proto", "google/protobuf/timestamp.proto", "orders-value.proto"}, names)
+	assert.Equal(t, "Order", set.File[2].GetMessageType()[0].GetName())
+	assert.Equal(t, ".shop.Cupcake", set.File[2].GetMessageType()[0].GetField()[0].GetTypeName())
+
+	_, err = ExportDescriptorSet(context.Background(), srClient, "missing")
+	assert.Error(t, err)
+}
+
+func TestExportDescriptorSet_RejectsNonProtobufSubjects(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+	require.NoError(t, err)
+
+	_, err = ExportDescriptorSet(context.Background(), registry, "cupcake")
+
+	assert.ErrorIs(t, err, errUnsupportedSchemaType)
+}
@@ -0,0 +1,66 @@
+package srclient
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// DiskCache is a SharedCache keeping the registry responses in files of
+// a directory, one per key, so a service restarted while the registry
+// is unreachable can still read the schemas it fetched before. Set it
+// with SetSharedCache. Files are written atomically, the directory can
+// be shared by the processes of a host.
+type DiskCache struct {
+	dir string
+}
+
+var _ SharedCache = new(DiskCache)
+
+// NewDiskCache creates a disk cache in dir, created if missing.
+func NewDiskCache(dir string) (*DiskCache, error) {
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		return nil, err
+	}
+	return &DiskCache{dir: dir}, nil
+}
+
+// Get reads the file of the key.
+func (cache *DiskCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	value, err := os.ReadFile(cache.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set writes the file of the key, through a temporary file renamed once
+// written so readers never see a partial value.
+func (cache *DiskCache) Set(_ context.Context, key string, value []byte) error {
+	file, err := os.CreateTemp(cache.dir, ".tmp-*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if _, err := file.Write(value); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), cache.path(key))
+}
+
+// path is the file of the key, named after its hash as keys are URLs.
+func (cache *DiskCache) path(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return filepath.Join(cache.dir, hex.EncodeToString(hash[:])+".json")
+}
@@ -0,0 +1,56 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDiskCache_OfflineStartup(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	cache, err := NewDiskCache(dir)
+	require.NoError(t, err)
+	before := CreateSchemaRegistryClient(server.URL)
+	before.SetSharedCache(cache)
+	_, err = before.GetSchema(context.Background(), 1)
+	require.NoError(t, err)
+	server.Close()
+
+	// Act
+	restarted, err := NewDiskCache(dir)
+	require.NoError(t, err)
+	after := CreateSchemaRegistryClient(server.URL)
+	after.SetSharedCache(restarted)
+	schema, err := after.GetSchema(context.Background(), 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, testSchema1, schema.Schema())
+}
+
+func TestDiskCache_GetSet(t *testing.T) {
+	t.Parallel()
+	cache, err := NewDiskCache(t.TempDir())
+	require.NoError(t, err)
+
+	_, missingOK, missingErr := cache.Get(context.Background(), "http://localhost:8081/schemas/ids/1")
+	setErr := cache.Set(context.Background(), "http://localhost:8081/schemas/ids/1", []byte(`{"schema": "\"string\""}`))
+	value, ok, err := cache.Get(context.Background(), "http://localhost:8081/schemas/ids/1")
+
+	assert.NoError(t, missingErr)
+	assert.False(t, missingOK)
+	assert.NoError(t, setErr)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+	assert.Equal(t, `{"schema": "\"string\""}`, string(value))
+}
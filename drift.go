@@ -0,0 +1,146 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// FieldChange is a field whose declared type differs between the
+// registry and the local file.
+type FieldChange struct {
+	Path       string
+	Registered string
+	Local      string
+}
+
+// DriftReport tells whether the latest schema of a subject matches a
+// local schema file, once formatting differences are left out.
+type DriftReport struct {
+	Subject string
+	Path    string
+	// Version, ID and RegisteredAt are the ones of the latest registered
+	// schema, RegisteredAt is zero when the registry doesn't tell.
+	Version      int
+	ID           int
+	RegisteredAt time.Time
+	InSync       bool
+	// Added lists the fields only found in the local file, Removed the
+	// fields only found in the registry.
+	Added   []SchemaField
+	Removed []SchemaField
+	Changed []FieldChange
+}
+
+// CompareWithLocal fetches the latest schema of the subject and diffs it
+// against the local schema file, to check that what runs in production
+// is what the repository holds. The schema type is taken from the file
+// extension, .avsc, .json or .proto, and defaults to the type of the
+// registered schema.
+func CompareWithLocal(ctx context.Context, client ISchemaRegistryClient, subject, path string) (*DriftReport, error) {
+	content, err := readSchemaFile(path)
+	if err != nil {
+		return nil, err
+	}
+	registered, err := client.GetLatestSchema(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	schemaType := schemaTypeOf(registered)
+	if localType, ok := schemaTypeOfFile(path); ok {
+		schemaType = localType
+	}
+	local := &Schema{schema: content, schemaType: &schemaType}
+
+	report := &DriftReport{
+		Subject:      subject,
+		Path:         path,
+		Version:      registered.Version(),
+		ID:           registered.ID(),
+		RegisteredAt: registered.RegisteredAt(),
+	}
+	report.InSync = schemaType == schemaTypeOf(registered) &&
+		normalizeSchema(schemaType, local.Schema()) == normalizeSchema(schemaType, registered.Schema())
+	if report.InSync {
+		return report, nil
+	}
+
+	// Schemas which can't be parsed are reported without field changes
+	registeredFields, _ := schemaFields(registered)
+	localFields, _ := schemaFields(local)
+	report.diffFields(registeredFields, localFields)
+	return report, nil
+}
+
+func (report *DriftReport) diffFields(registered, local []SchemaField) {
+	registeredByPath := make(map[string]SchemaField, len(registered))
+	for _, field := range registered {
+		registeredByPath[field.Path] = field
+	}
+	localByPath := make(map[string]SchemaField, len(local))
+	for _, field := range local {
+		localByPath[field.Path] = field
+		previous, ok := registeredByPath[field.Path]
+		switch {
+		case !ok:
+			report.Added = append(report.Added, field)
+		case previous.Type != field.Type:
+			report.Changed = append(report.Changed, FieldChange{Path: field.Path, Registered: previous.Type, Local: field.Type})
+		}
+	}
+	for _, field := range registered {
+		if _, ok := localByPath[field.Path]; !ok {
+			report.Removed = append(report.Removed, field)
+		}
+	}
+}
+
+// schemaTypeOfFile guesses the schema type from the file extension.
+func schemaTypeOfFile(path string) (SchemaType, bool) {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".avsc":
+		return Avro, true
+	case ".json":
+		return Json, true
+	case ".proto":
+		return Protobuf, true
+	}
+	return "", false
+}
+
+var (
+	protobufComments    = regexp.MustCompile(`(?s)//[^\n]*|/\*.*?\*/`)
+	protobufWhitespace  = regexp.MustCompile(`\s+`)
+	protobufPunctuation = regexp.MustCompile(`\s*([{}();=,<>\[\]])\s*`)
+)
+
+// normalizeSchema removes formatting from a schema: JSON documents are
+// compacted with sorted keys, Protobuf files lose their comments and
+// extra whitespace. Schemas which can't be parsed are only trimmed.
+func normalizeSchema(schemaType SchemaType, schema string) string {
+	if schemaType == Protobuf {
+		normalized := protobufComments.ReplaceAllString(schema, " ")
+		normalized = protobufWhitespace.ReplaceAllString(normalized, " ")
+		normalized = protobufPunctuation.ReplaceAllString(normalized, "$1")
+		return strings.TrimSpace(normalized)
+	}
+
+	var parsed interface{}
+	decoder := json.NewDecoder(strings.NewReader(schema))
+	decoder.UseNumber()
+	if err := decoder.Decode(&parsed); err != nil {
+		return strings.TrimSpace(schema)
+	}
+	var normalized bytes.Buffer
+	encoder := json.NewEncoder(&normalized)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(parsed); err != nil {
+		return strings.TrimSpace(schema)
+	}
+	return strings.TrimSpace(normalized.String())
+}
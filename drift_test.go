@@ -0,0 +1,95 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCompareWithLocal(t *testing.T) {
+	t.Parallel()
+	protobuf := Protobuf
+	responses := map[string]schemaResponse{
+		"/subjects/customer/versions/latest": {
+			Subject: "customer", Version: 3, ID: 7,
+			Schema: `{"type":"record","name":"Customer","fields":[{"name":"id","type":"long"},{"name":"email","type":"string"}]}`,
+		},
+		"/subjects/order/versions/latest": {
+			Subject: "order", Version: 1, ID: 8, SchemaType: &protobuf,
+			Schema: "syntax = \"proto3\";\nmessage Order {\n  string id = 1;\n}\n",
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode(responses[req.URL.String()])
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	tests := map[string]struct {
+		subject  string
+		file     string
+		content  string
+		expected DriftReport
+	}{
+		"formatting only": {
+			subject: "customer",
+			file:    "customer.avsc",
+			content: `{
+  "name": "Customer",
+  "type": "record",
+  "fields": [
+    {"name": "id", "type": "long"},
+    {"name": "email", "type": "string"}
+  ]
+}`,
+			expected: DriftReport{Subject: "customer", Version: 3, ID: 7, InSync: true},
+		},
+		"field drift": {
+			subject: "customer",
+			file:    "customer.avsc",
+			content: `{"type": "record", "name": "Customer", "fields": [{"name": "id", "type": "string"}, {"name": "phone", "type": "string"}]}`,
+			expected: DriftReport{
+				Subject: "customer", Version: 3, ID: 7,
+				Added:   []SchemaField{{Path: "phone", Name: "phone", Type: "string"}},
+				Removed: []SchemaField{{Path: "email", Name: "email", Type: "string"}},
+				Changed: []FieldChange{{Path: "id", Registered: "long", Local: "string"}},
+			},
+		},
+		"protobuf comments": {
+			subject:  "order",
+			file:     "order.proto",
+			content:  "// Orders placed by customers\nsyntax = \"proto3\";\n\nmessage Order {\n  string id = 1; // the order ID\n}",
+			expected: DriftReport{Subject: "order", Version: 1, ID: 8, InSync: true},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), testData.file)
+			require.NoError(t, os.WriteFile(path, []byte(testData.content), 0600))
+
+			report, err := CompareWithLocal(context.Background(), srClient, testData.subject, path)
+
+			require.NoError(t, err)
+			testData.expected.Path = path
+			assert.Equal(t, &testData.expected, report)
+		})
+	}
+}
+
+func TestCompareWithLocal_MissingFile(t *testing.T) {
+	t.Parallel()
+	srClient := CreateMockSchemaRegistryClient("mock://testingUrl")
+
+	_, err := CompareWithLocal(context.Background(), srClient, "customer", filepath.Join(t.TempDir(), "missing.avsc"))
+
+	assert.True(t, os.IsNotExist(err))
+}
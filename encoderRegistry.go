@@ -0,0 +1,184 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+)
+
+// EncoderConfig describes how the records produced to a topic are encoded.
+type EncoderConfig struct {
+	// SchemaType is the type of the schema, Avro is assumed when empty.
+	SchemaType SchemaType
+	// SchemaFile is the path of a local schema file used to encode the
+	// records. It is looked up in the registry, or registered when
+	// AutoRegister is set.
+	SchemaFile string
+	// Schema is used like SchemaFile when the schema is already in memory.
+	Schema string
+	// AutoRegister registers the local schema if it doesn't exist yet.
+	AutoRegister bool
+	// Subject pins the subject the schema is registered under, it
+	// defaults to the one derived with the SubjectNameStrategy.
+	Subject string
+	// Version pins the version of Subject used when no local schema is
+	// given, the latest version is used when zero.
+	Version int
+	// SubjectNameStrategy derives the subject from the topic, it
+	// defaults to TopicNameStrategy.
+	SubjectNameStrategy SubjectNameStrategy
+	// Key tells if the records encoded are keys rather than values,
+	// it is passed on to the SubjectNameStrategy.
+	Key bool
+}
+
+// EncoderRegistry encodes records in wire format with the schema
+// configured for their topic, so services producing to many topics
+// can share a single produce helper.
+type EncoderRegistry struct {
+	client     ISchemaRegistryClient
+	encoders   map[string]*EncoderConfig
+	schemas    map[string]*Schema
+	subjects   map[string]string
+	lock       sync.RWMutex
+	processors subjectProcessors
+}
+
+// NewEncoderRegistry creates an empty EncoderRegistry resolving the
+// schemas with the given client.
+func NewEncoderRegistry(client ISchemaRegistryClient) *EncoderRegistry {
+	return &EncoderRegistry{
+		client:   client,
+		encoders: make(map[string]*EncoderConfig),
+		schemas:  make(map[string]*Schema),
+		subjects: make(map[string]string),
+	}
+}
+
+// SetProcessors configures the processors run, in order, over the
+// records encoded for the subject. No processors removes them.
+func (registry *EncoderRegistry) SetProcessors(subject string, processors ...PayloadProcessor) {
+	registry.processors.set(subject, processors)
+}
+
+// Register configures how the records produced to the given topic are encoded.
+func (registry *EncoderRegistry) Register(topic string, config EncoderConfig) error {
+	if config.SchemaType == "" {
+		config.SchemaType = Avro
+	}
+	if config.SubjectNameStrategy == nil {
+		config.SubjectNameStrategy = TopicNameStrategy
+	}
+	switch config.SchemaType {
+	case Avro, Json:
+	default:
+		return fmt.Errorf("encoder for topic %s: %w", topic, errUnsupportedSchemaType)
+	}
+	if config.SchemaFile != "" {
+		content, err := readSchemaFile(config.SchemaFile)
+		if err != nil {
+			return fmt.Errorf("encoder for topic %s: %w", topic, err)
+		}
+		config.Schema = content
+	}
+	if config.Schema == "" && config.Subject == "" {
+		return fmt.Errorf("encoder for topic %s: either a schema or a subject is required", topic)
+	}
+
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.encoders[topic] = &config
+	delete(registry.schemas, topic)
+	return nil
+}
+
+// Encode encodes the native value in wire format for the given topic.
+// Avro values are expected in the native form used by goavro, JSON
+// values are marshalled with encoding/json.
+func (registry *EncoderRegistry) Encode(ctx context.Context, topic string, value interface{}) ([]byte, error) {
+	schema, err := registry.Schema(ctx, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := appendWireHeader(nil, schema.ID())
+	switch schemaTypeOf(schema) {
+	case Avro:
+		codec := schema.Codec()
+		if codec == nil {
+			return nil, fmt.Errorf("invalid Avro schema with id %d", schema.ID())
+		}
+		payload, err = codec.BinaryFromNative(payload, value)
+	case Json:
+		var body []byte
+		body, err = json.Marshal(value)
+		payload = append(payload, body...)
+	default:
+		return nil, errUnsupportedSchemaType
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	registry.lock.RLock()
+	subject := registry.subjects[topic]
+	registry.lock.RUnlock()
+	return registry.processors.process(ctx, subject, payload)
+}
+
+// Schema returns the schema the records of the topic are encoded with,
+// resolving it with the registry the first time.
+func (registry *EncoderRegistry) Schema(ctx context.Context, topic string) (*Schema, error) {
+	registry.lock.RLock()
+	schema, resolved := registry.schemas[topic]
+	config := registry.encoders[topic]
+	registry.lock.RUnlock()
+	if resolved {
+		return schema, nil
+	}
+	if config == nil {
+		return nil, fmt.Errorf("no encoder registered for topic %s", topic)
+	}
+
+	schema, subject, err := config.resolve(ctx, registry.client, topic)
+	if err != nil {
+		return nil, err
+	}
+
+	registry.lock.Lock()
+	defer registry.lock.Unlock()
+	registry.schemas[topic] = schema
+	registry.subjects[topic] = subject
+	return schema, nil
+}
+
+// resolve returns the schema of the topic along with its subject.
+func (config *EncoderConfig) resolve(ctx context.Context, client ISchemaRegistryClient, topic string) (*Schema, string, error) {
+	if config.Schema == "" {
+		var schema *Schema
+		var err error
+		if config.Version > 0 {
+			schema, err = client.GetSchemaByVersion(ctx, config.Subject, config.Version)
+		} else {
+			schema, err = client.GetLatestSchema(ctx, config.Subject)
+		}
+		return schema, config.Subject, err
+	}
+
+	subject := config.Subject
+	if subject == "" {
+		local, err := NewSchema(0, config.Schema, config.SchemaType, 0, nil, nil, nil)
+		if err != nil {
+			return nil, "", err
+		}
+		subject = config.SubjectNameStrategy(topic, config.Key, schemaRecordName(local))
+	}
+
+	schema, err := client.LookupSchema(ctx, subject, config.Schema, config.SchemaType)
+	if err == nil || !config.AutoRegister || !isNotFoundError(err) {
+		return schema, subject, err
+	}
+	schema, err = client.CreateSchema(ctx, subject, config.Schema, config.SchemaType)
+	return schema, subject, err
+}
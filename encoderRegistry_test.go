@@ -0,0 +1,62 @@
+package srclient
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncoderRegistry_Encode(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	bakery, err := registry.CreateSchema(context.Background(), "bakeries-value", testSchema2, Avro)
+	require.NoError(t, err)
+
+	schemaFile := filepath.Join(t.TempDir(), "cupcake.avsc")
+	require.NoError(t, os.WriteFile(schemaFile, []byte(testSchema1), 0600))
+
+	encoders := NewEncoderRegistry(registry)
+	require.NoError(t, encoders.Register("cupcakes", EncoderConfig{SchemaFile: schemaFile, AutoRegister: true}))
+	require.NoError(t, encoders.Register("bakeries", EncoderConfig{Subject: "bakeries-value"}))
+	require.NoError(t, encoders.Register("pies", EncoderConfig{Schema: testSchema1}))
+
+	// Act
+	cupcakePayload, cupcakeErr := encoders.Encode(context.Background(), "cupcakes", map[string]interface{}{"flavor": "vanilla"})
+	bakeryPayload, bakeryErr := encoders.Encode(context.Background(), "bakeries", map[string]interface{}{"number": 3})
+	_, pieErr := encoders.Encode(context.Background(), "pies", map[string]interface{}{"flavor": "apple"})
+	_, unknownErr := encoders.Encode(context.Background(), "unknown", map[string]interface{}{})
+
+	// Assert
+	require.NoError(t, cupcakeErr)
+	cupcake, err := registry.GetLatestSchema(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	id, body, err := parseWireFormat(cupcakePayload)
+	require.NoError(t, err)
+	assert.Equal(t, cupcake.ID(), id)
+	native, _, err := cupcake.Codec().NativeFromBinary(body)
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{"flavor": "vanilla"}, native)
+
+	require.NoError(t, bakeryErr)
+	id, _, err = parseWireFormat(bakeryPayload)
+	require.NoError(t, err)
+	assert.Equal(t, bakery.ID(), id)
+
+	// Not registered and not allowed to register
+	assert.True(t, isNotFoundError(pieErr))
+	assert.Error(t, unknownErr)
+}
+
+func TestEncoderRegistry_RegisterValidatesConfig(t *testing.T) {
+	t.Parallel()
+	encoders := NewEncoderRegistry(CreateMockSchemaRegistryClient("http://localhost:8081"))
+
+	assert.Error(t, encoders.Register("cupcakes", EncoderConfig{}))
+	assert.Error(t, encoders.Register("cupcakes", EncoderConfig{SchemaFile: "does-not-exist.avsc"}))
+	assert.Error(t, encoders.Register("cupcakes", EncoderConfig{Subject: "cupcakes-value", SchemaType: Protobuf}))
+}
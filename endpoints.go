@@ -0,0 +1,168 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+)
+
+// EndpointStatus describes an endpoint of a client created with
+// WithLatencyAwareEndpoints, as of its last probe.
+type EndpointStatus struct {
+	URL     string
+	Healthy bool
+	// Latency is the time the endpoint took to answer its last probe.
+	Latency  time.Duration
+	ProbedAt time.Time
+}
+
+// WithLatencyAwareEndpoints adds registries, such as the followers of a
+// registry in other regions, to the one given to NewClient. Every
+// interval the endpoints are probed with a request to their root and
+// the requests are sent to the fastest healthy one, the first endpoint
+// being used until the first probe completes. An endpoint failing a
+// request is left out until the next probe. The interval defaults to
+// 30 seconds.
+func WithLatencyAwareEndpoints(interval time.Duration, urls ...string) Option {
+	return func(options *clientOptions) {
+		options.endpointsInterval = interval
+		options.endpoints = append(options.endpoints, urls...)
+	}
+}
+
+// endpointSelector picks the endpoint of the requests.
+type endpointSelector struct {
+	interval  time.Duration
+	lock      sync.Mutex
+	endpoints []EndpointStatus
+	probedAt  time.Time
+	probing   bool
+}
+
+func newEndpointSelector(interval time.Duration, urls []string) *endpointSelector {
+	if interval <= 0 {
+		interval = 30 * time.Second
+	}
+	selector := &endpointSelector{interval: interval}
+	for _, url := range urls {
+		selector.endpoints = append(selector.endpoints, EndpointStatus{URL: url, Healthy: true})
+	}
+	return selector
+}
+
+// pick returns the fastest healthy endpoint, the first one when none is,
+// and tells whether the endpoints are due for a probe, which the caller
+// must then run.
+func (selector *endpointSelector) pick(now time.Time) (string, bool) {
+	selector.lock.Lock()
+	defer selector.lock.Unlock()
+
+	probe := !selector.probing && !now.Before(selector.probedAt.Add(selector.interval))
+	if probe {
+		selector.probing = true
+	}
+	best := -1
+	for i, endpoint := range selector.endpoints {
+		if endpoint.Healthy && (best < 0 || endpoint.Latency < selector.endpoints[best].Latency) {
+			best = i
+		}
+	}
+	if best < 0 {
+		best = 0
+	}
+	return selector.endpoints[best].URL, probe
+}
+
+func (selector *endpointSelector) failed(url string) {
+	selector.lock.Lock()
+	defer selector.lock.Unlock()
+	for i := range selector.endpoints {
+		if selector.endpoints[i].URL == url {
+			selector.endpoints[i].Healthy = false
+		}
+	}
+}
+
+func (selector *endpointSelector) probed(statuses []EndpointStatus, now time.Time) {
+	selector.lock.Lock()
+	defer selector.lock.Unlock()
+	selector.endpoints = statuses
+	selector.probedAt = now
+	selector.probing = false
+}
+
+func (selector *endpointSelector) statuses() []EndpointStatus {
+	selector.lock.Lock()
+	defer selector.lock.Unlock()
+	return append([]EndpointStatus(nil), selector.endpoints...)
+}
+
+// Endpoints returns the state of the endpoints of a client created with
+// WithLatencyAwareEndpoints, fastest first, nil for other clients.
+func (client *SchemaRegistryClient) Endpoints() []EndpointStatus {
+	if client.endpoints == nil {
+		return nil
+	}
+	statuses := client.endpoints.statuses()
+	sort.SliceStable(statuses, func(i, j int) bool {
+		if statuses[i].Healthy != statuses[j].Healthy {
+			return statuses[i].Healthy
+		}
+		return statuses[i].Latency < statuses[j].Latency
+	})
+	return statuses
+}
+
+// baseURL returns the URL of the registry the next request is sent to,
+// starting a probe of the endpoints when they are due for one.
+func (client *SchemaRegistryClient) baseURL() string {
+	if client.endpoints == nil {
+		return client.schemaRegistryURL
+	}
+	base, probe := client.endpoints.pick(client.now())
+	if probe && client.lifecycle.begin() == nil {
+		go func() {
+			defer client.lifecycle.end()
+			client.probeEndpoints()
+		}()
+	}
+	return base
+}
+
+// probeEndpoints measures how long every endpoint takes to answer a
+// request to its root. Endpoints answering with a server error or not
+// answering are unhealthy, other statuses such as 401 tell the endpoint
+// is up.
+func (client *SchemaRegistryClient) probeEndpoints() {
+	statuses := client.endpoints.statuses()
+	var wg sync.WaitGroup
+	for i := range statuses {
+		wg.Add(1)
+		go func(status *EndpointStatus) {
+			defer wg.Done()
+			start := time.Now()
+			status.Healthy = client.probeEndpointURL(status.URL)
+			status.Latency = time.Since(start)
+			status.ProbedAt = client.now()
+		}(&statuses[i])
+	}
+	wg.Wait()
+	client.endpoints.probed(statuses, client.now())
+}
+
+func (client *SchemaRegistryClient) probeEndpointURL(url string) bool {
+	ctx, cancel := context.WithTimeout(client.lifecycle.background, client.endpoints.interval)
+	defer cancel()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url+"/", nil)
+	if err != nil {
+		return false
+	}
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return false
+	}
+	resp.Body.Close()
+	return resp.StatusCode < http.StatusInternalServerError
+}
@@ -0,0 +1,77 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func regionRegistry(t *testing.T, latency time.Duration, subjects *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(latency)
+		if req.URL.Path == "/subjects" {
+			atomic.AddInt32(subjects, 1)
+		}
+		_, _ = rw.Write([]byte(`[]`))
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithLatencyAwareEndpoints(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var farCalls, nearCalls int32
+	far := regionRegistry(t, 50*time.Millisecond, &farCalls)
+	near := regionRegistry(t, 0, &nearCalls)
+	client := NewClient(far.URL, WithLatencyAwareEndpoints(time.Hour, near.URL))
+
+	// Act
+	client.probeEndpoints()
+	_, err := client.GetSubjects(context.Background())
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&farCalls))
+	assert.Equal(t, int32(1), atomic.LoadInt32(&nearCalls))
+	endpoints := client.Endpoints()
+	require.Len(t, endpoints, 2)
+	assert.Equal(t, near.URL, endpoints[0].URL)
+	assert.True(t, endpoints[1].Healthy)
+}
+
+func TestWithLatencyAwareEndpoints_Failover(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var farCalls, nearCalls int32
+	far := regionRegistry(t, 10*time.Millisecond, &farCalls)
+	near := regionRegistry(t, 0, &nearCalls)
+	client := NewClient(far.URL, WithLatencyAwareEndpoints(time.Hour, near.URL))
+	client.probeEndpoints()
+	near.Close()
+
+	// Act
+	_, failedErr := client.GetSubjects(context.Background())
+	_, err := client.GetSubjects(context.Background())
+
+	// Assert
+	assert.Error(t, failedErr)
+	require.NoError(t, err)
+	assert.Equal(t, int32(1), atomic.LoadInt32(&farCalls))
+	assert.False(t, client.Endpoints()[1].Healthy)
+}
+
+func TestWithLatencyAwareEndpoints_InvalidURL(t *testing.T) {
+	t.Parallel()
+	client := NewClient("http://localhost:8081", WithLatencyAwareEndpoints(time.Hour, "localhost:8082"))
+
+	_, err := client.GetSubjects(context.Background())
+
+	assert.ErrorIs(t, err, ErrInvalidURL)
+}
@@ -0,0 +1,154 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+)
+
+// EvolutionDirection tells which side of a schema change reads the data.
+type EvolutionDirection string
+
+const (
+	// EvolutionBackward means the proposed schema reads data written with a previous version.
+	EvolutionBackward EvolutionDirection = "BACKWARD"
+	// EvolutionForward means a previous version reads data written with the proposed schema.
+	EvolutionForward EvolutionDirection = "FORWARD"
+)
+
+// EvolutionFailure is a concrete record which could not be read
+// across a schema change.
+type EvolutionFailure struct {
+	Direction EvolutionDirection
+	// Version is the previous version involved.
+	Version int
+	// Payload is the record in wire format.
+	Payload []byte
+	Err     error
+}
+
+// EvolutionReport is the result of SimulateEvolution.
+type EvolutionReport struct {
+	// Checked is the number of records read.
+	Checked  int
+	Failures []EvolutionFailure
+}
+
+// Compatible tells if every record could be read.
+func (report *EvolutionReport) Compatible() bool {
+	return len(report.Failures) == 0
+}
+
+// SimulateEvolution checks a proposed schema against the actual data of the
+// subject rather than structurally: the golden corpus of the previous versions
+// is read with the proposed schema (backward), and samples written with the
+// proposed schema are read with the previous versions (forward), as required
+// by the compatibility level. samples is the number of forward samples generated.
+func SimulateEvolution(ctx context.Context, client ISchemaRegistryClient, corpus *GoldenCorpus, proposed string, schemaType SchemaType, level CompatibilityLevel, samples int) (*EvolutionReport, error) {
+	proposedSchema, err := NewSchema(0, proposed, schemaType, 0, nil, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	versions := corpus.Versions()
+	backward, forward, transitive := evolutionChecks(level)
+	if !transitive && len(versions) > 0 {
+		versions = versions[len(versions)-1:]
+	}
+
+	report := &EvolutionReport{}
+	for _, version := range versions {
+		if backward {
+			for _, payload := range corpus.Payloads[version] {
+				writerID, body, err := parseWireFormat(payload)
+				if err != nil {
+					return nil, err
+				}
+				writer, err := client.GetSchema(ctx, writerID)
+				if err != nil {
+					return nil, err
+				}
+				report.check(EvolutionBackward, version, payload, readWithSchema(proposedSchema, writer, body))
+			}
+		}
+
+		if forward {
+			reader, err := client.GetSchemaByVersion(ctx, corpus.Subject, version)
+			if err != nil {
+				return nil, err
+			}
+			generator := NewRandomGenerator(time.Now().UnixNano())
+			for i := 0; i < samples; i++ {
+				payload, err := generator.Payload(proposedSchema)
+				if err != nil {
+					return nil, err
+				}
+				report.check(EvolutionForward, version, payload, readWithSchema(reader, proposedSchema, payload[wireHeaderLength:]))
+			}
+		}
+	}
+
+	return report, nil
+}
+
+func (report *EvolutionReport) check(direction EvolutionDirection, version int, payload []byte, err error) {
+	report.Checked++
+	if err != nil {
+		report.Failures = append(report.Failures, EvolutionFailure{Direction: direction, Version: version, Payload: payload, Err: err})
+	}
+}
+
+// evolutionChecks tells which directions the compatibility level requires,
+// and if all previous versions are involved or only the latest one.
+func evolutionChecks(level CompatibilityLevel) (backward, forward, transitive bool) {
+	switch level {
+	case Backward:
+		return true, false, false
+	case BackwardTransitive:
+		return true, false, true
+	case Forward:
+		return false, true, false
+	case ForwardTransitive:
+		return false, true, true
+	case Full:
+		return true, true, false
+	case FullTransitive:
+		return true, true, true
+	default:
+		return false, false, false
+	}
+}
+
+// readWithSchema reads a body written with the writer schema using the reader schema.
+func readWithSchema(reader, writer *Schema, body []byte) error {
+	switch schemaTypeOf(reader) {
+	case Avro:
+		writerCodec, readerCodec := writer.Codec(), reader.Codec()
+		if writerCodec == nil || readerCodec == nil {
+			return fmt.Errorf("invalid Avro schema")
+		}
+		native, _, err := writerCodec.NativeFromBinary(body)
+		if err != nil {
+			return err
+		}
+		projection, err := newAvroProjection(reader, writer)
+		if err != nil {
+			return err
+		}
+		_, err = projection.project(native)
+		return err
+	case Json:
+		compiled := reader.JsonSchema()
+		if compiled == nil {
+			return fmt.Errorf("invalid JSON schema")
+		}
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return err
+		}
+		return compiled.Validate(value)
+	default:
+		return errUnsupportedSchemaType
+	}
+}
@@ -0,0 +1,79 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSimulateEvolution(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	corpus, err := BuildGoldenCorpus(context.Background(), registry, "cupcakes-value", 5, 42)
+	require.NoError(t, err)
+
+	tests := map[string]struct {
+		proposed   string
+		level      CompatibilityLevel
+		compatible bool
+		direction  EvolutionDirection
+	}{
+		"new field with default": {
+			proposed:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int", "default": 1}]}`,
+			level:      FullTransitive,
+			compatible: true,
+		},
+		"new field without default read backward": {
+			proposed:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int"}]}`,
+			level:      Backward,
+			compatible: false,
+			direction:  EvolutionBackward,
+		},
+		"new field without default read forward": {
+			proposed:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int"}]}`,
+			level:      Forward,
+			compatible: true,
+		},
+		"removed field without default read forward": {
+			proposed:   `{"type": "record", "name": "cupcake", "fields": [{"name": "size", "type": "int", "default": 1}]}`,
+			level:      Forward,
+			compatible: false,
+			direction:  EvolutionForward,
+		},
+		"nullable widening": {
+			proposed:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": ["null", "string"], "default": null}]}`,
+			level:      BackwardTransitive,
+			compatible: true,
+		},
+		"new nullable field with default": {
+			proposed:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "topping", "type": ["null", "string"], "default": null}]}`,
+			level:      Backward,
+			compatible: true,
+		},
+		"anything goes": {
+			proposed:   `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "int"}]}`,
+			level:      None,
+			compatible: true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			report, err := SimulateEvolution(context.Background(), registry, corpus, testData.proposed, Avro, testData.level, 5)
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.compatible, report.Compatible())
+			if !testData.compatible && assert.NotEmpty(t, report.Failures) {
+				assert.Equal(t, testData.direction, report.Failures[0].Direction)
+				assert.Equal(t, 1, report.Failures[0].Version)
+				assert.Error(t, report.Failures[0].Err)
+			}
+		})
+	}
+}
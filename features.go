@@ -0,0 +1,143 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+)
+
+// ErrFeatureNotSupported is returned when the registry the client talks to
+// does not implement an endpoint, as it happens with alternative
+// implementations such as Redpanda or Karapace.
+var ErrFeatureNotSupported = errors.New("feature not supported by this schema registry")
+
+// RegistryFeatures lists the optional parts of the Schema Registry
+// API which are not available on every registry implementation.
+type RegistryFeatures struct {
+	// Mode is set when the /mode endpoints are available.
+	Mode bool
+	// DeletedSubjects is set when soft deleted subjects can be listed.
+	DeletedSubjects bool
+}
+
+const mode = "/mode"
+
+// registryFeature is an optional part of the API, probed with a GET of
+// one of its endpoints.
+type registryFeature struct {
+	name  string
+	probe string
+}
+
+var (
+	modeFeature            = registryFeature{name: "mode", probe: mode}
+	deletedSubjectsFeature = registryFeature{name: "deleted subjects", probe: subjects + "?deleted=true"}
+)
+
+// featureDetection holds the features detected on the registry, each is
+// probed the first time it is needed, and again after the cache is reset.
+type featureDetection struct {
+	lock      sync.Mutex
+	supported map[registryFeature]bool
+}
+
+func (detection *featureDetection) reset() {
+	detection.lock.Lock()
+	defer detection.lock.Unlock()
+	detection.supported = nil
+}
+
+// Features probes the registry for the optional endpoints it supports.
+// The result is kept until the cache is reset.
+func (client *SchemaRegistryClient) Features(ctx context.Context) (*RegistryFeatures, error) {
+	features := &RegistryFeatures{}
+	var err error
+	if features.Mode, err = client.hasFeature(ctx, modeFeature); err != nil {
+		return nil, err
+	}
+	if features.DeletedSubjects, err = client.hasFeature(ctx, deletedSubjectsFeature); err != nil {
+		return nil, err
+	}
+	return features, nil
+}
+
+// requireFeature fails with an error wrapping ErrFeatureNotSupported,
+// before any other request is sent, when the registry doesn't have the
+// feature. When the probe isn't authorized, the feature is taken as
+// there and the call is attempted.
+func (client *SchemaRegistryClient) requireFeature(ctx context.Context, feature registryFeature) error {
+	supported, err := client.hasFeature(ctx, feature)
+	if isUnauthorizedError(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if !supported {
+		return fmt.Errorf("%w: %s", ErrFeatureNotSupported, feature.name)
+	}
+	return nil
+}
+
+// hasFeature probes the feature unless it was already detected. Only
+// the answers telling whether the endpoint exists are kept.
+func (client *SchemaRegistryClient) hasFeature(ctx context.Context, feature registryFeature) (bool, error) {
+	detection := &client.featureDetection
+	detection.lock.Lock()
+	defer detection.lock.Unlock()
+	if supported, ok := detection.supported[feature]; ok {
+		return supported, nil
+	}
+
+	supported, err := client.probeEndpoint(ctx, feature.probe)
+	if err != nil {
+		return false, err
+	}
+	if detection.supported == nil {
+		detection.supported = make(map[registryFeature]bool)
+	}
+	detection.supported[feature] = supported
+	return supported, nil
+}
+
+// probeEndpoint tells if the endpoint exists, errors are only returned
+// when the registry could not be reached or failed for other reasons.
+func (client *SchemaRegistryClient) probeEndpoint(ctx context.Context, uri string) (bool, error) {
+	_, err := client.httpRequest(ctx, "GET", uri, nil)
+	if isUnsupportedEndpointError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// isUnauthorizedError tells if the registry refused the request to the
+// credentials of the client, which says nothing about the endpoint.
+func isUnauthorizedError(err error) bool {
+	var registryErr Error
+	if errors.As(err, &registryErr) {
+		switch registryErr.Code {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return true
+		}
+		switch registryErr.Code / 100 {
+		case http.StatusUnauthorized, http.StatusForbidden:
+			return true
+		}
+	}
+	return false
+}
+
+// isUnsupportedEndpointError tells if the registry answered that it
+// doesn't know about the endpoint, as opposed to a missing resource.
+func isUnsupportedEndpointError(err error) bool {
+	var registryErr Error
+	if errors.As(err, &registryErr) {
+		switch registryErr.Code {
+		case http.StatusNotFound, http.StatusMethodNotAllowed, http.StatusNotImplemented:
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,253 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_Features(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		handler  http.HandlerFunc
+		expected RegistryFeatures
+	}{
+		"confluent": {
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/mode":
+					_, _ = rw.Write([]byte(`{"mode":"READWRITE"}`))
+				case "/subjects":
+					_, _ = rw.Write([]byte(`[]`))
+				}
+			},
+			expected: RegistryFeatures{Mode: true, DeletedSubjects: true},
+		},
+		"redpanda without mode": {
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/mode":
+					rw.WriteHeader(http.StatusNotFound)
+					_, _ = rw.Write([]byte(`{"error_code":404,"message":"Not found"}`))
+				case "/subjects":
+					_, _ = rw.Write([]byte(`[]`))
+				}
+			},
+			expected: RegistryFeatures{Mode: false, DeletedSubjects: true},
+		},
+		"plain text errors": {
+			handler: func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path == "/mode" {
+					http.Error(rw, "405 method not allowed", http.StatusMethodNotAllowed)
+					return
+				}
+				_, _ = rw.Write([]byte(`[]`))
+			},
+			expected: RegistryFeatures{Mode: false, DeletedSubjects: true},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				calls++
+				testData.handler(rw, req)
+			}))
+			defer server.Close()
+
+			srClient := CreateSchemaRegistryClient(server.URL)
+			features, err := srClient.Features(context.Background())
+			if assert.NoError(t, err) {
+				assert.Equal(t, testData.expected, *features)
+			}
+
+			// Detection happens only once
+			_, err = srClient.Features(context.Background())
+			assert.NoError(t, err)
+			assert.Equal(t, 2, calls)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_FeaturesReturnsServerErrors(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	_, err := srClient.Features(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestSchemaRegistryClient_UnsupportedFeatures(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		call  func(client *SchemaRegistryClient) error
+		probe string
+	}{
+		"get mode": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.GetMode(context.Background())
+				return err
+			},
+			probe: "GET /mode",
+		},
+		"set mode": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.SetMode(context.Background(), ReadOnly)
+				return err
+			},
+			probe: "GET /mode",
+		},
+		"get subject mode": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.GetSubjectMode(context.Background(), "cupcakes-value", true)
+				return err
+			},
+			probe: "GET /mode",
+		},
+		"set subject mode": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.SetSubjectMode(context.Background(), "cupcakes-value", ReadOnly)
+				return err
+			},
+			probe: "GET /mode",
+		},
+		"subjects including deleted": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.GetSubjectsIncludingDeleted(context.Background())
+				return err
+			},
+			probe: "GET /subjects?deleted=true",
+		},
+		"deleted versions": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.ListDeletedVersions(context.Background(), "cupcakes-value")
+				return err
+			},
+			probe: "GET /subjects?deleted=true",
+		},
+		"soft deleted subject": {
+			call: func(client *SchemaRegistryClient) error {
+				_, err := client.IsSubjectSoftDeleted(context.Background(), "cupcakes-value")
+				return err
+			},
+			probe: "GET /subjects?deleted=true",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.RequestURI())
+				rw.WriteHeader(http.StatusNotFound)
+				_, _ = rw.Write([]byte(`{"error_code":404,"message":"Not found"}`))
+			}))
+			defer server.Close()
+			srClient := CreateSchemaRegistryClient(server.URL)
+
+			// Act
+			err := testData.call(srClient)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrFeatureNotSupported)
+			assert.Equal(t, []string{testData.probe}, requests)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_FeatureProbesAreIndependent(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.Method+" "+req.URL.RequestURI())
+		switch req.URL.Path {
+		case "/mode":
+			rw.WriteHeader(http.StatusForbidden)
+			_, _ = rw.Write([]byte(`{"error_code":40301,"message":"User is denied operation on this server"}`))
+		case "/subjects":
+			_, _ = rw.Write([]byte(`["cupcakes-value"]`))
+		}
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	subjects, subjectsErr := srClient.GetSubjectsIncludingDeleted(context.Background())
+	_, subjectsAgainErr := srClient.GetSubjectsIncludingDeleted(context.Background())
+	_, modeErr := srClient.GetMode(context.Background())
+	srClient.ResetCache()
+	_, resetErr := srClient.GetSubjectsIncludingDeleted(context.Background())
+
+	// Assert
+	require.NoError(t, subjectsErr)
+	assert.Equal(t, []string{"cupcakes-value"}, subjects)
+	require.NoError(t, subjectsAgainErr)
+	require.NoError(t, resetErr)
+	var registryErr Error
+	if assert.ErrorAs(t, modeErr, &registryErr) {
+		assert.Equal(t, 40301, registryErr.Code)
+	}
+	assert.NotErrorIs(t, modeErr, ErrFeatureNotSupported)
+	assert.Equal(t, []string{
+		"GET /subjects?deleted=true", "GET /subjects?deleted=true", "GET /subjects?deleted=true",
+		"GET /mode", "GET /mode",
+		"GET /subjects?deleted=true", "GET /subjects?deleted=true",
+	}, requests)
+}
+
+func TestSchema_Deleted(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		body    string
+		deleted bool
+	}{
+		"active": {
+			body: `{"subject": "cupcakes-value", "version": 1, "id": 1, "schema": "\"string\""}`,
+		},
+		"confluent deleted": {
+			body:    `{"subject": "cupcakes-value", "version": 1, "id": 1, "schema": "\"string\"", "deleted": true}`,
+			deleted: true,
+		},
+		"redpanda deleted": {
+			body:    `{"subject": "cupcakes-value", "version": 1, "id": 1, "schema": "\"string\"", "is_deleted": true}`,
+			deleted: true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				_, _ = rw.Write([]byte(testData.body))
+			}))
+			defer server.Close()
+			srClient := CreateSchemaRegistryClient(server.URL)
+
+			// Act
+			schema, err := srClient.GetSchemaByVersion(context.Background(), "cupcakes-value", 1)
+
+			// Assert
+			if assert.NoError(t, err) {
+				assert.Equal(t, testData.deleted, schema.Deleted())
+			}
+		})
+	}
+}
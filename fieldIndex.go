@@ -0,0 +1,149 @@
+package srclient
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// FieldLocation is a subject version holding an indexed field.
+type FieldLocation struct {
+	Subject string
+	Version int
+	Path    string
+	Name    string
+	Type    string
+}
+
+// FieldIndex maps field names and paths to the subjects and versions
+// declaring them, to back data discovery tools without a separate
+// catalog. It is built and kept up to date by Refresh.
+type FieldIndex struct {
+	client ISchemaRegistryClient
+
+	lock     sync.RWMutex
+	subjects map[string]map[int][]FieldLocation
+	byName   map[string][]FieldLocation
+	byPath   map[string][]FieldLocation
+}
+
+// NewFieldIndex creates an empty index over the registry.
+func NewFieldIndex(client ISchemaRegistryClient) *FieldIndex {
+	return &FieldIndex{
+		client:   client,
+		subjects: make(map[string]map[int][]FieldLocation),
+	}
+}
+
+// Refresh brings the index up to date. Refreshes are incremental, only
+// the versions registered since the previous refresh are fetched, while
+// deleted subjects and versions are dropped.
+func (index *FieldIndex) Refresh(ctx context.Context) error {
+	subjectList, err := index.client.GetSubjects(ctx)
+	if err != nil {
+		return err
+	}
+
+	index.lock.RLock()
+	previous := index.subjects
+	index.lock.RUnlock()
+
+	updated := make(map[string]map[int][]FieldLocation, len(subjectList))
+	for _, subject := range subjectList {
+		versions, err := index.client.GetSchemaVersions(ctx, subject)
+		if isNotFoundError(err) {
+			continue
+		} else if err != nil {
+			return err
+		}
+
+		updated[subject] = make(map[int][]FieldLocation, len(versions))
+		for _, version := range versions {
+			if locations, ok := previous[subject][version]; ok {
+				updated[subject][version] = locations
+				continue
+			}
+			schema, err := index.client.GetSchemaByVersion(ctx, subject, version)
+			if isNotFoundError(err) {
+				continue
+			} else if err != nil {
+				return err
+			}
+
+			// Schemas which can't be parsed are indexed without fields
+			fields, _ := schemaFields(schema)
+			locations := make([]FieldLocation, 0, len(fields))
+			for _, field := range fields {
+				locations = append(locations, FieldLocation{Subject: subject, Version: version, Path: field.Path, Name: field.Name, Type: field.Type})
+			}
+			updated[subject][version] = locations
+		}
+	}
+
+	index.lock.Lock()
+	defer index.lock.Unlock()
+	index.subjects = updated
+	index.rebuild()
+	return nil
+}
+
+// rebuild computes the lookup maps, the lock must be held.
+func (index *FieldIndex) rebuild() {
+	index.byName = make(map[string][]FieldLocation)
+	index.byPath = make(map[string][]FieldLocation)
+	for _, versions := range index.subjects {
+		for _, locations := range versions {
+			for _, location := range locations {
+				name := strings.ToLower(location.Name)
+				index.byName[name] = append(index.byName[name], location)
+				index.byPath[location.Path] = append(index.byPath[location.Path], location)
+			}
+		}
+	}
+	for _, locations := range index.byName {
+		sortFieldLocations(locations)
+	}
+	for _, locations := range index.byPath {
+		sortFieldLocations(locations)
+	}
+}
+
+// Lookup returns where a field with the given name is declared, at any
+// depth. Names are compared case insensitively.
+func (index *FieldIndex) Lookup(name string) []FieldLocation {
+	index.lock.RLock()
+	defer index.lock.RUnlock()
+	return append([]FieldLocation(nil), index.byName[strings.ToLower(name)]...)
+}
+
+// LookupPath returns where a field with the given path is declared.
+func (index *FieldIndex) LookupPath(path string) []FieldLocation {
+	index.lock.RLock()
+	defer index.lock.RUnlock()
+	return append([]FieldLocation(nil), index.byPath[path]...)
+}
+
+// Paths returns all the indexed field paths, sorted.
+func (index *FieldIndex) Paths() []string {
+	index.lock.RLock()
+	defer index.lock.RUnlock()
+	paths := make([]string, 0, len(index.byPath))
+	for path := range index.byPath {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
+
+func sortFieldLocations(locations []FieldLocation) {
+	sort.Slice(locations, func(i, j int) bool {
+		if locations[i].Subject != locations[j].Subject {
+			return locations[i].Subject < locations[j].Subject
+		}
+		if locations[i].Version != locations[j].Version {
+			return locations[i].Version < locations[j].Version
+		}
+		return locations[i].Path < locations[j].Path
+	})
+}
@@ -0,0 +1,48 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type countingVersionClient struct {
+	*MockSchemaRegistryClient
+	fetched int
+}
+
+func (c *countingVersionClient) GetSchemaByVersion(ctx context.Context, subject string, version int) (*Schema, error) {
+	c.fetched++
+	return c.MockSchemaRegistryClient.GetSchemaByVersion(ctx, subject, version)
+}
+
+func TestFieldIndex_RefreshesIncrementally(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := &countingVersionClient{MockSchemaRegistryClient: CreateMockSchemaRegistryClient("http://localhost:8081")}
+	_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+	_, _ = registry.CreateSchema(context.Background(), "bakery", testSchema2, Avro)
+	index := NewFieldIndex(registry)
+
+	// Act
+	require.NoError(t, index.Refresh(context.Background()))
+
+	// Assert
+	assert.Equal(t, 2, registry.fetched)
+	assert.Equal(t, []FieldLocation{{Subject: "cupcake", Version: 1, Path: "flavor", Name: "flavor", Type: "string"}}, index.Lookup("FLAVOR"))
+	assert.Equal(t, []string{"flavor", "number"}, index.Paths())
+
+	// Act
+	_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema2, Avro)
+	_, err := registry.DeleteSubject(context.Background(), "bakery", false)
+	require.NoError(t, err)
+	require.NoError(t, index.Refresh(context.Background()))
+
+	// Assert
+	assert.Equal(t, 3, registry.fetched)
+	assert.Equal(t, []FieldLocation{{Subject: "cupcake", Version: 2, Path: "number", Name: "number", Type: "int"}}, index.LookupPath("number"))
+	assert.Len(t, index.Lookup("flavor"), 1)
+	assert.Empty(t, index.Lookup("ssn"))
+}
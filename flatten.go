@@ -0,0 +1,381 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// ErrFlattenNotSupported is returned when flattening Protobuf schemas
+// with references, imports of other packages can't be inlined.
+var ErrFlattenNotSupported = errors.New("flattening Protobuf schemas with references is not supported")
+
+// referenceResolver returns the schema a reference points to.
+type referenceResolver func(reference Reference) (schema string, schemaType SchemaType, references []Reference, err error)
+
+// FlattenSchema resolves the references of a schema, recursively, into a
+// single self-contained schema for the systems which can't handle
+// references, such as Flink or ksqlDB. Avro named types are inlined where
+// they are first used, JSON schemas get the referenced documents under
+// "definitions" with their $ref rewritten to point there. References
+// without a version resolve to the latest version of their subject.
+func FlattenSchema(ctx context.Context, client ISchemaRegistryClient, schema *Schema) (string, error) {
+	resolve := func(reference Reference) (string, SchemaType, []Reference, error) {
+		var referenced *Schema
+		var err error
+		if reference.Version > 0 {
+			referenced, err = client.GetSchemaByVersion(ctx, reference.Subject, reference.Version)
+		} else {
+			referenced, err = client.GetLatestSchema(ctx, reference.Subject)
+		}
+		if err != nil {
+			return "", "", nil, fmt.Errorf("reference %s: %w", reference.Name, err)
+		}
+		return referenced.Schema(), schemaTypeOf(referenced), referenced.References(), nil
+	}
+	return flattenSchema(schema.Schema(), schemaTypeOf(schema), schema.References(), resolve, make(map[string]bool))
+}
+
+// FlattenExport returns a copy of the export where the schemas with
+// references also come in flattened form, in Flattened, references being
+// resolved among the exported schemas. Their references are pruned of the
+// ones the schema doesn't use. Protobuf schemas are left as they are.
+func FlattenExport(export []ExportedSchema) ([]ExportedSchema, error) {
+	versions := make(map[string]map[int]ExportedSchema)
+	latest := make(map[string]ExportedSchema)
+	for _, exported := range export {
+		if versions[exported.Subject] == nil {
+			versions[exported.Subject] = make(map[int]ExportedSchema)
+		}
+		versions[exported.Subject][exported.Version] = exported
+		if exported.Version >= latest[exported.Subject].Version {
+			latest[exported.Subject] = exported
+		}
+	}
+	resolve := func(reference Reference) (string, SchemaType, []Reference, error) {
+		exported, ok := versions[reference.Subject][reference.Version]
+		if reference.Version <= 0 {
+			exported, ok = latest[reference.Subject]
+		}
+		if !ok {
+			return "", "", nil, fmt.Errorf("reference %s: version %d of subject %s is not part of the export", reference.Name, reference.Version, reference.Subject)
+		}
+		return exported.Schema, exported.SchemaType, exported.References, nil
+	}
+
+	flattened := make([]ExportedSchema, len(export))
+	for i, exported := range export {
+		flattened[i] = exported
+		if len(exported.References) == 0 || exported.SchemaType == Protobuf {
+			continue
+		}
+		schema, err := flattenSchema(exported.Schema, exported.SchemaType, exported.References, resolve, make(map[string]bool))
+		if err != nil {
+			return nil, fmt.Errorf("subject %s version %d: %w", exported.Subject, exported.Version, err)
+		}
+		flattened[i].Flattened = schema
+		flattened[i].References = usedReferences(exported.Schema, exported.SchemaType, exported.References)
+	}
+	return flattened, nil
+}
+
+// flattenSchema inlines the references of the schema, visiting holds the
+// subjects being flattened to detect cycles.
+func flattenSchema(schema string, schemaType SchemaType, references []Reference, resolve referenceResolver, visiting map[string]bool) (string, error) {
+	if len(references) == 0 {
+		return schema, nil
+	}
+	if schemaType == Protobuf {
+		return "", ErrFlattenNotSupported
+	}
+
+	type resolvedReference struct {
+		reference Reference
+		document  interface{}
+	}
+	resolved := make([]resolvedReference, 0, len(references))
+	for _, reference := range references {
+		key := fmt.Sprintf("%s/%d", reference.Subject, reference.Version)
+		if visiting[key] {
+			return "", fmt.Errorf("reference %s: cycle through subject %s", reference.Name, reference.Subject)
+		}
+		referenced, referencedType, referencedReferences, err := resolve(reference)
+		if err != nil {
+			return "", err
+		}
+		visiting[key] = true
+		flattened, err := flattenSchema(referenced, referencedType, referencedReferences, resolve, visiting)
+		delete(visiting, key)
+		if err != nil {
+			return "", err
+		}
+		document, err := decodeSchemaDocument(flattened)
+		if err != nil {
+			return "", fmt.Errorf("reference %s: %w", reference.Name, err)
+		}
+		resolved = append(resolved, resolvedReference{reference: reference, document: document})
+	}
+
+	document, err := decodeSchemaDocument(schema)
+	if err != nil {
+		return "", err
+	}
+	switch schemaType {
+	case Json:
+		documents := make(map[string]interface{}, len(resolved))
+		for _, reference := range resolved {
+			documents[reference.reference.Name] = reference.document
+		}
+		document = inlineJsonReferences(document, documents)
+	default:
+		inliner := &avroInliner{defined: make(map[string]bool), provided: make(map[string]int)}
+		for i, reference := range resolved {
+			defined := make(map[string]bool)
+			avroNamedTypes(reference.document, "", defined, make(map[string]bool))
+			for name := range defined {
+				inliner.provided[name] = i
+			}
+			inliner.documents = append(inliner.documents, reference.document)
+		}
+		document = inliner.inline(document, "")
+	}
+
+	var flattened bytes.Buffer
+	encoder := json.NewEncoder(&flattened)
+	encoder.SetEscapeHTML(false)
+	if err := encoder.Encode(document); err != nil {
+		return "", err
+	}
+	return strings.TrimSuffix(flattened.String(), "\n"), nil
+}
+
+// avroInliner replaces the first use of the named types provided by the
+// referenced schemas with the schemas themselves.
+type avroInliner struct {
+	defined   map[string]bool
+	provided  map[string]int
+	documents []interface{}
+}
+
+func (inliner *avroInliner) inline(schema interface{}, namespace string) interface{} {
+	switch typed := schema.(type) {
+	case string:
+		if avroPrimitives[typed] {
+			return typed
+		}
+		var index int
+		var ok bool
+		// Like the Java parser, a name not found in the namespace falls
+		// back to the null namespace
+		for _, fullName := range []string{avroFullName(typed, namespace), typed} {
+			if inliner.defined[fullName] {
+				return typed
+			}
+			if index, ok = inliner.provided[fullName]; ok {
+				break
+			}
+		}
+		if !ok {
+			return typed
+		}
+		document := inliner.documents[index]
+		avroNamedTypes(document, "", inliner.defined, make(map[string]bool))
+		if named, ok := document.(map[string]interface{}); ok && namespace != "" {
+			// A type of the null namespace would otherwise take the
+			// namespace of the schema it is inlined into
+			name, _ := named["name"].(string)
+			if _, ok := named["namespace"]; !ok && name != "" && !strings.Contains(name, ".") {
+				inlined := make(map[string]interface{}, len(named)+1)
+				for key, value := range named {
+					inlined[key] = value
+				}
+				inlined["namespace"] = ""
+				return inlined
+			}
+		}
+		return document
+	case []interface{}:
+		members := make([]interface{}, len(typed))
+		for i, member := range typed {
+			members[i] = inliner.inline(member, namespace)
+		}
+		return members
+	case map[string]interface{}:
+		inlined := make(map[string]interface{}, len(typed))
+		for key, value := range typed {
+			inlined[key] = value
+		}
+		typeName, ok := typed["type"].(string)
+		if !ok {
+			inlined["type"] = inliner.inline(typed["type"], namespace)
+			return inlined
+		}
+		switch typeName {
+		case "array":
+			inlined["items"] = inliner.inline(typed["items"], namespace)
+		case "map":
+			inlined["values"] = inliner.inline(typed["values"], namespace)
+		case "record", "error", "enum", "fixed":
+			name, _ := typed["name"].(string)
+			if ns, ok := typed["namespace"].(string); ok {
+				namespace = ns
+			}
+			fullName := avroFullName(name, namespace)
+			inliner.defined[fullName] = true
+			if idx := strings.LastIndex(fullName, "."); idx >= 0 {
+				namespace = fullName[:idx]
+			}
+			if fields, ok := typed["fields"].([]interface{}); ok {
+				inlinedFields := make([]interface{}, len(fields))
+				for i, field := range fields {
+					fieldMap, ok := field.(map[string]interface{})
+					if !ok {
+						inlinedFields[i] = field
+						continue
+					}
+					inlinedField := make(map[string]interface{}, len(fieldMap))
+					for key, value := range fieldMap {
+						inlinedField[key] = value
+					}
+					inlinedField["type"] = inliner.inline(fieldMap["type"], namespace)
+					inlinedFields[i] = inlinedField
+				}
+				inlined["fields"] = inlinedFields
+			}
+		default:
+			inlined["type"] = inliner.inline(typeName, namespace)
+		}
+		return inlined
+	}
+	return schema
+}
+
+// inlineJsonReferences moves the referenced documents under the
+// definitions of the schema and points the $ref to them.
+func inlineJsonReferences(document interface{}, documents map[string]interface{}) interface{} {
+	used := make(map[string]bool)
+	document = rewriteJsonRefs(document, func(ref string) string {
+		base, fragment := splitJsonRef(ref)
+		if _, ok := documents[base]; !ok {
+			return ref
+		}
+		used[base] = true
+		return jsonDefinitionRef(base) + fragment
+	})
+
+	root, ok := document.(map[string]interface{})
+	if !ok || len(used) == 0 {
+		return document
+	}
+	definitions, _ := root["definitions"].(map[string]interface{})
+	if definitions == nil {
+		definitions = make(map[string]interface{})
+	}
+	for name := range used {
+		prefix := jsonDefinitionRef(name)
+		embedded := rewriteJsonRefs(documents[name], func(ref string) string {
+			if strings.HasPrefix(ref, "#") {
+				return prefix + strings.TrimPrefix(ref, "#")
+			}
+			return ref
+		})
+		if embeddedMap, ok := embedded.(map[string]interface{}); ok {
+			// The embedded document is resolved against the base URI of
+			// the schema, not its own
+			delete(embeddedMap, "$id")
+			delete(embeddedMap, "$schema")
+		}
+		definitions[name] = embedded
+	}
+	root["definitions"] = definitions
+	return root
+}
+
+// rewriteJsonRefs returns a copy of the document with its $ref rewritten.
+func rewriteJsonRefs(document interface{}, rewrite func(ref string) string) interface{} {
+	switch typed := document.(type) {
+	case []interface{}:
+		items := make([]interface{}, len(typed))
+		for i, item := range typed {
+			items[i] = rewriteJsonRefs(item, rewrite)
+		}
+		return items
+	case map[string]interface{}:
+		rewritten := make(map[string]interface{}, len(typed))
+		for key, value := range typed {
+			if ref, ok := value.(string); ok && key == "$ref" {
+				rewritten[key] = rewrite(ref)
+				continue
+			}
+			rewritten[key] = rewriteJsonRefs(value, rewrite)
+		}
+		return rewritten
+	}
+	return document
+}
+
+func splitJsonRef(ref string) (string, string) {
+	parts := strings.SplitN(ref, "#", 2)
+	if len(parts) == 1 {
+		return parts[0], ""
+	}
+	return parts[0], parts[1]
+}
+
+// jsonDefinitionRef is the $ref of a document inlined under definitions,
+// its name escaped as a JSON pointer token.
+func jsonDefinitionRef(name string) string {
+	return "#/definitions/" + strings.NewReplacer("~", "~0", "/", "~1").Replace(name)
+}
+
+// decodeSchemaDocument decodes a JSON schema document keeping its numbers
+// as they are written.
+func decodeSchemaDocument(schema string) (interface{}, error) {
+	var document interface{}
+	decoder := json.NewDecoder(strings.NewReader(schema))
+	decoder.UseNumber()
+	if err := decoder.Decode(&document); err != nil {
+		return nil, err
+	}
+	return document, nil
+}
+
+// usedReferences prunes the references the schema doesn't use.
+func usedReferences(schema string, schemaType SchemaType, references []Reference) []Reference {
+	document, err := decodeSchemaDocument(schema)
+	if err != nil {
+		return references
+	}
+	used := make(map[string]bool)
+	switch schemaType {
+	case Json:
+		for _, ref := range jsonSchemaRefs(document, nil) {
+			base, _ := splitJsonRef(ref)
+			used[base] = true
+		}
+	default:
+		defined, names := make(map[string]bool), make(map[string]bool)
+		avroNamedTypes(document, "", defined, names)
+		used = names
+	}
+
+	if schemaType != Json {
+		// Names used in a namespace may resolve to the null namespace
+		for name := range used {
+			if idx := strings.LastIndex(name, "."); idx >= 0 {
+				used[name[idx+1:]] = true
+			}
+		}
+	}
+
+	var pruned []Reference
+	for _, reference := range references {
+		if used[reference.Name] {
+			pruned = append(pruned, reference)
+		}
+	}
+	return pruned
+}
@@ -0,0 +1,161 @@
+package srclient
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/crxfoz/goavro/v2"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFlattenExport(t *testing.T) {
+	t.Parallel()
+
+	address := `{"type":"record","name":"Address","namespace":"com.example","fields":[{"name":"street","type":"string"}]}`
+	country := `{"type":"enum","name":"Country","symbols":["FR","US"]}`
+	customer := `{"type":"record","name":"Customer","namespace":"com.example","fields":[` +
+		`{"name":"home","type":"Address"},{"name":"work","type":["null","com.example.Address"]},{"name":"country","type":"Country"}]}`
+	references := []Reference{
+		{Name: "com.example.Address", Subject: "address", Version: 1},
+		{Name: "Country", Subject: "country", Version: 1},
+		{Name: "com.example.Unused", Subject: "unused", Version: 1},
+	}
+	export := []ExportedSchema{
+		{Subject: "address", Version: 1, ID: 1, Schema: address, SchemaType: Avro},
+		{Subject: "country", Version: 1, ID: 2, Schema: country, SchemaType: Avro},
+		{Subject: "customer", Version: 1, ID: 3, Schema: customer, SchemaType: Avro, References: references},
+		{Subject: "unused", Version: 1, ID: 4, Schema: `{"type":"fixed","name":"Unused","namespace":"com.example","size":1}`, SchemaType: Avro},
+	}
+
+	// Act
+	flattened, err := FlattenExport(export)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, flattened, 4)
+	assert.Empty(t, flattened[0].Flattened)
+	assert.Equal(t, references, export[2].References)
+	assert.Equal(t, references[:2], flattened[2].References)
+	assert.Equal(t, customer, flattened[2].Schema)
+
+	codec, err := goavro.NewCodec(flattened[2].Flattened)
+	require.NoError(t, err)
+	native := map[string]interface{}{
+		"home":    map[string]interface{}{"street": "main"},
+		"work":    goavro.Union("com.example.Address", map[string]interface{}{"street": "side"}),
+		"country": "FR",
+	}
+	_, err = codec.BinaryFromNative(nil, native)
+	assert.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(flattened[2].Flattened, `"street"`))
+}
+
+func TestFlattenExportJsonSchema(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	address := `{"$schema":"http://json-schema.org/draft-07/schema#","$id":"address.json","type":"object",` +
+		`"properties":{"street":{"$ref":"#/definitions/street"}},"definitions":{"street":{"type":"string"}}}`
+	customer := `{"$schema":"http://json-schema.org/draft-07/schema#","type":"object",` +
+		`"properties":{"home":{"$ref":"types/address.json"},"street":{"$ref":"types/address.json#/definitions/street"}}}`
+	export := []ExportedSchema{
+		{Subject: "address", Version: 2, ID: 1, Schema: address, SchemaType: Json},
+		{Subject: "customer", Version: 1, ID: 2, Schema: customer, SchemaType: Json, References: []Reference{
+			{Name: "types/address.json", Subject: "address"},
+		}},
+	}
+
+	// Act
+	flattened, err := FlattenExport(export)
+
+	// Assert
+	require.NoError(t, err)
+	assert.NotContains(t, flattened[1].Flattened, "address.json#")
+	schema, err := jsonschema.CompileString("customer.json", flattened[1].Flattened)
+	require.NoError(t, err)
+	assert.NoError(t, schema.Validate(map[string]interface{}{
+		"home":   map[string]interface{}{"street": "main"},
+		"street": "side",
+	}))
+	assert.Error(t, schema.Validate(map[string]interface{}{
+		"home": map[string]interface{}{"street": 1},
+	}))
+}
+
+func TestFlattenExportErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		export []ExportedSchema
+	}{
+		"missing reference": {
+			export: []ExportedSchema{
+				{Subject: "customer", Version: 1, Schema: `{"type":"record","name":"C","fields":[{"name":"a","type":"A"}]}`, SchemaType: Avro,
+					References: []Reference{{Name: "A", Subject: "a", Version: 1}}},
+			},
+		},
+		"cycle": {
+			export: []ExportedSchema{
+				{Subject: "a", Version: 1, Schema: `{"type":"record","name":"A","fields":[{"name":"b","type":["null","B"]}]}`, SchemaType: Avro,
+					References: []Reference{{Name: "B", Subject: "b", Version: 1}}},
+				{Subject: "b", Version: 1, Schema: `{"type":"record","name":"B","fields":[{"name":"a","type":["null","A"]}]}`, SchemaType: Avro,
+					References: []Reference{{Name: "A", Subject: "a", Version: 1}}},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			_, err := FlattenExport(testData.export)
+
+			// Assert
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestFlattenSchema(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	ctx := context.Background()
+	client := CreateMockSchemaRegistryClient("mock://flatten")
+	_, err := client.CreateSchema(ctx, "money", `{"type":"record","name":"Money","fields":[{"name":"amount","type":"long","default":9007199254740993}]}`, Avro)
+	require.NoError(t, err)
+	_, err = client.CreateSchema(ctx, "payment", `{"type":"record","name":"Payment","namespace":"com.example","fields":[{"name":"money","type":"Money"}]}`, Avro,
+		Reference{Name: "Money", Subject: "money"})
+	require.NoError(t, err)
+	order, err := client.CreateSchema(ctx, "order", `{"type":"record","name":"Order","namespace":"com.example","fields":[{"name":"payment","type":"Payment"}]}`, Avro,
+		Reference{Name: "com.example.Payment", Subject: "payment", Version: 1})
+	require.NoError(t, err)
+
+	// Act
+	flattened, err := FlattenSchema(ctx, client, order)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Contains(t, flattened, `"namespace":""`)
+	assert.Contains(t, flattened, "9007199254740993")
+	_, err = goavro.NewCodec(flattened)
+	assert.NoError(t, err)
+}
+
+func TestFlattenSchemaProtobuf(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	ctx := context.Background()
+	client := CreateMockSchemaRegistryClient("mock://flatten")
+	schema, err := client.CreateSchema(ctx, "order", `syntax = "proto3"; import "money.proto"; message Order { Money money = 1; }`, Protobuf,
+		Reference{Name: "money.proto", Subject: "money", Version: 1})
+	require.NoError(t, err)
+
+	// Act
+	_, err = FlattenSchema(ctx, client, schema)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrFlattenNotSupported)
+}
@@ -1,10 +1,15 @@
 module github.com/crxfoz/srclient
 
-go 1.12
+go 1.16
 
 require (
 	github.com/crxfoz/goavro/v2 v2.14.0
+	github.com/golang/protobuf v1.5.0
+	github.com/jhump/protoreflect v1.14.1
+	github.com/klauspost/compress v1.15.9
 	github.com/santhosh-tekuri/jsonschema/v5 v5.0.0
 	github.com/stretchr/testify v1.7.5
 	golang.org/x/sync v0.0.0-20220513210516-0976fa681c29
+	google.golang.org/protobuf v1.26.0
+	gopkg.in/yaml.v3 v3.0.1
 )
@@ -0,0 +1,179 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// GoldenCorpus holds sample payloads, in wire format, for every
+// version of a subject. Stored alongside consumer code, it lets
+// consumer teams continuously check they can decode every
+// historical version of the schemas they read.
+type GoldenCorpus struct {
+	Subject string
+	// Payloads maps each version of the subject to its samples.
+	Payloads map[int][][]byte
+}
+
+// CorpusMismatch describes a sample which differs between two corpora,
+// or which could not be decoded.
+type CorpusMismatch struct {
+	Version int
+	Index   int
+	Reason  string
+}
+
+func (m CorpusMismatch) String() string {
+	return fmt.Sprintf("version %d sample %d: %s", m.Version, m.Index, m.Reason)
+}
+
+// BuildGoldenCorpus generates samplesPerVersion random payloads for every
+// version of the subject. The same seed produces the same values, but
+// goavro encodes the entries of Avro maps in random order, so samples
+// with maps may not be byte for byte identical.
+func BuildGoldenCorpus(ctx context.Context, client ISchemaRegistryClient, subject string, samplesPerVersion int, seed int64) (*GoldenCorpus, error) {
+	versions, err := client.GetSchemaVersions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	corpus := &GoldenCorpus{Subject: subject, Payloads: make(map[int][][]byte)}
+	for _, version := range versions {
+		schema, err := client.GetSchemaByVersion(ctx, subject, version)
+		if err != nil {
+			return nil, err
+		}
+
+		generator := NewRandomGenerator(seed + int64(version))
+		for i := 0; i < samplesPerVersion; i++ {
+			payload, err := generator.Payload(schema)
+			if err != nil {
+				return nil, fmt.Errorf("version %d of subject %s: %w", version, subject, err)
+			}
+			corpus.Payloads[version] = append(corpus.Payloads[version], payload)
+		}
+	}
+
+	return corpus, nil
+}
+
+// LoadGoldenCorpus reads the corpus of the subject saved under dir.
+func LoadGoldenCorpus(dir string, subject string) (*GoldenCorpus, error) {
+	subjectDir := filepath.Join(dir, url.PathEscape(subject))
+	versionDirs, err := os.ReadDir(subjectDir)
+	if err != nil {
+		return nil, err
+	}
+
+	corpus := &GoldenCorpus{Subject: subject, Payloads: make(map[int][][]byte)}
+	for _, versionDir := range versionDirs {
+		version, err := strconv.Atoi(versionDir.Name())
+		if err != nil || !versionDir.IsDir() {
+			continue
+		}
+
+		files, err := os.ReadDir(filepath.Join(subjectDir, versionDir.Name()))
+		if err != nil {
+			return nil, err
+		}
+		for _, file := range files {
+			if !strings.HasSuffix(file.Name(), ".bin") {
+				continue
+			}
+			payload, err := os.ReadFile(filepath.Join(subjectDir, versionDir.Name(), file.Name()))
+			if err != nil {
+				return nil, err
+			}
+			corpus.Payloads[version] = append(corpus.Payloads[version], payload)
+		}
+	}
+
+	return corpus, nil
+}
+
+// Save writes the corpus under dir, as <subject>/<version>/<sample>.bin
+// files, replacing the samples previously saved for the subject.
+func (corpus *GoldenCorpus) Save(dir string) error {
+	subjectDir := filepath.Join(dir, url.PathEscape(corpus.Subject))
+	if err := os.RemoveAll(subjectDir); err != nil {
+		return err
+	}
+
+	for version, payloads := range corpus.Payloads {
+		versionDir := filepath.Join(subjectDir, strconv.Itoa(version))
+		if err := os.MkdirAll(versionDir, 0755); err != nil {
+			return err
+		}
+		for i, payload := range payloads {
+			// Zero padded so samples are read back in the same order
+			name := filepath.Join(versionDir, fmt.Sprintf("%06d.bin", i))
+			if err := os.WriteFile(name, payload, 0644); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// Versions returns the versions of the corpus in ascending order.
+func (corpus *GoldenCorpus) Versions() []int {
+	versions := make([]int, 0, len(corpus.Payloads))
+	for version := range corpus.Payloads {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+	return versions
+}
+
+// Compare lists the samples which differ from the other corpus,
+// e.g. a stored corpus compared to a freshly generated one.
+func (corpus *GoldenCorpus) Compare(other *GoldenCorpus) []CorpusMismatch {
+	var mismatches []CorpusMismatch
+	for _, version := range corpus.Versions() {
+		otherPayloads, ok := other.Payloads[version]
+		if !ok {
+			mismatches = append(mismatches, CorpusMismatch{Version: version, Index: -1, Reason: "version missing"})
+			continue
+		}
+		for i, payload := range corpus.Payloads[version] {
+			switch {
+			case i >= len(otherPayloads):
+				mismatches = append(mismatches, CorpusMismatch{Version: version, Index: i, Reason: "sample missing"})
+			case !bytes.Equal(payload, otherPayloads[i]):
+				mismatches = append(mismatches, CorpusMismatch{Version: version, Index: i, Reason: "sample differs"})
+			}
+		}
+		for i := len(corpus.Payloads[version]); i < len(otherPayloads); i++ {
+			mismatches = append(mismatches, CorpusMismatch{Version: version, Index: i, Reason: "unexpected sample"})
+		}
+	}
+	for _, version := range other.Versions() {
+		if _, ok := corpus.Payloads[version]; !ok {
+			mismatches = append(mismatches, CorpusMismatch{Version: version, Index: -1, Reason: "unexpected version"})
+		}
+	}
+
+	return mismatches
+}
+
+// Verify decodes every sample of the corpus with the given function,
+// usually the consumer's own decoding path, and lists the failures.
+func (corpus *GoldenCorpus) Verify(ctx context.Context, decode func(ctx context.Context, payload []byte) error) []CorpusMismatch {
+	var failures []CorpusMismatch
+	for _, version := range corpus.Versions() {
+		for i, payload := range corpus.Payloads[version] {
+			if err := decode(ctx, payload); err != nil {
+				failures = append(failures, CorpusMismatch{Version: version, Index: i, Reason: err.Error()})
+			}
+		}
+	}
+	return failures
+}
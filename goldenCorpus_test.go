@@ -0,0 +1,74 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGoldenCorpus_BuildSaveLoadAndVerify(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	_, err = registry.CreateSchema(context.Background(), "cupcakes-value",
+		`{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int", "default": 1}]}`, Avro)
+	require.NoError(t, err)
+
+	// Act
+	corpus, err := BuildGoldenCorpus(context.Background(), registry, "cupcakes-value", 3, 42)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	require.NoError(t, corpus.Save(dir))
+	loaded, err := LoadGoldenCorpus(dir, "cupcakes-value")
+	require.NoError(t, err)
+
+	rebuilt, err := BuildGoldenCorpus(context.Background(), registry, "cupcakes-value", 3, 42)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []int{1, 2}, loaded.Versions())
+	assert.Empty(t, loaded.Compare(corpus))
+	assert.Empty(t, rebuilt.Compare(loaded))
+
+	decoders := NewDecoderRegistry(registry)
+	require.NoError(t, decoders.Register("cupcakes", DecoderConfig{}))
+	failures := loaded.Verify(context.Background(), func(ctx context.Context, payload []byte) error {
+		_, err := decoders.Decode(ctx, "cupcakes", payload)
+		return err
+	})
+	assert.Empty(t, failures)
+}
+
+func TestGoldenCorpus_CompareAndVerifyReportMismatches(t *testing.T) {
+	t.Parallel()
+	corpus := &GoldenCorpus{Subject: "cupcakes-value", Payloads: map[int][][]byte{
+		1: {[]byte("a"), []byte("b")},
+		2: {[]byte("c")},
+	}}
+	other := &GoldenCorpus{Subject: "cupcakes-value", Payloads: map[int][][]byte{
+		1: {[]byte("a"), []byte("x"), []byte("y")},
+		3: {[]byte("d")},
+	}}
+
+	mismatches := corpus.Compare(other)
+	assert.Equal(t, []CorpusMismatch{
+		{Version: 1, Index: 1, Reason: "sample differs"},
+		{Version: 1, Index: 2, Reason: "unexpected sample"},
+		{Version: 2, Index: -1, Reason: "version missing"},
+		{Version: 3, Index: -1, Reason: "unexpected version"},
+	}, mismatches)
+
+	failures := corpus.Verify(context.Background(), func(ctx context.Context, payload []byte) error {
+		if string(payload) == "b" {
+			return errors.New("cannot decode")
+		}
+		return nil
+	})
+	assert.Equal(t, []CorpusMismatch{{Version: 1, Index: 1, Reason: "cannot decode"}}, failures)
+}
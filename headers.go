@@ -0,0 +1,49 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+)
+
+// HeaderFunc returns headers to attach to a request, computed from its
+// context, such as the headers propagating a trace.
+type HeaderFunc func(ctx context.Context) http.Header
+
+// WithHeaders attaches the headers, for instance X-Tenant-Id, to every
+// request sent to the registry. They can't override the Content-Type
+// header, nor the Authorization header when the client has credentials.
+// Headers are added to the ones of previous WithHeaders options.
+func WithHeaders(headers http.Header) Option {
+	return func(options *clientOptions) {
+		if options.headers == nil {
+			options.headers = make(http.Header)
+		}
+		for name, values := range headers {
+			for _, value := range values {
+				options.headers.Add(name, value)
+			}
+		}
+	}
+}
+
+// WithHeaderFunc attaches the headers returned by the function to every
+// request sent to the registry, after the ones of WithHeaders.
+func WithHeaderFunc(headerFunc HeaderFunc) Option {
+	return func(options *clientOptions) {
+		options.headerFuncs = append(options.headerFuncs, headerFunc)
+	}
+}
+
+// setHeaders attaches the configured headers to the request.
+func (client *SchemaRegistryClient) setHeaders(ctx context.Context, req *http.Request) {
+	for name, values := range client.headers {
+		req.Header[name] = append([]string(nil), values...)
+	}
+	for _, headerFunc := range client.headerFuncs {
+		for name, values := range headerFunc(ctx) {
+			for _, value := range values {
+				req.Header.Add(name, value)
+			}
+		}
+	}
+}
@@ -0,0 +1,45 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type traceKey struct{}
+
+func TestSchemaRegistryClient_Headers(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var received http.Header
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		received = req.Header.Clone()
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL,
+		WithBearerToken(StaticToken("token")),
+		WithHeaders(http.Header{"X-Tenant-Id": {"team-a"}, "Authorization": {"Basic forged"}, "Content-Type": {"text/plain"}}),
+		WithHeaders(http.Header{"X-Tenant-Id": {"team-b"}}),
+		WithHeaderFunc(func(ctx context.Context) http.Header {
+			traceID, _ := ctx.Value(traceKey{}).(string)
+			return http.Header{"Traceparent": {traceID}}
+		}),
+	)
+	ctx := context.WithValue(context.Background(), traceKey{}, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01")
+
+	// Act
+	_, err := srClient.GetSchema(ctx, 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"team-a", "team-b"}, received.Values("X-Tenant-Id"))
+	assert.Equal(t, "00-4bf92f3577b34da6a3ce929d0e0e4736-00f067aa0ba902b7-01", received.Get("Traceparent"))
+	assert.Equal(t, []string{"Bearer token"}, received.Values("Authorization"))
+	assert.Equal(t, []string{contentType}, received.Values("Content-Type"))
+}
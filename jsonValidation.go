@@ -0,0 +1,65 @@
+package srclient
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// JsonViolation is a constraint of a JSON schema a value doesn't meet.
+type JsonViolation struct {
+	// Path is the JSON pointer to the offending part of the value,
+	// empty for the value itself.
+	Path    string
+	Message string
+}
+
+// JsonValidationError is returned when a value doesn't match the JSON
+// schema it is serialized or deserialized with.
+type JsonValidationError struct {
+	SchemaID   int
+	Violations []JsonViolation
+}
+
+func (e *JsonValidationError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, violation := range e.Violations {
+		path := violation.Path
+		if path == "" {
+			path = "/"
+		}
+		messages = append(messages, path+": "+violation.Message)
+	}
+	return fmt.Sprintf("value doesn't match JSON schema id %d: %s", e.SchemaID, strings.Join(messages, ", "))
+}
+
+// validateJson checks a decoded JSON value against the schema.
+func validateJson(schema *Schema, value interface{}) error {
+	compiled := schema.JsonSchema()
+	if compiled == nil {
+		return fmt.Errorf("invalid JSON schema with id %d", schema.ID())
+	}
+
+	err := compiled.Validate(value)
+	var validationErr *jsonschema.ValidationError
+	if !errors.As(err, &validationErr) {
+		return err
+	}
+	invalid := &JsonValidationError{SchemaID: schema.ID()}
+	invalid.addViolations(validationErr)
+	return invalid
+}
+
+// addViolations adds the leaves of the tree of validation errors, the
+// inner nodes only tell which keyword failed because of their causes.
+func (e *JsonValidationError) addViolations(err *jsonschema.ValidationError) {
+	if len(err.Causes) == 0 {
+		e.Violations = append(e.Violations, JsonViolation{Path: err.InstanceLocation, Message: err.Message})
+		return
+	}
+	for _, cause := range err.Causes {
+		e.addViolations(cause)
+	}
+}
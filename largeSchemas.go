@@ -0,0 +1,141 @@
+package srclient
+
+import (
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrSchemaTooLarge is returned when a schema is larger than what the
+// client, a proxy or the registry accept. Confluent registries store
+// schemas in a Kafka topic, so schemas larger than the max.message.bytes
+// of the topic are rejected.
+var ErrSchemaTooLarge = errors.New("schema too large")
+
+// SchemaSize returns the size in bytes of the request registering the
+// schema, to compare with the limits of the registry before registering
+// it. Schemas registered by clients minifying them can be measured once
+// minified with MinifySchema.
+func SchemaSize(schema string, schemaType SchemaType, references ...Reference) (int, error) {
+	request, err := newRegistrationRequest(schema, schemaType, nil, references, false)
+	if err != nil {
+		return 0, err
+	}
+	return requestSize(request)
+}
+
+// WithMaxSchemaBytes makes the client refuse to register schemas whose
+// registration request is larger than max bytes, as measured by
+// SchemaSize, with ErrSchemaTooLarge rather than sending them.
+func WithMaxSchemaBytes(max int) Option {
+	return func(options *clientOptions) {
+		options.maxSchemaBytes = max
+	}
+}
+
+// WithMaxResponseHeaderBytes sets the limit of the size of the response
+// headers of the transport, see http.Transport.
+func WithMaxResponseHeaderBytes(max int64) Option {
+	return func(options *clientOptions) {
+		options.maxResponseHeaderBytes = max
+	}
+}
+
+// WithTransportBufferSizes sets the size of the buffers the transport
+// uses to write requests and read responses, larger buffers speeding up
+// the transfer of large schemas, see http.Transport.
+func WithTransportBufferSizes(write, read int) Option {
+	return func(options *clientOptions) {
+		options.writeBufferSize = write
+		options.readBufferSize = read
+	}
+}
+
+// isSchemaTooLargeError tells if a registration was rejected because of
+// its size, by a proxy answering 413 or by the registry failing to write
+// the schema to its Kafka topic.
+func isSchemaTooLargeError(err error) bool {
+	var registryErr Error
+	if !errors.As(err, &registryErr) {
+		return false
+	}
+	return registryErr.Code == http.StatusRequestEntityTooLarge ||
+		strings.Contains(registryErr.Message, "RecordTooLargeException") ||
+		strings.Contains(registryErr.Message, "larger than the max")
+}
+
+// streamChunkBytes is the size of the chunks of the schema escaped and
+// written one after the other by writeRequest.
+const streamChunkBytes = 32 << 10
+
+// streamRequest writes the request into the body as the transport
+// reads it, so that large schemas aren't copied into a buffer before
+// being sent. The body has to be closed for the writing to stop when
+// the request isn't sent.
+func streamRequest(request schemaRequest) io.ReadCloser {
+	reader, writer := io.Pipe()
+	go func() {
+		writer.CloseWithError(writeRequest(writer, request))
+	}()
+	return reader
+}
+
+// writeRequest writes the request as json.Marshal encodes it, escaping
+// the schema chunk by chunk rather than at once.
+func writeRequest(w io.Writer, request schemaRequest) error {
+	schema := request.Schema
+	request.Schema = ""
+	rest, err := json.Marshal(request)
+	if err != nil {
+		return err
+	}
+	// The schema being the first field, rest starts with {"schema":""
+	prefix := `{"schema":"`
+	if _, err := io.WriteString(w, prefix); err != nil {
+		return err
+	}
+	for len(schema) > 0 {
+		end := len(schema)
+		if end > streamChunkBytes {
+			// Cut before the start of a rune not to split it
+			end = streamChunkBytes
+			for end > 0 && !utf8.RuneStart(schema[end]) {
+				end--
+			}
+			if end == 0 {
+				end = streamChunkBytes
+			}
+		}
+		chunk, err := json.Marshal(schema[:end])
+		if err != nil {
+			return err
+		}
+		if _, err := w.Write(chunk[1 : len(chunk)-1]); err != nil {
+			return err
+		}
+		schema = schema[end:]
+	}
+	_, err = w.Write(rest[len(prefix):])
+	return err
+}
+
+// requestSize returns the size of the encoded request, without keeping
+// the encoded request in memory.
+func requestSize(request schemaRequest) (int, error) {
+	var counter byteCounter
+	if err := writeRequest(&counter, request); err != nil {
+		return 0, err
+	}
+	return int(counter), nil
+}
+
+// byteCounter is a writer counting the bytes written to it.
+type byteCounter int
+
+func (counter *byteCounter) Write(p []byte) (int, error) {
+	*counter += byteCounter(len(p))
+	return len(p), nil
+}
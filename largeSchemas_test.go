@@ -0,0 +1,163 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaSize(t *testing.T) {
+	t.Parallel()
+
+	size, err := SchemaSize("\"string\"\n", Avro)
+	_, invalidErr := SchemaSize(`"string"`, SchemaType("XML"))
+
+	require.NoError(t, err)
+	assert.Equal(t, len(`{"schema":"\"string\" "}`), size)
+	assert.Error(t, invalidErr)
+}
+
+func TestWithMaxSchemaBytes(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithMaxSchemaBytes(32))
+
+	// Act
+	_, err := srClient.CreateSchema(context.Background(), "test1-value", testSchema1, Avro)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrSchemaTooLarge)
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
+
+func TestCreateSchema_RejectedAsTooLarge(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		status int
+		body   interface{}
+	}{
+		"by a proxy": {
+			status: http.StatusRequestEntityTooLarge,
+			body:   "Request Entity Too Large",
+		},
+		"by the registry": {
+			status: http.StatusInternalServerError,
+			body:   Error{Code: 50001, Message: "Error while registering schema: org.apache.kafka.common.errors.RecordTooLargeException"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.WriteHeader(testData.status)
+				_ = json.NewEncoder(rw).Encode(testData.body)
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL)
+
+			// Act
+			_, err := srClient.CreateSchema(context.Background(), "test1-value", testSchema1, Avro)
+
+			// Assert
+			assert.ErrorIs(t, err, ErrSchemaTooLarge)
+			var registryErr Error
+			assert.ErrorAs(t, err, &registryErr)
+		})
+	}
+}
+
+func TestConfigureTransport_Limits(t *testing.T) {
+	t.Parallel()
+	options := clientOptions{maxResponseHeaderBytes: 1 << 10, writeBufferSize: 1 << 16, readBufferSize: 1 << 17}
+
+	configured, err := configureTransport(&http.Client{}, &options)
+
+	require.NoError(t, err)
+	transport := configured.Transport.(*http.Transport)
+	assert.Equal(t, int64(1<<10), transport.MaxResponseHeaderBytes)
+	assert.Equal(t, 1<<16, transport.WriteBufferSize)
+	assert.Equal(t, 1<<17, transport.ReadBufferSize)
+}
+
+func TestCreateSchema_StreamsTheRequest(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var transferEncoding []string
+	var request schemaRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodPost {
+			transferEncoding = req.TransferEncoding
+			_ = json.NewDecoder(req.Body).Decode(&request)
+		}
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Version: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithMaxSchemaBytes(1<<20))
+
+	// Act
+	_, err := srClient.CreateSchema(context.Background(), "test1-value", testSchema1, Avro)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"chunked"}, transferEncoding)
+	expected, err := newRegistrationRequest(testSchema1, Avro, nil, nil, false)
+	require.NoError(t, err)
+	assert.Equal(t, expected.Schema, request.Schema)
+}
+
+func TestWriteRequest(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		request schemaRequest
+	}{
+		"small schema": {
+			request: schemaRequest{Schema: `{"type": "string"}`, SchemaType: "JSON"},
+		},
+		"schema spanning chunks": {
+			request: schemaRequest{
+				Schema:     strings.Repeat("{\"doc\": \"<crème brûlée> \u2028 €\"}", streamChunkBytes/8),
+				References: []Reference{{Name: "cupcake", Subject: "cupcakes-value", Version: 1}},
+				Metadata:   &SchemaMetadata{Properties: map[string]string{"owner": "bakery"}},
+			},
+		},
+		"invalid UTF-8": {
+			request: schemaRequest{Schema: strings.Repeat("\xe2\x82", streamChunkBytes)},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			expected, err := json.Marshal(testData.request)
+			require.NoError(t, err)
+			var written strings.Builder
+
+			// Act
+			err = writeRequest(&written, testData.request)
+			size, sizeErr := requestSize(testData.request)
+
+			// Assert
+			require.NoError(t, err)
+			require.NoError(t, sizeErr)
+			assert.Equal(t, string(expected), written.String())
+			assert.Equal(t, len(expected), size)
+		})
+	}
+}
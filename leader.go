@@ -0,0 +1,131 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"sync"
+)
+
+// LeaderDetector tells whether the registry at endpointURL is the
+// leader of its cluster, for deployments exposing it, for instance
+// through a management endpoint or a service discovery tag.
+type LeaderDetector func(ctx context.Context, endpointURL string) (bool, error)
+
+// WithLeaderWrites sends the requests changing the registry, such as
+// schema registrations, to the leader of the endpoints of a client
+// created with WithLatencyAwareEndpoints, while reads still go to the
+// fastest endpoint. The leader is found with the detector when given,
+// otherwise it is learnt from the registry: a write answered with a
+// forwarding error, as followers do while the leader is being elected,
+// is sent to the next endpoint, and the endpoint accepting it is used
+// for the next writes.
+func WithLeaderWrites(detector LeaderDetector) Option {
+	return func(options *clientOptions) {
+		options.leaderWrites = true
+		options.leaderDetector = detector
+	}
+}
+
+// leaderRouter remembers the leader of the endpoints.
+type leaderRouter struct {
+	detector LeaderDetector
+	lock     sync.Mutex
+	// leader is empty until known
+	leader string
+}
+
+func (router *leaderRouter) current() string {
+	router.lock.Lock()
+	defer router.lock.Unlock()
+	return router.leader
+}
+
+func (router *leaderRouter) elect(endpoint string) {
+	router.lock.Lock()
+	defer router.lock.Unlock()
+	router.leader = endpoint
+}
+
+func (router *leaderRouter) demote(endpoint string) {
+	router.lock.Lock()
+	defer router.lock.Unlock()
+	if router.leader == endpoint {
+		router.leader = ""
+	}
+}
+
+// leaderCandidates returns the endpoints to send a write to, in order:
+// the known or detected leader first then the others.
+func (client *SchemaRegistryClient) leaderCandidates(ctx context.Context) []string {
+	endpoints := []string{client.schemaRegistryURL}
+	if client.endpoints != nil {
+		endpoints = endpoints[:0]
+		for _, status := range client.endpoints.statuses() {
+			endpoints = append(endpoints, status.URL)
+		}
+	}
+
+	leader := client.leader.current()
+	if leader == "" && client.leader.detector != nil {
+		for _, endpoint := range endpoints {
+			if isLeader, err := client.leader.detector(ctx, endpoint); err == nil && isLeader {
+				leader = endpoint
+				client.leader.elect(leader)
+				break
+			}
+		}
+	}
+
+	candidates := make([]string, 0, len(endpoints))
+	if leader != "" {
+		candidates = append(candidates, leader)
+	}
+	for _, endpoint := range endpoints {
+		if endpoint != leader {
+			candidates = append(candidates, endpoint)
+		}
+	}
+	return candidates
+}
+
+// leaderRequest sends a write to the leader, then to the other endpoints
+// while they answer with forwarding errors.
+func (client *SchemaRegistryClient) leaderRequest(ctx context.Context, method, uri string, payload io.Reader) ([]byte, error) {
+	var body []byte
+	if payload != nil {
+		var err error
+		if body, err = io.ReadAll(payload); err != nil {
+			return nil, err
+		}
+	}
+
+	var attempts []RequestAttempt
+	for _, endpoint := range client.leaderCandidates(ctx) {
+		attempt := RequestAttempt{}
+		resp, err := client.httpRequestTo(ctx, endpoint, method, uri, bytes.NewReader(body), &attempt)
+		attempt.Err = err
+		attempts = append(attempts, attempt)
+		if !isForwardingError(err) {
+			if err == nil {
+				client.leader.elect(endpoint)
+				return resp, nil
+			}
+			return nil, newRetryError(attempts)
+		}
+		client.leader.demote(endpoint)
+	}
+	return nil, newRetryError(attempts)
+}
+
+// isForwardingError tells if a follower failed to forward a write to
+// the leader, because there was none or it couldn't be reached, in
+// which case the write wasn't applied.
+func isForwardingError(err error) bool {
+	var registryErr Error
+	if errors.As(err, &registryErr) {
+		return registryErr.Code == 50003 || registryErr.Code == 50004
+	}
+	return false
+}
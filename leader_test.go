@@ -0,0 +1,92 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// clusterNode serves schemas and, when it isn't the leader, fails
+// registrations as a follower which can't forward them.
+func clusterNode(t *testing.T, leader bool, writes *int32) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPost {
+			_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Version: 1, Schema: testSchema1})
+			return
+		}
+		atomic.AddInt32(writes, 1)
+		if !leader {
+			rw.WriteHeader(http.StatusInternalServerError)
+			_ = json.NewEncoder(rw).Encode(Error{Code: 50003, Message: "Error while forwarding the request to the leader"})
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1})
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithLeaderWrites(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		detector               func(leaderURL string) LeaderDetector
+		expectedFollowerWrites int32
+	}{
+		"learnt from forwarding errors": {
+			detector:               func(string) LeaderDetector { return nil },
+			expectedFollowerWrites: 1,
+		},
+		"detected": {
+			detector: func(leaderURL string) LeaderDetector {
+				return func(_ context.Context, endpointURL string) (bool, error) {
+					return endpointURL == leaderURL, nil
+				}
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var followerWrites, leaderWrites int32
+			follower := clusterNode(t, false, &followerWrites)
+			leader := clusterNode(t, true, &leaderWrites)
+			srClient := NewClient(follower.URL,
+				WithLatencyAwareEndpoints(time.Hour, leader.URL),
+				WithLeaderWrites(testData.detector(leader.URL)),
+			)
+
+			// Act
+			_, firstErr := srClient.CreateSchema(context.Background(), "test1-value", testSchema1, Avro)
+			_, secondErr := srClient.CreateSchema(context.Background(), "test2-value", testSchema1, Avro)
+
+			// Assert
+			require.NoError(t, firstErr)
+			require.NoError(t, secondErr)
+			assert.Equal(t, testData.expectedFollowerWrites, atomic.LoadInt32(&followerWrites))
+			assert.Equal(t, int32(2), atomic.LoadInt32(&leaderWrites))
+		})
+	}
+}
+
+func TestWithLeaderWrites_NoLeader(t *testing.T) {
+	t.Parallel()
+	var writes int32
+	first := clusterNode(t, false, &writes)
+	second := clusterNode(t, false, &writes)
+	srClient := NewClient(first.URL, WithLatencyAwareEndpoints(time.Hour, second.URL), WithLeaderWrites(nil))
+
+	_, err := srClient.CreateSchema(context.Background(), "test1-value", testSchema1, Avro)
+
+	assert.True(t, isForwardingError(err))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&writes))
+}
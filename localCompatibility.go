@@ -0,0 +1,295 @@
+package srclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// CheckLocalCompatibility checks structurally, without a registry, that
+// the proposed schema can replace the previous one under the given
+// compatibility level, and returns the incompatibilities found. Avro
+// schemas follow the schema resolution rules of the specification, JSON
+// and Protobuf schemas are checked for fields changing type. Transitive
+// levels are checked against the single previous schema given. Named
+// types coming from references are compared by name only.
+func CheckLocalCompatibility(previous, proposed string, schemaType SchemaType, level CompatibilityLevel) ([]string, error) {
+	backward, forward, _ := evolutionChecks(level)
+	if !backward && !forward {
+		return nil, nil
+	}
+
+	if schemaType == Avro {
+		var previousSchema, proposedSchema interface{}
+		if err := json.Unmarshal([]byte(previous), &previousSchema); err != nil {
+			return nil, fmt.Errorf("previous schema: %w", err)
+		}
+		if err := json.Unmarshal([]byte(proposed), &proposedSchema); err != nil {
+			return nil, fmt.Errorf("proposed schema: %w", err)
+		}
+		var problems []string
+		if backward {
+			problems = append(problems, avroResolutionProblems(proposedSchema, previousSchema, "reading previous data")...)
+		}
+		if forward {
+			problems = append(problems, avroResolutionProblems(previousSchema, proposedSchema, "reading new data with the previous schema")...)
+		}
+		return problems, nil
+	}
+
+	previousFields, err := schemaFields(&Schema{schema: previous, schemaType: &schemaType})
+	if err != nil {
+		return nil, fmt.Errorf("previous schema: %w", err)
+	}
+	proposedFields, err := schemaFields(&Schema{schema: proposed, schemaType: &schemaType})
+	if err != nil {
+		return nil, fmt.Errorf("proposed schema: %w", err)
+	}
+	report := &DriftReport{}
+	report.diffFields(previousFields, proposedFields)
+	var problems []string
+	for _, change := range report.Changed {
+		problems = append(problems, fmt.Sprintf("%s: type changed from %s to %s", change.Path, change.Registered, change.Local))
+	}
+	return problems, nil
+}
+
+// avroResolutionProblems resolves the writer schema with the reader
+// schema, as a decoder would, and describes what can't be resolved.
+func avroResolutionProblems(reader, writer interface{}, context string) []string {
+	resolver := avroResolver{
+		readerNames: avroDefinitions(reader),
+		writerNames: avroDefinitions(writer),
+		resolving:   make(map[string]bool),
+	}
+
+	var problems []string
+	resolver.resolve(reader, writer, "", "", "", &problems)
+	for i, problem := range problems {
+		problems[i] = fmt.Sprintf("%s (%s)", problem, context)
+	}
+	return problems
+}
+
+type avroResolver struct {
+	// Named types by full name, the resolution compares unqualified names
+	readerNames map[string]map[string]interface{}
+	writerNames map[string]map[string]interface{}
+	// resolving holds the named types being resolved, recursive types
+	// are resolved once
+	resolving map[string]bool
+}
+
+func avroShortName(name string) string {
+	return name[strings.LastIndex(name, ".")+1:]
+}
+
+// avroKind returns the kind of an Avro type used in the namespace, along
+// with its definition for complex types and the namespace of the types
+// it uses. References to named types are followed, unknown names are
+// kept as the kind.
+func avroKind(schema interface{}, namespace string, names map[string]map[string]interface{}) (string, map[string]interface{}, string) {
+	switch typed := schema.(type) {
+	case string:
+		if fullName, ok := avroLookupName(names, typed, namespace); ok {
+			kind, _ := names[fullName]["type"].(string)
+			return kind, names[fullName], avroNamespaceOf(fullName)
+		}
+		return typed, nil, namespace
+	case []interface{}:
+		return "union", nil, namespace
+	case map[string]interface{}:
+		if kind, ok := typed["type"].(string); ok {
+			switch kind {
+			case "record", "error", "enum", "fixed":
+				return kind, typed, avroNamespaceOf(avroDefinitionName(typed, namespace))
+			case "array", "map":
+				return kind, typed, namespace
+			}
+			return avroKind(kind, namespace, names)
+		}
+		return avroKind(typed["type"], namespace, names)
+	}
+	return "", nil, namespace
+}
+
+var avroKnownKinds = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true, "float": true, "double": true, "bytes": true, "string": true,
+	"record": true, "error": true, "enum": true, "fixed": true, "array": true, "map": true,
+}
+
+// avroDefinedName names a type for comparisons by name, unknown kinds
+// are names themselves.
+func avroDefinedName(kind string, definition map[string]interface{}) string {
+	if definition != nil {
+		if name, ok := definition["name"].(string); ok {
+			return name
+		}
+	}
+	return kind
+}
+
+var avroPromotions = map[string][]string{
+	"int":    {"long", "float", "double"},
+	"long":   {"float", "double"},
+	"float":  {"double"},
+	"string": {"bytes"},
+	"bytes":  {"string"},
+}
+
+func (resolver *avroResolver) matches(reader, writer interface{}, readerNamespace, writerNamespace string) bool {
+	var problems []string
+	resolver.resolve(reader, writer, readerNamespace, writerNamespace, "", &problems)
+	return len(problems) == 0
+}
+
+// resolve resolves the writer type with the reader type, the names they
+// use being in their namespace.
+func (resolver *avroResolver) resolve(reader, writer interface{}, readerNamespace, writerNamespace, path string, problems *[]string) {
+	at := path
+	if at == "" {
+		at = "schema"
+	}
+
+	if writerUnion, ok := writer.([]interface{}); ok {
+		for _, member := range writerUnion {
+			resolver.resolve(reader, member, readerNamespace, writerNamespace, path, problems)
+		}
+		return
+	}
+	writerKind, writerDefinition, writerScope := avroKind(writer, writerNamespace, resolver.writerNames)
+	if readerUnion, ok := reader.([]interface{}); ok {
+		for _, member := range readerUnion {
+			if resolver.matches(member, writer, readerNamespace, writerNamespace) {
+				resolver.resolve(member, writer, readerNamespace, writerNamespace, path, problems)
+				return
+			}
+		}
+		*problems = append(*problems, fmt.Sprintf("%s: union has no member for %s", at, writerKind))
+		return
+	}
+	readerKind, readerDefinition, readerScope := avroKind(reader, readerNamespace, resolver.readerNames)
+
+	// Named types defined by references are only known by name
+	if !avroKnownKinds[readerKind] || !avroKnownKinds[writerKind] {
+		readerName, writerName := avroDefinedName(readerKind, readerDefinition), avroDefinedName(writerKind, writerDefinition)
+		if avroShortName(readerName) != avroShortName(writerName) {
+			*problems = append(*problems, fmt.Sprintf("%s: %s can't be read as %s", at, writerName, readerName))
+		}
+		return
+	}
+	if readerKind != writerKind {
+		for _, promoted := range avroPromotions[writerKind] {
+			if promoted == readerKind {
+				return
+			}
+		}
+		*problems = append(*problems, fmt.Sprintf("%s: %s can't be read as %s", at, writerKind, readerKind))
+		return
+	}
+	if readerDefinition == nil || writerDefinition == nil {
+		return
+	}
+
+	switch readerKind {
+	case "array":
+		resolver.resolve(readerDefinition["items"], writerDefinition["items"], readerScope, writerScope, path+"[]", problems)
+		return
+	case "map":
+		resolver.resolve(readerDefinition["values"], writerDefinition["values"], readerScope, writerScope, path+"{}", problems)
+		return
+	}
+
+	readerName, _ := readerDefinition["name"].(string)
+	writerName, _ := writerDefinition["name"].(string)
+	if avroShortName(readerName) != avroShortName(writerName) && !avroHasAlias(readerDefinition, writerName) {
+		*problems = append(*problems, fmt.Sprintf("%s: %s %s can't be read as %s", at, writerKind, writerName, readerName))
+		return
+	}
+	key := avroFullName(readerName, readerScope) + "<" + avroFullName(writerName, writerScope)
+	if resolver.resolving[key] {
+		return
+	}
+	resolver.resolving[key] = true
+	defer delete(resolver.resolving, key)
+
+	switch readerKind {
+	case "record", "error":
+		resolver.resolveRecord(readerDefinition, writerDefinition, readerScope, writerScope, path, problems)
+	case "enum":
+		readerSymbols := make(map[string]bool)
+		symbols, _ := readerDefinition["symbols"].([]interface{})
+		for _, symbol := range symbols {
+			readerSymbols[fmt.Sprint(symbol)] = true
+		}
+		if _, hasDefault := readerDefinition["default"]; hasDefault {
+			return
+		}
+		var missing []string
+		symbols, _ = writerDefinition["symbols"].([]interface{})
+		for _, symbol := range symbols {
+			if !readerSymbols[fmt.Sprint(symbol)] {
+				missing = append(missing, fmt.Sprint(symbol))
+			}
+		}
+		if len(missing) > 0 {
+			*problems = append(*problems, fmt.Sprintf("%s: enum %s is missing symbols %s", at, readerName, strings.Join(missing, ", ")))
+		}
+	case "fixed":
+		if fmt.Sprint(readerDefinition["size"]) != fmt.Sprint(writerDefinition["size"]) {
+			*problems = append(*problems, fmt.Sprintf("%s: fixed %s changed size from %v to %v", at, readerName, writerDefinition["size"], readerDefinition["size"]))
+		}
+	}
+}
+
+func (resolver *avroResolver) resolveRecord(reader, writer map[string]interface{}, readerNamespace, writerNamespace, path string, problems *[]string) {
+	writerFields := make(map[string]map[string]interface{})
+	fields, _ := writer["fields"].([]interface{})
+	for _, field := range fields {
+		if fieldMap, ok := field.(map[string]interface{}); ok {
+			name, _ := fieldMap["name"].(string)
+			writerFields[name] = fieldMap
+		}
+	}
+
+	var missing []string
+	fields, _ = reader["fields"].([]interface{})
+	for _, field := range fields {
+		fieldMap, ok := field.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		name, _ := fieldMap["name"].(string)
+		writerField, ok := writerFields[name]
+		if !ok {
+			aliases, _ := fieldMap["aliases"].([]interface{})
+			for _, alias := range aliases {
+				if writerField, ok = writerFields[fmt.Sprint(alias)]; ok {
+					break
+				}
+			}
+		}
+		if ok {
+			resolver.resolve(fieldMap["type"], writerField["type"], readerNamespace, writerNamespace, joinFieldPath(path, name), problems)
+			continue
+		}
+		if _, hasDefault := fieldMap["default"]; !hasDefault {
+			missing = append(missing, joinFieldPath(path, name))
+		}
+	}
+	sort.Strings(missing)
+	for _, field := range missing {
+		*problems = append(*problems, fmt.Sprintf("%s: field is missing from the written data and has no default", field))
+	}
+}
+
+func avroHasAlias(definition map[string]interface{}, name string) bool {
+	aliases, _ := definition["aliases"].([]interface{})
+	for _, alias := range aliases {
+		if avroShortName(fmt.Sprint(alias)) == avroShortName(name) {
+			return true
+		}
+	}
+	return false
+}
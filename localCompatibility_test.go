@@ -0,0 +1,131 @@
+package srclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLocalCompatibility(t *testing.T) {
+	t.Parallel()
+	customerV1 := `{"type": "record", "name": "Customer", "namespace": "com.shop", "fields": [
+		{"name": "id", "type": "int"},
+		{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["ACTIVE", "CLOSED"]}},
+		{"name": "tags", "type": {"type": "array", "items": "string"}}
+	]}`
+	tests := map[string]struct {
+		previous   string
+		proposed   string
+		schemaType SchemaType
+		level      CompatibilityLevel
+		expected   []string
+	}{
+		"avro added field with default": {
+			previous: customerV1,
+			proposed: `{"type": "record", "name": "Customer", "namespace": "com.shop", "fields": [
+				{"name": "id", "type": "long"},
+				{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["ACTIVE", "CLOSED", "FROZEN"]}},
+				{"name": "tags", "type": {"type": "array", "items": "string"}},
+				{"name": "email", "type": ["null", "string"], "default": null}
+			]}`,
+			schemaType: Avro,
+			level:      Backward,
+		},
+		"avro added field without default and referenced type": {
+			previous: customerV1,
+			proposed: `{"type": "record", "name": "Customer", "namespace": "com.shop", "fields": [
+				{"name": "id", "type": "int"},
+				{"name": "status", "type": "com.shop.Status"},
+				{"name": "tags", "type": {"type": "array", "items": "string"}},
+				{"name": "email", "type": "string"}
+			]}`,
+			schemaType: Avro,
+			level:      Backward,
+			expected:   []string{"email: field is missing from the written data and has no default (reading previous data)"},
+		},
+		"avro full": {
+			previous: customerV1,
+			proposed: `{"type": "record", "name": "Customer", "namespace": "com.shop", "fields": [
+				{"name": "id", "type": "long"},
+				{"name": "status", "type": {"type": "enum", "name": "Status", "symbols": ["ACTIVE"]}},
+				{"name": "tags", "type": {"type": "array", "items": "int"}}
+			]}`,
+			schemaType: Avro,
+			level:      Full,
+			expected: []string{
+				"status: enum Status is missing symbols CLOSED (reading previous data)",
+				"tags[]: string can't be read as int (reading previous data)",
+				"id: long can't be read as int (reading new data with the previous schema)",
+				"tags[]: int can't be read as string (reading new data with the previous schema)",
+			},
+		},
+		"avro union": {
+			previous:   `{"type": "record", "name": "Customer", "fields": [{"name": "email", "type": ["null", "string"]}]}`,
+			proposed:   `{"type": "record", "name": "Customer", "fields": [{"name": "email", "type": "string"}]}`,
+			schemaType: Avro,
+			level:      Backward,
+			expected:   []string{"email: null can't be read as string (reading previous data)"},
+		},
+		"avro same names in different namespaces": {
+			previous: `{"type": "record", "name": "Order", "namespace": "com.shop", "fields": [
+				{"name": "billing", "type": {"type": "record", "name": "Address", "namespace": "com.billing", "fields": [{"name": "iban", "type": "string"}]}},
+				{"name": "shipping", "type": {"type": "record", "name": "Address", "fields": [{"name": "street", "type": "string"}]}},
+				{"name": "pickup", "type": "Address"}
+			]}`,
+			proposed: `{"type": "record", "name": "Order", "namespace": "com.shop", "fields": [
+				{"name": "shipping", "type": {"type": "record", "name": "Address", "fields": [{"name": "street", "type": "string"}]}},
+				{"name": "billing", "type": {"type": "record", "name": "Address", "namespace": "com.billing", "fields": [{"name": "iban", "type": "string"}]}},
+				{"name": "pickup", "type": "Address"}
+			]}`,
+			schemaType: Avro,
+			level:      Full,
+		},
+		"avro qualified reference": {
+			previous: `{"type": "record", "name": "Order", "namespace": "com.shop", "fields": [
+				{"name": "shipping", "type": {"type": "record", "name": "Address", "fields": [{"name": "street", "type": "string"}]}},
+				{"name": "billing", "type": {"type": "record", "name": "Address", "namespace": "com.billing", "fields": [{"name": "iban", "type": "string"}]}},
+				{"name": "invoicing", "type": "com.billing.Address"}
+			]}`,
+			proposed: `{"type": "record", "name": "Order", "namespace": "com.shop", "fields": [
+				{"name": "shipping", "type": {"type": "record", "name": "Address", "fields": [{"name": "street", "type": "string"}]}},
+				{"name": "billing", "type": {"type": "record", "name": "Address", "namespace": "com.billing", "fields": [{"name": "iban", "type": "string"}]}},
+				{"name": "invoicing", "type": "com.shop.Address"}
+			]}`,
+			schemaType: Avro,
+			level:      Backward,
+			expected:   []string{"invoicing.street: field is missing from the written data and has no default (reading previous data)"},
+		},
+		"avro none": {
+			previous:   customerV1,
+			proposed:   `"string"`,
+			schemaType: Avro,
+			level:      None,
+		},
+		"json type change": {
+			previous:   `{"type": "object", "properties": {"id": {"type": "integer"}, "name": {"type": "string"}}}`,
+			proposed:   `{"type": "object", "properties": {"id": {"type": "string"}}}`,
+			schemaType: Json,
+			level:      Forward,
+			expected:   []string{"id: type changed from integer to string"},
+		},
+		"protobuf type change": {
+			previous:   "syntax = \"proto3\";\nmessage Order {\n  string id = 1;\n}\n",
+			proposed:   "syntax = \"proto3\";\nmessage Order {\n  int64 id = 1;\n  string note = 2;\n}\n",
+			schemaType: Protobuf,
+			level:      Backward,
+			expected:   []string{"Order.id: type changed from string to int64"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			problems, err := CheckLocalCompatibility(testData.previous, testData.proposed, testData.schemaType, testData.level)
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, problems)
+		})
+	}
+}
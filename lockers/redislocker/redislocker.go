@@ -0,0 +1,158 @@
+// Package redislocker locks the subjects of a schema registry with
+// Redis, so that the replicas of a service register their schemas one
+// after the other, see srclient.WithSubjectLocker.
+package redislocker
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	defaultKeyPrefix     = "srclient:subject-lock:"
+	defaultTTL           = 30 * time.Second
+	defaultRetryInterval = 100 * time.Millisecond
+)
+
+// unlockScript deletes the key of a lock only while it holds the token
+// of the lock, not to release a lock which expired and was taken since.
+var unlockScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("del", KEYS[1])
+end
+return 0`)
+
+// renewScript extends the TTL of the key of a lock while it holds the
+// token of the lock.
+var renewScript = redis.NewScript(`
+if redis.call("get", KEYS[1]) == ARGV[1] then
+	return redis.call("pexpire", KEYS[1], ARGV[2])
+end
+return 0`)
+
+// Locker locks a subject by setting its key to a random token with
+// SET NX and a TTL, so that the lock of a replica dying while holding it
+// expires. The TTL is renewed while the lock is held and the key deleted
+// on unlock if it still holds the token. It implements
+// srclient.SubjectLocker.
+type Locker struct {
+	client        redis.UniversalClient
+	keyPrefix     string
+	ttl           time.Duration
+	retryInterval time.Duration
+}
+
+// Option configures a Locker.
+type Option func(*Locker)
+
+// WithKeyPrefix sets the prefix of the keys of the locks, the subject
+// being appended to it, srclient:subject-lock: by default.
+func WithKeyPrefix(prefix string) Option {
+	return func(locker *Locker) {
+		locker.keyPrefix = prefix
+	}
+}
+
+// WithTTL sets how long a lock outlives a replica dying while holding
+// it, 30 seconds by default. Held locks are renewed every third of it.
+func WithTTL(ttl time.Duration) Option {
+	return func(locker *Locker) {
+		locker.ttl = ttl
+	}
+}
+
+// WithRetryInterval sets how often a locked subject is tried again,
+// every 100 milliseconds by default.
+func WithRetryInterval(interval time.Duration) Option {
+	return func(locker *Locker) {
+		locker.retryInterval = interval
+	}
+}
+
+// New returns a locker of subjects using the Redis client.
+func New(client redis.UniversalClient, options ...Option) *Locker {
+	locker := &Locker{
+		client:        client,
+		keyPrefix:     defaultKeyPrefix,
+		ttl:           defaultTTL,
+		retryInterval: defaultRetryInterval,
+	}
+	for _, option := range options {
+		option(locker)
+	}
+	return locker
+}
+
+// Lock blocks until the subject is locked or the context is done. The
+// returned function releases the lock, a lock it fails to release
+// expiring after the TTL.
+func (locker *Locker) Lock(ctx context.Context, subject string) (func(), error) {
+	key := locker.keyPrefix + subject
+	token, err := randomToken()
+	if err != nil {
+		return nil, err
+	}
+
+	for {
+		acquired, err := locker.client.SetNX(ctx, key, token, locker.ttl).Result()
+		if err != nil {
+			return nil, err
+		}
+		if acquired {
+			return locker.hold(key, token), nil
+		}
+
+		timer := time.NewTimer(locker.retryInterval)
+		select {
+		case <-timer.C:
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// hold renews the TTL of the lock until the returned function releases it.
+func (locker *Locker) hold(key, token string) func() {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(locker.ttl / 3)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				ctx, cancel := context.WithTimeout(context.Background(), locker.ttl/3)
+				_ = renewScript.Run(ctx, locker.client, []string{key}, token, locker.ttl.Milliseconds()).Err()
+				cancel()
+			case <-done:
+				return
+			}
+		}
+	}()
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			close(done)
+			<-stopped
+			ctx, cancel := context.WithTimeout(context.Background(), locker.ttl)
+			defer cancel()
+			_ = unlockScript.Run(ctx, locker.client, []string{key}, token).Err()
+		})
+	}
+}
+
+func randomToken() (string, error) {
+	token := make([]byte, 16)
+	if _, err := rand.Read(token); err != nil {
+		return "", err
+	}
+	return hex.EncodeToString(token), nil
+}
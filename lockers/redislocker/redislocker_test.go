@@ -0,0 +1,90 @@
+package redislocker
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestLocker(t *testing.T, options ...Option) (*Locker, *miniredis.Miniredis) {
+	server := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: server.Addr()})
+	t.Cleanup(func() { _ = client.Close() })
+	return New(client, append([]Option{WithRetryInterval(time.Millisecond)}, options...)...), server
+}
+
+func TestLocker_Lock(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	locker, _ := newTestLocker(t)
+	other := New(locker.client, WithRetryInterval(time.Millisecond))
+	unlock, err := locker.Lock(context.Background(), "orders-value")
+	require.NoError(t, err)
+
+	// Act
+	otherUnlock, otherErr := other.Lock(context.Background(), "payments-value")
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, blockedErr := other.Lock(ctx, "orders-value")
+	acquired := make(chan struct{})
+	go func() {
+		unlock, err := other.Lock(context.Background(), "orders-value")
+		assert.NoError(t, err)
+		unlock()
+		close(acquired)
+	}()
+	unlock()
+
+	// Assert
+	require.NoError(t, otherErr)
+	otherUnlock()
+	assert.ErrorIs(t, blockedErr, context.DeadlineExceeded)
+	select {
+	case <-acquired:
+	case <-time.After(time.Second):
+		t.Fatal("the subject wasn't unlocked")
+	}
+}
+
+func TestLocker_UnlockKeepsLocksTakenSince(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	locker, server := newTestLocker(t, WithKeyPrefix("locks:"))
+	unlock, err := locker.Lock(context.Background(), "orders-value")
+	require.NoError(t, err)
+	// The lock expired and another replica took it
+	require.NoError(t, server.Set("locks:orders-value", "other"))
+
+	// Act
+	unlock()
+	unlock()
+
+	// Assert
+	value, err := server.Get("locks:orders-value")
+	require.NoError(t, err)
+	assert.Equal(t, "other", value)
+}
+
+func TestLocker_RenewsHeldLocks(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	locker, server := newTestLocker(t, WithTTL(300*time.Millisecond))
+	unlock, err := locker.Lock(context.Background(), "orders-value")
+	require.NoError(t, err)
+	defer unlock()
+
+	// Act
+	server.FastForward(250 * time.Millisecond)
+
+	// Assert
+	assert.Eventually(t, func() bool {
+		return server.TTL(defaultKeyPrefix+"orders-value") > 200*time.Millisecond
+	}, time.Second, 10*time.Millisecond)
+	server.FastForward(250 * time.Millisecond)
+	assert.True(t, server.Exists(defaultKeyPrefix+"orders-value"))
+}
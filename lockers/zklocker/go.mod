@@ -0,0 +1,8 @@
+module github.com/crxfoz/srclient/lockers/zklocker
+
+go 1.16
+
+require (
+	github.com/go-zookeeper/zk v1.0.3
+	github.com/stretchr/testify v1.7.5
+)
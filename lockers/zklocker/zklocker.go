@@ -0,0 +1,169 @@
+// Package zklocker locks the subjects of a schema registry with
+// ZooKeeper, so that the replicas of a service register their schemas
+// one after the other, see srclient.WithSubjectLocker.
+package zklocker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/go-zookeeper/zk"
+)
+
+const (
+	defaultRoot = "/srclient/subject-locks"
+	lockPrefix  = "lock-"
+	// sequenceDigits is the length of the sequence ZooKeeper appends to
+	// the name of sequential nodes.
+	sequenceDigits = 10
+)
+
+// Locker locks subjects with the lock recipe of ZooKeeper: each lock
+// is an ephemeral sequential node under the node of the subject, held
+// once it is the lowest of them, so that the locks of a replica losing
+// its session are released. It implements srclient.SubjectLocker.
+type Locker struct {
+	conn *zk.Conn
+	root string
+	acl  []zk.ACL
+}
+
+// Option configures a Locker.
+type Option func(*Locker)
+
+// WithRoot sets the node under which the nodes of the subjects are
+// created, /srclient/subject-locks by default.
+func WithRoot(root string) Option {
+	return func(locker *Locker) {
+		locker.root = strings.TrimSuffix(root, "/")
+	}
+}
+
+// WithACL sets the ACL of the nodes created by the locker, open to
+// everyone by default.
+func WithACL(acl []zk.ACL) Option {
+	return func(locker *Locker) {
+		locker.acl = acl
+	}
+}
+
+// New returns a locker of subjects using the ZooKeeper connection.
+func New(conn *zk.Conn, options ...Option) *Locker {
+	locker := &Locker{
+		conn: conn,
+		root: defaultRoot,
+		acl:  zk.WorldACL(zk.PermAll),
+	}
+	for _, option := range options {
+		option(locker)
+	}
+	return locker
+}
+
+// Lock blocks until the subject is locked or the context is done, the
+// returned function releases the lock.
+func (locker *Locker) Lock(ctx context.Context, subject string) (func(), error) {
+	dir := locker.root + "/" + nodeName(subject)
+	if err := locker.createPath(dir); err != nil {
+		return nil, err
+	}
+	node, err := locker.conn.CreateProtectedEphemeralSequential(dir+"/"+lockPrefix, nil, locker.acl)
+	if err != nil {
+		return nil, err
+	}
+	if err := locker.await(ctx, dir, node); err != nil {
+		_ = locker.conn.Delete(node, -1)
+		return nil, err
+	}
+
+	var once sync.Once
+	return func() {
+		once.Do(func() {
+			_ = locker.conn.Delete(node, -1)
+		})
+	}, nil
+}
+
+// await waits until node is the lowest lock of the subject, watching
+// the lock right before it rather than every lock.
+func (locker *Locker) await(ctx context.Context, dir, node string) error {
+	sequence, err := sequenceOf(node)
+	if err != nil {
+		return err
+	}
+
+	for {
+		children, _, err := locker.conn.Children(dir)
+		if err != nil {
+			return err
+		}
+		previous, ok := previousLock(children, sequence)
+		if !ok {
+			return nil
+		}
+
+		exists, _, events, err := locker.conn.ExistsW(dir + "/" + previous)
+		if err != nil {
+			return err
+		}
+		if !exists {
+			continue
+		}
+		select {
+		case <-events:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+}
+
+// createPath creates the missing nodes of the path.
+func (locker *Locker) createPath(path string) error {
+	current := ""
+	for _, name := range strings.Split(strings.TrimPrefix(path, "/"), "/") {
+		current += "/" + name
+		_, err := locker.conn.Create(current, nil, 0, locker.acl)
+		if err != nil && !errors.Is(err, zk.ErrNodeExists) {
+			return fmt.Errorf("creating %s: %w", current, err)
+		}
+	}
+	return nil
+}
+
+// previousLock returns the lock with the highest sequence lower than
+// sequence, if any.
+func previousLock(children []string, sequence int) (string, bool) {
+	previous, previousSequence := "", -1
+	for _, child := range children {
+		childSequence, err := sequenceOf(child)
+		if err != nil || !strings.Contains(child, lockPrefix) {
+			continue
+		}
+		if childSequence < sequence && childSequence > previousSequence {
+			previous, previousSequence = child, childSequence
+		}
+	}
+	return previous, previousSequence >= 0
+}
+
+func sequenceOf(node string) (int, error) {
+	if len(node) < sequenceDigits {
+		return 0, fmt.Errorf("node %s has no sequence", node)
+	}
+	return strconv.Atoi(node[len(node)-sequenceDigits:])
+}
+
+// nodeName escapes the subject into the name of a node, subjects being
+// free to hold slashes or to be named like the relative paths.
+func nodeName(subject string) string {
+	name := url.PathEscape(subject)
+	if name == "." || name == ".." {
+		return strings.ReplaceAll(name, ".", "%2E")
+	}
+	return name
+}
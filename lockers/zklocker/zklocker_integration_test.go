@@ -0,0 +1,67 @@
+//go:build integration
+// +build integration
+
+package zklocker
+
+import (
+	"context"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-zookeeper/zk"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ZOOKEEPER_SERVERS lists the servers to test against, comma separated,
+// such as localhost:2181 with the confluent profile of docker compose.
+var zookeeperServers = strings.Split(os.Getenv("ZOOKEEPER_SERVERS"), ",")
+
+func TestLocker_Lock(t *testing.T) {
+	// Arrange
+	conn, _, err := zk.Connect(zookeeperServers, 5*time.Second)
+	require.NoError(t, err)
+	defer conn.Close()
+	otherConn, _, err := zk.Connect(zookeeperServers, 5*time.Second)
+	require.NoError(t, err)
+	locker := New(conn, WithRoot("/srclient-test/subject-locks"))
+	other := New(otherConn, WithRoot("/srclient-test/subject-locks"))
+	unlock, err := locker.Lock(context.Background(), "orders-value")
+	require.NoError(t, err)
+
+	// Act
+	otherUnlock, otherErr := other.Lock(context.Background(), "payments-value")
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	_, blockedErr := other.Lock(ctx, "orders-value")
+	acquired := make(chan struct{})
+	go func() {
+		unlock, err := other.Lock(context.Background(), "orders-value")
+		assert.NoError(t, err)
+		unlock()
+		close(acquired)
+	}()
+	unlock()
+
+	// Assert
+	require.NoError(t, otherErr)
+	otherUnlock()
+	assert.ErrorIs(t, blockedErr, context.DeadlineExceeded)
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the subject wasn't unlocked")
+	}
+
+	// Locks of a closed session are released
+	_, err = other.Lock(context.Background(), "orders-value")
+	require.NoError(t, err)
+	otherConn.Close()
+	ctx, cancel = context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+	unlock, err = locker.Lock(ctx, "orders-value")
+	require.NoError(t, err)
+	unlock()
+}
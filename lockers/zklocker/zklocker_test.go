@@ -0,0 +1,59 @@
+package zklocker
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNodeName(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		subject  string
+		expected string
+	}{
+		"plain subject":    {subject: "orders-value", expected: "orders-value"},
+		"record name":      {subject: "com.bakery.cupcake", expected: "com.bakery.cupcake"},
+		"slashes":          {subject: "team/orders-value", expected: "team%2Forders-value"},
+		"current node":     {subject: ".", expected: "%2E"},
+		"parent node":      {subject: "..", expected: "%2E%2E"},
+		"escaped sequence": {subject: "orders%2F", expected: "orders%252F"},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testData.expected, nodeName(testData.subject))
+		})
+	}
+}
+
+func TestPreviousLock(t *testing.T) {
+	t.Parallel()
+	children := []string{
+		"_c_d1c1e1f0-lock-0000000003",
+		"_c_a0b1c2d3-lock-0000000000",
+		"_c_b1c2d3e4-lock-0000000002",
+		"unrelated",
+	}
+	tests := map[string]struct {
+		sequence   int
+		expected   string
+		expectedOk bool
+	}{
+		"lowest lock":  {sequence: 0},
+		"right after":  {sequence: 2, expected: "_c_a0b1c2d3-lock-0000000000", expectedOk: true},
+		"highest lock": {sequence: 3, expected: "_c_b1c2d3e4-lock-0000000002", expectedOk: true},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			previous, ok := previousLock(children, testData.sequence)
+			assert.Equal(t, testData.expected, previous)
+			assert.Equal(t, testData.expectedOk, ok)
+		})
+	}
+}
@@ -0,0 +1,257 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"sort"
+	"time"
+)
+
+// ExportedSchema is a single subject version as exported from
+// a registry, with the coordinates needed to import it elsewhere.
+type ExportedSchema struct {
+	Subject    string      `json:"subject"`
+	Version    int         `json:"version"`
+	ID         int         `json:"id"`
+	Schema     string      `json:"schema"`
+	SchemaType SchemaType  `json:"schemaType"`
+	References []Reference `json:"references,omitempty"`
+	// Flattened is the schema with its references inlined, as set by
+	// FlattenExport for the systems which can't handle references.
+	Flattened string `json:"flattened,omitempty"`
+	// RegisteredAt is set when the source registry returns registration times.
+	RegisteredAt *time.Time `json:"registeredAt,omitempty"`
+}
+
+// MigrationAction tells what has to be done with an exported
+// schema for it to keep its ID in the target registry.
+type MigrationAction string
+
+const (
+	// MigrationSkip means the target already has the same schema under this ID.
+	MigrationSkip MigrationAction = "SKIP"
+	// MigrationRegister means the ID is free in the target and can be reserved.
+	MigrationRegister MigrationAction = "REGISTER"
+	// MigrationConflict means the target uses the ID for a different schema.
+	MigrationConflict MigrationAction = "CONFLICT"
+)
+
+// MigrationStep is the planned action for a single exported schema.
+type MigrationStep struct {
+	Schema ExportedSchema
+	Action MigrationAction
+	// Existing is the schema found in the target under the same ID, if any.
+	Existing *Schema
+}
+
+// MigrationPlan lists the steps needed to move an export into a
+// target registry while preserving schema IDs.
+type MigrationPlan struct {
+	Steps []MigrationStep
+	// MaxSourceID is the highest schema ID found in the export.
+	MaxSourceID int
+	// Reserved lists the IDs bound to a placeholder schema by
+	// ApplyMigrationPlan and not imported for real yet.
+	Reserved []int
+}
+
+// Conflicts returns the steps whose ID is already taken in the target by a different schema.
+func (plan *MigrationPlan) Conflicts() []MigrationStep {
+	return plan.stepsWithAction(MigrationConflict)
+}
+
+// Pending returns the steps which still have to be registered in the target.
+func (plan *MigrationPlan) Pending() []MigrationStep {
+	return plan.stepsWithAction(MigrationRegister)
+}
+
+func (plan *MigrationPlan) stepsWithAction(action MigrationAction) []MigrationStep {
+	var steps []MigrationStep
+	for _, step := range plan.Steps {
+		if step.Action == action {
+			steps = append(steps, step)
+		}
+	}
+	return steps
+}
+
+// ExportSchemas reads every version of every subject of the registry.
+func ExportSchemas(ctx context.Context, client ISchemaRegistryClient) ([]ExportedSchema, error) {
+	subjects, err := client.GetSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(subjects)
+
+	var export []ExportedSchema
+	for _, subject := range subjects {
+		versions, err := client.GetSchemaVersions(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		for _, version := range versions {
+			schema, err := client.GetSchemaByVersion(ctx, subject, version)
+			if err != nil {
+				return nil, err
+			}
+			export = append(export, exportSchema(subject, schema))
+		}
+	}
+
+	return export, nil
+}
+
+// PlanMigration checks every exported schema ID against the target
+// registry and tells which ones are free, already migrated or colliding.
+func PlanMigration(ctx context.Context, source []ExportedSchema, target ISchemaRegistryClient) (*MigrationPlan, error) {
+	plan := &MigrationPlan{}
+	for _, exported := range source {
+		if exported.ID > plan.MaxSourceID {
+			plan.MaxSourceID = exported.ID
+		}
+
+		step := MigrationStep{Schema: exported, Action: MigrationRegister}
+		existing, err := target.GetSchema(ctx, exported.ID)
+		switch {
+		case isNotFoundError(err):
+		case err != nil:
+			return nil, err
+		case existing.Schema() == exported.Schema:
+			step.Action = MigrationSkip
+			step.Existing = existing
+		default:
+			step.Action = MigrationConflict
+			step.Existing = existing
+		}
+		plan.Steps = append(plan.Steps, step)
+	}
+
+	return plan, nil
+}
+
+// ImportSchema registers a schema under the given ID and version, the
+// target registry (or subject) must be in IMPORT mode for this to work.
+func (client *SchemaRegistryClient) ImportSchema(ctx context.Context, exported ExportedSchema) (*Schema, error) {
+	if err := client.authorize(exported.Subject, true); err != nil {
+		return nil, err
+	}
+	references := client.prefixedReferences(exported.References)
+	if references == nil {
+		references = make([]Reference, 0)
+	}
+
+	schemaReq := schemaRequest{
+		Schema:     exported.Schema,
+		SchemaType: exported.SchemaType.String(),
+		References: references,
+		ID:         exported.ID,
+		Version:    exported.Version,
+	}
+	schemaBytes, err := json.Marshal(schemaReq)
+	if err != nil {
+		return nil, err
+	}
+	payload := bytes.NewBuffer(schemaBytes)
+	resp, err := client.httpRequest(ctx, "POST", fmt.Sprintf(subjectVersions, url.QueryEscape(client.prefixed(exported.Subject))), payload)
+	if err != nil {
+		client.audit(ctx, AuditEvent{Operation: AuditRegisterSchema, Subject: exported.Subject, SchemaID: exported.ID, Version: exported.Version}, err)
+		return nil, err
+	}
+
+	schemaResp := new(schemaResponse)
+	err = json.Unmarshal(resp, &schemaResp)
+	client.audit(ctx, AuditEvent{Operation: AuditRegisterSchema, Subject: exported.Subject, SchemaID: exported.ID, Version: exported.Version}, err)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewSchema(schemaResp.ID, exported.Schema, exported.SchemaType, exported.Version, exported.References, nil, nil)
+}
+
+// ApplyMigrationPlan imports every pending step of the plan, keeping the
+// source IDs. It refuses to run if the plan has conflicts. When placeholderSubject
+// is set, a placeholder schema is registered under that subject instead of the real
+// one, which only reserves the ID, and the ID is recorded in plan.Reserved.
+//
+// A placeholder binds the ID to a different schema, so the registry rejects the
+// real import of a reserved ID until the placeholder subject is permanently deleted:
+// delete it with DeleteSubject, then again with permanent set, before applying the
+// plan without a placeholder subject.
+func (client *SchemaRegistryClient) ApplyMigrationPlan(ctx context.Context, plan *MigrationPlan, placeholderSubject string) error {
+	if conflicts := plan.Conflicts(); len(conflicts) > 0 {
+		return fmt.Errorf("migration plan has %d conflicting schema IDs", len(conflicts))
+	}
+
+	for _, step := range plan.Pending() {
+		exported := step.Schema
+		if placeholderSubject != "" {
+			if plan.isReserved(exported.ID) {
+				continue
+			}
+			exported = placeholderSchema(placeholderSubject, exported.ID)
+		}
+		if _, err := client.ImportSchema(ctx, exported); err != nil {
+			return fmt.Errorf("importing schema id %d of subject %s: %w", exported.ID, exported.Subject, err)
+		}
+		plan.setReserved(exported.ID, placeholderSubject != "")
+	}
+
+	return nil
+}
+
+func (plan *MigrationPlan) isReserved(id int) bool {
+	for _, reserved := range plan.Reserved {
+		if reserved == id {
+			return true
+		}
+	}
+	return false
+}
+
+// setReserved records or forgets the placeholder reserving an ID.
+func (plan *MigrationPlan) setReserved(id int, reserved bool) {
+	for i, existing := range plan.Reserved {
+		if existing == id {
+			if !reserved {
+				plan.Reserved = append(plan.Reserved[:i], plan.Reserved[i+1:]...)
+			}
+			return
+		}
+	}
+	if reserved {
+		plan.Reserved = append(plan.Reserved, id)
+	}
+}
+
+func exportSchema(subject string, schema *Schema) ExportedSchema {
+	schemaType := Avro
+	if schema.SchemaType() != nil {
+		schemaType = *schema.SchemaType()
+	}
+
+	exported := ExportedSchema{
+		Subject:    subject,
+		Version:    schema.Version(),
+		ID:         schema.ID(),
+		Schema:     schema.Schema(),
+		SchemaType: schemaType,
+		References: schema.References(),
+	}
+	if registeredAt := schema.RegisteredAt(); !registeredAt.IsZero() {
+		exported.RegisteredAt = &registeredAt
+	}
+	return exported
+}
+
+// placeholderSchema is a unique, trivially valid Avro schema used to reserve an ID.
+func placeholderSchema(subject string, id int) ExportedSchema {
+	return ExportedSchema{
+		Subject:    subject,
+		ID:         id,
+		Schema:     fmt.Sprintf(`{"type": "record", "name": "placeholder_%d", "fields": []}`, id),
+		SchemaType: Avro,
+	}
+}
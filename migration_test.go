@@ -0,0 +1,181 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlanMigration_DetectsCollisions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	source := CreateMockSchemaRegistryClient("http://source:8081")
+	_, _ = source.SetSchema(context.Background(), 1, "cupcake", testSchema1, Avro, 1)
+	_, _ = source.SetSchema(context.Background(), 2, "cupcake", testSchema2, Avro, 2)
+	_, _ = source.SetSchema(context.Background(), 3, "bakery", testSchema2, Avro, 1)
+
+	target := CreateMockSchemaRegistryClient("http://target:8081")
+	_, _ = target.SetSchema(context.Background(), 1, "cupcake", testSchema1, Avro, 1)
+	_, _ = target.SetSchema(context.Background(), 3, "other", testSchema1, Avro, 1)
+
+	export, err := ExportSchemas(context.Background(), source)
+	require.NoError(t, err)
+	require.Len(t, export, 3)
+
+	// Act
+	plan, err := PlanMigration(context.Background(), export, target)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, plan.MaxSourceID)
+
+	actions := map[int]MigrationAction{}
+	for _, step := range plan.Steps {
+		actions[step.Schema.ID] = step.Action
+	}
+	assert.Equal(t, map[int]MigrationAction{1: MigrationSkip, 2: MigrationRegister, 3: MigrationConflict}, actions)
+
+	conflicts := plan.Conflicts()
+	if assert.Len(t, conflicts, 1) {
+		assert.Equal(t, "bakery", conflicts[0].Schema.Subject)
+		assert.Equal(t, testSchema1, conflicts[0].Existing.Schema())
+	}
+	assert.Len(t, plan.Pending(), 1)
+}
+
+func TestSchemaRegistryClient_ApplyMigrationPlan(t *testing.T) {
+	t.Parallel()
+	var received []schemaRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		require.Equal(t, "/subjects/_placeholders/versions", req.URL.String())
+		var schemaReq schemaRequest
+		require.NoError(t, json.NewDecoder(req.Body).Decode(&schemaReq))
+		received = append(received, schemaReq)
+		response, _ := json.Marshal(schemaResponse{ID: schemaReq.ID})
+		_, _ = rw.Write(response)
+	}))
+	defer server.Close()
+
+	plan := &MigrationPlan{Steps: []MigrationStep{
+		{Schema: ExportedSchema{Subject: "cupcake", ID: 7, Version: 1, Schema: testSchema1}, Action: MigrationRegister},
+		{Schema: ExportedSchema{Subject: "cupcake", ID: 8, Version: 2, Schema: testSchema2}, Action: MigrationSkip},
+	}}
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	err := srClient.ApplyMigrationPlan(context.Background(), plan, "_placeholders")
+
+	assert.NoError(t, err)
+	if assert.Len(t, received, 1) {
+		assert.Equal(t, 7, received[0].ID)
+		assert.Contains(t, received[0].Schema, "placeholder_7")
+	}
+	assert.Equal(t, []int{7}, plan.Reserved)
+
+	plan.Steps[1].Action = MigrationConflict
+	assert.Error(t, srClient.ApplyMigrationPlan(context.Background(), plan, ""))
+}
+
+func TestSchemaRegistryClient_ApplyMigrationPlanAfterPlaceholders(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	type registered struct {
+		subject string
+		schema  string
+		deleted bool
+	}
+	ids := map[int]*registered{}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		subject := strings.TrimSuffix(strings.TrimPrefix(req.URL.Path, "/subjects/"), "/versions")
+		switch req.Method {
+		case http.MethodPost:
+			var schemaReq schemaRequest
+			require.NoError(t, json.NewDecoder(req.Body).Decode(&schemaReq))
+			if existing, ok := ids[schemaReq.ID]; ok && existing.schema != schemaReq.Schema {
+				rw.WriteHeader(http.StatusUnprocessableEntity)
+				_ = json.NewEncoder(rw).Encode(Error{Code: 42207, Message: fmt.Sprintf("Overwrite new schema with id %d is not permitted.", schemaReq.ID)})
+				return
+			}
+			ids[schemaReq.ID] = &registered{subject: subject, schema: schemaReq.Schema}
+			_ = json.NewEncoder(rw).Encode(schemaResponse{ID: schemaReq.ID})
+		case http.MethodDelete:
+			var versions []int
+			for id, existing := range ids {
+				if existing.subject != subject {
+					continue
+				}
+				versions = append(versions, 1)
+				if req.URL.Query().Get("permanent") == "true" {
+					delete(ids, id)
+				} else {
+					existing.deleted = true
+				}
+			}
+			_ = json.NewEncoder(rw).Encode(versions)
+		}
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+	plan := &MigrationPlan{Steps: []MigrationStep{
+		{Schema: ExportedSchema{Subject: "cupcake", ID: 7, Version: 1, Schema: testSchema1}, Action: MigrationRegister},
+	}}
+
+	// Act
+	reserveErr := srClient.ApplyMigrationPlan(context.Background(), plan, "_placeholders")
+	reserved := append([]int(nil), plan.Reserved...)
+	rejectedErr := srClient.ApplyMigrationPlan(context.Background(), plan, "")
+	_, softErr := srClient.DeleteSubject(context.Background(), "_placeholders", false)
+	_, hardErr := srClient.DeleteSubject(context.Background(), "_placeholders", true)
+	importErr := srClient.ApplyMigrationPlan(context.Background(), plan, "")
+
+	// Assert
+	require.NoError(t, reserveErr)
+	assert.Equal(t, []int{7}, reserved)
+	assert.ErrorContains(t, rejectedErr, "Overwrite new schema with id 7")
+	require.NoError(t, softErr)
+	require.NoError(t, hardErr)
+	require.NoError(t, importErr)
+	assert.Empty(t, plan.Reserved)
+	if assert.Contains(t, ids, 7) {
+		assert.Equal(t, "cupcake", ids[7].subject)
+		assert.Equal(t, testSchema1, ids[7].schema)
+	}
+}
+
+func TestSchemaRegistryClient_ImportSchemaAudit(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/subjects/bakery/versions" {
+			rw.WriteHeader(http.StatusUnprocessableEntity)
+			_ = json.NewEncoder(rw).Encode(Error{Code: 42205, Message: "Subject bakery is not in import mode"})
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 7})
+	}))
+	defer server.Close()
+	var events []AuditEvent
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	srClient := NewClient(server.URL, WithClock(clock), WithAuditSink(AuditSinkFunc(func(ctx context.Context, event AuditEvent) {
+		events = append(events, event)
+	})))
+
+	// Act
+	_, err := srClient.ImportSchema(context.Background(), ExportedSchema{Subject: "cupcake", ID: 7, Version: 3, Schema: testSchema1})
+	_, importErr := srClient.ImportSchema(context.Background(), ExportedSchema{Subject: "bakery", ID: 8, Version: 1, Schema: testSchema2})
+
+	// Assert
+	require.NoError(t, err)
+	require.Error(t, importErr)
+	assert.Equal(t, []AuditEvent{
+		{Time: clock.Now(), Operation: AuditRegisterSchema, Subject: "cupcake", SchemaID: 7, Version: 3},
+		{Time: clock.Now(), Operation: AuditRegisterSchema, Subject: "bakery", SchemaID: 8, Version: 1, Err: importErr},
+	}, events)
+}
@@ -0,0 +1,70 @@
+package srclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"regexp"
+)
+
+var newlines = regexp.MustCompile(`\r?\n`)
+
+// WithSchemaMinification removes the insignificant whitespace of Avro
+// and JSON schemas before registering or looking them up, by re-encoding
+// them as compact JSON, to reduce their size in the registry and on the
+// wire. Invalid JSON documents are then refused instead of being sent.
+// Without it only newlines are replaced by spaces.
+func WithSchemaMinification(enabled bool) Option {
+	return func(options *clientOptions) {
+		options.minifySchemas = enabled
+	}
+}
+
+// MinifySchema removes the insignificant whitespace of an Avro or JSON
+// schema, keeping the order of the fields and the strings as they are.
+func MinifySchema(schema string) (string, error) {
+	var minified bytes.Buffer
+	if err := json.Compact(&minified, []byte(schema)); err != nil {
+		return "", fmt.Errorf("invalid schema: %w", err)
+	}
+	return minified.String(), nil
+}
+
+// registrationRequest returns the body of the request registering or
+// looking up the schema, see newRegistrationRequest.
+func registrationRequest(schema string, schemaType SchemaType, metadata *SchemaMetadata, references []Reference, minify bool) ([]byte, error) {
+	request, err := newRegistrationRequest(schema, schemaType, metadata, references, minify)
+	if err != nil {
+		return nil, err
+	}
+	return json.Marshal(request)
+}
+
+// newRegistrationRequest returns the request registering or looking up
+// the schema. Avro and JSON schemas are minified when minify is set,
+// their newlines replaced by spaces otherwise.
+func newRegistrationRequest(schema string, schemaType SchemaType, metadata *SchemaMetadata, references []Reference, minify bool) (schemaRequest, error) {
+	switch schemaType {
+	case Avro, Json:
+		if !minify {
+			schema = newlines.ReplaceAllString(schema, " ")
+			break
+		}
+		minified, err := MinifySchema(schema)
+		if err != nil {
+			return schemaRequest{}, err
+		}
+		schema = minified
+	case Protobuf:
+	default:
+		canonical, err := canonicalCustomSchema(schema, schemaType)
+		if err != nil {
+			return schemaRequest{}, err
+		}
+		schema = canonical
+	}
+	if references == nil {
+		references = make([]Reference, 0)
+	}
+	return schemaRequest{Schema: schema, SchemaType: schemaType.String(), References: references, Metadata: metadata}, nil
+}
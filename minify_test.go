@@ -0,0 +1,76 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMinifySchema(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		schema   string
+		expected string
+		err      bool
+	}{
+		"record": {
+			schema:   "{\n  \"type\": \"record\",\n  \"name\": \"a b\",\n  \"fields\": []\n}",
+			expected: `{"type":"record","name":"a b","fields":[]}`,
+		},
+		"primitive": {
+			schema:   " \"string\" ",
+			expected: `"string"`,
+		},
+		"invalid": {
+			schema: "{\"type\":",
+			err:    true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			minified, err := MinifySchema(testData.schema)
+
+			// Assert
+			if testData.err {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, minified)
+		})
+	}
+}
+
+func TestWithSchemaMinification(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registered := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var body schemaRequest
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		registered <- body.Schema
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithSchemaMinification(true))
+
+	// Act
+	_, err := srClient.LookupSchema(context.Background(), "test1-value", "{\n  \"type\": \"string\"\n}", Avro)
+	_, invalidErr := srClient.CreateSchema(context.Background(), "test1-value", "{\"type\":", Avro)
+
+	// Assert
+	assert.Error(t, err)
+	assert.Equal(t, `{"type":"string"}`, <-registered)
+	assert.Error(t, invalidErr)
+	assert.Empty(t, registered)
+}
@@ -7,6 +7,8 @@ import (
 	"net/url"
 	"regexp"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/crxfoz/goavro/v2"
@@ -21,7 +23,10 @@ var (
 	errSchemaAlreadyRegistered = errors.New("schema already registered")
 	errSchemaNotFound          = errors.New("schema not found")
 	errSubjectNotFound         = errors.New("subject not found")
-	errNotImplemented          = errors.New("not implemented")
+	errConfigNotFound          = errors.New("subject compatibility level not configured")
+	errInvalidCompatibility    = errors.New("invalid compatibility level")
+	errModeNotFound            = errors.New("subject mode not configured")
+	errInvalidMode             = errors.New("invalid mode")
 )
 
 // MockSchemaRegistryClient represents an in-memory SchemaRegistryClient for testing purposes.
@@ -35,16 +40,36 @@ type MockSchemaRegistryClient struct {
 	// schemaIDs is a map of schema ID to the actual schema
 	schemaIDs map[int]*Schema
 
+	// deletedVersions is a map of subject to its soft deleted versions
+	deletedVersions map[string]map[int]*Schema
+
 	// idCounter is used to generate unique IDs for each schema
 	idCounter int
+
+	// globalCompatibility is the compatibility level of subjects without their own
+	globalCompatibility CompatibilityLevel
+
+	// subjectCompatibility is a map of subject to its compatibility level
+	subjectCompatibility map[string]CompatibilityLevel
+
+	// globalMode is the mode of subjects without their own
+	globalMode Mode
+
+	// subjectModes is a map of subject to its mode
+	subjectModes map[string]Mode
 }
 
 // CreateMockSchemaRegistryClient initializes a MockSchemaRegistryClient
 func CreateMockSchemaRegistryClient(mockURL string) *MockSchemaRegistryClient {
 	mockClient := &MockSchemaRegistryClient{
-		schemaRegistryURL: mockURL,
-		schemaVersions:    map[string]map[int]*Schema{},
-		schemaIDs:         map[int]*Schema{},
+		schemaRegistryURL:    mockURL,
+		schemaVersions:       map[string]map[int]*Schema{},
+		schemaIDs:            map[int]*Schema{},
+		deletedVersions:      map[string]map[int]*Schema{},
+		globalCompatibility:  Backward,
+		subjectCompatibility: map[string]CompatibilityLevel{},
+		globalMode:           ReadWrite,
+		subjectModes:         map[string]Mode{},
 	}
 
 	return mockClient
@@ -53,10 +78,25 @@ func CreateMockSchemaRegistryClient(mockURL string) *MockSchemaRegistryClient {
 // avroRegex is used to remove whitespace from the schema string
 var avroRegex = regexp.MustCompile(`\r?\n`)
 
-// CreateSchema generates a new schema with the given details, references are unused
-func (mck *MockSchemaRegistryClient) CreateSchema(ctx context.Context, subject string, schema string, schemaType SchemaType, _ ...Reference) (*Schema, error) {
+// CreateSchema generates a new schema with the given details, references are recorded but not resolved
+func (mck *MockSchemaRegistryClient) CreateSchema(ctx context.Context, subject string, schema string, schemaType SchemaType, references ...Reference) (*Schema, error) {
 	mck.idCounter++
-	return mck.SetSchema(ctx, mck.idCounter, subject, schema, schemaType, -1)
+	created, err := mck.SetSchema(ctx, mck.idCounter, subject, schema, schemaType, -1)
+	if err != nil {
+		return nil, err
+	}
+	created.references = references
+	return created, nil
+}
+
+// CreateSchemaWithMetadata generates a new schema with the metadata attached, references are recorded but not resolved
+func (mck *MockSchemaRegistryClient) CreateSchemaWithMetadata(ctx context.Context, subject string, schema string, schemaType SchemaType, metadata *SchemaMetadata, references ...Reference) (*Schema, error) {
+	created, err := mck.CreateSchema(ctx, subject, schema, schemaType, references...)
+	if err != nil {
+		return nil, err
+	}
+	created.metadata = metadata
+	return created, nil
 }
 
 // SetSchema overwrites a schema with the given id. Allows you to set a schema with a specific ID for testing purposes.
@@ -73,7 +113,11 @@ func (mck *MockSchemaRegistryClient) SetSchema(_ context.Context, id int, subjec
 	case Protobuf:
 		break
 	default:
-		return nil, errInvalidSchemaType
+		canonical, err := canonicalCustomSchema(schema, schemaType)
+		if err != nil {
+			return nil, err
+		}
+		schema = canonical
 	}
 
 	resultFromSchemaCache, ok := mck.schemaVersions[subject]
@@ -111,6 +155,84 @@ func (mck *MockSchemaRegistryClient) GetSchema(_ context.Context, schemaID int)
 	return thisSchema, nil
 }
 
+// GetSchemaBySubjectAndID Returns a Schema for the given ID if it is registered under the given subject
+func (mck *MockSchemaRegistryClient) GetSchemaBySubjectAndID(_ context.Context, subject string, schemaID int) (*Schema, error) {
+	for _, schema := range mck.schemaVersions[subject] {
+		if schema.id == schemaID {
+			return schema, nil
+		}
+	}
+
+	posErr := url.Error{
+		Op:  "GET",
+		URL: fmt.Sprintf("%s/schemas/ids/%d?subject=%s", mck.schemaRegistryURL, schemaID, subject),
+		Err: errSchemaNotFound,
+	}
+	return nil, &posErr
+}
+
+// GetSchemaVersionsByID Returns the subject versions registered with the given ID, ordered by subject and version
+func (mck *MockSchemaRegistryClient) GetSchemaVersionsByID(_ context.Context, schemaID int) ([]SubjectVersion, error) {
+	if _, ok := mck.schemaIDs[schemaID]; !ok {
+		posErr := url.Error{
+			Op:  "GET",
+			URL: fmt.Sprintf("%s/schemas/ids/%d/versions", mck.schemaRegistryURL, schemaID),
+			Err: errSchemaNotFound,
+		}
+		return nil, &posErr
+	}
+
+	subjectVersions := []SubjectVersion{}
+	for subject, versions := range mck.schemaVersions {
+		for version, schema := range versions {
+			if schema.id == schemaID {
+				subjectVersions = append(subjectVersions, SubjectVersion{Subject: subject, Version: version})
+			}
+		}
+	}
+	sort.Slice(subjectVersions, func(i, j int) bool {
+		if subjectVersions[i].Subject != subjectVersions[j].Subject {
+			return subjectVersions[i].Subject < subjectVersions[j].Subject
+		}
+		return subjectVersions[i].Version < subjectVersions[j].Version
+	})
+	return subjectVersions, nil
+}
+
+// GetSubjectsBySchemaID Returns the sorted subjects registered with the given ID, including the soft deleted ones
+// when deleted is set
+func (mck *MockSchemaRegistryClient) GetSubjectsBySchemaID(_ context.Context, schemaID int, deleted bool) ([]string, error) {
+	if _, ok := mck.schemaIDs[schemaID]; !ok {
+		posErr := url.Error{
+			Op:  "GET",
+			URL: fmt.Sprintf("%s/schemas/ids/%d/subjects", mck.schemaRegistryURL, schemaID),
+			Err: errSchemaNotFound,
+		}
+		return nil, &posErr
+	}
+
+	found := map[string]struct{}{}
+	collect := func(schemaVersions map[string]map[int]*Schema) {
+		for subject, versions := range schemaVersions {
+			for _, schema := range versions {
+				if schema.id == schemaID {
+					found[subject] = struct{}{}
+				}
+			}
+		}
+	}
+	collect(mck.schemaVersions)
+	if deleted {
+		collect(mck.deletedVersions)
+	}
+	subjects := make([]string, 0, len(found))
+	for subject := range found {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+	return subjects, nil
+}
+
 // GetLatestSchema Returns the highest ordinal version of a Schema for a given `concrete subject`
 func (mck *MockSchemaRegistryClient) GetLatestSchema(ctx context.Context, subject string) (*Schema, error) {
 	// Error is never returned
@@ -163,6 +285,17 @@ func (mck *MockSchemaRegistryClient) GetSchemaByVersion(_ context.Context, subje
 	return schema, nil
 }
 
+// SubjectExists Returns whether the subject has any registered version
+func (mck *MockSchemaRegistryClient) SubjectExists(_ context.Context, subject string) (bool, error) {
+	return len(mck.schemaVersions[subject]) > 0, nil
+}
+
+// VersionExists Returns whether the given version of the subject is registered
+func (mck *MockSchemaRegistryClient) VersionExists(_ context.Context, subject string, version int) (bool, error) {
+	_, ok := mck.schemaVersions[subject][version]
+	return ok, nil
+}
+
 // GetSubjects Returns all registered subjects
 func (mck *MockSchemaRegistryClient) GetSubjects(_ context.Context) ([]string, error) {
 	var allSubjects []string
@@ -174,34 +307,132 @@ func (mck *MockSchemaRegistryClient) GetSubjects(_ context.Context) ([]string, e
 	return allSubjects, nil
 }
 
-// GetSubjectsIncludingDeleted is not implemented and returns an error
-func (mck *MockSchemaRegistryClient) GetSubjectsIncludingDeleted(_ context.Context) ([]string, error) {
-	return nil, errNotImplemented
+// GetSubjectsIncludingDeleted Returns all registered subjects, including the soft deleted ones
+func (mck *MockSchemaRegistryClient) GetSubjectsIncludingDeleted(ctx context.Context) ([]string, error) {
+	allSubjects, _ := mck.GetSubjects(ctx)
+
+	for subject := range mck.deletedVersions {
+		if _, ok := mck.schemaVersions[subject]; !ok {
+			allSubjects = append(allSubjects, subject)
+		}
+	}
+
+	return allSubjects, nil
 }
 
-// DeleteSubject removes given subject from the cache
-func (mck *MockSchemaRegistryClient) DeleteSubject(_ context.Context, subject string, _ bool) error {
+// ListDeletedVersions Returns the soft deleted versions of the subject
+func (mck *MockSchemaRegistryClient) ListDeletedVersions(_ context.Context, subject string) ([]int, error) {
+	_, active := mck.schemaVersions[subject]
+	deletedVersionMap, deleted := mck.deletedVersions[subject]
+	if !active && !deleted {
+		posErr := url.Error{
+			Op:  "GET",
+			URL: fmt.Sprintf("%s/subjects/%s/versions?deleted=true", mck.schemaRegistryURL, subject),
+			Err: errSubjectNotFound,
+		}
+		return nil, &posErr
+	}
+
+	var versions = []int{}
+	for version := range deletedVersionMap {
+		versions = append(versions, version)
+	}
+	sort.Ints(versions)
+
+	return versions, nil
+}
+
+// IsSubjectSoftDeleted Returns whether every version of the subject was soft deleted
+func (mck *MockSchemaRegistryClient) IsSubjectSoftDeleted(_ context.Context, subject string) (bool, error) {
+	return len(mck.schemaVersions[subject]) == 0 && len(mck.deletedVersions[subject]) > 0, nil
+}
+
+// UndeleteSubject registers again the soft deleted versions of the subject newer than its latest active version,
+// they keep their ID but get a new version like with a real registry
+func (mck *MockSchemaRegistryClient) UndeleteSubject(ctx context.Context, subject string) ([]*Schema, error) {
+	deleted, err := mck.ListDeletedVersions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	latestActive := 0
+	if versions := mck.allVersions(subject); len(versions) > 0 {
+		latestActive = versions[len(versions)-1]
+	}
+
+	var restored []*Schema
+	for _, version := range deleted {
+		if version < latestActive {
+			continue
+		}
+
+		deletedSchema := mck.deletedVersions[subject][version]
+		if existing, err := mck.LookupSchema(ctx, subject, deletedSchema.schema, schemaTypeOf(deletedSchema)); err == nil {
+			restored = append(restored, existing)
+			continue
+		}
+		schema, err := mck.SetSchema(ctx, deletedSchema.id, subject, deletedSchema.schema, schemaTypeOf(deletedSchema), -1)
+		if err != nil {
+			return restored, err
+		}
+		restored = append(restored, schema)
+	}
+
+	return restored, nil
+}
+
+// DeleteSubject soft deletes the given subject, or removes it along with its soft deleted versions when permanent,
+// and returns the deleted versions
+func (mck *MockSchemaRegistryClient) DeleteSubject(_ context.Context, subject string, permanent bool) ([]int, error) {
+	var versions []int
+	for version := range mck.schemaVersions[subject] {
+		versions = append(versions, version)
+	}
+	if permanent {
+		for version := range mck.deletedVersions[subject] {
+			versions = append(versions, version)
+		}
+		delete(mck.deletedVersions, subject)
+	} else if active, ok := mck.schemaVersions[subject]; ok {
+		if _, ok := mck.deletedVersions[subject]; !ok {
+			mck.deletedVersions[subject] = map[int]*Schema{}
+		}
+		for version, schema := range active {
+			mck.softDelete(subject, version, schema)
+		}
+	}
+
 	delete(mck.schemaVersions, subject)
-	return nil
+	sort.Ints(versions)
+	return versions, nil
 }
 
-// DeleteSubjectByVersion removes given subject's version from cache
-func (mck *MockSchemaRegistryClient) DeleteSubjectByVersion(_ context.Context, subject string, version int, _ bool) error {
-	_, ok := mck.schemaVersions[subject]
-	if !ok {
+// DeleteSubjectByVersion soft deletes the given subject's version, or removes it when permanent, and returns the
+// deleted version
+func (mck *MockSchemaRegistryClient) DeleteSubjectByVersion(_ context.Context, subject string, version int, permanent bool) (int, error) {
+	_, active := mck.schemaVersions[subject]
+	_, deleted := mck.deletedVersions[subject]
+	if !active && !deleted {
 		posErr := url.Error{
 			Op:  "DELETE",
 			URL: fmt.Sprintf("%s/subjects/%s/versions/%d", mck.schemaRegistryURL, subject, version),
 			Err: errSubjectNotFound,
 		}
-		return &posErr
+		return 0, &posErr
 	}
 
-	for schemaVersion := range mck.schemaVersions[subject] {
-		if schemaVersion == version {
-			delete(mck.schemaVersions[subject], schemaVersion)
-			return nil
+	if schema, ok := mck.schemaVersions[subject][version]; ok {
+		delete(mck.schemaVersions[subject], version)
+		if len(mck.schemaVersions[subject]) == 0 {
+			delete(mck.schemaVersions, subject)
+		}
+		if !permanent {
+			mck.softDelete(subject, version, schema)
 		}
+		return version, nil
+	}
+	if _, ok := mck.deletedVersions[subject][version]; ok && permanent {
+		delete(mck.deletedVersions[subject], version)
+		return version, nil
 	}
 
 	posErr := url.Error{
@@ -209,22 +440,152 @@ func (mck *MockSchemaRegistryClient) DeleteSubjectByVersion(_ context.Context, s
 		URL: fmt.Sprintf("%s/subjects/%s/versions/%d", mck.schemaRegistryURL, subject, version),
 		Err: errSchemaNotFound,
 	}
-	return &posErr
+	return 0, &posErr
+}
+
+// GetReferencedBy returns the IDs of the schemas referencing the version of the subject
+func (mck *MockSchemaRegistryClient) GetReferencedBy(_ context.Context, subject string, version int) ([]int, error) {
+	if _, ok := mck.schemaVersions[subject][version]; !ok {
+		posErr := url.Error{
+			Op:  "GET",
+			URL: fmt.Sprintf("%s/subjects/%s/versions/%d/referencedby", mck.schemaRegistryURL, subject, version),
+			Err: errSchemaNotFound,
+		}
+		return nil, &posErr
+	}
+
+	var referencing = []int{}
+	for _, schema := range mck.schemaIDs {
+		for _, reference := range schema.references {
+			if reference.Subject == subject && reference.Version == version {
+				referencing = append(referencing, schema.id)
+				break
+			}
+		}
+	}
+	sort.Ints(referencing)
+	return referencing, nil
+}
+
+// ChangeSubjectCompatibilityLevel Sets the compatibility level of the subject
+func (mck *MockSchemaRegistryClient) ChangeSubjectCompatibilityLevel(_ context.Context, subject string, compatibility CompatibilityLevel) (*CompatibilityLevel, error) {
+	if compatibility == "" || !validCompatibilityLevel(compatibility) {
+		posErr := url.Error{
+			Op:  "PUT",
+			URL: fmt.Sprintf("%s/config/%s", mck.schemaRegistryURL, subject),
+			Err: errInvalidCompatibility,
+		}
+		return nil, &posErr
+	}
+
+	mck.subjectCompatibility[subject] = compatibility
+	return &compatibility, nil
 }
 
-// ChangeSubjectCompatibilityLevel is not implemented
-func (mck *MockSchemaRegistryClient) ChangeSubjectCompatibilityLevel(context.Context, string, CompatibilityLevel) (*CompatibilityLevel, error) {
-	return nil, errNotImplemented
+// ChangeGlobalCompatibilityLevel Sets the global compatibility level
+func (mck *MockSchemaRegistryClient) ChangeGlobalCompatibilityLevel(_ context.Context, compatibility CompatibilityLevel) (*CompatibilityLevel, error) {
+	if compatibility == "" || !validCompatibilityLevel(compatibility) {
+		posErr := url.Error{
+			Op:  "PUT",
+			URL: mck.schemaRegistryURL + "/config",
+			Err: errInvalidCompatibility,
+		}
+		return nil, &posErr
+	}
+
+	mck.globalCompatibility = compatibility
+	return &compatibility, nil
 }
 
-// GetGlobalCompatibilityLevel is not implemented
+// DeleteSubjectCompatibilityLevel Removes the compatibility level of the subject and returns it
+func (mck *MockSchemaRegistryClient) DeleteSubjectCompatibilityLevel(ctx context.Context, subject string) (*CompatibilityLevel, error) {
+	removed, err := mck.ResetSubjectConfig(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	return &removed.CompatibilityLevel, nil
+}
+
+// GetGlobalCompatibilityLevel Returns the global compatibility level, BACKWARD unless changed
 func (mck *MockSchemaRegistryClient) GetGlobalCompatibilityLevel(_ context.Context) (*CompatibilityLevel, error) {
-	return nil, errNotImplemented
+	level := mck.globalCompatibility
+	return &level, nil
+}
+
+// GetCompatibilityLevel Returns the compatibility level of the subject, or the global one if defaultToGlobal is set
+// and the subject has none
+func (mck *MockSchemaRegistryClient) GetCompatibilityLevel(_ context.Context, subject string, defaultToGlobal bool) (*CompatibilityLevel, error) {
+	if level, ok := mck.subjectCompatibility[subject]; ok {
+		return &level, nil
+	}
+	if defaultToGlobal {
+		level := mck.globalCompatibility
+		return &level, nil
+	}
+
+	posErr := url.Error{
+		Op:  "GET",
+		URL: fmt.Sprintf("%s/config/%s", mck.schemaRegistryURL, subject),
+		Err: errConfigNotFound,
+	}
+	return nil, &posErr
+}
+
+// GetMode Returns the global mode, READWRITE unless changed
+func (mck *MockSchemaRegistryClient) GetMode(_ context.Context) (*Mode, error) {
+	mode := mck.globalMode
+	return &mode, nil
+}
+
+// SetMode Changes the global mode, modes aren't enforced
+func (mck *MockSchemaRegistryClient) SetMode(_ context.Context, mode Mode) (*Mode, error) {
+	if !validMode(mode) {
+		return nil, &url.Error{Op: "PUT", URL: mck.schemaRegistryURL + "/mode", Err: errInvalidMode}
+	}
+	mck.globalMode = mode
+	return &mode, nil
+}
+
+// GetSubjectMode Returns the mode of the subject, or the global one if defaultToGlobal is set and the subject has none
+func (mck *MockSchemaRegistryClient) GetSubjectMode(_ context.Context, subject string, defaultToGlobal bool) (*Mode, error) {
+	if mode, ok := mck.subjectModes[subject]; ok {
+		return &mode, nil
+	}
+	if defaultToGlobal {
+		mode := mck.globalMode
+		return &mode, nil
+	}
+	return nil, &url.Error{Op: "GET", URL: fmt.Sprintf("%s/mode/%s", mck.schemaRegistryURL, subject), Err: errModeNotFound}
+}
+
+// SetSubjectMode Changes the mode of the subject, modes aren't enforced
+func (mck *MockSchemaRegistryClient) SetSubjectMode(_ context.Context, subject string, mode Mode) (*Mode, error) {
+	if !validMode(mode) {
+		return nil, &url.Error{Op: "PUT", URL: fmt.Sprintf("%s/mode/%s", mck.schemaRegistryURL, subject), Err: errInvalidMode}
+	}
+	mck.subjectModes[subject] = mode
+	return &mode, nil
 }
 
-// GetCompatibilityLevel is not implemented
-func (mck *MockSchemaRegistryClient) GetCompatibilityLevel(context.Context, string, bool) (*CompatibilityLevel, error) {
-	return nil, errNotImplemented
+// GetGlobalConfig Returns the global configuration, only the compatibility level is set
+func (mck *MockSchemaRegistryClient) GetGlobalConfig(_ context.Context) (*Config, error) {
+	return &Config{CompatibilityLevel: mck.globalCompatibility}, nil
+}
+
+// ResetSubjectConfig Removes the compatibility level of the subject and returns the removed configuration
+func (mck *MockSchemaRegistryClient) ResetSubjectConfig(_ context.Context, subject string) (*Config, error) {
+	level, ok := mck.subjectCompatibility[subject]
+	if !ok {
+		posErr := url.Error{
+			Op:  "DELETE",
+			URL: fmt.Sprintf("%s/config/%s", mck.schemaRegistryURL, subject),
+			Err: errConfigNotFound,
+		}
+		return nil, &posErr
+	}
+
+	delete(mck.subjectCompatibility, subject)
+	return &Config{CompatibilityLevel: level}, nil
 }
 
 // SetCredentials is not implemented
@@ -252,19 +613,170 @@ func (mck *MockSchemaRegistryClient) ResetCache() {
 	// Nothing because there is no lock for cache
 }
 
+// InvalidateSubject is not implemented
+func (mck *MockSchemaRegistryClient) InvalidateSubject(string) {
+	// Nothing because there is no cache to invalidate
+}
+
+// InvalidateSchemaID is not implemented
+func (mck *MockSchemaRegistryClient) InvalidateSchemaID(int) {
+	// Nothing because there is no cache to invalidate
+}
+
+// InvalidateLatest is not implemented
+func (mck *MockSchemaRegistryClient) InvalidateLatest(string) {
+	// Nothing because there is no cache to invalidate
+}
+
+// Close is not implemented
+func (mck *MockSchemaRegistryClient) Close(context.Context) error {
+	// Nothing because the mock holds no connection nor background work
+	return nil
+}
+
 // CodecCreationEnabled is not implemented
 func (mck *MockSchemaRegistryClient) CodecCreationEnabled(bool) {
 	// Nothing because codecs do not matter in the inMem storage of schemas
 }
 
-// IsSchemaCompatible is not implemented
-func (mck *MockSchemaRegistryClient) IsSchemaCompatible(context.Context, string, string, string, SchemaType, ...Reference) (bool, error) {
-	return false, errNotImplemented
+// IsSchemaCompatible Checks the schema against the given version, a number or "latest", with CheckLocalCompatibility
+// under the effective compatibility level of the subject, references are unused
+func (mck *MockSchemaRegistryClient) IsSchemaCompatible(ctx context.Context, subject, schema, version string, schemaType SchemaType, _ ...Reference) (bool, error) {
+	var previous *Schema
+	var err error
+	if version == "latest" {
+		previous, err = mck.GetLatestSchema(ctx, subject)
+	} else {
+		var number int
+		if number, err = strconv.Atoi(version); err == nil {
+			previous, err = mck.GetSchemaByVersion(ctx, subject, number)
+		}
+	}
+	if err != nil {
+		return false, err
+	}
+	if schemaTypeOf(previous) != schemaType {
+		return false, nil
+	}
+
+	level, _ := mck.GetCompatibilityLevel(ctx, subject, true)
+	problems, err := CheckLocalCompatibility(previous.schema, schema, schemaType, *level)
+	if err != nil {
+		return false, err
+	}
+	return len(problems) == 0, nil
 }
 
-// LookupSchema is not implemented
-func (mck *MockSchemaRegistryClient) LookupSchema(context.Context, string, string, SchemaType, ...Reference) (*Schema, error) {
-	return nil, errNotImplemented
+// LookupSchema Returns the version of the subject registered with the given schema, references are unused
+func (mck *MockSchemaRegistryClient) LookupSchema(_ context.Context, subject string, schema string, schemaType SchemaType, _ ...Reference) (*Schema, error) {
+	switch schemaType {
+	case Avro, Json:
+		schema = avroRegex.ReplaceAllString(schema, " ")
+	case Protobuf:
+		break
+	default:
+		canonical, err := canonicalCustomSchema(schema, schemaType)
+		if err != nil {
+			return nil, err
+		}
+		schema = canonical
+	}
+
+	schemaVersionMap, ok := mck.schemaVersions[subject]
+	if !ok {
+		posErr := url.Error{
+			Op:  "POST",
+			URL: fmt.Sprintf("%s/subjects/%s", mck.schemaRegistryURL, subject),
+			Err: errSubjectNotFound,
+		}
+		return nil, &posErr
+	}
+
+	for _, existing := range schemaVersionMap {
+		if existing.schema == schema {
+			return existing, nil
+		}
+	}
+
+	posErr := url.Error{
+		Op:  "POST",
+		URL: fmt.Sprintf("%s/subjects/%s", mck.schemaRegistryURL, subject),
+		Err: errSchemaNotFound,
+	}
+	return nil, &posErr
+}
+
+// SearchSchemas returns the schema versions matched by the predicate, ordered by subject and version
+func (mck *MockSchemaRegistryClient) SearchSchemas(_ context.Context, predicate SchemaPredicate) ([]SchemaMatch, error) {
+	var allSubjects []string
+	for subject := range mck.schemaVersions {
+		allSubjects = append(allSubjects, subject)
+	}
+	sort.Strings(allSubjects)
+
+	var matches []SchemaMatch
+	for _, subject := range allSubjects {
+		for _, version := range mck.allVersions(subject) {
+			schema := mck.schemaVersions[subject][version]
+			if predicate(subject, schema) {
+				matches = append(matches, SchemaMatch{Subject: subject, Schema: schema})
+			}
+		}
+	}
+	return matches, nil
+}
+
+// GetAllSchemas returns the schema versions filtered by the options, ordered by subject and version
+func (mck *MockSchemaRegistryClient) GetAllSchemas(_ context.Context, options SchemaListOptions) ([]*Schema, error) {
+	subjectSet := make(map[string]bool)
+	for subject := range mck.schemaVersions {
+		subjectSet[subject] = true
+	}
+	if options.Deleted {
+		for subject := range mck.deletedVersions {
+			subjectSet[subject] = true
+		}
+	}
+	var allSubjects []string
+	for subject := range subjectSet {
+		if strings.HasPrefix(subject, options.SubjectPrefix) {
+			allSubjects = append(allSubjects, subject)
+		}
+	}
+	sort.Strings(allSubjects)
+
+	var schemas []*Schema
+	for _, subject := range allSubjects {
+		versions := make(map[int]*Schema)
+		for version, schema := range mck.schemaVersions[subject] {
+			versions[version] = schema
+		}
+		if options.Deleted {
+			for version, schema := range mck.deletedVersions[subject] {
+				versions[version] = schema
+			}
+		}
+		numbers := make([]int, 0, len(versions))
+		for version := range versions {
+			numbers = append(numbers, version)
+		}
+		sort.Ints(numbers)
+		if options.LatestOnly && len(numbers) > 0 {
+			numbers = numbers[len(numbers)-1:]
+		}
+		for _, version := range numbers {
+			schemas = append(schemas, versions[version])
+		}
+	}
+
+	if options.Offset >= len(schemas) {
+		return nil, nil
+	}
+	schemas = schemas[options.Offset:]
+	if options.Limit > 0 && options.Limit < len(schemas) {
+		schemas = schemas[:options.Limit]
+	}
+	return schemas, nil
 }
 
 /*
@@ -296,6 +808,15 @@ func (mck *MockSchemaRegistryClient) generateVersion(id int, subject string, sch
 		}
 	}
 
+	// Like registries, don't reuse the numbers of soft deleted versions
+	if givenVersion <= 0 {
+		for version := range mck.deletedVersions[subject] {
+			if version >= currentVersion {
+				currentVersion = version + 1
+			}
+		}
+	}
+
 	// Add a codec, required otherwise Codec() panics and the mock registry is unusable
 	codec, _ := goavro.NewCodec(schema)
 
@@ -305,6 +826,7 @@ func (mck *MockSchemaRegistryClient) generateVersion(id int, subject string, sch
 		version:    currentVersion,
 		codec:      codec,
 		schemaType: &schemaType,
+		subject:    subject,
 	}
 
 	schemaVersionMap[currentVersion] = schemaToRegister
@@ -314,6 +836,14 @@ func (mck *MockSchemaRegistryClient) generateVersion(id int, subject string, sch
 	return schemaToRegister, nil
 }
 
+// softDelete moves the version of the subject to its soft deleted versions
+func (mck *MockSchemaRegistryClient) softDelete(subject string, version int, schema *Schema) {
+	if _, ok := mck.deletedVersions[subject]; !ok {
+		mck.deletedVersions[subject] = map[int]*Schema{}
+	}
+	mck.deletedVersions[subject][version] = schema
+}
+
 // allVersions returns all versions for a given subject, assumes it exists
 func (mck *MockSchemaRegistryClient) allVersions(subject string) []int {
 	var versions []int
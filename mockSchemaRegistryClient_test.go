@@ -292,6 +292,21 @@ func TestMockSchemaRegistryClient_CreateSchema_ReturnsErrorOnDuplicateSchema(t *
 	assert.ErrorIs(t, err, errSchemaAlreadyRegistered)
 }
 
+func TestMockSchemaRegistryClient_CreateSchemaWithMetadata_AttachesMetadata(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	metadata := &SchemaMetadata{Tags: map[string][]string{"cupcake.flavor": {"PII"}}}
+
+	// Act
+	schema, err := registry.CreateSchemaWithMetadata(context.Background(), "cupcake", testSchema1, Avro, metadata)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Same(t, metadata, schema.Metadata())
+	assert.Same(t, schema, registry.schemaIDs[schema.ID()])
+}
+
 func TestMockSchemaRegistryClient_GetSchema_ReturnsSchema(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -325,6 +340,22 @@ func TestMockSchemaRegistryClient_GetSchema_ReturnsErrOnNotFound(t *testing.T) {
 	assert.Nil(t, result)
 }
 
+func TestMockSchemaRegistryClient_GetSchemaBySubjectAndID_ReturnsSchema(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, _ := registry.SetSchema(context.Background(), 12, "cupcake", testSchema1, Avro, 1)
+
+	// Act
+	result, err := registry.GetSchemaBySubjectAndID(context.Background(), "cupcake", 12)
+	_, otherErr := registry.GetSchemaBySubjectAndID(context.Background(), "bakery", 12)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Same(t, schema, result)
+	assert.ErrorIs(t, otherErr, errSchemaNotFound)
+}
+
 func TestMockSchemaRegistryClient_GetLatestSchema_ReturnsErrorOn0SchemaVersions(t *testing.T) {
 	t.Parallel()
 	// Arrange
@@ -496,17 +527,80 @@ func TestMockSchemaRegistryClient_GetSubjects_ReturnsAllSubjects(t *testing.T) {
 	assert.Contains(t, result, "3")
 }
 
-func TestMockSchemaRegistryClient_GetSubjectsIncludingDeleted_IsNotImplemented(t *testing.T) {
+func TestMockSchemaRegistryClient_SoftDelete(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	first, _ := registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+	_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema2, Avro)
+	_, _ = registry.CreateSchema(context.Background(), "bakery", testSchema2, Avro)
 
 	// Act
-	result, err := registry.GetSubjectsIncludingDeleted(context.Background())
+	deletedVersion, versionErr := registry.DeleteSubjectByVersion(context.Background(), "cupcake", 2, false)
+	deletedVersions, subjectErr := registry.DeleteSubject(context.Background(), "cupcake", false)
 
 	// Assert
-	assert.Nil(t, result)
-	assert.ErrorIs(t, err, errNotImplemented)
+	assert.NoError(t, versionErr)
+	assert.Equal(t, 2, deletedVersion)
+	assert.NoError(t, subjectErr)
+	assert.Equal(t, []int{1}, deletedVersions)
+	subjects, _ := registry.GetSubjects(context.Background())
+	assert.Equal(t, []string{"bakery"}, subjects)
+	allSubjects, err := registry.GetSubjectsIncludingDeleted(context.Background())
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []string{"bakery", "cupcake"}, allSubjects)
+	deleted, err := registry.ListDeletedVersions(context.Background(), "cupcake")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, deleted)
+	softDeleted, err := registry.IsSubjectSoftDeleted(context.Background(), "cupcake")
+	assert.NoError(t, err)
+	assert.True(t, softDeleted)
+	softDeleted, _ = registry.IsSubjectSoftDeleted(context.Background(), "bakery")
+	assert.False(t, softDeleted)
+	_, err = registry.ListDeletedVersions(context.Background(), "pie")
+	assert.True(t, isNotFoundError(err))
+
+	// Versions numbers aren't reused
+	again, err := registry.SetSchema(context.Background(), first.ID(), "cupcake", testSchema1, Avro, -1)
+	if assert.NoError(t, err) {
+		assert.Equal(t, 3, again.Version())
+	}
+
+	// Permanent deletion
+	_, err = registry.DeleteSubjectByVersion(context.Background(), "cupcake", 1, true)
+	assert.NoError(t, err)
+	deleted, _ = registry.ListDeletedVersions(context.Background(), "cupcake")
+	assert.Equal(t, []int{2}, deleted)
+	deleted, err = registry.DeleteSubject(context.Background(), "cupcake", true)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{2, 3}, deleted)
+	allSubjects, _ = registry.GetSubjectsIncludingDeleted(context.Background())
+	assert.Equal(t, []string{"bakery"}, allSubjects)
+}
+
+func TestMockSchemaRegistryClient_UndeleteSubject(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	first, _ := registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+	second, _ := registry.CreateSchema(context.Background(), "cupcake", testSchema2, Avro)
+	_, _ = registry.DeleteSubject(context.Background(), "cupcake", false)
+
+	// Act
+	restored, err := registry.UndeleteSubject(context.Background(), "cupcake")
+
+	// Assert
+	assert.NoError(t, err)
+	if assert.Len(t, restored, 2) {
+		assert.Equal(t, first.ID(), restored[0].ID())
+		assert.Equal(t, 3, restored[0].Version())
+		assert.Equal(t, second.ID(), restored[1].ID())
+		assert.Equal(t, 4, restored[1].Version())
+	}
+	versions, _ := registry.GetSchemaVersions(context.Background(), "cupcake")
+	assert.Equal(t, []int{3, 4}, versions)
+	_, err = registry.UndeleteSubject(context.Background(), "pie")
+	assert.True(t, isNotFoundError(err))
 }
 
 func TestMockSchemaRegistryClient_DeleteSubject_DeletesSubject(t *testing.T) {
@@ -517,7 +611,7 @@ func TestMockSchemaRegistryClient_DeleteSubject_DeletesSubject(t *testing.T) {
 	}
 
 	// Act
-	err := registry.DeleteSubject(context.Background(), "b", false)
+	_, err := registry.DeleteSubject(context.Background(), "b", false)
 
 	// Assert
 	assert.Nil(t, err)
@@ -536,10 +630,11 @@ func TestMockSchemaRegistryClient_DeleteSubjectByVersion_DeletesSubjectVersion(t
 	}
 
 	// Act
-	err := registry.DeleteSubjectByVersion(context.Background(), "b", 2, false)
+	deleted, err := registry.DeleteSubjectByVersion(context.Background(), "b", 2, false)
 
 	// Assert
 	assert.Nil(t, err)
+	assert.Equal(t, 2, deleted)
 	if assert.NotNil(t, registry.schemaVersions["b"]) {
 		assert.Nil(t, registry.schemaVersions["b"][2])
 	}
@@ -550,7 +645,7 @@ func TestMockSchemaRegistryClient_DeleteSubjectByVersion_ReturnsErrorOnSubjectNo
 	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
 
 	// Act
-	err := registry.DeleteSubjectByVersion(context.Background(), "cupcake", 5, false)
+	_, err := registry.DeleteSubjectByVersion(context.Background(), "cupcake", 5, false)
 
 	// Assert
 	assert.ErrorIs(t, err, errSubjectNotFound)
@@ -567,73 +662,201 @@ func TestMockSchemaRegistryClient_DeleteSubjectByVersion_ReturnsErrorOnVersionNo
 	}
 
 	// Act
-	err := registry.DeleteSubjectByVersion(context.Background(), "cupcake", 5, false)
+	_, err := registry.DeleteSubjectByVersion(context.Background(), "cupcake", 5, false)
 
 	// Assert
 	assert.ErrorIs(t, err, errSchemaNotFound)
 }
 
-func TestMockSchemaRegistryClient_ChangeSubjectCompatibilityLevel_IsNotImplemented(t *testing.T) {
+func TestMockSchemaRegistryClient_CompatibilityLevel(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
 
 	// Act
-	result, err := registry.ChangeSubjectCompatibilityLevel(context.Background(), "", "")
+	changed, changeErr := registry.ChangeSubjectCompatibilityLevel(context.Background(), "cupcake", Full)
+	global, globalErr := registry.GetGlobalCompatibilityLevel(context.Background())
+	subjectLevel, subjectErr := registry.GetCompatibilityLevel(context.Background(), "cupcake", false)
+	defaulted, defaultedErr := registry.GetCompatibilityLevel(context.Background(), "bakery", true)
+	_, unsetErr := registry.GetCompatibilityLevel(context.Background(), "bakery", false)
+	_, invalidErr := registry.ChangeSubjectCompatibilityLevel(context.Background(), "cupcake", "SIDEWAYS")
 
 	// Assert
-	assert.Nil(t, result)
-	assert.ErrorIs(t, err, errNotImplemented)
+	assert.NoError(t, changeErr)
+	assert.Equal(t, Full, *changed)
+	assert.NoError(t, globalErr)
+	assert.Equal(t, Backward, *global)
+	assert.NoError(t, subjectErr)
+	assert.Equal(t, Full, *subjectLevel)
+	assert.NoError(t, defaultedErr)
+	assert.Equal(t, Backward, *defaulted)
+	assert.True(t, isNotFoundError(unsetErr))
+	assert.ErrorIs(t, invalidErr, errInvalidCompatibility)
 }
 
-func TestMockSchemaRegistryClient_GetGlobalCompatibilityLevel_IsNotImplemented(t *testing.T) {
+func TestMockSchemaRegistryClient_Mode(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
 
 	// Act
-	result, err := registry.GetGlobalCompatibilityLevel(context.Background())
+	changed, changeErr := registry.SetSubjectMode(context.Background(), "cupcake", ReadOnly)
+	global, globalErr := registry.SetMode(context.Background(), Import)
+	subjectMode, subjectErr := registry.GetSubjectMode(context.Background(), "cupcake", false)
+	defaulted, defaultedErr := registry.GetSubjectMode(context.Background(), "bakery", true)
+	_, unsetErr := registry.GetSubjectMode(context.Background(), "bakery", false)
+	_, invalidErr := registry.SetMode(context.Background(), "WRITEONLY")
+	current, currentErr := registry.GetMode(context.Background())
 
 	// Assert
-	assert.Nil(t, result)
-	assert.ErrorIs(t, err, errNotImplemented)
+	assert.NoError(t, changeErr)
+	assert.Equal(t, ReadOnly, *changed)
+	assert.NoError(t, globalErr)
+	assert.Equal(t, Import, *global)
+	assert.NoError(t, subjectErr)
+	assert.Equal(t, ReadOnly, *subjectMode)
+	assert.NoError(t, defaultedErr)
+	assert.Equal(t, Import, *defaulted)
+	assert.True(t, isNotFoundError(unsetErr))
+	assert.ErrorIs(t, invalidErr, errInvalidMode)
+	assert.NoError(t, currentErr)
+	assert.Equal(t, Import, *current)
 }
 
-func TestMockSchemaRegistryClient_GetCompatibilityLevel_IsNotImplemented(t *testing.T) {
+func TestMockSchemaRegistryClient_GlobalConfigAndReset(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, _ = registry.ChangeSubjectCompatibilityLevel(context.Background(), "cupcake", None)
 
 	// Act
-	result, err := registry.GetCompatibilityLevel(context.Background(), "", false)
+	globalConfig, globalErr := registry.GetGlobalConfig(context.Background())
+	removed, resetErr := registry.ResetSubjectConfig(context.Background(), "cupcake")
+	_, resetAgainErr := registry.ResetSubjectConfig(context.Background(), "cupcake")
 
 	// Assert
-	assert.Nil(t, result)
-	assert.ErrorIs(t, err, errNotImplemented)
+	assert.NoError(t, globalErr)
+	assert.Equal(t, &Config{CompatibilityLevel: Backward}, globalConfig)
+	assert.NoError(t, resetErr)
+	assert.Equal(t, &Config{CompatibilityLevel: None}, removed)
+	assert.True(t, isNotFoundError(resetAgainErr))
+	level, _ := registry.GetCompatibilityLevel(context.Background(), "cupcake", true)
+	assert.Equal(t, Backward, *level)
 }
 
-func TestMockSchemaRegistryClient_IsSchemaCompatible_IsNotImplemented(t *testing.T) {
+func TestMockSchemaRegistryClient_ChangeGlobalCompatibilityLevel(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
 
 	// Act
-	result, err := registry.IsSchemaCompatible(context.Background(), "", "", "", "")
+	changed, changeErr := registry.ChangeGlobalCompatibilityLevel(context.Background(), Forward)
+	_, invalidErr := registry.ChangeGlobalCompatibilityLevel(context.Background(), "SIDEWAYS")
 
 	// Assert
-	assert.False(t, result)
-	assert.ErrorIs(t, err, errNotImplemented)
+	assert.NoError(t, changeErr)
+	assert.Equal(t, Forward, *changed)
+	assert.ErrorIs(t, invalidErr, errInvalidCompatibility)
+	global, _ := registry.GetGlobalCompatibilityLevel(context.Background())
+	assert.Equal(t, Forward, *global)
+	level, _ := registry.GetCompatibilityLevel(context.Background(), "cupcake", true)
+	assert.Equal(t, Forward, *level)
 }
 
-func TestMockSchemaRegistryClient_LookupSchema_IsNotImplemented(t *testing.T) {
+func TestMockSchemaRegistryClient_DeleteSubjectCompatibilityLevel(t *testing.T) {
 	t.Parallel()
 	// Arrange
 	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, _ = registry.ChangeSubjectCompatibilityLevel(context.Background(), "cupcake", Full)
 
 	// Act
-	result, err := registry.LookupSchema(context.Background(), "", "", "")
+	removed, deleteErr := registry.DeleteSubjectCompatibilityLevel(context.Background(), "cupcake")
+	_, deleteAgainErr := registry.DeleteSubjectCompatibilityLevel(context.Background(), "cupcake")
 
 	// Assert
-	assert.Nil(t, result)
-	assert.ErrorIs(t, err, errNotImplemented)
+	assert.NoError(t, deleteErr)
+	assert.Equal(t, Full, *removed)
+	assert.True(t, isNotFoundError(deleteAgainErr))
+	level, _ := registry.GetCompatibilityLevel(context.Background(), "cupcake", true)
+	assert.Equal(t, Backward, *level)
+}
+
+func TestMockSchemaRegistryClient_IsSchemaCompatible(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+	withDefault := `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int", "default": 1}]}`
+	withoutDefault := `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int"}]}`
+
+	// Act
+	compatible, compatibleErr := registry.IsSchemaCompatible(context.Background(), "cupcake", withDefault, "latest", Avro)
+	incompatible, incompatibleErr := registry.IsSchemaCompatible(context.Background(), "cupcake", withoutDefault, "1", Avro)
+	_, _ = registry.ChangeSubjectCompatibilityLevel(context.Background(), "cupcake", None)
+	unchecked, uncheckedErr := registry.IsSchemaCompatible(context.Background(), "cupcake", withoutDefault, "latest", Avro)
+	_, missingErr := registry.IsSchemaCompatible(context.Background(), "pie", withDefault, "latest", Avro)
+
+	// Assert
+	assert.NoError(t, compatibleErr)
+	assert.True(t, compatible)
+	assert.NoError(t, incompatibleErr)
+	assert.False(t, incompatible)
+	assert.NoError(t, uncheckedErr)
+	assert.True(t, unchecked)
+	assert.True(t, isNotFoundError(missingErr))
+}
+
+func TestMockSchemaRegistryClient_LookupSchema_ReturnsRegisteredSchema(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, _ := registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+
+	// Act
+	result, err := registry.LookupSchema(context.Background(), "cupcake", testSchema1, Avro)
+
+	// Assert
+	assert.Nil(t, err)
+	assert.Same(t, schema, result)
+}
+
+func TestMockSchemaRegistryClient_LookupSchema_ReturnsErrorOnNotFound(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+
+	// Act
+	_, subjectErr := registry.LookupSchema(context.Background(), "bakery", testSchema1, Avro)
+	_, schemaErr := registry.LookupSchema(context.Background(), "cupcake", testSchema2, Avro)
+	_, typeErr := registry.LookupSchema(context.Background(), "cupcake", testSchema1, "")
+
+	// Assert
+	assert.ErrorIs(t, subjectErr, errSubjectNotFound)
+	assert.ErrorIs(t, schemaErr, errSchemaNotFound)
+	assert.ErrorIs(t, typeErr, errInvalidSchemaType)
+}
+
+func TestMockSchemaRegistryClient_SubjectAndVersionExists(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, _ = registry.SetSchema(context.Background(), 1, "cupcake", testSchema1, Avro, 3)
+
+	// Act & Assert
+	exists, err := registry.SubjectExists(context.Background(), "cupcake")
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	exists, err = registry.SubjectExists(context.Background(), "bakery")
+	assert.Nil(t, err)
+	assert.False(t, exists)
+
+	exists, err = registry.VersionExists(context.Background(), "cupcake", 3)
+	assert.Nil(t, err)
+	assert.True(t, exists)
+
+	exists, err = registry.VersionExists(context.Background(), "cupcake", 1)
+	assert.Nil(t, err)
+	assert.False(t, exists)
 }
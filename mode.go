@@ -0,0 +1,127 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+)
+
+// Mode is the mode of the registry or of a subject, it tells which
+// changes the registry accepts.
+type Mode string
+
+const (
+	// ReadWrite accepts registrations, the default mode.
+	ReadWrite Mode = "READWRITE"
+	// ReadOnly refuses registrations and deletions, to freeze a registry
+	// during a migration or a failover.
+	ReadOnly Mode = "READONLY"
+	// Import accepts registrations with the IDs and versions given, to
+	// copy the schemas of another registry.
+	Import Mode = "IMPORT"
+)
+
+const modeBySubject = "/mode/%s"
+
+type modeRequest struct {
+	Mode Mode `json:"mode"`
+}
+
+type modeResponse modeRequest
+
+// GetMode returns the mode of the registry. Registries without the
+// /mode endpoints answer with an error wrapping ErrFeatureNotSupported.
+func (client *SchemaRegistryClient) GetMode(ctx context.Context) (*Mode, error) {
+	return client.getMode(ctx, mode)
+}
+
+// SetMode changes the mode of the registry. Registries only switch to
+// IMPORT while they hold no schemas. Clients confined to some subjects by
+// WithSubjectPrefix or the Write list of their access policy are refused
+// with a PolicyError.
+func (client *SchemaRegistryClient) SetMode(ctx context.Context, newMode Mode) (*Mode, error) {
+	if err := client.authorizeGlobal(); err != nil {
+		return nil, err
+	}
+	return client.setMode(ctx, "", mode, newMode)
+}
+
+// GetSubjectMode returns the mode of the subject. If defaultToGlobal is
+// set to true and the subject has no mode, the mode of the registry is
+// returned.
+func (client *SchemaRegistryClient) GetSubjectMode(ctx context.Context, subject string, defaultToGlobal bool) (*Mode, error) {
+	if err := client.authorize(subject, false); err != nil {
+		return nil, err
+	}
+	return client.getMode(ctx, fmt.Sprintf(modeBySubject+"?defaultToGlobal=%t", url.QueryEscape(client.prefixed(subject)), defaultToGlobal))
+}
+
+// SetSubjectMode changes the mode of the subject, such as making it
+// READONLY while it is being migrated.
+func (client *SchemaRegistryClient) SetSubjectMode(ctx context.Context, subject string, newMode Mode) (*Mode, error) {
+	if err := client.authorize(subject, true); err != nil {
+		return nil, err
+	}
+	return client.setMode(ctx, subject, fmt.Sprintf(modeBySubject, url.QueryEscape(client.prefixed(subject))), newMode)
+}
+
+func (client *SchemaRegistryClient) getMode(ctx context.Context, uri string) (*Mode, error) {
+	if err := client.requireFeature(ctx, modeFeature); err != nil {
+		return nil, err
+	}
+	resp, err := client.httpRequest(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, modeError(err)
+	}
+
+	var modeResp = new(modeResponse)
+	if err := json.Unmarshal(resp, &modeResp); err != nil {
+		return nil, err
+	}
+	return &modeResp.Mode, nil
+}
+
+func (client *SchemaRegistryClient) setMode(ctx context.Context, subject, uri string, newMode Mode) (*Mode, error) {
+	if !validMode(newMode) {
+		return nil, fmt.Errorf("invalid mode %q. valid values are READWRITE, READONLY or IMPORT", newMode)
+	}
+	if err := client.requireFeature(ctx, modeFeature); err != nil {
+		return nil, err
+	}
+
+	modeReqBytes, err := json.Marshal(modeRequest{Mode: newMode})
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.httpRequest(ctx, "PUT", uri, bytes.NewBuffer(modeReqBytes))
+	client.audit(ctx, AuditEvent{Operation: AuditChangeMode, Subject: subject, Mode: newMode}, err)
+	if err != nil {
+		return nil, modeError(err)
+	}
+
+	var modeResp = new(modeResponse)
+	if err := json.Unmarshal(resp, &modeResp); err != nil {
+		return nil, err
+	}
+	return &modeResp.Mode, nil
+}
+
+func validMode(mode Mode) bool {
+	switch mode {
+	case ReadWrite, ReadOnly, Import:
+		return true
+	}
+	return false
+}
+
+// modeError tells registries without the /mode endpoints apart, their
+// errors wrapping ErrFeatureNotSupported. Subjects without a mode are
+// reported by Confluent registries with their own error code.
+func modeError(err error) error {
+	if isUnsupportedEndpointError(err) {
+		return newKindError(ErrFeatureNotSupported, ErrFeatureNotSupported.Error(), err)
+	}
+	return err
+}
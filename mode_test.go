@@ -0,0 +1,155 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_Mode(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		call         func(client *SchemaRegistryClient) (*Mode, error)
+		expectedReq  string
+		expectedBody string
+	}{
+		"get mode": {
+			call:        func(client *SchemaRegistryClient) (*Mode, error) { return client.GetMode(context.Background()) },
+			expectedReq: "GET /mode",
+		},
+		"set mode": {
+			call: func(client *SchemaRegistryClient) (*Mode, error) {
+				return client.SetMode(context.Background(), ReadOnly)
+			},
+			expectedReq:  "PUT /mode",
+			expectedBody: `{"mode":"READONLY"}`,
+		},
+		"get subject mode": {
+			call: func(client *SchemaRegistryClient) (*Mode, error) {
+				return client.GetSubjectMode(context.Background(), "test1-value", true)
+			},
+			expectedReq: "GET /mode/test1-value?defaultToGlobal=true",
+		},
+		"set subject mode": {
+			call: func(client *SchemaRegistryClient) (*Mode, error) {
+				return client.SetSubjectMode(context.Background(), "test1-value", ReadOnly)
+			},
+			expectedReq:  "PUT /mode/test1-value",
+			expectedBody: `{"mode":"READONLY"}`,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var request, body string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				request = req.Method + " " + req.URL.RequestURI()
+				content, _ := io.ReadAll(req.Body)
+				body = string(content)
+				_ = json.NewEncoder(rw).Encode(modeResponse{Mode: ReadOnly})
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL)
+
+			// Act
+			mode, err := testData.call(srClient)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, ReadOnly, *mode)
+			assert.Equal(t, testData.expectedReq, request)
+			if testData.expectedBody != "" {
+				assert.JSONEq(t, testData.expectedBody, body)
+			}
+		})
+	}
+}
+
+func TestSchemaRegistryClient_ModeErrors(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var events []AuditEvent
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodGet && req.URL.Path == "/mode":
+			_ = json.NewEncoder(rw).Encode(modeResponse{Mode: ReadWrite})
+		case req.URL.Path == "/subjects":
+			_, _ = rw.Write([]byte(`[]`))
+		case req.URL.Path == "/mode":
+			// Registries only reading the mode
+			rw.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{"error_code": 404, "message": "HTTP 404 Not Found"})
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(rw).Encode(map[string]interface{}{"error_code": 40409, "message": "Subject 'test1-value' does not have subject-level mode configured"})
+		}
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithAuditSink(AuditSinkFunc(func(ctx context.Context, event AuditEvent) {
+		events = append(events, event)
+	})))
+
+	// Act
+	_, unsupportedErr := srClient.SetMode(context.Background(), Import)
+	_, notFoundErr := srClient.GetSubjectMode(context.Background(), "test1-value", false)
+	_, invalidErr := srClient.SetMode(context.Background(), "WRITEONLY")
+
+	// Assert
+	assert.ErrorIs(t, unsupportedErr, ErrFeatureNotSupported)
+	assert.NotErrorIs(t, notFoundErr, ErrFeatureNotSupported)
+	assert.True(t, isNotFoundError(notFoundErr))
+	assert.ErrorContains(t, invalidErr, "invalid mode")
+	require.Len(t, events, 1)
+	assert.Equal(t, AuditChangeMode, events[0].Operation)
+	assert.Equal(t, Import, events[0].Mode)
+	assert.Error(t, events[0].Err)
+}
+
+func TestSchemaRegistryClient_SetModeConfined(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		options []Option
+	}{
+		"subject prefix": {
+			options: []Option{WithSubjectPrefix("team-a.")},
+		},
+		"write allowlist": {
+			options: []Option{WithAccessPolicy(AccessPolicy{Write: []string{"orders-*"}})},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var requests []string
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				requests = append(requests, req.Method+" "+req.URL.RequestURI())
+				_ = json.NewEncoder(rw).Encode(modeResponse{Mode: ReadOnly})
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL, testData.options...)
+
+			// Act
+			_, err := srClient.SetMode(context.Background(), ReadOnly)
+
+			// Assert
+			var policyErr *PolicyError
+			require.True(t, errors.As(err, &policyErr))
+			assert.Equal(t, PolicyError{Write: true}, *policyErr)
+			assert.Empty(t, requests)
+		})
+	}
+}
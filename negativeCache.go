@@ -0,0 +1,84 @@
+package srclient
+
+import (
+	"sync"
+	"time"
+)
+
+// WithNegativeCaching remembers for ttl that a schema ID, subject or
+// version wasn't found, so repeated lookups of missing schemas get the
+// not found error without reaching the registry. Registering a schema
+// forgets them, as does ForgetNotFound.
+func WithNegativeCaching(ttl time.Duration) Option {
+	return func(options *clientOptions) {
+		options.negativeCacheTTL = ttl
+	}
+}
+
+// ForgetNotFound forgets the schemas remembered as not found by the
+// negative cache, to see schemas registered by other clients without
+// waiting for its TTL.
+func (client *SchemaRegistryClient) ForgetNotFound() {
+	client.notFound.reset()
+}
+
+type notFoundEntry struct {
+	err      error
+	cachedAt time.Time
+}
+
+// notFoundCache holds the not found errors of the requests, by URI.
+type notFoundCache struct {
+	lock sync.Mutex
+	// ttl is zero or less when not found errors aren't cached
+	ttl     time.Duration
+	entries map[string]notFoundEntry
+}
+
+// get returns the not found error of the URI if it is still fresh.
+func (cache *notFoundCache) get(uri string, now time.Time) error {
+	if cache.ttl <= 0 {
+		return nil
+	}
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	entry, ok := cache.entries[uri]
+	if !ok {
+		return nil
+	}
+	if now.Sub(entry.cachedAt) >= cache.ttl {
+		delete(cache.entries, uri)
+		return nil
+	}
+	return entry.err
+}
+
+// remember keeps the error of the URI if it is a not found error.
+func (cache *notFoundCache) remember(uri string, err error, now time.Time) {
+	if cache.ttl <= 0 || !isNotFoundError(err) {
+		return
+	}
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if cache.entries == nil {
+		cache.entries = make(map[string]notFoundEntry)
+	}
+	cache.entries[uri] = notFoundEntry{err: err, cachedAt: now}
+}
+
+// forgetIf forgets the errors of the URIs which match.
+func (cache *notFoundCache) forgetIf(matches func(uri string) bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	for uri := range cache.entries {
+		if matches(uri) {
+			delete(cache.entries, uri)
+		}
+	}
+}
+
+func (cache *notFoundCache) reset() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.entries = nil
+}
@@ -0,0 +1,112 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWithNegativeCaching(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		elapsed  time.Duration
+		forget   bool
+		expected int32
+	}{
+		"within ttl": {
+			elapsed:  time.Second,
+			expected: 1,
+		},
+		"after ttl": {
+			elapsed:  time.Minute,
+			expected: 2,
+		},
+		"forgotten": {
+			elapsed:  time.Second,
+			forget:   true,
+			expected: 2,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var calls int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				atomic.AddInt32(&calls, 1)
+				rw.WriteHeader(http.StatusNotFound)
+				_ = json.NewEncoder(rw).Encode(map[string]interface{}{"error_code": 40403, "message": "Schema not found"})
+			}))
+			defer server.Close()
+			clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			srClient := NewClient(server.URL, WithClock(clock), WithNegativeCaching(time.Minute))
+			_, err := srClient.GetSchema(context.Background(), 1)
+			assert.Error(t, err)
+
+			// Act
+			clock.Advance(testData.elapsed)
+			if testData.forget {
+				srClient.ForgetNotFound()
+			}
+			_, err = srClient.GetSchema(context.Background(), 1)
+
+			// Assert
+			assert.True(t, isNotFoundError(err))
+			assert.Equal(t, testData.expected, atomic.LoadInt32(&calls))
+		})
+	}
+}
+
+func TestWithNegativeCaching_BySubjectAndID(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"error_code": 40403, "message": "Schema not found"})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithNegativeCaching(time.Minute))
+	_, err := srClient.GetSchemaBySubjectAndID(context.Background(), "cupcakes-value", 1)
+	assert.Error(t, err)
+
+	// Act
+	_, err = srClient.GetSchemaBySubjectAndID(context.Background(), "cupcakes-value", 1)
+	_, otherErr := srClient.GetSchemaBySubjectAndID(context.Background(), "orders-value", 1)
+
+	// Assert
+	assert.True(t, isNotFoundError(err))
+	assert.True(t, isNotFoundError(otherErr))
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
+
+func TestWithNegativeCaching_OnlyNotFound(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		rw.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithNegativeCaching(time.Minute))
+
+	// Act
+	_, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+	_, againErr := srClient.GetLatestSchema(context.Background(), "test1-value")
+
+	// Assert
+	assert.Error(t, err)
+	assert.Error(t, againErr)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+}
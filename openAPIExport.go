@@ -0,0 +1,296 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+const openAPIComponentRef = "#/components/schemas/"
+
+var openAPINameRegex = regexp.MustCompile(`[^A-Za-z0-9._-]`)
+
+// OpenAPIComponents holds OpenAPI 3.0 component schemas, it marshals
+// to the "components" object of an OpenAPI document.
+type OpenAPIComponents struct {
+	Schemas map[string]interface{} `json:"schemas"`
+}
+
+// ExportOpenAPI converts the latest schema of each subject into OpenAPI
+// component schemas, so HTTP APIs fronting Kafka topics can publish docs
+// straight from the registry. JSON schemas are always converted, Avro
+// schemas only when includeAvro is set, other subjects are skipped.
+// The components are named after the subjects, definitions and Avro
+// named types get their own components, which subjects named after their
+// Avro record share.
+func ExportOpenAPI(ctx context.Context, client ISchemaRegistryClient, subjects []string, includeAvro bool) (*OpenAPIComponents, error) {
+	components := &OpenAPIComponents{Schemas: make(map[string]interface{})}
+	for _, subject := range subjects {
+		schema, err := client.GetLatestSchema(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(schema.Schema()), &parsed); err != nil {
+			return nil, fmt.Errorf("subject %s: %w", subject, err)
+		}
+
+		name := openAPIName(subject)
+		switch schemaTypeOf(schema) {
+		case Json:
+			components.Schemas[name] = components.fromJsonSchema(name, parsed)
+		case Avro:
+			if !includeAvro {
+				continue
+			}
+			converted, err := components.fromAvro(parsed, "")
+			if err != nil {
+				return nil, fmt.Errorf("subject %s: %w", subject, err)
+			}
+			// Subjects named after their record, as with RecordNameStrategy,
+			// already are the component of the record
+			if ref, ok := converted.(map[string]interface{}); ok && ref["$ref"] == openAPIComponentRef+name {
+				continue
+			}
+			components.Schemas[name] = converted
+		}
+	}
+
+	return components, nil
+}
+
+// fromJsonSchema turns a JSON schema into an OpenAPI 3.0 schema: local
+// definitions become components and type unions with null become nullable.
+func (components *OpenAPIComponents) fromJsonSchema(name string, schema interface{}) interface{} {
+	root, ok := schema.(map[string]interface{})
+	if !ok {
+		return schema
+	}
+
+	for _, keyword := range []string{"definitions", "$defs"} {
+		definitions, _ := root[keyword].(map[string]interface{})
+		for definition, definitionSchema := range definitions {
+			components.Schemas[name+"_"+openAPIName(definition)] = convertJsonSchema(name, definitionSchema)
+		}
+	}
+	return convertJsonSchema(name, root)
+}
+
+func convertJsonSchema(name string, schema interface{}) interface{} {
+	switch typed := schema.(type) {
+	case []interface{}:
+		converted := make([]interface{}, len(typed))
+		for i, item := range typed {
+			converted[i] = convertJsonSchema(name, item)
+		}
+		return converted
+	case map[string]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, value := range typed {
+			switch key {
+			case "$schema", "$id", "definitions", "$defs":
+				continue
+			case "$ref":
+				ref, _ := value.(string)
+				for _, prefix := range []string{"#/definitions/", "#/$defs/"} {
+					if strings.HasPrefix(ref, prefix) {
+						ref = openAPIComponentRef + name + "_" + openAPIName(strings.TrimPrefix(ref, prefix))
+					}
+				}
+				converted[key] = ref
+			case "type":
+				types, isUnion := value.([]interface{})
+				if !isUnion {
+					converted[key] = value
+					continue
+				}
+				var nonNull []interface{}
+				for _, t := range types {
+					if t == "null" {
+						converted["nullable"] = true
+					} else {
+						nonNull = append(nonNull, t)
+					}
+				}
+				if len(nonNull) == 1 {
+					converted[key] = nonNull[0]
+				} else if len(nonNull) > 1 {
+					oneOf := make([]interface{}, len(nonNull))
+					for i, t := range nonNull {
+						oneOf[i] = map[string]interface{}{"type": t}
+					}
+					converted["oneOf"] = oneOf
+				}
+			default:
+				converted[key] = convertJsonSchema(name, value)
+			}
+		}
+		return converted
+	default:
+		return schema
+	}
+}
+
+// fromAvro converts an Avro schema, named types are added as components
+// and referenced.
+func (components *OpenAPIComponents) fromAvro(schema interface{}, namespace string) (interface{}, error) {
+	switch typed := schema.(type) {
+	case string:
+		if converted, ok := avroPrimitiveToOpenAPI(typed, ""); ok {
+			return converted, nil
+		}
+		return map[string]interface{}{"$ref": openAPIComponentRef + openAPIName(avroFullName(typed, namespace))}, nil
+	case []interface{}:
+		var options []interface{}
+		nullable := false
+		for _, member := range typed {
+			if member == "null" {
+				nullable = true
+				continue
+			}
+			converted, err := components.fromAvro(member, namespace)
+			if err != nil {
+				return nil, err
+			}
+			options = append(options, converted)
+		}
+		var result map[string]interface{}
+		if len(options) == 1 {
+			result = map[string]interface{}{"allOf": options}
+			if option, ok := options[0].(map[string]interface{}); ok && option["$ref"] == nil {
+				result = option
+			}
+		} else {
+			result = map[string]interface{}{"oneOf": options}
+		}
+		if nullable {
+			result["nullable"] = true
+		}
+		return result, nil
+	case map[string]interface{}:
+		return components.fromAvroComplex(typed, namespace)
+	default:
+		return nil, fmt.Errorf("invalid Avro schema %v", schema)
+	}
+}
+
+func (components *OpenAPIComponents) fromAvroComplex(schema map[string]interface{}, namespace string) (interface{}, error) {
+	typeName, ok := schema["type"].(string)
+	if !ok {
+		return components.fromAvro(schema["type"], namespace)
+	}
+	logicalType, _ := schema["logicalType"].(string)
+	if converted, ok := avroPrimitiveToOpenAPI(typeName, logicalType); ok {
+		return converted, nil
+	}
+
+	switch typeName {
+	case "array":
+		items, err := components.fromAvro(schema["items"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "array", "items": items}, nil
+	case "map":
+		values, err := components.fromAvro(schema["values"], namespace)
+		if err != nil {
+			return nil, err
+		}
+		return map[string]interface{}{"type": "object", "additionalProperties": values}, nil
+	case "record", "error", "enum", "fixed":
+	default:
+		return components.fromAvro(typeName, namespace)
+	}
+
+	name, _ := schema["name"].(string)
+	if ns, ok := schema["namespace"].(string); ok {
+		namespace = ns
+	}
+	fullName := avroFullName(name, namespace)
+	if idx := strings.LastIndex(fullName, "."); idx >= 0 {
+		namespace = fullName[:idx]
+	}
+
+	converted := map[string]interface{}{}
+	if doc, ok := schema["doc"].(string); ok {
+		converted["description"] = doc
+	}
+	switch typeName {
+	case "enum":
+		converted["type"] = "string"
+		converted["enum"] = schema["symbols"]
+	case "fixed":
+		converted["type"] = "string"
+		converted["format"] = "byte"
+	default:
+		converted["type"] = "object"
+		properties := map[string]interface{}{}
+		var required []interface{}
+		fields, _ := schema["fields"].([]interface{})
+		for _, field := range fields {
+			fieldMap, ok := field.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid Avro field %v", field)
+			}
+			fieldName, _ := fieldMap["name"].(string)
+			property, err := components.fromAvro(fieldMap["type"], namespace)
+			if err != nil {
+				return nil, err
+			}
+			if doc, ok := fieldMap["doc"].(string); ok {
+				if propertyMap, ok := property.(map[string]interface{}); ok && propertyMap["$ref"] == nil {
+					propertyMap["description"] = doc
+				}
+			}
+			properties[fieldName] = property
+			if _, hasDefault := fieldMap["default"]; !hasDefault {
+				required = append(required, fieldName)
+			}
+		}
+		converted["properties"] = properties
+		if len(required) > 0 {
+			converted["required"] = required
+		}
+	}
+
+	componentName := openAPIName(fullName)
+	components.Schemas[componentName] = converted
+	return map[string]interface{}{"$ref": openAPIComponentRef + componentName}, nil
+}
+
+// avroPrimitiveToOpenAPI converts the primitive Avro types, logical
+// types are kept as format to document the meaning of the value.
+func avroPrimitiveToOpenAPI(typeName, logicalType string) (map[string]interface{}, bool) {
+	var converted map[string]interface{}
+	switch typeName {
+	case "null":
+		converted = map[string]interface{}{"nullable": true}
+	case "boolean":
+		converted = map[string]interface{}{"type": "boolean"}
+	case "int":
+		converted = map[string]interface{}{"type": "integer", "format": "int32"}
+	case "long":
+		converted = map[string]interface{}{"type": "integer", "format": "int64"}
+	case "float":
+		converted = map[string]interface{}{"type": "number", "format": "float"}
+	case "double":
+		converted = map[string]interface{}{"type": "number", "format": "double"}
+	case "bytes":
+		converted = map[string]interface{}{"type": "string", "format": "byte"}
+	case "string":
+		converted = map[string]interface{}{"type": "string"}
+	default:
+		return nil, false
+	}
+	if logicalType != "" {
+		converted["format"] = logicalType
+	}
+	return converted, true
+}
+
+func openAPIName(name string) string {
+	return openAPINameRegex.ReplaceAllString(name, "_")
+}
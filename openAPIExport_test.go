@@ -0,0 +1,87 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestExportOpenAPI(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "orders-value", `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"definitions": {"flavor": {"type": "string", "enum": ["vanilla"]}},
+		"properties": {
+			"flavor": {"$ref": "#/definitions/flavor"},
+			"note": {"type": ["string", "null"]}
+		}
+	}`, Json)
+	require.NoError(t, err)
+	_, err = registry.CreateSchema(context.Background(), "cupcakes-value", `{
+		"type": "record", "name": "cupcake", "namespace": "com.bakery",
+		"fields": [
+			{"name": "flavor", "type": "string", "doc": "The flavor"},
+			{"name": "topping", "type": ["null", {"type": "enum", "name": "Topping", "symbols": ["SPRINKLES"]}], "default": null},
+			{"name": "bakedAt", "type": {"type": "long", "logicalType": "timestamp-millis"}}
+		]
+	}`, Avro)
+	require.NoError(t, err)
+
+	// Act
+	withoutAvro, err := ExportOpenAPI(context.Background(), registry, []string{"orders-value", "cupcakes-value"}, false)
+	require.NoError(t, err)
+	components, err := ExportOpenAPI(context.Background(), registry, []string{"orders-value", "cupcakes-value"}, true)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Len(t, withoutAvro.Schemas, 2)
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"flavor": map[string]interface{}{"$ref": "#/components/schemas/orders-value_flavor"},
+			"note":   map[string]interface{}{"type": "string", "nullable": true},
+		},
+	}, components.Schemas["orders-value"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": []interface{}{"vanilla"}}, components.Schemas["orders-value_flavor"])
+
+	assert.Equal(t, map[string]interface{}{"$ref": "#/components/schemas/com.bakery.cupcake"}, components.Schemas["cupcakes-value"])
+	assert.Equal(t, map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"flavor":  map[string]interface{}{"type": "string", "description": "The flavor"},
+			"topping": map[string]interface{}{"allOf": []interface{}{map[string]interface{}{"$ref": "#/components/schemas/com.bakery.Topping"}}, "nullable": true},
+			"bakedAt": map[string]interface{}{"type": "integer", "format": "timestamp-millis"},
+		},
+		"required": []interface{}{"flavor", "bakedAt"},
+	}, components.Schemas["com.bakery.cupcake"])
+	assert.Equal(t, map[string]interface{}{"type": "string", "enum": []interface{}{"SPRINKLES"}}, components.Schemas["com.bakery.Topping"])
+}
+
+func TestExportOpenAPI_RecordNameSubjects(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "com.bakery.cupcake", `{
+		"type": "record", "name": "cupcake", "namespace": "com.bakery",
+		"fields": [{"name": "flavor", "type": "string"}]
+	}`, Avro)
+	require.NoError(t, err)
+
+	// Act
+	components, err := ExportOpenAPI(context.Background(), registry, []string{"com.bakery.cupcake"}, true)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, map[string]interface{}{
+		"com.bakery.cupcake": map[string]interface{}{
+			"type":       "object",
+			"properties": map[string]interface{}{"flavor": map[string]interface{}{"type": "string"}},
+			"required":   []interface{}{"flavor"},
+		},
+	}, components.Schemas)
+}
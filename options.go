@@ -0,0 +1,236 @@
+package srclient
+
+import (
+	"net/http"
+	"time"
+)
+
+// Option configures a client created with NewClient.
+type Option func(*clientOptions)
+
+type clientOptions struct {
+	httpClient             *http.Client
+	timeout                time.Duration
+	readWeight             int
+	writeWeight            int // zero when reads and writes share readWeight
+	credentials            *credentials
+	cachingEnabled         bool
+	cacheLatest            bool
+	codecCreationEnabled   bool
+	maxResponseBytes       int64
+	clock                  Clock
+	checksumPinning        bool
+	auditSink              AuditSink
+	approver               Approver
+	subjectPrefix          string
+	requestBudget          *RequestBudget
+	accessPolicy           *AccessPolicy
+	subjectLocker          SubjectLocker
+	headers                http.Header
+	headerFuncs            []HeaderFunc
+	proxyURL               string
+	writeTimeout           time.Duration
+	dialTimeout            time.Duration
+	tlsHandshakeTimeout    time.Duration
+	responseHeaderTimeout  time.Duration
+	endpoints              []string
+	endpointsInterval      time.Duration
+	cacheTTL               time.Duration
+	cacheCapacity          int
+	readYourWrites         time.Duration
+	leaderWrites           bool
+	leaderDetector         LeaderDetector
+	retry                  retryPolicy
+	maxSchemaBytes         int
+	maxResponseHeaderBytes int64
+	writeBufferSize        int
+	readBufferSize         int
+	minifySchemas          bool
+	negativeCacheTTL       time.Duration
+	streamProcessors       []StreamProcessor
+	subjectVersions        bool
+}
+
+// NewClient creates a client configured once and for all by the given
+// options, applied in order, rather than by setters called after it is
+// created and possibly already shared between goroutines. Without
+// options the client behaves like one made by CreateSchemaRegistryClient.
+func NewClient(schemaRegistryURL string, opts ...Option) *SchemaRegistryClient {
+	options := clientOptions{
+		readWeight:     16,
+		cachingEnabled: true,
+		clock:          systemClock{},
+	}
+	for _, opt := range opts {
+		opt(&options)
+	}
+
+	httpClient := &http.Client{Timeout: 5 * time.Second}
+	if options.httpClient != nil {
+		httpClient = options.httpClient
+	}
+	if options.timeout > 0 {
+		// Don't change the timeout of a client the caller may share.
+		withTimeout := *httpClient
+		withTimeout.Timeout = options.timeout
+		httpClient = &withTimeout
+	}
+	httpClient, transportErr := configureTransport(httpClient, &options)
+
+	readSem := newRequestSemaphore(options.readWeight)
+	writeSem := readSem
+	if options.writeWeight > 0 {
+		writeSem = newRequestSemaphore(options.writeWeight)
+	}
+
+	client := newSchemaRegistryClient(schemaRegistryURL, httpClient, readSem, writeSem)
+	if client.urlErr == nil {
+		client.urlErr = transportErr
+	}
+	client.credentials = options.credentials
+	client.writeTimeout = options.writeTimeout
+	client.cacheTTL = options.cacheTTL
+	client.readYourWrites = options.readYourWrites
+	client.retry = options.retry
+	client.maxSchemaBytes = options.maxSchemaBytes
+	client.minifySchemas = options.minifySchemas
+	client.notFound.ttl = options.negativeCacheTTL
+	client.streamProcessors = options.streamProcessors
+	client.subjectVersions = options.subjectVersions
+	if options.leaderWrites {
+		client.leader = &leaderRouter{detector: options.leaderDetector}
+	}
+	client.idSchemaCache = newSchemaCache(options.cacheCapacity)
+	client.subjectSchemaCache = newSchemaCache(options.cacheCapacity)
+	client.stale.capacity = options.cacheCapacity
+	if len(options.endpoints) > 0 {
+		urls := append([]string{schemaRegistryURL}, options.endpoints...)
+		for _, url := range options.endpoints {
+			if err := ValidateSchemaRegistryURL(url); err != nil && client.urlErr == nil {
+				client.urlErr = err
+			}
+		}
+		client.endpoints = newEndpointSelector(options.endpointsInterval, urls)
+	}
+	client.cachingEnabled = options.cachingEnabled
+	client.cacheLatest = options.cacheLatest
+	client.codecCreationEnabled = options.codecCreationEnabled
+	client.maxResponseBytes = options.maxResponseBytes
+	client.clock = options.clock
+	client.checksums.enabled = options.checksumPinning
+	client.auditSink = options.auditSink
+	client.approver = options.approver
+	client.subjectPrefix = options.subjectPrefix
+	client.accessPolicy = options.accessPolicy
+	client.subjectLocker = options.subjectLocker
+	client.headers = options.headers
+	client.headerFuncs = options.headerFuncs
+	if options.requestBudget != nil {
+		client.budget = &requestBudget{budget: *options.requestBudget}
+	}
+	return client
+}
+
+// WithHTTPClient sets the http.Client used to reach the registry.
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(options *clientOptions) {
+		options.httpClient = httpClient
+	}
+}
+
+// WithTimeout sets how long requests to the registry may take, it
+// defaults to five seconds. The http.Client given to WithHTTPClient is
+// copied rather than changed.
+func WithTimeout(timeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.timeout = timeout
+	}
+}
+
+// WithBasicAuth authenticates the requests with a username and password.
+func WithBasicAuth(username, password string) Option {
+	return func(options *clientOptions) {
+		if len(username) > 0 && len(password) > 0 {
+			options.credentials = &credentials{username: username, password: password}
+		}
+	}
+}
+
+// WithBearerToken authenticates the requests with the tokens of the
+// provider, it overrides WithBasicAuth.
+func WithBearerToken(token TokenProvider) Option {
+	return func(options *clientOptions) {
+		if token != nil {
+			options.credentials = &credentials{bearerToken: token}
+		}
+	}
+}
+
+// WithSemaphoreWeight limits the concurrent requests to the registry,
+// it defaults to 16. Zero disables the limit.
+func WithSemaphoreWeight(weight int) Option {
+	return func(options *clientOptions) {
+		options.readWeight = weight
+		options.writeWeight = 0
+	}
+}
+
+// WithConcurrencyBudgets limits the concurrent read and write requests
+// separately, like CreateSchemaRegistryClientWithConcurrencyBudgets.
+// SetReadSemaphoreWeight and SetWriteSemaphoreWeight change them later.
+func WithConcurrencyBudgets(readWeight, writeWeight int) Option {
+	return func(options *clientOptions) {
+		options.readWeight = readWeight
+		options.writeWeight = writeWeight
+	}
+}
+
+// WithCaching enables or disables the schema caches, they are enabled
+// by default.
+func WithCaching(enabled bool) Option {
+	return func(options *clientOptions) {
+		options.cachingEnabled = enabled
+	}
+}
+
+// WithCacheTTL makes the cached schemas expire ttl after they were
+// fetched, for long-lived services to eventually see schemas changed in
+// the registry, such as metadata updates, without calling ResetCache.
+// Zero, the default, keeps them until the cache is reset.
+func WithCacheTTL(ttl time.Duration) Option {
+	return func(options *clientOptions) {
+		options.cacheTTL = ttl
+	}
+}
+
+// WithCacheLatest controls if the latest versions of subjects are cached.
+func WithCacheLatest(enabled bool) Option {
+	return func(options *clientOptions) {
+		options.cacheLatest = enabled
+	}
+}
+
+// WithCodecCreation enables the creation of Avro codecs for the
+// schemas returned.
+func WithCodecCreation(enabled bool) Option {
+	return func(options *clientOptions) {
+		options.codecCreationEnabled = enabled
+	}
+}
+
+// WithMaxResponseBytes limits the size of the responses read from the
+// registry, like SetMaxResponseBytes.
+func WithMaxResponseBytes(max int64) Option {
+	return func(options *clientOptions) {
+		options.maxResponseBytes = max
+	}
+}
+
+// WithClock sets the clock used by the client, like SetClock.
+func WithClock(clock Clock) Option {
+	return func(options *clientOptions) {
+		if clock != nil {
+			options.clock = clock
+		}
+	}
+}
@@ -0,0 +1,128 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewClient_Defaults(t *testing.T) {
+	t.Parallel()
+	srClient := NewClient("http://localhost:8081")
+
+	assert.Equal(t, 5*time.Second, srClient.httpClient.Timeout)
+	assert.Same(t, srClient.readSem, srClient.writeSem)
+	assert.True(t, srClient.getCachingEnabled())
+	assert.False(t, srClient.getCacheLatest())
+	assert.False(t, srClient.getCodecCreationEnabled())
+	assert.Nil(t, srClient.credentials)
+}
+
+func TestNewClient_Options(t *testing.T) {
+	t.Parallel()
+	httpClient := &http.Client{Timeout: time.Second}
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+
+	srClient := NewClient("http://localhost:8081",
+		WithHTTPClient(httpClient),
+		WithTimeout(time.Minute),
+		WithConcurrencyBudgets(4, 2),
+		WithCaching(false),
+		WithCacheLatest(true),
+		WithCodecCreation(true),
+		WithMaxResponseBytes(1024),
+		WithClock(clock),
+	)
+
+	assert.Equal(t, time.Minute, srClient.httpClient.Timeout)
+	assert.Equal(t, time.Second, httpClient.Timeout)
+	assert.NotSame(t, srClient.readSem, srClient.writeSem)
+	assert.False(t, srClient.getCachingEnabled())
+	assert.True(t, srClient.getCacheLatest())
+	assert.True(t, srClient.getCodecCreationEnabled())
+	assert.Equal(t, int64(1024), srClient.getMaxResponseBytes())
+	assert.Equal(t, clock, srClient.getClock())
+}
+
+func TestNewClient_Authentication(t *testing.T) {
+	t.Parallel()
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		authorization = req.Header.Get("Authorization")
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+
+	tests := map[string]struct {
+		opts     []Option
+		expected string
+	}{
+		"basic auth": {
+			opts:     []Option{WithBasicAuth("user", "secret")},
+			expected: "Basic dXNlcjpzZWNyZXQ=",
+		},
+		"bearer token overrides basic auth": {
+			opts:     []Option{WithBasicAuth("user", "secret"), WithBearerToken(StaticToken("token"))},
+			expected: "Bearer token",
+		},
+		"no credentials": {
+			expected: "",
+		},
+	}
+
+	for name, testData := range tests {
+		srClient := NewClient(server.URL, testData.opts...)
+
+		_, err := srClient.GetSchema(context.Background(), 1)
+
+		require.NoError(t, err, name)
+		assert.Equal(t, testData.expected, authorization, name)
+	}
+}
+
+func TestWithCacheTTL(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		elapsed       time.Duration
+		expectedCalls int
+	}{
+		"fresh":   {elapsed: 59 * time.Second, expectedCalls: 2},
+		"expired": {elapsed: time.Minute, expectedCalls: 4},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				calls++
+				_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Version: 1, Schema: testSchema1})
+			}))
+			defer server.Close()
+			clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			srClient := NewClient(server.URL, WithClock(clock), WithCacheTTL(time.Minute))
+			_, err := srClient.GetSchema(context.Background(), 1)
+			require.NoError(t, err)
+			_, err = srClient.GetSchemaByVersion(context.Background(), "test1-value", 1)
+			require.NoError(t, err)
+
+			// Act
+			clock.Advance(testData.elapsed)
+			_, byIDErr := srClient.GetSchema(context.Background(), 1)
+			_, byVersionErr := srClient.GetSchemaByVersion(context.Background(), "test1-value", 1)
+
+			// Assert
+			require.NoError(t, byIDErr)
+			require.NoError(t, byVersionErr)
+			assert.Equal(t, testData.expectedCalls, calls)
+		})
+	}
+}
@@ -0,0 +1,72 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// PayloadProcessor transforms the body of serialized records, the part
+// following the wire format header, for instance to compress, encrypt
+// or wrap it in an envelope. The header is left untouched so consumers
+// can still tell which schema a record was written with. Unprocess
+// reverts Process before the body is deserialized.
+type PayloadProcessor interface {
+	Process(ctx context.Context, subject string, body []byte) ([]byte, error)
+	Unprocess(ctx context.Context, subject string, body []byte) ([]byte, error)
+}
+
+// subjectProcessors holds the chains of processors configured per
+// subject, the zero value is ready to use.
+type subjectProcessors struct {
+	lock      sync.RWMutex
+	bySubject map[string][]PayloadProcessor
+}
+
+func (processors *subjectProcessors) set(subject string, chain []PayloadProcessor) {
+	processors.lock.Lock()
+	defer processors.lock.Unlock()
+	if len(chain) == 0 {
+		delete(processors.bySubject, subject)
+		return
+	}
+	if processors.bySubject == nil {
+		processors.bySubject = make(map[string][]PayloadProcessor)
+	}
+	processors.bySubject[subject] = append([]PayloadProcessor(nil), chain...)
+}
+
+func (processors *subjectProcessors) get(subject string) []PayloadProcessor {
+	processors.lock.RLock()
+	defer processors.lock.RUnlock()
+	return processors.bySubject[subject]
+}
+
+// process runs the chain of the subject over the body of the payload,
+// in order.
+func (processors *subjectProcessors) process(ctx context.Context, subject string, payload []byte) ([]byte, error) {
+	chain := processors.get(subject)
+	if len(chain) == 0 {
+		return payload, nil
+	}
+	body := payload[wireHeaderLength:]
+	for _, processor := range chain {
+		var err error
+		if body, err = processor.Process(ctx, subject, body); err != nil {
+			return nil, fmt.Errorf("processing payload of subject %s: %w", subject, err)
+		}
+	}
+	return append(payload[:wireHeaderLength:wireHeaderLength], body...), nil
+}
+
+// unprocess reverts the chain of the subject over a body, in reverse order.
+func (processors *subjectProcessors) unprocess(ctx context.Context, subject string, body []byte) ([]byte, error) {
+	chain := processors.get(subject)
+	for i := len(chain) - 1; i >= 0; i-- {
+		var err error
+		if body, err = chain[i].Unprocess(ctx, subject, body); err != nil {
+			return nil, fmt.Errorf("unprocessing payload of subject %s: %w", subject, err)
+		}
+	}
+	return body, nil
+}
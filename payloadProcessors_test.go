@@ -0,0 +1,80 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// envelopeProcessor wraps bodies between a prefix and a suffix.
+type envelopeProcessor struct {
+	prefix, suffix string
+}
+
+func (processor envelopeProcessor) Process(_ context.Context, _ string, body []byte) ([]byte, error) {
+	return []byte(processor.prefix + string(body) + processor.suffix), nil
+}
+
+func (processor envelopeProcessor) Unprocess(_ context.Context, _ string, body []byte) ([]byte, error) {
+	if !bytes.HasPrefix(body, []byte(processor.prefix)) || !bytes.HasSuffix(body, []byte(processor.suffix)) {
+		return nil, errors.New("missing envelope")
+	}
+	return body[len(processor.prefix) : len(body)-len(processor.suffix)], nil
+}
+
+func TestPayloadProcessors_RoundTrip(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	bakery, err := registry.CreateSchema(context.Background(), "bakeries-value", testSchema2, Avro)
+	require.NoError(t, err)
+
+	encoders := NewEncoderRegistry(registry)
+	require.NoError(t, encoders.Register("cupcakes", EncoderConfig{Subject: "cupcakes-value"}))
+	require.NoError(t, encoders.Register("bakeries", EncoderConfig{Subject: "bakeries-value"}))
+	decoders := NewDecoderRegistry(registry)
+	require.NoError(t, decoders.Register("cupcakes", DecoderConfig{}))
+	require.NoError(t, decoders.Register("bakeries", DecoderConfig{SubjectNameStrategy: TopicNameStrategy}))
+
+	chain := []PayloadProcessor{envelopeProcessor{"(", ")"}, envelopeProcessor{"[", "]"}}
+	encoders.SetProcessors("cupcakes-value", chain...)
+	decoders.SetProcessors("cupcakes-value", chain...)
+
+	// Act
+	cupcakePayload, cupcakeErr := encoders.Encode(context.Background(), "cupcakes", map[string]interface{}{"flavor": "vanilla"})
+	bakeryPayload, bakeryErr := encoders.Encode(context.Background(), "bakeries", map[string]interface{}{"number": 3})
+
+	// Assert
+	require.NoError(t, cupcakeErr)
+	_, body, err := parseWireFormat(cupcakePayload)
+	require.NoError(t, err)
+	assert.True(t, bytes.HasPrefix(body, []byte("[(")))
+	assert.True(t, bytes.HasSuffix(body, []byte(")]")))
+	record, err := decoders.Decode(context.Background(), "cupcakes", cupcakePayload)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"flavor": "vanilla"}, record.Value)
+	}
+
+	// Other subjects are left untouched
+	require.NoError(t, bakeryErr)
+	assert.Equal(t, encodeAvroTestRecord(t, bakery, map[string]interface{}{"number": 3}), bakeryPayload)
+	record, err = decoders.Decode(context.Background(), "bakeries", bakeryPayload)
+	if assert.NoError(t, err) {
+		assert.Equal(t, map[string]interface{}{"number": int32(3)}, record.Value)
+	}
+
+	// Unprocessing errors are reported
+	_, err = decoders.Decode(context.Background(), "cupcakes", encodeAvroTestRecord(t, bakery, map[string]interface{}{"number": 3}))
+	assert.Error(t, err)
+
+	// Removed processors
+	decoders.SetProcessors("cupcakes-value")
+	_, err = decoders.Decode(context.Background(), "cupcakes", cupcakePayload)
+	assert.Error(t, err)
+}
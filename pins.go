@@ -0,0 +1,83 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SchemaPin pins a subject to one of its versions or to a schema ID.
+type SchemaPin struct {
+	Version int `yaml:"version,omitempty"`
+	ID      int `yaml:"id,omitempty"`
+}
+
+// SchemaPins maps subjects to the schema serializers and deserializers
+// must use, rather than the latest one, so batch jobs process their
+// input the same way whatever is registered since.
+type SchemaPins map[string]SchemaPin
+
+// LoadSchemaPins reads a pin file, a YAML, or JSON, map of subjects to
+// their pinned version or ID:
+//
+//	orders-value:
+//	  version: 3
+//	payments-value:
+//	  id: 42
+func LoadSchemaPins(path string) (SchemaPins, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	var pins SchemaPins
+	if err := yaml.Unmarshal(content, &pins); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := pins.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return pins, nil
+}
+
+// Validate checks every subject is pinned to either a version or an ID.
+func (pins SchemaPins) Validate() error {
+	subjects := make([]string, 0, len(pins))
+	for subject := range pins {
+		subjects = append(subjects, subject)
+	}
+	sort.Strings(subjects)
+
+	for _, subject := range subjects {
+		pin := pins[subject]
+		if pin.Version < 0 || pin.ID < 0 || (pin.Version > 0) == (pin.ID > 0) {
+			return fmt.Errorf("subject %s must be pinned to either a version or an id", subject)
+		}
+	}
+	return nil
+}
+
+// schema fetches the pinned schema of the subject, it returns nil when
+// the subject isn't pinned. Schemas pinned by ID must be registered
+// under the subject.
+func (pins SchemaPins) schema(ctx context.Context, client ISchemaRegistryClient, subject string) (*Schema, error) {
+	pin, ok := pins[subject]
+	if !ok {
+		return nil, nil
+	}
+
+	var schema *Schema
+	var err error
+	if pin.ID > 0 {
+		schema, err = client.GetSchemaBySubjectAndID(ctx, subject, pin.ID)
+	} else {
+		schema, err = client.GetSchemaByVersion(ctx, subject, pin.Version)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("pinned schema of subject %s: %w", subject, err)
+	}
+	return schema, nil
+}
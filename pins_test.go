@@ -0,0 +1,123 @@
+package srclient
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSchemaPins(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		content     string
+		expected    SchemaPins
+		expectedErr string
+	}{
+		"yaml": {
+			content:  "orders-value:\n  version: 3\npayments-value:\n  id: 42\n",
+			expected: SchemaPins{"orders-value": {Version: 3}, "payments-value": {ID: 42}},
+		},
+		"json": {
+			content:  `{"orders-value": {"version": 3}}`,
+			expected: SchemaPins{"orders-value": {Version: 3}},
+		},
+		"version and id": {
+			content:     "orders-value:\n  version: 3\n  id: 42\n",
+			expectedErr: "subject orders-value must be pinned to either a version or an id",
+		},
+		"neither version nor id": {
+			content:     "orders-value: {}\n",
+			expectedErr: "subject orders-value must be pinned to either a version or an id",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			path := filepath.Join(t.TempDir(), "pins.yaml")
+			require.NoError(t, os.WriteFile(path, []byte(testData.content), 0600))
+
+			pins, err := LoadSchemaPins(path)
+
+			if testData.expectedErr != "" {
+				assert.EqualError(t, err, path+": "+testData.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, pins)
+		})
+	}
+}
+
+func TestSchemaPins_Serde(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	first, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	latest, err := registry.CreateSchema(context.Background(), "cupcakes-value",
+		`{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int", "default": 1}]}`, Avro)
+	require.NoError(t, err)
+
+	serializer := NewSerializer(registry)
+	serializer.SetPins(SchemaPins{"cupcakes-value": {Version: 1}})
+	deserializer := NewDeserializer(registry)
+	deserializer.SetPins(SchemaPins{"cupcakes-value": {ID: first.ID()}})
+
+	// Act
+	pinned, pinnedErr := serializer.Serialize(context.Background(), "cupcakes-value", map[string]interface{}{"flavor": "vanilla"})
+	value, reader, readErr := deserializer.DeserializeSubject(context.Background(), "cupcakes-value",
+		encodeAvroTestRecord(t, latest, map[string]interface{}{"flavor": "lemon", "size": 2}))
+
+	// Assert
+	require.NoError(t, pinnedErr)
+	id, _, err := parseWireFormat(pinned)
+	require.NoError(t, err)
+	assert.Equal(t, first.ID(), id)
+
+	require.NoError(t, readErr)
+	assert.Equal(t, first.ID(), reader.ID())
+	assert.Equal(t, map[string]interface{}{"flavor": "lemon"}, value)
+
+	// Subjects which aren't pinned use the latest and the writer schemas
+	_, err = registry.CreateSchema(context.Background(), "bakeries-value", testSchema2, Avro)
+	require.NoError(t, err)
+	_, err = serializer.Serialize(context.Background(), "bakeries-value", map[string]interface{}{"number": 3})
+	assert.NoError(t, err)
+	_, reader, err = deserializer.DeserializeSubject(context.Background(), "pies-value",
+		encodeAvroTestRecord(t, latest, map[string]interface{}{"flavor": "lemon", "size": 2}))
+	require.NoError(t, err)
+	assert.Equal(t, latest.ID(), reader.ID())
+
+	// Pinned schemas must exist
+	serializer.SetPins(SchemaPins{"cupcakes-value": {Version: 7}})
+	_, err = serializer.Serialize(context.Background(), "cupcakes-value", map[string]interface{}{"flavor": "vanilla"})
+	assert.Error(t, err)
+}
+
+func TestSchemaPins_DeserializeWithUnionReader(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	writer, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	pinned, err := registry.CreateSchema(context.Background(), "cupcakes-value",
+		`{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": ["null", "string"], "default": null}]}`, Avro)
+	require.NoError(t, err)
+	deserializer := NewDeserializer(registry)
+	deserializer.SetPins(SchemaPins{"cupcakes-value": {ID: pinned.ID()}})
+
+	// Act
+	value, reader, err := deserializer.DeserializeSubject(context.Background(), "cupcakes-value",
+		encodeAvroTestRecord(t, writer, map[string]interface{}{"flavor": "lemon"}))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, pinned.ID(), reader.ID())
+	assert.Equal(t, map[string]interface{}{"flavor": map[string]interface{}{"string": "lemon"}}, value)
+}
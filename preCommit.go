@@ -0,0 +1,151 @@
+package srclient
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/crxfoz/goavro/v2"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/santhosh-tekuri/jsonschema/v5"
+)
+
+// SchemaProblem is a schema file which fails a local check.
+type SchemaProblem struct {
+	File    string
+	Subject string
+	Problem string
+}
+
+func (p SchemaProblem) String() string {
+	return fmt.Sprintf("%s (%s): %s", p.File, p.Subject, p.Problem)
+}
+
+// PreviousRevision reads a schema file, relative to the checked
+// directory, as it was before the change being checked. It returns an
+// error matching fs.ErrNotExist for new files.
+type PreviousRevision func(file string) ([]byte, error)
+
+// GitRevision reads the files of the directory at a git revision, such
+// as HEAD from a pre-commit hook, with git show.
+func GitRevision(dir, revision string) PreviousRevision {
+	return func(file string) ([]byte, error) {
+		var stdout, stderr bytes.Buffer
+		command := exec.Command("git", "show", revision+":./"+filepath.ToSlash(file))
+		command.Dir = dir
+		command.Stdout = &stdout
+		command.Stderr = &stderr
+		if err := command.Run(); err != nil {
+			message := strings.TrimSpace(stderr.String())
+			if strings.Contains(message, "does not exist") || strings.Contains(message, "exists on disk, but not in") {
+				return nil, fmt.Errorf("%s at %s: %w", file, revision, fs.ErrNotExist)
+			}
+			return nil, fmt.Errorf("git show %s:%s: %v: %s", revision, file, err, message)
+		}
+		return stdout.Bytes(), nil
+	}
+}
+
+// CheckSchemaChanges is the registry free counterpart of a sync, cheap
+// enough for pre-commit hooks: every schema file mapped by the
+// configuration must parse, and when previous is given, must be
+// compatible with its previous revision according to the compatibility
+// level of its subject, BACKWARD when none is configured. Schemas with
+// references are only checked for well formedness, the referenced types
+// being compared by name.
+func CheckSchemaChanges(dir string, config *SyncConfig, previous PreviousRevision) ([]SchemaProblem, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+
+	var problems []SchemaProblem
+	for _, mapping := range config.Subjects {
+		file := filepath.ToSlash(filepath.Clean(mapping.File))
+		content, err := readSchemaFile(filepath.Join(dir, filepath.FromSlash(file)))
+		if err != nil {
+			return nil, err
+		}
+		schemaType := mapping.SchemaType
+		if schemaType == "" {
+			schemaType, _ = schemaTypeOfFile(file)
+		}
+		problem := func(format string, args ...interface{}) {
+			problems = append(problems, SchemaProblem{File: file, Subject: mapping.Subject, Problem: fmt.Sprintf(format, args...)})
+		}
+
+		if err := parseSchemaFile(content, schemaType, len(mapping.References) > 0); err != nil {
+			problem("invalid %s schema: %v", schemaType, err)
+			continue
+		}
+		if previous == nil {
+			continue
+		}
+		previousBytes, err := previous(file)
+		if errors.Is(err, fs.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		previousContent, err := SanitizeSchema(previousBytes)
+		if err != nil {
+			problem("previous revision: %v", err)
+			continue
+		}
+
+		level := mapping.Compatibility
+		if level == "" {
+			level = config.Compatibility
+		}
+		if level == "" {
+			level = Backward
+		}
+		incompatibilities, err := CheckLocalCompatibility(previousContent, content, schemaType, level)
+		if err != nil {
+			problem("previous revision: %v", err)
+			continue
+		}
+		for _, incompatibility := range incompatibilities {
+			problem("not %s compatible: %s", level, incompatibility)
+		}
+	}
+	return problems, nil
+}
+
+// parseSchemaFile checks that a schema is well formed, schemas with
+// references can't be compiled on their own and are only parsed.
+func parseSchemaFile(content string, schemaType SchemaType, hasReferences bool) error {
+	switch schemaType {
+	case Avro:
+		if hasReferences {
+			var parsed interface{}
+			return json.Unmarshal([]byte(content), &parsed)
+		}
+		_, err := goavro.NewCodec(content)
+		return err
+	case Json:
+		if hasReferences {
+			var parsed interface{}
+			return json.Unmarshal([]byte(content), &parsed)
+		}
+		_, err := jsonschema.CompileString("schema.json", content)
+		return err
+	case Protobuf:
+		parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(map[string]string{"schema.proto": content})}
+		_, err := parser.ParseFilesButDoNotLink("schema.proto")
+		return err
+	default:
+		handler, ok := schemaTypeHandler(schemaType)
+		if !ok {
+			return errUnsupportedSchemaType
+		}
+		if handler.Parse == nil {
+			return nil
+		}
+		return handler.Parse(content)
+	}
+}
@@ -0,0 +1,104 @@
+package srclient
+
+import (
+	"fmt"
+	"io/fs"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckSchemaChanges(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	files := map[string]string{
+		"customer.avsc": `{"type": "record", "name": "Customer", "fields": [{"name": "id", "type": "long"}, {"name": "email", "type": "string"}]}`,
+		"order.avsc":    `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "long"}]}`,
+		"payment.json":  `{"type": "object", "properties": {"amount": {"type": "number"}}`,
+		"refund.avsc":   `{"type": "record", "name": "Refund", "fields": [{"name": "order", "type": "Order"}]}`,
+	}
+	for file, content := range files {
+		require.NoError(t, os.WriteFile(filepath.Join(dir, file), []byte(content), 0600))
+	}
+	config := &SyncConfig{
+		Compatibility: Full,
+		Subjects: []SyncMapping{
+			{Subject: "customers-value", File: "customer.avsc", Compatibility: Backward},
+			{Subject: "orders-value", File: "order.avsc"},
+			{Subject: "payments-value", File: "payment.json"},
+			{Subject: "refunds-value", File: "refund.avsc", References: []Reference{{Name: "Order", Subject: "orders-value"}}},
+		},
+	}
+	previous := map[string]string{
+		"customer.avsc": `{"type": "record", "name": "Customer", "fields": [{"name": "id", "type": "long"}]}`,
+		"order.avsc":    `{"type": "record", "name": "Order", "fields": [{"name": "id", "type": "int"}]}`,
+	}
+
+	tests := map[string]struct {
+		previous PreviousRevision
+		expected []SchemaProblem
+	}{
+		"parse only": {
+			expected: []SchemaProblem{
+				{File: "payment.json", Subject: "payments-value", Problem: "invalid JSON schema: jsonschema: invalid json schema.json: unexpected EOF"},
+			},
+		},
+		"against previous revision": {
+			previous: func(file string) ([]byte, error) {
+				content, ok := previous[file]
+				if !ok {
+					return nil, fmt.Errorf("%s: %w", file, fs.ErrNotExist)
+				}
+				return []byte(content), nil
+			},
+			expected: []SchemaProblem{
+				{File: "customer.avsc", Subject: "customers-value", Problem: "not BACKWARD compatible: email: field is missing from the written data and has no default (reading previous data)"},
+				{File: "order.avsc", Subject: "orders-value", Problem: "not FULL compatible: id: long can't be read as int (reading new data with the previous schema)"},
+				{File: "payment.json", Subject: "payments-value", Problem: "invalid JSON schema: jsonschema: invalid json schema.json: unexpected EOF"},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			problems, err := CheckSchemaChanges(dir, config, testData.previous)
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, problems)
+		})
+	}
+}
+
+func TestGitRevision(t *testing.T) {
+	t.Parallel()
+	if _, err := exec.LookPath("git"); err != nil {
+		t.Skip("git is not installed")
+	}
+	dir := t.TempDir()
+	git := func(args ...string) {
+		command := exec.Command("git", append([]string{"-c", "user.name=test", "-c", "user.email=test@example.com"}, args...)...)
+		command.Dir = dir
+		output, err := command.CombinedOutput()
+		require.NoError(t, err, string(output))
+	}
+	require.NoError(t, os.MkdirAll(filepath.Join(dir, "schemas"), 0700))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "order.avsc"), []byte(`"int"`), 0600))
+	git("init", "-q")
+	git("add", "-A")
+	git("commit", "-q", "-m", "schemas")
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "schemas", "order.avsc"), []byte(`"long"`), 0600))
+
+	previous := GitRevision(filepath.Join(dir, "schemas"), "HEAD")
+	content, err := previous("order.avsc")
+	_, missingErr := previous("refund.avsc")
+
+	require.NoError(t, err)
+	assert.Equal(t, `"int"`, string(content))
+	assert.ErrorIs(t, missingErr, fs.ErrNotExist)
+}
@@ -0,0 +1,210 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+)
+
+// defaultProbeInterval is the interval of the probers created with an
+// interval of zero or less.
+const defaultProbeInterval = time.Minute
+
+// ProbeStep is a step of the end-to-end check of a subject.
+type ProbeStep string
+
+const (
+	// ProbeFetch fetches the latest schema of the subject.
+	ProbeFetch ProbeStep = "FETCH"
+	// ProbeCompile compiles the schema, along with its references.
+	ProbeCompile ProbeStep = "COMPILE"
+	// ProbeEncode encodes a random sample with the schema.
+	ProbeEncode ProbeStep = "ENCODE"
+	// ProbeDecode decodes the sample back.
+	ProbeDecode ProbeStep = "DECODE"
+)
+
+// ProbeResult is the outcome of the check of a subject. When the check
+// fails, Step is the step which failed and Err tells why.
+type ProbeResult struct {
+	Subject   string
+	Healthy   bool
+	Step      ProbeStep
+	Err       error
+	Version   int
+	ID        int
+	Latency   time.Duration
+	CheckedAt time.Time
+}
+
+// Prober periodically checks, for each subject, that the latest schema
+// can be fetched, compiled and used to encode and decode a sample, for
+// teams running the registry as a tier-1 dependency. Protobuf schemas are
+// compiled but not used to encode samples.
+type Prober struct {
+	client   ISchemaRegistryClient
+	subjects []string
+	interval time.Duration
+
+	lock     sync.RWMutex
+	clock    Clock
+	onResult func(result ProbeResult)
+	results  map[string]ProbeResult
+}
+
+// NewProber creates a prober checking the subjects every interval, or
+// every minute when the interval is zero or less.
+func NewProber(client ISchemaRegistryClient, interval time.Duration, subjects ...string) *Prober {
+	if interval <= 0 {
+		interval = defaultProbeInterval
+	}
+	return &Prober{
+		client:   client,
+		subjects: append([]string(nil), subjects...),
+		interval: interval,
+		clock:    systemClock{},
+		results:  make(map[string]ProbeResult, len(subjects)),
+	}
+}
+
+// SetClock replaces the clock timing the probes, nil restores the
+// system clock.
+func (prober *Prober) SetClock(clock Clock) {
+	if clock == nil {
+		clock = systemClock{}
+	}
+	prober.lock.Lock()
+	defer prober.lock.Unlock()
+	prober.clock = clock
+}
+
+// OnResult registers a callback receiving every result, to export
+// them as metrics or alerts.
+func (prober *Prober) OnResult(callback func(result ProbeResult)) {
+	prober.lock.Lock()
+	defer prober.lock.Unlock()
+	prober.onResult = callback
+}
+
+// Run probes the subjects every interval until the context is done,
+// starting right away.
+func (prober *Prober) Run(ctx context.Context) error {
+	for {
+		prober.Probe(ctx)
+
+		prober.lock.RLock()
+		clock := prober.clock
+		prober.lock.RUnlock()
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-clock.After(prober.interval):
+		}
+	}
+}
+
+// Probe checks every subject once, concurrently, and returns the
+// results in subject order.
+func (prober *Prober) Probe(ctx context.Context) []ProbeResult {
+	results := make([]ProbeResult, len(prober.subjects))
+	var wg sync.WaitGroup
+	for i, subject := range prober.subjects {
+		wg.Add(1)
+		go func(i int, subject string) {
+			defer wg.Done()
+			results[i] = prober.probe(ctx, subject)
+		}(i, subject)
+	}
+	wg.Wait()
+
+	prober.lock.Lock()
+	for _, result := range results {
+		prober.results[result.Subject] = result
+	}
+	onResult := prober.onResult
+	prober.lock.Unlock()
+	if onResult != nil {
+		for _, result := range results {
+			onResult(result)
+		}
+	}
+	return results
+}
+
+// Results returns the latest result of each probed subject, sorted by subject.
+func (prober *Prober) Results() []ProbeResult {
+	prober.lock.RLock()
+	defer prober.lock.RUnlock()
+	results := make([]ProbeResult, 0, len(prober.results))
+	for _, result := range prober.results {
+		results = append(results, result)
+	}
+	sort.Slice(results, func(i, j int) bool {
+		return results[i].Subject < results[j].Subject
+	})
+	return results
+}
+
+// Healthy tells whether every subject passed its latest check, subjects
+// not probed yet are not healthy.
+func (prober *Prober) Healthy() bool {
+	prober.lock.RLock()
+	defer prober.lock.RUnlock()
+	for _, subject := range prober.subjects {
+		if !prober.results[subject].Healthy {
+			return false
+		}
+	}
+	return true
+}
+
+func (prober *Prober) probe(ctx context.Context, subject string) ProbeResult {
+	prober.lock.RLock()
+	clock := prober.clock
+	prober.lock.RUnlock()
+
+	start := clock.Now()
+	result := ProbeResult{Subject: subject, CheckedAt: start}
+	step, err := prober.check(ctx, subject, &result)
+	result.Latency = clock.Now().Sub(start)
+	if err != nil {
+		result.Step = step
+		result.Err = err
+		return result
+	}
+	result.Healthy = true
+	return result
+}
+
+func (prober *Prober) check(ctx context.Context, subject string, result *ProbeResult) (ProbeStep, error) {
+	schema, err := prober.client.GetLatestSchema(ctx, subject)
+	if err != nil {
+		return ProbeFetch, err
+	}
+	result.Version = schema.Version()
+	result.ID = schema.ID()
+
+	switch schemaTypeOf(schema) {
+	case Avro:
+		if schema.Codec() == nil {
+			return ProbeCompile, fmt.Errorf("invalid Avro schema with id %d", schema.ID())
+		}
+	case Json:
+		if schema.JsonSchema() == nil {
+			return ProbeCompile, fmt.Errorf("invalid JSON schema with id %d", schema.ID())
+		}
+	case Protobuf:
+		_, err := protobufFileDescriptor(ctx, prober.client, subject, schema)
+		return ProbeCompile, err
+	default:
+		return ProbeCompile, errUnsupportedSchemaType
+	}
+
+	payload, err := NewRandomGenerator(result.CheckedAt.UnixNano()).Payload(schema)
+	if err != nil {
+		return ProbeEncode, err
+	}
+	return ProbeDecode, readWithSchema(schema, schema, payload[wireHeaderLength:])
+}
@@ -0,0 +1,121 @@
+package srclient
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestProber_Probe(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	srClient := CreateMockSchemaRegistryClient("mock://testingUrl")
+	_, err := srClient.CreateSchema(context.Background(), "customers-value", testSchema1, Avro)
+	require.NoError(t, err)
+	_, err = srClient.CreateSchema(context.Background(), "orders-value", `{"type": "object", "properties": {"id": {"type": "integer"}}, "required": ["id"]}`, Json)
+	require.NoError(t, err)
+	_, err = srClient.CreateSchema(context.Background(), "broken-value", `{"type": "record", "name": "broken"}`, Avro)
+	require.NoError(t, err)
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	prober := NewProber(srClient, time.Minute, "customers-value", "orders-value", "broken-value", "missing-value")
+	prober.SetClock(clock)
+
+	// Act
+	results := prober.Probe(context.Background())
+
+	// Assert
+	require.Len(t, results, 4)
+	assert.Equal(t, ProbeResult{Subject: "customers-value", Healthy: true, Version: 1, ID: 1, CheckedAt: clock.Now()}, results[0])
+	assert.Equal(t, ProbeResult{Subject: "orders-value", Healthy: true, Version: 1, ID: 2, CheckedAt: clock.Now()}, results[1])
+	assert.False(t, results[2].Healthy)
+	assert.Equal(t, ProbeCompile, results[2].Step)
+	assert.Equal(t, 3, results[2].ID)
+	assert.False(t, results[3].Healthy)
+	assert.Equal(t, ProbeFetch, results[3].Step)
+	assert.ErrorIs(t, results[3].Err, errSchemaNotFound)
+	assert.Equal(t, []string{"broken-value", "customers-value", "missing-value", "orders-value"}, subjectsOf(prober.Results()))
+	assert.False(t, prober.Healthy())
+}
+
+func TestProber_Run(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	srClient := CreateMockSchemaRegistryClient("mock://testingUrl")
+	_, err := srClient.CreateSchema(context.Background(), "customers-value", testSchema1, Avro)
+	require.NoError(t, err)
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	prober := NewProber(srClient, time.Minute, "customers-value")
+	prober.SetClock(clock)
+	var lock sync.Mutex
+	var checks []time.Time
+	prober.OnResult(func(result ProbeResult) {
+		lock.Lock()
+		defer lock.Unlock()
+		checks = append(checks, result.CheckedAt)
+	})
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+
+	// Act
+	go func() { done <- prober.Run(ctx) }()
+	require.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+	clock.Advance(time.Minute)
+	require.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+	cancel()
+
+	// Assert
+	assert.ErrorIs(t, <-done, context.Canceled)
+	lock.Lock()
+	defer lock.Unlock()
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	assert.Equal(t, []time.Time{start, start.Add(time.Minute)}, checks)
+	assert.True(t, prober.Healthy())
+}
+
+func TestNewProber_Interval(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		interval time.Duration
+		expected time.Duration
+	}{
+		"positive interval": {interval: time.Second, expected: time.Second},
+		"zero interval":     {interval: 0, expected: defaultProbeInterval},
+		"negative interval": {interval: -time.Second, expected: defaultProbeInterval},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			srClient := CreateMockSchemaRegistryClient("mock://testingUrl")
+			clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+			prober := NewProber(srClient, testData.interval)
+			prober.SetClock(clock)
+			ctx, cancel := context.WithCancel(context.Background())
+			done := make(chan error)
+
+			// Act
+			go func() { done <- prober.Run(ctx) }()
+
+			// Assert
+			require.Eventually(t, func() bool { return clock.Waiters() == 1 }, time.Second, time.Millisecond)
+			clock.Advance(testData.expected - time.Nanosecond)
+			assert.Equal(t, 1, clock.Waiters())
+			cancel()
+			assert.ErrorIs(t, <-done, context.Canceled)
+		})
+	}
+}
+
+func subjectsOf(results []ProbeResult) []string {
+	subjects := make([]string, 0, len(results))
+	for _, result := range results {
+		subjects = append(subjects, result.Subject)
+	}
+	return subjects
+}
@@ -0,0 +1,88 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+)
+
+// PromotionResult gives the coordinates in the target registry of a
+// schema promoted from the source registry.
+type PromotionResult struct {
+	Subject       string
+	SourceVersion int
+	SourceID      int
+	// Schema is the schema in the target registry, with its ID and
+	// version there.
+	Schema *Schema
+	// Registered is false when the target already had the schema.
+	Registered bool
+	// References are the promotions of the referenced schemas, promoted
+	// before the schema referencing them.
+	References []*PromotionResult
+}
+
+// String prints the new coordinates of the schema.
+func (result *PromotionResult) String() string {
+	state := "already in target"
+	if result.Registered {
+		state = "registered"
+	}
+	return fmt.Sprintf("%s version %d (id %d) promoted as version %d (id %d), %s",
+		result.Subject, result.SourceVersion, result.SourceID, result.Schema.Version(), result.Schema.ID(), state)
+}
+
+// Promote copies a version of a subject from the source registry, such
+// as the staging one, to the target registry, such as the production
+// one, along with the schemas it references, which are promoted first
+// and referenced by their versions in the target. Nothing is registered
+// under the subject unless the target accepts the schema as its next
+// version.
+func Promote(ctx context.Context, source, target ISchemaRegistryClient, subject string, version int) (*PromotionResult, error) {
+	return promote(ctx, source, target, subject, version, make(map[promotionKey]*PromotionResult))
+}
+
+type promotionKey struct {
+	subject string
+	version int
+}
+
+func promote(ctx context.Context, source, target ISchemaRegistryClient, subject string, version int, promoted map[promotionKey]*PromotionResult) (*PromotionResult, error) {
+	key := promotionKey{subject: subject, version: version}
+	if result, ok := promoted[key]; ok {
+		return result, nil
+	}
+
+	schema, err := source.GetSchemaByVersion(ctx, subject, version)
+	if err != nil {
+		return nil, fmt.Errorf("subject %s version %d: %w", subject, version, err)
+	}
+	result := &PromotionResult{Subject: subject, SourceVersion: version, SourceID: schema.ID()}
+
+	references := make([]Reference, 0, len(schema.References()))
+	for _, reference := range schema.References() {
+		referenced, err := promote(ctx, source, target, reference.Subject, reference.Version, promoted)
+		if err != nil {
+			return nil, err
+		}
+		result.References = append(result.References, referenced)
+		reference.Version = referenced.Schema.Version()
+		references = append(references, reference)
+	}
+
+	schemaType := schemaTypeOf(schema)
+	compatible, err := target.IsSchemaCompatible(ctx, subject, schema.Schema(), "latest", schemaType, references...)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+	if err == nil && !compatible {
+		return nil, fmt.Errorf("subject %s version %d isn't compatible with the target registry", subject, version)
+	}
+
+	spec := SchemaSpec{Subject: subject, Schema: schema.Schema(), SchemaType: schemaType, References: references, Metadata: schema.Metadata()}
+	result.Schema, result.Registered, err = registerSpec(ctx, target, spec)
+	if err != nil {
+		return nil, fmt.Errorf("subject %s version %d: %w", subject, version, err)
+	}
+	promoted[key] = result
+	return result, nil
+}
@@ -0,0 +1,67 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPromote(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	source := CreateMockSchemaRegistryClient("http://stage:8081")
+	target := CreateMockSchemaRegistryClient("http://prod:8081")
+	_, err := source.CreateSchema(context.Background(), "addresses-value", testSchema1, Avro)
+	require.NoError(t, err)
+	_, err = source.CreateSchema(context.Background(), "addresses-value", setAddressSchema, Avro)
+	require.NoError(t, err)
+	_, err = source.CreateSchema(context.Background(), "customers-value", setCustomerSchema, Avro,
+		Reference{Name: "Address", Subject: "addresses-value", Version: 2})
+	require.NoError(t, err)
+
+	// Act
+	result, err := Promote(context.Background(), source, target, "customers-value", 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.True(t, result.Registered)
+	assert.Equal(t, 1, result.Schema.Version())
+	require.Len(t, result.References, 1)
+	assert.Equal(t, 1, result.References[0].Schema.Version())
+	assert.Equal(t, []Reference{{Name: "Address", Subject: "addresses-value", Version: 1}}, result.Schema.References())
+	assert.Equal(t, "customers-value version 1 (id 3) promoted as version 1 (id 2), registered", result.String())
+}
+
+func TestPromote_AlreadyInTarget(t *testing.T) {
+	t.Parallel()
+	source := CreateMockSchemaRegistryClient("http://stage:8081")
+	target := CreateMockSchemaRegistryClient("http://prod:8081")
+	for _, client := range []*MockSchemaRegistryClient{source, target} {
+		_, err := client.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+		require.NoError(t, err)
+	}
+
+	result, err := Promote(context.Background(), source, target, "cupcakes-value", 1)
+
+	require.NoError(t, err)
+	assert.False(t, result.Registered)
+	versions, err := target.GetSchemaVersions(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, versions)
+}
+
+func TestPromote_Incompatible(t *testing.T) {
+	t.Parallel()
+	source := CreateMockSchemaRegistryClient("http://stage:8081")
+	target := CreateMockSchemaRegistryClient("http://prod:8081")
+	_, err := source.CreateSchema(context.Background(), "cupcakes-value", testSchema2, Avro)
+	require.NoError(t, err)
+	_, err = target.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+
+	_, err = Promote(context.Background(), source, target, "cupcakes-value", 1)
+
+	assert.ErrorContains(t, err, "isn't compatible")
+}
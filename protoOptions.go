@@ -0,0 +1,174 @@
+package srclient
+
+import (
+	"fmt"
+	"io"
+	"path"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/golang/protobuf/proto"
+	"github.com/jhump/protoreflect/desc"
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"github.com/jhump/protoreflect/dynamic"
+)
+
+// SchemaLoadOption configures LoadSchemaSpecs.
+type SchemaLoadOption func(*schemaLoader)
+
+// WithProtoOptionsMetadata copies the custom options of the .proto files
+// loaded into the metadata of their specs, with ProtoOptionsMetadata, to
+// keep governance information such as owners or PII annotations next to
+// the schemas in the registry. Only the options named, by the full name
+// of their extension, are copied, all of them when none is.
+func WithProtoOptionsMetadata(options ...string) SchemaLoadOption {
+	return func(loader *schemaLoader) {
+		loader.protoOptions = true
+		loader.protoOptionNames = options
+	}
+}
+
+// ProtoOptionsMetadata returns the custom options of a .proto file as
+// schema metadata, nil when it has none. The file options become
+// properties named after their extension. The options of the messages,
+// fields and enums set to true become tags of the element, named after
+// the last part of the name of their extension, the other values become
+// properties named after the element and the extension, such as
+// "shop.Customer.email:shop.owner". Only the options named are kept, all
+// of them when none is.
+func ProtoOptionsMetadata(file *desc.FileDescriptor, options ...string) (*SchemaMetadata, error) {
+	extensions := dynamic.NewExtensionRegistryWithDefaults()
+	extensions.AddExtensionsFromFileRecursively(file)
+	wanted := make(map[string]bool, len(options))
+	for _, option := range options {
+		wanted[option] = true
+	}
+	metadata := &SchemaMetadata{Tags: make(map[string][]string), Properties: make(map[string]string)}
+
+	collect := func(element string, message proto.Message) error {
+		if message == nil || reflect.ValueOf(message).IsNil() {
+			return nil
+		}
+		dynamicOptions, err := dynamic.AsDynamicMessageWithExtensionRegistry(message, extensions)
+		if err != nil {
+			return err
+		}
+		for _, extension := range dynamicOptions.GetKnownExtensions() {
+			name := extension.GetFullyQualifiedName()
+			if (len(wanted) > 0 && !wanted[name]) || !dynamicOptions.HasField(extension) {
+				continue
+			}
+			value := dynamicOptions.GetField(extension)
+			switch {
+			case element == "":
+				metadata.Properties[name] = protoOptionValue(extension, value)
+			case value == true:
+				tag := name[strings.LastIndex(name, ".")+1:]
+				metadata.Tags[element] = append(metadata.Tags[element], tag)
+			case value != false:
+				metadata.Properties[element+":"+name] = protoOptionValue(extension, value)
+			}
+		}
+		return nil
+	}
+
+	if err := collect("", file.GetFileOptions()); err != nil {
+		return nil, err
+	}
+	var collectMessage func(message *desc.MessageDescriptor) error
+	collectMessage = func(message *desc.MessageDescriptor) error {
+		if err := collect(message.GetFullyQualifiedName(), message.GetMessageOptions()); err != nil {
+			return err
+		}
+		for _, field := range message.GetFields() {
+			if err := collect(field.GetFullyQualifiedName(), field.GetFieldOptions()); err != nil {
+				return err
+			}
+		}
+		for _, enum := range message.GetNestedEnumTypes() {
+			if err := collect(enum.GetFullyQualifiedName(), enum.GetEnumOptions()); err != nil {
+				return err
+			}
+		}
+		for _, nested := range message.GetNestedMessageTypes() {
+			if err := collectMessage(nested); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for _, message := range file.GetMessageTypes() {
+		if err := collectMessage(message); err != nil {
+			return nil, err
+		}
+	}
+	for _, enum := range file.GetEnumTypes() {
+		if err := collect(enum.GetFullyQualifiedName(), enum.GetEnumOptions()); err != nil {
+			return nil, err
+		}
+	}
+
+	if len(metadata.Tags) == 0 && len(metadata.Properties) == 0 {
+		return nil, nil
+	}
+	for element := range metadata.Tags {
+		sort.Strings(metadata.Tags[element])
+	}
+	if len(metadata.Tags) == 0 {
+		metadata.Tags = nil
+	}
+	if len(metadata.Properties) == 0 {
+		metadata.Properties = nil
+	}
+	return metadata, nil
+}
+
+// protoOptionValue formats the value of an option, enums by the name of
+// their value and repeated options as a comma separated list.
+func protoOptionValue(extension *desc.FieldDescriptor, value interface{}) string {
+	if values, ok := value.([]interface{}); ok {
+		formatted := make([]string, len(values))
+		for i, item := range values {
+			formatted[i] = protoOptionValue(extension, item)
+		}
+		return strings.Join(formatted, ",")
+	}
+	if enum := extension.GetEnumType(); enum != nil {
+		if number, ok := value.(int32); ok {
+			if enumValue := enum.FindValueByNumber(number); enumValue != nil {
+				return enumValue.GetName()
+			}
+		}
+	}
+	if bytes, ok := value.([]byte); ok {
+		return string(bytes)
+	}
+	return fmt.Sprint(value)
+}
+
+// protoMetadata parses a .proto file of the directory, its imports being
+// resolved from the directory or from the directory of the file, and
+// returns the metadata of its options.
+func (loader *schemaLoader) protoMetadata(file string) (*SchemaMetadata, error) {
+	importPaths := []string{"."}
+	if dir := path.Dir(file); dir != "." {
+		importPaths = append(importPaths, dir)
+	}
+	parser := protoparse.Parser{
+		ImportPaths: importPaths,
+		Accessor: func(name string) (io.ReadCloser, error) {
+			content, err := readSchemaFile(filepath.Join(loader.root, filepath.FromSlash(name)))
+			if err != nil {
+				return nil, err
+			}
+			return io.NopCloser(strings.NewReader(content)), nil
+		},
+	}
+	files, err := parser.ParseFiles(file)
+	if err != nil {
+		return nil, err
+	}
+	return ProtoOptionsMetadata(files[0], loader.protoOptionNames...)
+}
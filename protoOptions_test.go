@@ -0,0 +1,80 @@
+package srclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testProtoOptions = `syntax = "proto3";
+package shop;
+import "google/protobuf/descriptor.proto";
+
+enum Level { LOW = 0; HIGH = 1; }
+extend google.protobuf.FileOptions { string owner = 50000; }
+extend google.protobuf.MessageOptions { Level retention = 50001; }
+extend google.protobuf.FieldOptions { bool pii = 50002; repeated string labels = 50003; }
+`
+
+func TestLoadSchemaSpecs_ProtoOptions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options  []SchemaLoadOption
+		expected *SchemaMetadata
+	}{
+		"without extraction": {},
+		"all options": {
+			options: []SchemaLoadOption{WithProtoOptionsMetadata()},
+			expected: &SchemaMetadata{
+				Tags: map[string][]string{"shop.Customer.email": {"pii"}},
+				Properties: map[string]string{
+					"shop.owner":                               "team-a",
+					"shop.Customer:shop.retention":             "HIGH",
+					"shop.Customer.Address.street:shop.labels": "address,free-text",
+				},
+			},
+		},
+		"named options": {
+			options: []SchemaLoadOption{WithProtoOptionsMetadata("shop.pii")},
+			expected: &SchemaMetadata{
+				Tags: map[string][]string{"shop.Customer.email": {"pii"}},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			dir := writeSchemaFiles(t, map[string]string{
+				"options.proto": testProtoOptions,
+				"shop/customer.proto": `syntax = "proto3";
+package shop;
+import "options.proto";
+option (shop.owner) = "team-a";
+option go_package = "shop";
+message Customer {
+	option (shop.retention) = HIGH;
+	string email = 1 [(shop.pii) = true, deprecated = true];
+	string name = 2 [(shop.pii) = false];
+	message Address {
+		string street = 1 [(shop.labels) = "address", (shop.labels) = "free-text"];
+	}
+}`,
+			})
+
+			// Act
+			specs, err := LoadSchemaSpecs(dir, nil, testData.options...)
+
+			// Assert
+			require.NoError(t, err)
+			require.Len(t, specs, 2)
+			assert.Equal(t, "shop/customer.proto", specs[1].Subject)
+			assert.Equal(t, testData.expected, specs[1].Metadata)
+			assert.Nil(t, specs[0].Metadata)
+		})
+	}
+}
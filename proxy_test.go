@@ -0,0 +1,57 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithProxy(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var proxied atomic.Value
+	proxy := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		proxied.Store(req.URL.String())
+		rw.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		fmt.Fprint(rw, `{"schema": "\"string\""}`)
+	}))
+	t.Cleanup(proxy.Close)
+	client := NewClient("http://registry.internal:8081", WithProxy(proxy.URL))
+
+	// Act
+	schema, err := client.GetSchema(context.Background(), 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, `"string"`, schema.Schema())
+	assert.Equal(t, "http://registry.internal:8081/schemas/ids/1", proxied.Load())
+}
+
+func TestWithProxy_Invalid(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		proxyURL string
+	}{
+		"unsupported scheme": {proxyURL: "ftp://proxy:21"},
+		"missing host":       {proxyURL: "socks5://"},
+		"unparsable":         {proxyURL: "http://proxy:port"},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			client := NewClient("http://localhost:8081", WithProxy(testData.proxyURL))
+
+			_, err := client.GetSchema(context.Background(), 1)
+
+			assert.ErrorIs(t, err, ErrInvalidURL)
+		})
+	}
+}
@@ -0,0 +1,583 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"math/big"
+	"math/rand"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	defaultRandomMaxLength = 5
+	defaultRandomMaxDepth  = 4
+	randomJsonAttempts     = 20
+	randomAlphabet         = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
+)
+
+// RandomGenerator produces random values which are valid against Avro
+// and JSON schemas, to be used in load tests and contract tests.
+type RandomGenerator struct {
+	// MaxLength caps the length of generated strings, arrays and maps.
+	MaxLength int
+	// MaxDepth caps the nesting of recursive schemas, optional branches
+	// (null union members, optional properties) are taken past it.
+	MaxDepth int
+
+	rand *rand.Rand
+	lock sync.Mutex
+}
+
+// NewRandomGenerator creates a RandomGenerator, generators created with
+// the same seed produce the same values.
+func NewRandomGenerator(seed int64) *RandomGenerator {
+	return &RandomGenerator{
+		MaxLength: defaultRandomMaxLength,
+		MaxDepth:  defaultRandomMaxDepth,
+		rand:      rand.New(rand.NewSource(seed)),
+	}
+}
+
+// GenerateRandom generates a random payload, in wire format, valid
+// against the latest schema of the subject.
+func GenerateRandom(ctx context.Context, client ISchemaRegistryClient, subject string) ([]byte, error) {
+	schema, err := client.GetLatestSchema(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	return NewRandomGenerator(time.Now().UnixNano()).Payload(schema)
+}
+
+// Payload generates a random value for the schema and encodes it in wire format.
+func (g *RandomGenerator) Payload(schema *Schema) ([]byte, error) {
+	value, err := g.Value(schema)
+	if err != nil {
+		return nil, err
+	}
+
+	payload := appendWireHeader(nil, schema.ID())
+	switch schemaTypeOf(schema) {
+	case Avro:
+		return schema.Codec().BinaryFromNative(payload, value)
+	default:
+		body, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		return append(payload, body...), nil
+	}
+}
+
+// Value generates a random value for the schema. Avro values use the
+// native representation of goavro, JSON values are made of maps, slices,
+// strings, float64, booleans and nil like encoding/json produces.
+func (g *RandomGenerator) Value(schema *Schema) (interface{}, error) {
+	g.lock.Lock()
+	defer g.lock.Unlock()
+
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(schema.Schema()), &parsed); err != nil {
+		return nil, err
+	}
+
+	switch schemaTypeOf(schema) {
+	case Avro:
+		if schema.Codec() == nil {
+			return nil, fmt.Errorf("invalid Avro schema with id %d", schema.ID())
+		}
+		walker := &randomAvro{g: g, named: avroDefinitions(parsed)}
+		return walker.value(parsed, "", 0)
+	case Json:
+		root, ok := parsed.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("invalid JSON schema with id %d", schema.ID())
+		}
+		walker := &randomJson{g: g, root: root}
+		compiled := schema.JsonSchema()
+		for attempt := 0; attempt < randomJsonAttempts; attempt++ {
+			value, err := walker.value(root, 0)
+			if err != nil {
+				return nil, err
+			}
+			if compiled == nil || compiled.Validate(value) == nil {
+				return value, nil
+			}
+		}
+		return nil, fmt.Errorf("could not generate a valid value for JSON schema with id %d", schema.ID())
+	default:
+		return nil, errUnsupportedSchemaType
+	}
+}
+
+func (g *RandomGenerator) length(min int) int {
+	max := g.MaxLength
+	if max < min {
+		max = min
+	}
+	return min + g.rand.Intn(max-min+1)
+}
+
+func (g *RandomGenerator) string(min, max int) string {
+	if max < min {
+		max = min + g.MaxLength
+	}
+	length := min + g.rand.Intn(max-min+1)
+	var builder strings.Builder
+	for i := 0; i < length; i++ {
+		builder.WriteByte(randomAlphabet[g.rand.Intn(len(randomAlphabet))])
+	}
+	return builder.String()
+}
+
+func (g *RandomGenerator) uuid() string {
+	b := make([]byte, 16)
+	g.rand.Read(b)
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:])
+}
+
+func (g *RandomGenerator) time() time.Time {
+	// Anywhere between 2000 and 2030
+	return time.Unix(946684800+g.rand.Int63n(946080000), 0).UTC()
+}
+
+// randomAvro walks an Avro schema, with the named types it defines.
+type randomAvro struct {
+	g     *RandomGenerator
+	named map[string]map[string]interface{}
+}
+
+func (w *randomAvro) value(schema interface{}, namespace string, depth int) (interface{}, error) {
+	switch typed := schema.(type) {
+	case string:
+		return w.primitive(typed, namespace, depth)
+	case []interface{}:
+		return w.union(typed, namespace, depth)
+	case map[string]interface{}:
+		return w.complex(typed, namespace, depth)
+	default:
+		return nil, fmt.Errorf("invalid Avro schema %v", schema)
+	}
+}
+
+func (w *randomAvro) primitive(typeName string, namespace string, depth int) (interface{}, error) {
+	r := w.g.rand
+	switch typeName {
+	case "null":
+		return nil, nil
+	case "boolean":
+		return r.Intn(2) == 1, nil
+	case "int":
+		return r.Int31() - math.MaxInt32/2, nil
+	case "long":
+		return r.Int63() - math.MaxInt64/2, nil
+	case "float":
+		return r.Float32() * 1000, nil
+	case "double":
+		return r.Float64() * 1000, nil
+	case "bytes":
+		b := make([]byte, w.g.length(0))
+		r.Read(b)
+		return b, nil
+	case "string":
+		return w.g.string(0, w.g.MaxLength), nil
+	}
+
+	fullName, ok := avroLookupName(w.named, typeName, namespace)
+	if !ok {
+		return nil, fmt.Errorf("unknown Avro type %s", typeName)
+	}
+	return w.complex(w.named[fullName], avroNamespaceOf(fullName), depth)
+}
+
+func (w *randomAvro) union(members []interface{}, namespace string, depth int) (interface{}, error) {
+	if len(members) == 0 {
+		return nil, fmt.Errorf("empty Avro union")
+	}
+
+	member := members[w.g.rand.Intn(len(members))]
+	if depth >= w.g.MaxDepth {
+		// Stop recursing as soon as possible
+		for _, candidate := range members {
+			if candidate == "null" {
+				member = candidate
+			}
+		}
+	}
+	if member == "null" {
+		return nil, nil
+	}
+
+	value, err := w.value(member, namespace, depth+1)
+	if err != nil {
+		return nil, err
+	}
+	return map[string]interface{}{w.unionName(member, namespace): value}, nil
+}
+
+// unionName is the name goavro expects for a union member.
+func (w *randomAvro) unionName(member interface{}, namespace string) string {
+	switch typed := member.(type) {
+	case string:
+		if fullName, ok := avroLookupName(w.named, typed, namespace); ok {
+			return fullName
+		}
+		return typed
+	case map[string]interface{}:
+		typeName, _ := typed["type"].(string)
+		switch typeName {
+		case "record", "enum", "fixed":
+			return avroDefinitionName(typed, namespace)
+		}
+		if logicalType, ok := typed["logicalType"].(string); ok {
+			return typeName + "." + logicalType
+		}
+		return typeName
+	default:
+		return ""
+	}
+}
+
+func (w *randomAvro) complex(schema map[string]interface{}, namespace string, depth int) (interface{}, error) {
+	r := w.g.rand
+	typeName, _ := schema["type"].(string)
+	if typeName == "" {
+		// e.g. {"type": ["null", "string"]} or {"type": {"type": "array", ...}}
+		return w.value(schema["type"], namespace, depth)
+	}
+
+	switch typeName {
+	case "record", "error", "enum", "fixed":
+		namespace = avroNamespaceOf(avroDefinitionName(schema, namespace))
+	}
+
+	if value, ok, err := w.logical(schema, typeName); ok || err != nil {
+		return value, err
+	}
+
+	switch typeName {
+	case "record", "error":
+		fields, _ := schema["fields"].([]interface{})
+		record := make(map[string]interface{}, len(fields))
+		for _, field := range fields {
+			fieldMap, ok := field.(map[string]interface{})
+			if !ok {
+				return nil, fmt.Errorf("invalid Avro field %v", field)
+			}
+			name, _ := fieldMap["name"].(string)
+			value, err := w.value(fieldMap["type"], namespace, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			record[name] = value
+		}
+		return record, nil
+	case "enum":
+		symbols, _ := schema["symbols"].([]interface{})
+		if len(symbols) == 0 {
+			return nil, fmt.Errorf("Avro enum without symbols")
+		}
+		return symbols[r.Intn(len(symbols))], nil
+	case "fixed":
+		size, _ := schema["size"].(float64)
+		b := make([]byte, int(size))
+		r.Read(b)
+		return b, nil
+	case "array":
+		length := w.g.length(0)
+		if depth >= w.g.MaxDepth {
+			length = 0
+		}
+		items := make([]interface{}, 0, length)
+		for i := 0; i < length; i++ {
+			item, err := w.value(schema["items"], namespace, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	case "map":
+		length := w.g.length(0)
+		if depth >= w.g.MaxDepth {
+			length = 0
+		}
+		values := make(map[string]interface{}, length)
+		for i := 0; i < length; i++ {
+			value, err := w.value(schema["values"], namespace, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			values[w.g.string(1, w.g.MaxLength+1)] = value
+		}
+		return values, nil
+	default:
+		return w.primitive(typeName, namespace, depth)
+	}
+}
+
+// logical generates values for the Avro logical types, ok is false when
+// the schema doesn't have a supported logical type.
+func (w *randomAvro) logical(schema map[string]interface{}, typeName string) (interface{}, bool, error) {
+	r := w.g.rand
+	logicalType, _ := schema["logicalType"].(string)
+	switch typeName + "." + logicalType {
+	case "int.date":
+		return w.g.time().Truncate(24 * time.Hour), true, nil
+	case "int.time-millis":
+		return time.Duration(r.Int63n(int64(24*time.Hour/time.Millisecond))) * time.Millisecond, true, nil
+	case "long.time-micros":
+		return time.Duration(r.Int63n(int64(24*time.Hour/time.Microsecond))) * time.Microsecond, true, nil
+	case "long.timestamp-millis":
+		return w.g.time().Add(time.Duration(r.Int63n(1000)) * time.Millisecond), true, nil
+	case "long.timestamp-micros":
+		return w.g.time().Add(time.Duration(r.Int63n(1000000)) * time.Microsecond), true, nil
+	case "string.uuid":
+		return w.g.uuid(), true, nil
+	case "bytes.decimal", "fixed.decimal":
+		precision, _ := schema["precision"].(float64)
+		scale, _ := schema["scale"].(float64)
+		if precision <= 0 {
+			return nil, true, fmt.Errorf("Avro decimal without precision")
+		}
+		if typeName == "fixed" {
+			// Keep the unscaled value within the fixed size
+			size, _ := schema["size"].(float64)
+			if maxPrecision := math.Floor(math.Log10(2) * (8*size - 1)); maxPrecision < precision {
+				precision = maxPrecision
+			}
+		}
+		if precision > 18 {
+			precision = 18
+		}
+		max := int64(math.Pow10(int(precision)))
+		unscaled := r.Int63n(max)
+		if r.Intn(2) == 1 {
+			unscaled = -unscaled
+		}
+		return big.NewRat(unscaled, int64(math.Pow10(int(scale)))), true, nil
+	default:
+		return nil, false, nil
+	}
+}
+
+// randomJson walks a JSON schema, following local references from the root.
+type randomJson struct {
+	g    *RandomGenerator
+	root map[string]interface{}
+}
+
+func (w *randomJson) value(schema map[string]interface{}, depth int) (interface{}, error) {
+	r := w.g.rand
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved, err := w.resolve(ref)
+		if err != nil {
+			return nil, err
+		}
+		return w.value(resolved, depth+1)
+	}
+	if constant, ok := schema["const"]; ok {
+		return constant, nil
+	}
+	if enum, ok := schema["enum"].([]interface{}); ok && len(enum) > 0 {
+		return enum[r.Intn(len(enum))], nil
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		if options, ok := schema[keyword].([]interface{}); ok && len(options) > 0 {
+			option, _ := options[r.Intn(len(options))].(map[string]interface{})
+			return w.value(option, depth+1)
+		}
+	}
+	if all, ok := schema["allOf"].([]interface{}); ok && len(all) > 0 {
+		merged := make(map[string]interface{})
+		for _, part := range all {
+			partMap, _ := part.(map[string]interface{})
+			for key, value := range partMap {
+				merged[key] = value
+			}
+		}
+		return w.value(merged, depth+1)
+	}
+
+	typeName := w.typeOf(schema, depth)
+	switch typeName {
+	case "null":
+		return nil, nil
+	case "boolean":
+		return r.Intn(2) == 1, nil
+	case "integer":
+		min, max := w.bounds(schema, 1)
+		step := 1.0
+		if multipleOf, ok := schema["multipleOf"].(float64); ok && multipleOf > 0 {
+			step = multipleOf
+		}
+		low, high := math.Ceil(min/step), math.Floor(max/step)
+		if high < low {
+			return nil, fmt.Errorf("no integer satisfies the schema bounds")
+		}
+		return (low + float64(r.Int63n(int64(high-low)+1))) * step, nil
+	case "number":
+		min, max := w.bounds(schema, 0)
+		return min + r.Float64()*(max-min), nil
+	case "string":
+		return w.string(schema), nil
+	case "array":
+		minItems := intKeyword(schema, "minItems", 0)
+		length := w.g.length(minItems)
+		if maxItems := intKeyword(schema, "maxItems", -1); maxItems >= 0 && length > maxItems {
+			length = maxItems
+		}
+		if depth >= w.g.MaxDepth {
+			length = minItems
+		}
+		itemSchema, _ := schema["items"].(map[string]interface{})
+		items := make([]interface{}, 0, length)
+		for i := 0; i < length; i++ {
+			item, err := w.value(itemSchema, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			items = append(items, item)
+		}
+		return items, nil
+	default:
+		properties, _ := schema["properties"].(map[string]interface{})
+		required := make(map[string]bool)
+		if requiredList, ok := schema["required"].([]interface{}); ok {
+			for _, name := range requiredList {
+				required[fmt.Sprint(name)] = true
+			}
+		}
+
+		names := make([]string, 0, len(properties))
+		for name := range properties {
+			names = append(names, name)
+		}
+		// Map iteration order is random, sort to stay reproducible
+		sort.Strings(names)
+
+		object := make(map[string]interface{}, len(properties))
+		for _, name := range names {
+			if !required[name] && (depth >= w.g.MaxDepth || r.Intn(4) == 0) {
+				continue
+			}
+			propertySchema, _ := properties[name].(map[string]interface{})
+			value, err := w.value(propertySchema, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			object[name] = value
+		}
+		return object, nil
+	}
+}
+
+func (w *randomJson) typeOf(schema map[string]interface{}, depth int) string {
+	switch typed := schema["type"].(type) {
+	case string:
+		return typed
+	case []interface{}:
+		if len(typed) == 0 {
+			return "null"
+		}
+		if depth >= w.g.MaxDepth {
+			for _, candidate := range typed {
+				if candidate == "null" {
+					return "null"
+				}
+			}
+		}
+		return fmt.Sprint(typed[w.g.rand.Intn(len(typed))])
+	}
+
+	switch {
+	case schema["properties"] != nil:
+		return "object"
+	case schema["items"] != nil:
+		return "array"
+	default:
+		return "string"
+	}
+}
+
+// bounds returns the inclusive range of a numeric schema.
+func (w *randomJson) bounds(schema map[string]interface{}, exclusiveStep float64) (float64, float64) {
+	min, max := -1000.0, 1000.0
+	if value, ok := schema["minimum"].(float64); ok {
+		min = value
+	}
+	if value, ok := schema["exclusiveMinimum"].(float64); ok {
+		min = value + exclusiveStep
+	}
+	if value, ok := schema["maximum"].(float64); ok {
+		max = value
+	}
+	if value, ok := schema["exclusiveMaximum"].(float64); ok {
+		max = value - exclusiveStep
+	}
+	if _, ok := schema["minimum"]; ok && schema["maximum"] == nil && schema["exclusiveMaximum"] == nil {
+		max = min + 1000
+	}
+	if _, ok := schema["maximum"]; ok && schema["minimum"] == nil && schema["exclusiveMinimum"] == nil {
+		min = max - 1000
+	}
+	return min, max
+}
+
+func (w *randomJson) string(schema map[string]interface{}) string {
+	switch schema["format"] {
+	case "date-time":
+		return w.g.time().Format(time.RFC3339)
+	case "date":
+		return w.g.time().Format("2006-01-02")
+	case "email":
+		return w.g.string(1, 8) + "@example.com"
+	case "uuid":
+		return w.g.uuid()
+	case "uri":
+		return "https://example.com/" + w.g.string(1, 8)
+	}
+
+	minLength := intKeyword(schema, "minLength", 0)
+	maxLength := intKeyword(schema, "maxLength", minLength+w.g.MaxLength)
+	return w.g.string(minLength, maxLength)
+}
+
+// resolve follows a local JSON pointer reference such as #/definitions/name.
+func (w *randomJson) resolve(ref string) (map[string]interface{}, error) {
+	if !strings.HasPrefix(ref, "#") {
+		return nil, fmt.Errorf("only local references are supported, got %s", ref)
+	}
+
+	var current interface{} = w.root
+	for _, part := range strings.Split(strings.TrimPrefix(ref, "#"), "/") {
+		if part == "" {
+			continue
+		}
+		part = strings.NewReplacer("~1", "/", "~0", "~").Replace(part)
+		currentMap, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("unresolvable reference %s", ref)
+		}
+		current = currentMap[part]
+	}
+
+	resolved, ok := current.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("unresolvable reference %s", ref)
+	}
+	return resolved, nil
+}
+
+func intKeyword(schema map[string]interface{}, keyword string, defaultValue int) int {
+	if value, ok := schema[keyword].(float64); ok {
+		return int(value)
+	}
+	return defaultValue
+}
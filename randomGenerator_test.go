@@ -0,0 +1,112 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	randomAvroSchema = `{
+		"type": "record",
+		"name": "Order",
+		"namespace": "com.bakery",
+		"fields": [
+			{"name": "id", "type": {"type": "string", "logicalType": "uuid"}},
+			{"name": "quantity", "type": "int"},
+			{"name": "price", "type": {"type": "bytes", "logicalType": "decimal", "precision": 6, "scale": 2}},
+			{"name": "createdAt", "type": {"type": "long", "logicalType": "timestamp-millis"}},
+			{"name": "day", "type": {"type": "int", "logicalType": "date"}},
+			{"name": "flavor", "type": {"type": "enum", "name": "Flavor", "symbols": ["VANILLA", "CHOCOLATE"]}},
+			{"name": "favorite", "type": ["null", "Flavor"]},
+			{"name": "tags", "type": {"type": "array", "items": "string"}},
+			{"name": "extras", "type": {"type": "map", "values": "double"}},
+			{"name": "checksum", "type": {"type": "fixed", "name": "Checksum", "size": 4}},
+			{"name": "gift", "type": ["null", {"type": "record", "name": "Gift", "fields": [{"name": "note", "type": "string"}]}]},
+			{"name": "lastGift", "type": ["null", "Gift"]},
+			{"name": "next", "type": ["null", "Order"]}
+		]
+	}`
+	randomJsonSchema = `{
+		"type": "object",
+		"definitions": {
+			"flavor": {"type": "string", "enum": ["vanilla", "chocolate"]}
+		},
+		"properties": {
+			"id": {"type": "string", "format": "uuid"},
+			"quantity": {"type": "integer", "minimum": 1, "maximum": 12, "multipleOf": 2},
+			"price": {"type": "number", "exclusiveMinimum": 0, "maximum": 100},
+			"name": {"type": "string", "minLength": 3, "maxLength": 10},
+			"flavor": {"$ref": "#/definitions/flavor"},
+			"tags": {"type": "array", "items": {"type": "string"}, "minItems": 1, "maxItems": 3}
+		},
+		"required": ["id", "quantity", "price", "name", "flavor", "tags"]
+	}`
+)
+
+func TestRandomGenerator_AvroValuesAreValid(t *testing.T) {
+	t.Parallel()
+	schema, err := NewSchema(1, randomAvroSchema, Avro, 1, nil, nil, nil)
+	require.NoError(t, err)
+	generator := NewRandomGenerator(42)
+
+	for i := 0; i < 50; i++ {
+		payload, err := generator.Payload(schema)
+		require.NoError(t, err)
+
+		id, body, err := parseWireFormat(payload)
+		require.NoError(t, err)
+		assert.Equal(t, 1, id)
+		_, _, err = schema.Codec().NativeFromBinary(body)
+		assert.NoError(t, err)
+	}
+}
+
+func TestRandomGenerator_JsonValuesAreValid(t *testing.T) {
+	t.Parallel()
+	schema, err := NewSchema(2, randomJsonSchema, Json, 1, nil, nil, nil)
+	require.NoError(t, err)
+	generator := NewRandomGenerator(42)
+
+	for i := 0; i < 50; i++ {
+		value, err := generator.Value(schema)
+		require.NoError(t, err)
+		assert.NoError(t, schema.JsonSchema().Validate(value))
+
+		quantity := value.(map[string]interface{})["quantity"].(float64)
+		assert.Equal(t, 0.0, float64(int(quantity)%2))
+	}
+}
+
+func TestRandomGenerator_IsReproducible(t *testing.T) {
+	t.Parallel()
+	schema, err := NewSchema(2, randomJsonSchema, Json, 1, nil, nil, nil)
+	require.NoError(t, err)
+
+	value1, err := NewRandomGenerator(7).Value(schema)
+	require.NoError(t, err)
+	value2, err := NewRandomGenerator(7).Value(schema)
+	require.NoError(t, err)
+
+	assert.Equal(t, value1, value2)
+}
+
+func TestGenerateRandom(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, err := registry.CreateSchema(context.Background(), "orders-value", randomJsonSchema, Json)
+	require.NoError(t, err)
+
+	payload, err := GenerateRandom(context.Background(), registry, "orders-value")
+
+	require.NoError(t, err)
+	id, body, err := parseWireFormat(payload)
+	require.NoError(t, err)
+	assert.Equal(t, schema.ID(), id)
+	var value interface{}
+	require.NoError(t, json.Unmarshal(body, &value))
+	assert.NoError(t, schema.JsonSchema().Validate(value))
+}
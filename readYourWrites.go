@@ -0,0 +1,42 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+)
+
+// WithReadYourWrites makes schema registrations wait, for up to timeout,
+// until the registry serves the new version, for the reads following a
+// registration not to miss it on a follower lagging behind the leader.
+// When the version doesn't show up in time the registered schema is
+// returned along with an error.
+func WithReadYourWrites(timeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.readYourWrites = timeout
+	}
+}
+
+// awaitRegistration polls the registry, bypassing the caches, until it
+// serves the registered schema under the subject: by version when the
+// registry told it, by lookup otherwise.
+func (client *SchemaRegistryClient) awaitRegistration(ctx context.Context, subject string, registered *Schema, schema string, schemaType SchemaType, references []Reference) error {
+	what := fmt.Sprintf("schema id %d under subject %s", registered.ID(), subject)
+	return waitFor(ctx, client.getClock(), client.readYourWrites, what, func(ctx context.Context) (bool, error) {
+		if registered.Version() > 0 {
+			uri := fmt.Sprintf(subjectByVersion, url.QueryEscape(client.prefixed(subject)), strconv.Itoa(registered.Version()))
+			_, err := client.httpRequest(ctx, "GET", uri, nil)
+			if isNotFoundError(err) {
+				return false, nil
+			}
+			return err == nil, err
+		}
+		found, err := client.LookupSchema(ctx, subject, schema, schemaType, references...)
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return err == nil && found.ID() == registered.ID(), err
+	})
+}
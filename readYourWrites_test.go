@@ -0,0 +1,63 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// laggingFollower serves registrations at once but the new version only
+// after it was asked for it a number of times.
+func laggingFollower(t *testing.T, lag int32) (*httptest.Server, *int32) {
+	var versionCalls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch {
+		case req.Method == http.MethodPost:
+			_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1})
+		case req.URL.Path == "/schemas/ids/1":
+			_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Version: 3, Schema: testSchema1})
+		case atomic.AddInt32(&versionCalls, 1) <= lag:
+			rw.WriteHeader(http.StatusNotFound)
+			_ = json.NewEncoder(rw).Encode(Error{Code: 40402, Message: "Version not found."})
+		default:
+			_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Version: 3, Schema: testSchema1})
+		}
+	}))
+	t.Cleanup(server.Close)
+	return server, &versionCalls
+}
+
+func TestWithReadYourWrites(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server, versionCalls := laggingFollower(t, 2)
+	srClient := NewClient(server.URL, WithReadYourWrites(5*time.Second))
+
+	// Act
+	schema, err := srClient.CreateSchema(context.Background(), "test1-value", testSchema1, Avro)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 3, schema.Version())
+	assert.Equal(t, int32(3), atomic.LoadInt32(versionCalls))
+}
+
+func TestWithReadYourWrites_Timeout(t *testing.T) {
+	t.Parallel()
+	server, _ := laggingFollower(t, 100)
+	srClient := NewClient(server.URL, WithReadYourWrites(150*time.Millisecond))
+
+	schema, err := srClient.CreateSchema(context.Background(), "test1-value", testSchema1, Avro)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.ErrorContains(t, err, "schema registered but not served yet")
+	require.NotNil(t, schema)
+	assert.Equal(t, 1, schema.ID())
+}
@@ -0,0 +1,207 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"sync"
+)
+
+// SchemaSpec is a schema to register with RegisterSet. References to
+// subjects of the same set without a version use the version registered
+// by the set.
+type SchemaSpec struct {
+	Subject    string
+	Schema     string
+	SchemaType SchemaType
+	References []Reference
+	Metadata   *SchemaMetadata
+}
+
+// SetRollbackPolicy tells RegisterSet what to do with the versions it
+// registered when registering the set fails part way.
+type SetRollbackPolicy string
+
+const (
+	// KeepRegistered leaves the versions registered before the failure.
+	KeepRegistered SetRollbackPolicy = "KEEP"
+	// SoftDeleteRegistered soft deletes them, most dependent first.
+	SoftDeleteRegistered SetRollbackPolicy = "SOFT_DELETE"
+)
+
+// RegisterSetOptions configures RegisterSet. Concurrency is the number
+// of schemas registered at the same time, among the ones which don't
+// depend on each other, it defaults to one.
+type RegisterSetOptions struct {
+	Concurrency int
+	Rollback    SetRollbackPolicy
+}
+
+// RegisterSetResult is the outcome of RegisterSet.
+type RegisterSetResult struct {
+	// Schemas are the schemas of the specs, in the order of the specs,
+	// nil for the specs not registered.
+	Schemas []*Schema
+	// Registered tells for each spec if a new version was registered,
+	// as opposed to the schema being already registered.
+	Registered []bool
+	// RolledBack are the versions soft deleted after a failure.
+	RolledBack []*Schema
+}
+
+// RegisterSet registers a set of schemas referencing each other, every
+// schema after the ones it references. Schemas already registered under
+// their subject are left unchanged. If a registration fails, the versions
+// registered so far are handled according to the rollback policy, and
+// the error is returned along with the partial result.
+func RegisterSet(ctx context.Context, client ISchemaRegistryClient, specs []SchemaSpec, options RegisterSetOptions) (*RegisterSetResult, error) {
+	levels, err := registrationLevels(specs)
+	if err != nil {
+		return nil, err
+	}
+	concurrency := options.Concurrency
+	if concurrency < 1 {
+		concurrency = 1
+	}
+
+	result := &RegisterSetResult{
+		Schemas:    make([]*Schema, len(specs)),
+		Registered: make([]bool, len(specs)),
+	}
+	versions := make(map[string]int, len(specs))
+	for _, level := range levels {
+		errs := make([]error, len(level))
+		sem := make(chan struct{}, concurrency)
+		var wg sync.WaitGroup
+		for i, index := range level {
+			spec := specs[index]
+			spec.References = setReferences(spec.References, versions)
+			wg.Add(1)
+			sem <- struct{}{}
+			go func(i, index int, spec SchemaSpec) {
+				defer wg.Done()
+				defer func() { <-sem }()
+				result.Schemas[index], result.Registered[index], errs[i] = registerSpec(ctx, client, spec)
+			}(i, index, spec)
+		}
+		wg.Wait()
+
+		for i, index := range level {
+			if errs[i] != nil {
+				err := fmt.Errorf("subject %s: %w", specs[index].Subject, errs[i])
+				if options.Rollback == SoftDeleteRegistered {
+					if rollbackErr := rollbackSet(ctx, client, specs, levels, result); rollbackErr != nil {
+						err = fmt.Errorf("%w, rollback failed: %s", err, rollbackErr)
+					}
+				}
+				return result, err
+			}
+			versions[specs[index].Subject] = result.Schemas[index].Version()
+		}
+	}
+	return result, nil
+}
+
+// registrationLevels orders the specs topologically, each level only
+// referencing the subjects of the previous ones.
+func registrationLevels(specs []SchemaSpec) ([][]int, error) {
+	bySubject := make(map[string]int, len(specs))
+	for i, spec := range specs {
+		if _, ok := bySubject[spec.Subject]; ok {
+			return nil, fmt.Errorf("subject %s appears more than once in the set", spec.Subject)
+		}
+		bySubject[spec.Subject] = i
+	}
+
+	pending := make([]int, len(specs))
+	dependents := make([][]int, len(specs))
+	for i, spec := range specs {
+		for _, reference := range spec.References {
+			if referenced, ok := bySubject[reference.Subject]; ok {
+				pending[i]++
+				dependents[referenced] = append(dependents[referenced], i)
+			}
+		}
+	}
+
+	var levels [][]int
+	var level []int
+	for i := range specs {
+		if pending[i] == 0 {
+			level = append(level, i)
+		}
+	}
+	ordered := 0
+	for len(level) > 0 {
+		levels = append(levels, level)
+		ordered += len(level)
+		var next []int
+		for _, i := range level {
+			for _, dependent := range dependents[i] {
+				pending[dependent]--
+				if pending[dependent] == 0 {
+					next = append(next, dependent)
+				}
+			}
+		}
+		level = next
+	}
+	if ordered != len(specs) {
+		return nil, fmt.Errorf("schemas of the set reference each other in a cycle")
+	}
+	return levels, nil
+}
+
+// setReferences pins the references to subjects of the set without a
+// version to the versions registered.
+func setReferences(references []Reference, versions map[string]int) []Reference {
+	pinned := make([]Reference, len(references))
+	for i, reference := range references {
+		if version, ok := versions[reference.Subject]; ok && reference.Version == 0 {
+			reference.Version = version
+		}
+		pinned[i] = reference
+	}
+	return pinned
+}
+
+// registerSpec registers the schema of the spec unless it is already
+// registered, and tells if it was.
+func registerSpec(ctx context.Context, client ISchemaRegistryClient, spec SchemaSpec) (*Schema, bool, error) {
+	existing, err := client.LookupSchema(ctx, spec.Subject, spec.Schema, spec.SchemaType, spec.References...)
+	if err == nil {
+		return existing, false, nil
+	}
+	if !isNotFoundError(err) {
+		return nil, false, err
+	}
+
+	schema, err := client.CreateSchemaWithMetadata(ctx, spec.Subject, spec.Schema, spec.SchemaType, spec.Metadata, spec.References...)
+	if err != nil {
+		return nil, false, err
+	}
+	if schema.Version() == 0 {
+		// Schemas fetched by ID don't always tell their version
+		if schema, err = client.LookupSchema(ctx, spec.Subject, spec.Schema, spec.SchemaType, spec.References...); err != nil {
+			return nil, true, err
+		}
+	}
+	return schema, true, nil
+}
+
+// rollbackSet soft deletes the versions registered by the set, the
+// dependents before the schemas they reference.
+func rollbackSet(ctx context.Context, client ISchemaRegistryClient, specs []SchemaSpec, levels [][]int, result *RegisterSetResult) error {
+	for l := len(levels) - 1; l >= 0; l-- {
+		for _, index := range levels[l] {
+			schema := result.Schemas[index]
+			if !result.Registered[index] || schema == nil {
+				continue
+			}
+			if _, err := client.DeleteSubjectByVersion(ctx, specs[index].Subject, schema.Version(), false); err != nil {
+				return fmt.Errorf("subject %s version %d: %w", specs[index].Subject, schema.Version(), err)
+			}
+			result.RolledBack = append(result.RolledBack, schema)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,122 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	setAddressSchema  = `{"type": "record", "name": "Address", "fields": [{"name": "city", "type": "string"}]}`
+	setCustomerSchema = `{"type": "record", "name": "Customer", "fields": [{"name": "address", "type": "Address"}]}`
+	setOrderSchema    = `{"type": "record", "name": "Order", "fields": [{"name": "customer", "type": "Customer"}]}`
+)
+
+// registeringClient records the registrations of the mock client and
+// fails the ones of a subject.
+type registeringClient struct {
+	*MockSchemaRegistryClient
+	lock       sync.Mutex
+	registered []string
+	references map[string][]Reference
+	failing    string
+}
+
+func (client *registeringClient) CreateSchemaWithMetadata(ctx context.Context, subject string, schema string, schemaType SchemaType, metadata *SchemaMetadata, references ...Reference) (*Schema, error) {
+	client.lock.Lock()
+	client.registered = append(client.registered, subject)
+	client.references[subject] = references
+	client.lock.Unlock()
+	if subject == client.failing {
+		return nil, errors.New("registry unavailable")
+	}
+	return client.MockSchemaRegistryClient.CreateSchemaWithMetadata(ctx, subject, schema, schemaType, metadata, references...)
+}
+
+func setSpecs() []SchemaSpec {
+	return []SchemaSpec{
+		{Subject: "orders-value", Schema: setOrderSchema, SchemaType: Avro, References: []Reference{{Name: "Customer", Subject: "customers-value"}}},
+		{Subject: "customers-value", Schema: setCustomerSchema, SchemaType: Avro, References: []Reference{{Name: "Address", Subject: "addresses-value"}}},
+		{Subject: "addresses-value", Schema: setAddressSchema, SchemaType: Avro},
+	}
+}
+
+func TestRegisterSet(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	client := &registeringClient{MockSchemaRegistryClient: CreateMockSchemaRegistryClient("http://localhost:8081"), references: make(map[string][]Reference)}
+	_, err := client.MockSchemaRegistryClient.CreateSchema(context.Background(), "addresses-value", setAddressSchema, Avro)
+	require.NoError(t, err)
+
+	// Act
+	result, err := RegisterSet(context.Background(), client, setSpecs(), RegisterSetOptions{Concurrency: 4})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []string{"customers-value", "orders-value"}, client.registered)
+	assert.Equal(t, []bool{true, true, false}, result.Registered)
+	assert.Equal(t, []Reference{{Name: "Address", Subject: "addresses-value", Version: 1}}, client.references["customers-value"])
+	assert.Equal(t, []Reference{{Name: "Customer", Subject: "customers-value", Version: 1}}, client.references["orders-value"])
+	for _, schema := range result.Schemas {
+		assert.NotNil(t, schema)
+	}
+}
+
+func TestRegisterSet_Rollback(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		policy             SetRollbackPolicy
+		expectedRolledBack int
+		expectedVersions   []int
+	}{
+		"keep registered": {
+			policy:           KeepRegistered,
+			expectedVersions: []int{1},
+		},
+		"soft delete registered": {
+			policy:             SoftDeleteRegistered,
+			expectedRolledBack: 2,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			client := &registeringClient{MockSchemaRegistryClient: CreateMockSchemaRegistryClient("http://localhost:8081"), references: make(map[string][]Reference), failing: "orders-value"}
+
+			// Act
+			result, err := RegisterSet(context.Background(), client, setSpecs(), RegisterSetOptions{Rollback: testData.policy})
+
+			// Assert
+			assert.ErrorContains(t, err, "subject orders-value: registry unavailable")
+			assert.Len(t, result.RolledBack, testData.expectedRolledBack)
+			versions, _ := client.GetSchemaVersions(context.Background(), "customers-value")
+			assert.Equal(t, testData.expectedVersions, versions)
+		})
+	}
+}
+
+func TestRegisterSet_InvalidSets(t *testing.T) {
+	t.Parallel()
+	client := CreateMockSchemaRegistryClient("http://localhost:8081")
+	duplicated := []SchemaSpec{
+		{Subject: "addresses-value", Schema: setAddressSchema, SchemaType: Avro},
+		{Subject: "addresses-value", Schema: setAddressSchema, SchemaType: Avro},
+	}
+	cycle := []SchemaSpec{
+		{Subject: "a-value", Schema: setAddressSchema, SchemaType: Avro, References: []Reference{{Name: "B", Subject: "b-value"}}},
+		{Subject: "b-value", Schema: setAddressSchema, SchemaType: Avro, References: []Reference{{Name: "A", Subject: "a-value"}}},
+	}
+
+	_, duplicatedErr := RegisterSet(context.Background(), client, duplicated, RegisterSetOptions{})
+	_, cycleErr := RegisterSet(context.Background(), client, cycle, RegisterSetOptions{})
+
+	assert.ErrorContains(t, duplicatedErr, "more than once")
+	assert.ErrorContains(t, cycleErr, "cycle")
+}
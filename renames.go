@@ -0,0 +1,160 @@
+package srclient
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+)
+
+// SubjectRename is a subject of the source registry whose schemas were
+// found under another subject of the target registry.
+type SubjectRename struct {
+	From string
+	To   string
+	// Matched is the number of versions of From found under To, out of
+	// Versions.
+	Matched  int
+	Versions int
+}
+
+// DetectRenames matches the schemas of two exports, of two registries
+// or of the same registry before and after a reorganisation, by their
+// fingerprint, formatting left out, to tell which subjects missing from
+// the target were renamed or moved. A subject is mapped to the subject
+// of the target, missing from the source, holding most of its versions.
+// Renames are sorted by source subject.
+func DetectRenames(source, target []ExportedSchema) []SubjectRename {
+	sourceSubjects := exportedSubjects(source)
+	targetSubjects := exportedSubjects(target)
+
+	// Fingerprints of the schemas of the target subjects unknown to the source
+	candidates := make(map[string][]string)
+	for _, exported := range target {
+		if _, ok := sourceSubjects[exported.Subject]; ok {
+			continue
+		}
+		fingerprint := exportedFingerprint(exported)
+		candidates[fingerprint] = append(candidates[fingerprint], exported.Subject)
+	}
+
+	var renames []SubjectRename
+	for subject, versions := range sourceSubjects {
+		if _, ok := targetSubjects[subject]; ok {
+			continue
+		}
+		matches := make(map[string]int)
+		for _, exported := range versions {
+			for _, candidate := range uniqueStrings(candidates[exportedFingerprint(exported)]) {
+				matches[candidate]++
+			}
+		}
+		best := SubjectRename{From: subject, Versions: len(versions)}
+		for candidate, matched := range matches {
+			if matched > best.Matched || (matched == best.Matched && candidate < best.To) {
+				best.To, best.Matched = candidate, matched
+			}
+		}
+		if best.Matched > 0 {
+			renames = append(renames, best)
+		}
+	}
+	sort.Slice(renames, func(i, j int) bool { return renames[i].From < renames[j].From })
+	return renames
+}
+
+// SubjectMapping maps subjects of a source registry to their names in
+// the target registry.
+type SubjectMapping map[string]string
+
+// NewSubjectMapping returns the mapping of the renames.
+func NewSubjectMapping(renames []SubjectRename) SubjectMapping {
+	mapping := make(SubjectMapping, len(renames))
+	for _, rename := range renames {
+		mapping[rename.From] = rename.To
+	}
+	return mapping
+}
+
+// LoadSubjectMapping reads a mapping file written by WriteTo, a JSON
+// object such as {"orders": "orders-value"}.
+func LoadSubjectMapping(path string) (SubjectMapping, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	mapping := make(SubjectMapping)
+	if err := json.Unmarshal(content, &mapping); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return mapping, nil
+}
+
+// WriteTo writes the mapping as an indented JSON object.
+func (mapping SubjectMapping) WriteTo(w io.Writer) (int64, error) {
+	content, err := json.MarshalIndent(mapping, "", "  ")
+	if err != nil {
+		return 0, err
+	}
+	written, err := w.Write(append(content, '\n'))
+	return int64(written), err
+}
+
+// Apply returns a copy of the export with the subjects, and the subjects
+// of the references, renamed, for PlanMigration to match the schemas
+// against their new subjects in the target.
+func (mapping SubjectMapping) Apply(export []ExportedSchema) []ExportedSchema {
+	renamed := make([]ExportedSchema, 0, len(export))
+	for _, exported := range export {
+		if to, ok := mapping[exported.Subject]; ok {
+			exported.Subject = to
+		}
+		if len(exported.References) > 0 {
+			references := make([]Reference, 0, len(exported.References))
+			for _, reference := range exported.References {
+				if to, ok := mapping[reference.Subject]; ok {
+					reference.Subject = to
+				}
+				references = append(references, reference)
+			}
+			exported.References = references
+		}
+		renamed = append(renamed, exported)
+	}
+	return renamed
+}
+
+func exportedSubjects(export []ExportedSchema) map[string][]ExportedSchema {
+	subjects := make(map[string][]ExportedSchema)
+	for _, exported := range export {
+		subjects[exported.Subject] = append(subjects[exported.Subject], exported)
+	}
+	return subjects
+}
+
+// exportedFingerprint is the SHA-256 of the type and normalized text of
+// a schema, its references are left out as their subjects may be
+// renamed too.
+func exportedFingerprint(exported ExportedSchema) string {
+	schemaType := exported.SchemaType
+	if schemaType == "" {
+		schemaType = Avro
+	}
+	hash := sha256.Sum256([]byte(string(schemaType) + "\n" + normalizeSchema(schemaType, exported.Schema)))
+	return hex.EncodeToString(hash[:])
+}
+
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	unique := values[:0:0]
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			unique = append(unique, value)
+		}
+	}
+	return unique
+}
@@ -0,0 +1,61 @@
+package srclient
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectRenames(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	source := []ExportedSchema{
+		{Subject: "orders", Version: 1, Schema: testSchema1, SchemaType: Avro},
+		{Subject: "orders", Version: 2, Schema: testSchema2, SchemaType: Avro},
+		{Subject: "customers", Version: 1, Schema: setCustomerSchema, SchemaType: Avro, References: []Reference{{Name: "Address", Subject: "addresses", Version: 1}}},
+		{Subject: "addresses", Version: 1, Schema: setAddressSchema, SchemaType: Avro},
+		{Subject: "payments", Version: 1, Schema: setOrderSchema, SchemaType: Avro},
+	}
+	target := []ExportedSchema{
+		{Subject: "orders-value", Version: 1, Schema: testSchema1},
+		{Subject: "orders-value", Version: 2, Schema: "  " + testSchema2 + "\n"},
+		{Subject: "customers-value", Version: 1, Schema: setCustomerSchema, SchemaType: Avro, References: []Reference{{Name: "Address", Subject: "addresses-value", Version: 1}}},
+		{Subject: "addresses-value", Version: 1, Schema: setAddressSchema, SchemaType: Avro},
+		{Subject: "cupcakes", Version: 1, Schema: testSchema1, SchemaType: Avro},
+	}
+
+	// Act
+	renames := DetectRenames(source, target)
+
+	// Assert
+	assert.Equal(t, []SubjectRename{
+		{From: "addresses", To: "addresses-value", Matched: 1, Versions: 1},
+		{From: "customers", To: "customers-value", Matched: 1, Versions: 1},
+		{From: "orders", To: "orders-value", Matched: 2, Versions: 2},
+	}, renames)
+	migrated := NewSubjectMapping(renames).Apply(source)
+	assert.Equal(t, "customers-value", migrated[2].Subject)
+	assert.Equal(t, []Reference{{Name: "Address", Subject: "addresses-value", Version: 1}}, migrated[2].References)
+	assert.Equal(t, "payments", migrated[4].Subject)
+	assert.Equal(t, "addresses", source[2].References[0].Subject)
+}
+
+func TestSubjectMapping_WriteToAndLoad(t *testing.T) {
+	t.Parallel()
+	mapping := SubjectMapping{"orders": "orders-value"}
+	var written bytes.Buffer
+	path := filepath.Join(t.TempDir(), "mapping.json")
+
+	_, err := mapping.WriteTo(&written)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(path, written.Bytes(), 0o600))
+	loaded, err := LoadSubjectMapping(path)
+
+	require.NoError(t, err)
+	assert.Equal(t, "{\n  \"orders\": \"orders-value\"\n}\n", written.String())
+	assert.Equal(t, mapping, loaded)
+}
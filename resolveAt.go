@@ -0,0 +1,96 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+)
+
+var (
+	// ErrNoVersionAt is returned when the subject had no version yet at the requested time.
+	ErrNoVersionAt = errors.New("no schema version registered at that time")
+	// ErrNoRegistrationTimes is returned when the registry doesn't tell when versions were registered.
+	ErrNoRegistrationTimes = errors.New("the registry returns no registration time")
+)
+
+// ResolveSchemaAt returns the version of the subject which was the
+// latest one at the given time, to decode historical records with the
+// schema they were produced with when replaying a topic. Versions are
+// ordered by version number, IDs being shared by the subjects and kept
+// when an older schema is registered again. When only some versions
+// come with their registration time, usually after a registry upgrade,
+// the time of the others is estimated from the version numbers: versions
+// older than the first timed one are taken as always active, versions
+// in between two timed ones get an interpolated time and versions newer
+// than the last timed one are taken as registered right after it.
+func ResolveSchemaAt(ctx context.Context, client ISchemaRegistryClient, subject string, at time.Time) (*Schema, error) {
+	versions, err := client.GetSchemaVersions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	schemas := make([]*Schema, 0, len(versions))
+	for _, version := range versions {
+		schema, err := client.GetSchemaByVersion(ctx, subject, version)
+		if isNotFoundError(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		schemas = append(schemas, schema)
+	}
+	sort.Slice(schemas, func(i, j int) bool {
+		return schemas[i].Version() < schemas[j].Version()
+	})
+
+	registered := estimateRegistrationTimes(schemas)
+	if registered == nil {
+		return nil, fmt.Errorf("resolving %s at %s: %w", subject, at.Format(time.RFC3339), ErrNoRegistrationTimes)
+	}
+	var active *Schema
+	for i, schema := range schemas {
+		if !registered[i].After(at) {
+			active = schema
+		}
+	}
+	if active == nil {
+		return nil, fmt.Errorf("resolving %s at %s: %w", subject, at.Format(time.RFC3339), ErrNoVersionAt)
+	}
+	return active, nil
+}
+
+// estimateRegistrationTimes returns the registration time of each
+// schema, sorted by version, estimating the missing ones. It returns nil when
+// no schema has a registration time.
+func estimateRegistrationTimes(schemas []*Schema) []time.Time {
+	var timed []int
+	for i, schema := range schemas {
+		if !schema.registeredAt.IsZero() {
+			timed = append(timed, i)
+		}
+	}
+	if len(timed) == 0 {
+		return nil
+	}
+
+	registered := make([]time.Time, len(schemas))
+	for i := range schemas {
+		next := sort.SearchInts(timed, i)
+		switch {
+		case next < len(timed) && timed[next] == i:
+			registered[i] = schemas[i].registeredAt
+		case next == 0:
+			// Older than any timed version, active from the start
+			registered[i] = time.Time{}
+		case next == len(timed):
+			registered[i] = schemas[timed[next-1]].registeredAt
+		default:
+			before, after := schemas[timed[next-1]], schemas[timed[next]]
+			span := after.registeredAt.Sub(before.registeredAt)
+			position := float64(schemas[i].Version()-before.Version()) / float64(after.Version()-before.Version())
+			registered[i] = before.registeredAt.Add(time.Duration(float64(span) * position))
+		}
+	}
+	return registered
+}
@@ -0,0 +1,68 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveSchemaAt(t *testing.T) {
+	t.Parallel()
+	seconds := func(s int64) int64 { return s * 1000 }
+	responses := map[string]interface{}{
+		"/subjects/orders/versions":     []int{1, 2, 3, 4},
+		"/subjects/orders/versions/1":   schemaResponse{Subject: "orders", Version: 1, ID: 3, Schema: `"int"`},
+		"/subjects/orders/versions/2":   schemaResponse{Subject: "orders", Version: 2, ID: 5, Schema: `"long"`, Timestamp: seconds(1000)},
+		"/subjects/orders/versions/3":   schemaResponse{Subject: "orders", Version: 3, ID: 9, Schema: `"float"`},
+		"/subjects/orders/versions/4":   schemaResponse{Subject: "orders", Version: 4, ID: 13, Schema: `"double"`, Timestamp: seconds(2000)},
+		"/subjects/legacy/versions":     []int{1},
+		"/subjects/legacy/versions/1":   schemaResponse{Subject: "legacy", Version: 1, ID: 1, Schema: `"int"`},
+		"/subjects/reverted/versions":   []int{1, 2, 3},
+		"/subjects/reverted/versions/1": schemaResponse{Subject: "reverted", Version: 1, ID: 10, Schema: `"long"`, Timestamp: seconds(1000)},
+		"/subjects/reverted/versions/2": schemaResponse{Subject: "reverted", Version: 2, ID: 4, Schema: `"int"`},
+		"/subjects/reverted/versions/3": schemaResponse{Subject: "reverted", Version: 3, ID: 11, Schema: `"float"`, Timestamp: seconds(3000)},
+		"/subjects/recent/versions":     []int{1},
+		"/subjects/recent/versions/1":   schemaResponse{Subject: "recent", Version: 1, ID: 20, Schema: `"int"`, Timestamp: seconds(3000)},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode(responses[req.URL.String()])
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	tests := map[string]struct {
+		subject         string
+		at              int64
+		expectedVersion int
+		expectedErr     error
+	}{
+		"before any timed version":      {subject: "orders", at: 500, expectedVersion: 1},
+		"timed version":                 {subject: "orders", at: 1200, expectedVersion: 2},
+		"interpolated version":          {subject: "orders", at: 1500, expectedVersion: 3},
+		"latest version":                {subject: "orders", at: 2500, expectedVersion: 4},
+		"no registration time":          {subject: "legacy", at: 2500, expectedErr: ErrNoRegistrationTimes},
+		"older schema registered again": {subject: "reverted", at: 2500, expectedVersion: 2},
+		"before the older schema":       {subject: "reverted", at: 1500, expectedVersion: 1},
+		"before the first version":      {subject: "recent", at: 2500, expectedErr: ErrNoVersionAt},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			schema, err := ResolveSchemaAt(context.Background(), srClient, testData.subject, time.Unix(testData.at, 0))
+
+			if testData.expectedErr != nil {
+				assert.ErrorIs(t, err, testData.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testData.expectedVersion, schema.Version())
+		})
+	}
+}
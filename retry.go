@@ -0,0 +1,111 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// RequestAttempt describes an attempt of a request to the registry.
+type RequestAttempt struct {
+	Time     time.Time
+	Endpoint string
+	// StatusCode is zero when the registry didn't answer.
+	StatusCode int
+	Err        error
+}
+
+// RetryError is returned by the requests which failed after being
+// attempted more than once, with the history of the attempts, oldest
+// first. It unwraps to the error of the last attempt.
+type RetryError struct {
+	Attempts []RequestAttempt
+}
+
+// newRetryError returns the error of the single attempt, or a RetryError
+// when there were more.
+func newRetryError(attempts []RequestAttempt) error {
+	if len(attempts) == 1 {
+		return attempts[0].Err
+	}
+	return &RetryError{Attempts: attempts}
+}
+
+// Error tells the whole story in a single line, such as: "503 Service
+// Unavailable after 2 attempts [2024-01-01T00:00:00Z http://a:8081 503:
+// 503 Service Unavailable; 2024-01-01T00:00:01Z http://a:8081 503: 503
+// Service Unavailable]".
+func (e *RetryError) Error() string {
+	history := make([]string, 0, len(e.Attempts))
+	for _, attempt := range e.Attempts {
+		status := "no response"
+		if attempt.StatusCode != 0 {
+			status = fmt.Sprint(attempt.StatusCode)
+		}
+		history = append(history, fmt.Sprintf("%s %s %s: %s", attempt.Time.UTC().Format(time.RFC3339Nano), attempt.Endpoint, status, oneLine(attempt.Err)))
+	}
+	return fmt.Sprintf("%s after %d attempts [%s]", oneLine(e.Unwrap()), len(e.Attempts), strings.Join(history, "; "))
+}
+
+// oneLine returns the message of the error with its whitespace, such as
+// the newlines of registry responses, collapsed.
+func oneLine(err error) string {
+	return strings.Join(strings.Fields(err.Error()), " ")
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Attempts[len(e.Attempts)-1].Err
+}
+
+// WithRetries retries the reads failing because the registry couldn't
+// be reached or answered with a server error or 429 Too Many Requests,
+// up to maxAttempts attempts in total, waiting backoff before the first
+// retry then twice as long before each next one. Writes aren't retried
+// as they may have been applied.
+func WithRetries(maxAttempts int, backoff time.Duration) Option {
+	return func(options *clientOptions) {
+		options.retry = retryPolicy{maxAttempts: maxAttempts, backoff: backoff}
+	}
+}
+
+type retryPolicy struct {
+	maxAttempts int
+	backoff     time.Duration
+}
+
+// retriedRequest sends a read until it succeeds, fails for good or runs
+// out of attempts.
+func (client *SchemaRegistryClient) retriedRequest(ctx context.Context, method, uri string) ([]byte, error) {
+	var attempts []RequestAttempt
+	backoff := client.retry.backoff
+	for {
+		attempt := RequestAttempt{}
+		resp, err := client.httpRequestTo(ctx, client.baseURL(), method, uri, nil, &attempt)
+		if err == nil {
+			return resp, nil
+		}
+		attempt.Err = err
+		attempts = append(attempts, attempt)
+		if len(attempts) >= client.retry.maxAttempts || !isRetryable(ctx, attempt) {
+			return nil, newRetryError(attempts)
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, newRetryError(attempts)
+		case <-client.getClock().After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// isRetryable tells if an attempt failed for a reason which may go away.
+func isRetryable(ctx context.Context, attempt RequestAttempt) bool {
+	if ctx.Err() != nil || errors.Is(attempt.Err, ErrInvalidURL) || errors.Is(attempt.Err, ErrBudgetExceeded) {
+		return false
+	}
+	return attempt.StatusCode == 0 || attempt.StatusCode == http.StatusTooManyRequests || attempt.StatusCode >= http.StatusInternalServerError
+}
@@ -0,0 +1,107 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithRetries(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		status           int
+		failures         int32
+		expectedAttempts int32
+		expectedErr      bool
+	}{
+		"recovers": {
+			status:           http.StatusServiceUnavailable,
+			failures:         2,
+			expectedAttempts: 3,
+		},
+		"gives up": {
+			status:           http.StatusServiceUnavailable,
+			failures:         5,
+			expectedAttempts: 3,
+			expectedErr:      true,
+		},
+		"not retryable": {
+			status:           http.StatusNotFound,
+			failures:         5,
+			expectedAttempts: 1,
+			expectedErr:      true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			var attempts int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if atomic.AddInt32(&attempts, 1) <= testData.failures {
+					rw.WriteHeader(testData.status)
+					_ = json.NewEncoder(rw).Encode(Error{Code: testData.status * 100, Message: http.StatusText(testData.status)})
+					return
+				}
+				_ = json.NewEncoder(rw).Encode([]string{"cupcakes-value"})
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL, WithRetries(3, time.Millisecond))
+
+			// Act
+			subjects, err := srClient.GetSubjects(context.Background())
+
+			// Assert
+			assert.Equal(t, testData.expectedAttempts, atomic.LoadInt32(&attempts))
+			if testData.expectedErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, []string{"cupcakes-value"}, subjects)
+		})
+	}
+}
+
+func TestRetryError(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusServiceUnavailable)
+		_ = json.NewEncoder(rw).Encode(Error{Code: 50302, Message: "unavailable"})
+	}))
+	defer server.Close()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	srClient := NewClient(server.URL, WithRetries(2, time.Second), WithClock(clock))
+	go func() {
+		for clock.Waiters() == 0 {
+			time.Sleep(time.Millisecond)
+		}
+		clock.Advance(time.Second)
+	}()
+
+	// Act
+	_, err := srClient.GetSubjects(context.Background())
+
+	// Assert
+	var retryErr *RetryError
+	require.True(t, errors.As(err, &retryErr))
+	require.Len(t, retryErr.Attempts, 2)
+	assert.Equal(t, server.URL, retryErr.Attempts[1].Endpoint)
+	assert.Equal(t, http.StatusServiceUnavailable, retryErr.Attempts[1].StatusCode)
+	var registryErr Error
+	require.True(t, errors.As(err, &registryErr))
+	assert.Equal(t, 50302, registryErr.Code)
+	body := `{"error_code":50302,"message":"unavailable"}`
+	assert.Equal(t, body+" after 2 attempts [2024-01-01T00:00:00Z "+server.URL+" 503: "+body+"; 2024-01-01T00:00:01Z "+server.URL+" 503: "+body+"]", err.Error())
+}
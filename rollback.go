@@ -0,0 +1,112 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// RollbackResult describes the versions removed, or to be removed in a
+// dry run, by RollbackSubject.
+type RollbackResult struct {
+	Subject   string
+	ToVersion int
+	DryRun    bool
+	// Versions are the versions newer than ToVersion, newest first.
+	Versions []int
+	// ReferencedBy maps the versions still referenced to the IDs of the
+	// schemas referencing them, which block the rollback.
+	ReferencedBy map[int][]int
+	// Deleted are the versions soft deleted, newest first.
+	Deleted []int
+}
+
+// Blocked tells whether versions to remove are still referenced.
+func (result *RollbackResult) Blocked() bool {
+	return len(result.ReferencedBy) > 0
+}
+
+// String prints one line per version to remove, as a deployment
+// pipeline would log it.
+func (result *RollbackResult) String() string {
+	var builder strings.Builder
+	deleted := make(map[int]bool, len(result.Deleted))
+	for _, version := range result.Deleted {
+		deleted[version] = true
+	}
+	for _, version := range result.Versions {
+		switch {
+		case len(result.ReferencedBy[version]) > 0:
+			fmt.Fprintf(&builder, "%-12s %s version %d, referenced by schema ids %v\n", "REFERENCED", result.Subject, version, result.ReferencedBy[version])
+		case deleted[version]:
+			fmt.Fprintf(&builder, "%-12s %s version %d\n", "DELETED", result.Subject, version)
+		default:
+			fmt.Fprintf(&builder, "%-12s %s version %d\n", "PENDING", result.Subject, version)
+		}
+	}
+	switch {
+	case len(result.Versions) == 0:
+		fmt.Fprintf(&builder, "%s is already at version %d", result.Subject, result.ToVersion)
+	case result.Blocked():
+		fmt.Fprintf(&builder, "rollback of %s to version %d blocked by %d referenced versions", result.Subject, result.ToVersion, len(result.ReferencedBy))
+	default:
+		fmt.Fprintf(&builder, "%s rolled back to version %d", result.Subject, result.ToVersion)
+		if result.DryRun {
+			builder.WriteString(" (dry run)")
+		}
+	}
+	return builder.String()
+}
+
+// RollbackSubject soft deletes the versions of the subject newer than
+// toVersion, newest first, making toVersion the latest version again.
+// Nothing is deleted when one of these versions is still referenced by
+// other schemas, the result lists the references and an error is
+// returned. With dryRun nothing is deleted and the result tells what
+// would be.
+func RollbackSubject(ctx context.Context, client ISchemaRegistryClient, subject string, toVersion int, dryRun bool) (*RollbackResult, error) {
+	versions, err := client.GetSchemaVersions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	result := &RollbackResult{Subject: subject, ToVersion: toVersion, DryRun: dryRun}
+	found := false
+	for _, version := range versions {
+		found = found || version == toVersion
+		if version > toVersion {
+			result.Versions = append(result.Versions, version)
+		}
+	}
+	if !found {
+		return nil, fmt.Errorf("subject %s has no version %d", subject, toVersion)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(result.Versions)))
+
+	for _, version := range result.Versions {
+		referencing, err := client.GetReferencedBy(ctx, subject, version)
+		if err != nil {
+			return nil, err
+		}
+		if len(referencing) > 0 {
+			if result.ReferencedBy == nil {
+				result.ReferencedBy = make(map[int][]int)
+			}
+			result.ReferencedBy[version] = referencing
+		}
+	}
+	if result.Blocked() {
+		return result, fmt.Errorf("can't roll back subject %s to version %d, %d newer versions are still referenced", subject, toVersion, len(result.ReferencedBy))
+	}
+	if dryRun {
+		return result, nil
+	}
+
+	for _, version := range result.Versions {
+		if _, err := client.DeleteSubjectByVersion(ctx, subject, version, false); err != nil {
+			return result, fmt.Errorf("version %d: %w", version, err)
+		}
+		result.Deleted = append(result.Deleted, version)
+	}
+	return result, nil
+}
@@ -0,0 +1,80 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func rollbackClient(t *testing.T) *MockSchemaRegistryClient {
+	client := CreateMockSchemaRegistryClient("http://localhost:8081")
+	for _, schema := range []string{testSchema1, testSchema2, setAddressSchema} {
+		_, err := client.CreateSchema(context.Background(), "cupcakes-value", schema, Avro)
+		require.NoError(t, err)
+	}
+	return client
+}
+
+func TestRollbackSubject(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	client := rollbackClient(t)
+
+	// Act
+	result, err := RollbackSubject(context.Background(), client, "cupcakes-value", 1, false)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []int{3, 2}, result.Versions)
+	assert.Equal(t, []int{3, 2}, result.Deleted)
+	latest, err := client.GetLatestSchema(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	assert.Equal(t, 1, latest.Version())
+	assert.Equal(t, "DELETED      cupcakes-value version 3\nDELETED      cupcakes-value version 2\ncupcakes-value rolled back to version 1", result.String())
+}
+
+func TestRollbackSubject_DryRun(t *testing.T) {
+	t.Parallel()
+	client := rollbackClient(t)
+
+	result, err := RollbackSubject(context.Background(), client, "cupcakes-value", 2, true)
+
+	require.NoError(t, err)
+	assert.Empty(t, result.Deleted)
+	versions, err := client.GetSchemaVersions(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	assert.Len(t, versions, 3)
+	assert.Equal(t, "PENDING      cupcakes-value version 3\ncupcakes-value rolled back to version 2 (dry run)", result.String())
+}
+
+func TestRollbackSubject_Referenced(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	client := rollbackClient(t)
+	referencing, err := client.CreateSchema(context.Background(), "orders-value", setCustomerSchema, Avro,
+		Reference{Name: "Address", Subject: "cupcakes-value", Version: 3})
+	require.NoError(t, err)
+
+	// Act
+	result, err := RollbackSubject(context.Background(), client, "cupcakes-value", 1, false)
+
+	// Assert
+	assert.Error(t, err)
+	require.NotNil(t, result)
+	assert.Equal(t, map[int][]int{3: {referencing.ID()}}, result.ReferencedBy)
+	assert.Empty(t, result.Deleted)
+	versions, err := client.GetSchemaVersions(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	assert.Len(t, versions, 3)
+}
+
+func TestRollbackSubject_UnknownVersion(t *testing.T) {
+	t.Parallel()
+	client := rollbackClient(t)
+
+	_, err := RollbackSubject(context.Background(), client, "cupcakes-value", 7, false)
+
+	assert.ErrorContains(t, err, "has no version 7")
+}
@@ -0,0 +1,125 @@
+package srclient
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// WithCacheCapacity bounds the number of schemas kept by each schema
+// cache, the least recently used ones being evicted first, for services
+// touching many subjects. Zero, the default, doesn't bound them.
+func WithCacheCapacity(entries int) Option {
+	return func(options *clientOptions) {
+		options.cacheCapacity = entries
+	}
+}
+
+// schemaCacheEntry is an entry of the schema caches.
+type schemaCacheEntry struct {
+	schema   *Schema
+	cachedAt time.Time
+	// etag is the ETag of the response of the latest version, if any
+	etag string
+}
+
+func (client *SchemaRegistryClient) newSchemaCacheEntry(schema *Schema) schemaCacheEntry {
+	return schemaCacheEntry{schema: schema, cachedAt: client.now()}
+}
+
+// fresh tells whether a cache entry can be used, it must be set and not
+// older than the cache TTL.
+func (client *SchemaRegistryClient) fresh(cached schemaCacheEntry) bool {
+	if cached.schema == nil {
+		return false
+	}
+	return client.cacheTTL <= 0 || client.now().Sub(cached.cachedAt) < client.cacheTTL
+}
+
+// schemaCache is a least recently used cache of schemas, keyed by
+// schema ID or by subjectCacheKey.
+type schemaCache struct {
+	lock sync.Mutex
+	// capacity is zero or less when the cache isn't bounded
+	capacity int
+	entries  map[interface{}]*list.Element
+	// order holds the keys, most recently used first
+	order *list.List
+}
+
+type schemaCacheItem struct {
+	key   interface{}
+	entry schemaCacheEntry
+}
+
+func newSchemaCache(capacity int) *schemaCache {
+	return &schemaCache{
+		capacity: capacity,
+		entries:  make(map[interface{}]*list.Element),
+		order:    list.New(),
+	}
+}
+
+// get returns the entry of the key, the zero entry when there is none.
+func (cache *schemaCache) get(key interface{}) schemaCacheEntry {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	element, ok := cache.entries[key]
+	if !ok {
+		return schemaCacheEntry{}
+	}
+	cache.order.MoveToFront(element)
+	return element.Value.(*schemaCacheItem).entry
+}
+
+func (cache *schemaCache) set(key interface{}, entry schemaCacheEntry) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if element, ok := cache.entries[key]; ok {
+		element.Value.(*schemaCacheItem).entry = entry
+		cache.order.MoveToFront(element)
+		return
+	}
+	cache.entries[key] = cache.order.PushFront(&schemaCacheItem{key: key, entry: entry})
+	for cache.capacity > 0 && cache.order.Len() > cache.capacity {
+		oldest := cache.order.Back()
+		cache.order.Remove(oldest)
+		delete(cache.entries, oldest.Value.(*schemaCacheItem).key)
+	}
+}
+
+func (cache *schemaCache) reset() {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	cache.entries = make(map[interface{}]*list.Element)
+	cache.order.Init()
+}
+
+// removeIf removes the entries whose key matches.
+func (cache *schemaCache) removeIf(matches func(key interface{}) bool) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	for key, element := range cache.entries {
+		if matches(key) {
+			cache.order.Remove(element)
+			delete(cache.entries, key)
+		}
+	}
+}
+
+func (cache *schemaCache) len() int {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	return cache.order.Len()
+}
+
+// each calls fn with the entries, most recently used first, without
+// changing their order.
+func (cache *schemaCache) each(fn func(key interface{}, entry schemaCacheEntry)) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	for element := cache.order.Front(); element != nil; element = element.Next() {
+		item := element.Value.(*schemaCacheItem)
+		fn(item.key, item.entry)
+	}
+}
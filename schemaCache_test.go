@@ -0,0 +1,154 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaCache_EvictsLeastRecentlyUsed(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	cache := newSchemaCache(2)
+	cache.set(1, schemaCacheEntry{schema: &Schema{id: 1}})
+	cache.set(2, schemaCacheEntry{schema: &Schema{id: 2}})
+
+	// Act
+	cache.get(1)
+	cache.set(3, schemaCacheEntry{schema: &Schema{id: 3}})
+
+	// Assert
+	assert.Equal(t, 2, cache.len())
+	assert.Equal(t, 1, cache.get(1).schema.ID())
+	assert.Nil(t, cache.get(2).schema)
+	assert.Equal(t, 3, cache.get(3).schema.ID())
+}
+
+func TestSchemaCache_Unbounded(t *testing.T) {
+	t.Parallel()
+	cache := newSchemaCache(0)
+
+	for id := 0; id < 100; id++ {
+		cache.set(id, schemaCacheEntry{schema: &Schema{id: id}})
+	}
+
+	assert.Equal(t, 100, cache.len())
+}
+
+func TestWithCacheCapacity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		_ = json.NewEncoder(rw).Encode(schemaResponse{Schema: testSchema1})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithCacheCapacity(2))
+
+	// Act
+	for _, id := range []int{1, 2, 3, 1} {
+		_, err := srClient.GetSchema(context.Background(), id)
+		require.NoError(t, err)
+	}
+
+	// Assert
+	assert.Equal(t, 4, calls)
+	assert.Equal(t, 2, srClient.idSchemaCache.len())
+}
+
+func TestSchemaRegistryClient_Invalidate(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		invalidate      func(client *SchemaRegistryClient)
+		expectedIDs     []int
+		expectedSubject []subjectCacheKey
+	}{
+		"subject": {
+			invalidate:  func(client *SchemaRegistryClient) { client.InvalidateSubject("test1-value") },
+			expectedIDs: []int{1, 2},
+			expectedSubject: []subjectCacheKey{
+				versionCacheKey("test2-value", "1"),
+			},
+		},
+		"schema id": {
+			invalidate:  func(client *SchemaRegistryClient) { client.InvalidateSchemaID(1) },
+			expectedIDs: []int{2},
+			expectedSubject: []subjectCacheKey{
+				versionCacheKey("test1-value", "1"),
+				versionCacheKey("test1-value", "latest"),
+				versionCacheKey("test2-value", "1"),
+			},
+		},
+		"latest": {
+			invalidate:  func(client *SchemaRegistryClient) { client.InvalidateLatest("test1-value") },
+			expectedIDs: []int{1, 2},
+			expectedSubject: []subjectCacheKey{
+				versionCacheKey("test1-value", "1"),
+				idCacheKey("test1-value", 1),
+				versionCacheKey("test2-value", "1"),
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			srClient := NewClient("http://localhost:8081")
+			schema1, schema2 := &Schema{id: 1, version: 1}, &Schema{id: 2, version: 1}
+			srClient.idSchemaCache.set(1, srClient.newSchemaCacheEntry(schema1))
+			srClient.idSchemaCache.set(2, srClient.newSchemaCacheEntry(schema2))
+			srClient.subjectSchemaCache.set(versionCacheKey("test1-value", "1"), srClient.newSchemaCacheEntry(schema1))
+			srClient.subjectSchemaCache.set(versionCacheKey("test1-value", "latest"), srClient.newSchemaCacheEntry(schema1))
+			srClient.subjectSchemaCache.set(idCacheKey("test1-value", 1), srClient.newSchemaCacheEntry(schema1))
+			srClient.subjectSchemaCache.set(versionCacheKey("test2-value", "1"), srClient.newSchemaCacheEntry(schema2))
+
+			// Act
+			testData.invalidate(srClient)
+
+			// Assert
+			var ids []int
+			srClient.idSchemaCache.each(func(key interface{}, _ schemaCacheEntry) {
+				ids = append(ids, key.(int))
+			})
+			var subjectKeys []subjectCacheKey
+			srClient.subjectSchemaCache.each(func(key interface{}, _ schemaCacheEntry) {
+				subjectKeys = append(subjectKeys, key.(subjectCacheKey))
+			})
+			assert.ElementsMatch(t, testData.expectedIDs, ids)
+			assert.ElementsMatch(t, testData.expectedSubject, subjectKeys)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_InvalidateSubjectForgetsNotFound(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+		rw.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(rw).Encode(map[string]interface{}{"error_code": 40401, "message": "Subject not found"})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithNegativeCaching(time.Minute))
+	_, _ = srClient.GetLatestSchema(context.Background(), "test1-value")
+	_, _ = srClient.GetLatestSchema(context.Background(), "test2-value")
+
+	// Act
+	srClient.InvalidateSubject("test1-value")
+	_, _ = srClient.GetLatestSchema(context.Background(), "test1-value")
+	_, _ = srClient.GetLatestSchema(context.Background(), "test2-value")
+
+	// Assert
+	assert.Equal(t, 3, calls)
+}
@@ -0,0 +1,214 @@
+package srclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// SchemaField describes a field found in a schema. Path is the dotted
+// path from the root of the schema, with "[]" marking array items and
+// "{}" map values, Type is the declared type of the field, named types
+// are given by name.
+type SchemaField struct {
+	Path string
+	Name string
+	Type string
+}
+
+// schemaFields lists the fields declared by an Avro, JSON or Protobuf
+// schema. Referenced schemas are not followed, their fields are listed
+// with the referenced schema itself.
+func schemaFields(schema *Schema) ([]SchemaField, error) {
+	switch schemaTypeOf(schema) {
+	case Avro:
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(schema.Schema()), &parsed); err != nil {
+			return nil, err
+		}
+		var fields []SchemaField
+		avroFields(parsed, "", &fields)
+		return fields, nil
+	case Json:
+		var parsed interface{}
+		if err := json.Unmarshal([]byte(schema.Schema()), &parsed); err != nil {
+			return nil, err
+		}
+		var fields []SchemaField
+		jsonSchemaFields(parsed, "", &fields)
+		return fields, nil
+	case Protobuf:
+		return protobufFields(schema.Schema())
+	default:
+		return nil, errUnsupportedSchemaType
+	}
+}
+
+func avroFields(schema interface{}, path string, fields *[]SchemaField) {
+	switch typed := schema.(type) {
+	case []interface{}:
+		for _, member := range typed {
+			avroFields(member, path, fields)
+		}
+	case map[string]interface{}:
+		switch typed["type"] {
+		case "record", "error":
+			recordFields, _ := typed["fields"].([]interface{})
+			for _, field := range recordFields {
+				fieldMap, ok := field.(map[string]interface{})
+				if !ok {
+					continue
+				}
+				name, _ := fieldMap["name"].(string)
+				fieldPath := joinFieldPath(path, name)
+				*fields = append(*fields, SchemaField{Path: fieldPath, Name: name, Type: avroTypeName(fieldMap["type"])})
+				avroFields(fieldMap["type"], fieldPath, fields)
+			}
+		case "array":
+			avroFields(typed["items"], path+"[]", fields)
+		case "map":
+			avroFields(typed["values"], path+"{}", fields)
+		default:
+			if _, ok := typed["type"].(string); !ok {
+				avroFields(typed["type"], path, fields)
+			}
+		}
+	}
+}
+
+// avroTypeName names an Avro type, nullable unions are named after
+// their non null member.
+func avroTypeName(schema interface{}) string {
+	switch typed := schema.(type) {
+	case string:
+		return typed
+	case []interface{}:
+		var members []interface{}
+		for _, member := range typed {
+			if member != "null" {
+				members = append(members, member)
+			}
+		}
+		if len(members) == 1 {
+			return avroTypeName(members[0])
+		}
+		return "union"
+	case map[string]interface{}:
+		typeName, ok := typed["type"].(string)
+		if !ok {
+			return avroTypeName(typed["type"])
+		}
+		switch typeName {
+		case "record", "error", "enum", "fixed":
+			name, _ := typed["name"].(string)
+			namespace, _ := typed["namespace"].(string)
+			return avroFullName(name, namespace)
+		}
+		return typeName
+	}
+	return ""
+}
+
+func jsonSchemaFields(schema interface{}, path string, fields *[]SchemaField) {
+	typed, ok := schema.(map[string]interface{})
+	if !ok {
+		return
+	}
+
+	properties, _ := typed["properties"].(map[string]interface{})
+	for _, name := range sortedKeys(properties) {
+		fieldPath := joinFieldPath(path, name)
+		*fields = append(*fields, SchemaField{Path: fieldPath, Name: name, Type: jsonSchemaTypeName(properties[name])})
+		jsonSchemaFields(properties[name], fieldPath, fields)
+	}
+	if items, ok := typed["items"]; ok {
+		jsonSchemaFields(items, path+"[]", fields)
+	}
+	if additional, ok := typed["additionalProperties"].(map[string]interface{}); ok {
+		jsonSchemaFields(additional, path+"{}", fields)
+	}
+	for _, keyword := range []string{"allOf", "anyOf", "oneOf"} {
+		subschemas, _ := typed[keyword].([]interface{})
+		for _, subschema := range subschemas {
+			jsonSchemaFields(subschema, path, fields)
+		}
+	}
+}
+
+func jsonSchemaTypeName(schema interface{}) string {
+	typed, ok := schema.(map[string]interface{})
+	if !ok {
+		return ""
+	}
+	if ref, ok := typed["$ref"].(string); ok {
+		return ref
+	}
+	switch types := typed["type"].(type) {
+	case string:
+		return types
+	case []interface{}:
+		var names []string
+		for _, t := range types {
+			if name, ok := t.(string); ok && name != "null" {
+				names = append(names, name)
+			}
+		}
+		return strings.Join(names, "|")
+	}
+	return ""
+}
+
+// protobufFields parses the file without linking it, the imports are
+// not needed to list the fields.
+func protobufFields(content string) ([]SchemaField, error) {
+	parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(map[string]string{"schema.proto": content})}
+	files, err := parser.ParseFilesButDoNotLink("schema.proto")
+	if err != nil {
+		return nil, err
+	}
+
+	var fields []SchemaField
+	for _, message := range files[0].GetMessageType() {
+		protobufMessageFields(message, message.GetName(), &fields)
+	}
+	return fields, nil
+}
+
+func protobufMessageFields(message *descriptorpb.DescriptorProto, path string, fields *[]SchemaField) {
+	for _, field := range message.GetField() {
+		typeName := strings.TrimPrefix(field.GetTypeName(), ".")
+		if typeName == "" {
+			typeName = strings.ToLower(strings.TrimPrefix(field.GetType().String(), "TYPE_"))
+		}
+		if field.GetLabel() == descriptorpb.FieldDescriptorProto_LABEL_REPEATED {
+			typeName = fmt.Sprintf("repeated %s", typeName)
+		}
+		*fields = append(*fields, SchemaField{Path: joinFieldPath(path, field.GetName()), Name: field.GetName(), Type: typeName})
+	}
+	for _, nested := range message.GetNestedType() {
+		if nested.GetOptions().GetMapEntry() {
+			continue
+		}
+		protobufMessageFields(nested, joinFieldPath(path, nested.GetName()), fields)
+	}
+}
+
+func joinFieldPath(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
+
+func sortedKeys(values map[string]interface{}) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,73 @@
+package srclient
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaFields(t *testing.T) {
+	t.Parallel()
+	protobuf := Protobuf
+	json := Json
+	tests := map[string]struct {
+		schema   *Schema
+		expected []SchemaField
+	}{
+		"avro": {
+			schema: &Schema{schema: `{"type": "record", "name": "customer", "namespace": "com.shop", "fields": [
+				{"name": "ssn", "type": ["null", "string"]},
+				{"name": "address", "type": {"type": "record", "name": "Address", "fields": [{"name": "zip", "type": "int"}]}},
+				{"name": "orders", "type": {"type": "array", "items": {"type": "record", "name": "Order", "fields": [{"name": "id", "type": "long"}]}}}
+			]}`},
+			expected: []SchemaField{
+				{Path: "ssn", Name: "ssn", Type: "string"},
+				{Path: "address", Name: "address", Type: "Address"},
+				{Path: "address.zip", Name: "zip", Type: "int"},
+				{Path: "orders", Name: "orders", Type: "array"},
+				{Path: "orders[].id", Name: "id", Type: "long"},
+			},
+		},
+		"json": {
+			schema: &Schema{schemaType: &json, schema: `{"type": "object", "properties": {
+				"ssn": {"type": ["string", "null"]},
+				"tags": {"type": "array", "items": {"type": "object", "properties": {"name": {"type": "string"}}}},
+				"address": {"$ref": "#/definitions/address"}
+			}}`},
+			expected: []SchemaField{
+				{Path: "address", Name: "address", Type: "#/definitions/address"},
+				{Path: "ssn", Name: "ssn", Type: "string"},
+				{Path: "tags", Name: "tags", Type: "array"},
+				{Path: "tags[].name", Name: "name", Type: "string"},
+			},
+		},
+		"protobuf": {
+			schema: &Schema{schemaType: &protobuf, schema: `syntax = "proto3";
+import "other.proto";
+message Customer {
+  string ssn = 1;
+  repeated other.Order orders = 2;
+  message Address {
+    int32 zip = 1;
+  }
+}`},
+			expected: []SchemaField{
+				{Path: "Customer.ssn", Name: "ssn", Type: "string"},
+				{Path: "Customer.orders", Name: "orders", Type: "repeated other.Order"},
+				{Path: "Customer.Address.zip", Name: "zip", Type: "int32"},
+			},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			fields, err := schemaFields(testData.schema)
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, fields)
+		})
+	}
+}
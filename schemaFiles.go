@@ -0,0 +1,59 @@
+package srclient
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"os"
+	"unicode/utf8"
+)
+
+var (
+	utf8BOM    = []byte{0xEF, 0xBB, 0xBF}
+	utf16BOMs  = [][]byte{{0xFE, 0xFF}, {0xFF, 0xFE}}
+	crlf       = []byte("\r\n")
+	cr         = []byte("\r")
+	lf         = []byte("\n")
+	errNotUTF8 = errors.New("schema is not valid UTF-8")
+)
+
+// SanitizeSchema prepares the content of a schema file for registration,
+// it strips the UTF-8 byte order mark and turns Windows and old Mac line
+// endings into newlines. Contents which aren't UTF-8, such as files
+// saved as UTF-16, are refused with the offset of the first invalid byte
+// rather than failing registration with a parse error.
+func SanitizeSchema(content []byte) (string, error) {
+	for _, bom := range utf16BOMs {
+		if bytes.HasPrefix(content, bom) {
+			return "", fmt.Errorf("%w: UTF-16 byte order mark, save the file as UTF-8", errNotUTF8)
+		}
+	}
+	content = bytes.TrimPrefix(content, utf8BOM)
+	if !utf8.Valid(content) {
+		offset := 0
+		for offset < len(content) {
+			r, size := utf8.DecodeRune(content[offset:])
+			if r == utf8.RuneError && size <= 1 {
+				break
+			}
+			offset += size
+		}
+		return "", fmt.Errorf("%w: invalid byte at offset %d", errNotUTF8, offset)
+	}
+	content = bytes.ReplaceAll(content, crlf, lf)
+	content = bytes.ReplaceAll(content, cr, lf)
+	return string(content), nil
+}
+
+// readSchemaFile reads a schema file and sanitizes it.
+func readSchemaFile(path string) (string, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return "", err
+	}
+	schema, err := SanitizeSchema(content)
+	if err != nil {
+		return "", fmt.Errorf("%s: %w", path, err)
+	}
+	return schema, nil
+}
@@ -0,0 +1,77 @@
+package srclient
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizeSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content  []byte
+		expected string
+		err      bool
+	}{
+		"plain": {
+			content:  []byte("{\"type\": \"string\"}\n"),
+			expected: "{\"type\": \"string\"}\n",
+		},
+		"bom and crlf": {
+			content:  []byte("\xEF\xBB\xBF{\r\n\"type\": \"string\"\r\n}\r\n"),
+			expected: "{\n\"type\": \"string\"\n}\n",
+		},
+		"cr": {
+			content:  []byte("{\r\"type\": \"string\"\r}"),
+			expected: "{\n\"type\": \"string\"\n}",
+		},
+		"utf-16": {
+			content: []byte("\xFF\xFE{\x00}\x00"),
+			err:     true,
+		},
+		"latin-1": {
+			content: []byte("{\"doc\": \"caf\xE9\"}"),
+			err:     true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			schema, err := SanitizeSchema(testData.content)
+
+			// Assert
+			if testData.err {
+				assert.ErrorIs(t, err, errNotUTF8)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, schema)
+		})
+	}
+}
+
+func TestReadSchemaFile(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := t.TempDir()
+	valid := filepath.Join(dir, "valid.avsc")
+	invalid := filepath.Join(dir, "invalid.avsc")
+	require.NoError(t, os.WriteFile(valid, []byte("\xEF\xBB\xBF\"string\"\r\n"), 0600))
+	require.NoError(t, os.WriteFile(invalid, []byte("\"caf\xE9\""), 0600))
+
+	// Act
+	schema, err := readSchemaFile(valid)
+	_, invalidErr := readSchemaFile(invalid)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "\"string\"\n", schema)
+	assert.ErrorContains(t, invalidErr, "invalid.avsc: schema is not valid UTF-8: invalid byte at offset 4")
+}
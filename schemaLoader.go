@@ -0,0 +1,359 @@
+package srclient
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path"
+	"path/filepath"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+var protobufImports = regexp.MustCompile(`import\s+(?:public\s+|weak\s+)?"([^"]+)"\s*;`)
+
+var avroPrimitives = map[string]bool{
+	"null": true, "boolean": true, "int": true, "long": true,
+	"float": true, "double": true, "bytes": true, "string": true,
+}
+
+// LoadSchemaSpecs reads schema files into specs ready for RegisterSet or
+// the CI helpers. path is either a schema file or a directory whose
+// schema files are all loaded, hidden directories aside. The type of a
+// schema is taken from the extension of its file, .avsc for Avro and
+// .proto for Protobuf, .json files being JSON schemas unless they hold
+// an Avro schema. Avro IDL .avdl files are compiled with CompileAvroIDL,
+// each of their named types giving a spec.
+//
+// References are resolved to the files of the directory: Protobuf
+// imports and JSON Schema $ref relative to the file or to the directory,
+// and Avro named types defined by other Avro files. The files referenced
+// are loaded too, and the references are left without a version for
+// RegisterSet to pin them to the versions it registers. References which
+// can't be resolved, such as the well-known Protobuf types, are left out.
+//
+// subjectFor names the subject of each file from its slash separated
+// path relative to the directory, it defaults to the path itself like
+// SyncProtoModule.
+func LoadSchemaSpecs(path string, subjectFor func(file string) string, options ...SchemaLoadOption) ([]SchemaSpec, error) {
+	if subjectFor == nil {
+		subjectFor = func(file string) string { return file }
+	}
+	info, err := os.Stat(path)
+	if err != nil {
+		return nil, err
+	}
+
+	loader := &schemaLoader{root: path, files: make(map[string]*loadedSchemaFile)}
+	for _, option := range options {
+		option(loader)
+	}
+	var queue []string
+	if info.IsDir() {
+		if queue, err = loader.schemaFiles(); err != nil {
+			return nil, err
+		}
+	} else {
+		loader.root = filepath.Dir(path)
+		queue = []string{filepath.Base(path)}
+	}
+
+	queued := make(map[string]bool)
+	for _, file := range queue {
+		queued[file] = true
+	}
+	var specs []SchemaSpec
+	for len(queue) > 0 {
+		file := queue[0]
+		queue = queue[1:]
+		if isAvroIDLFile(file) {
+			idlSpecs, err := loader.avroIDL(file, subjectFor)
+			if err != nil {
+				return nil, err
+			}
+			specs = append(specs, idlSpecs...)
+			continue
+		}
+		loaded, err := loader.load(file)
+		if err != nil {
+			return nil, err
+		}
+		references, err := loader.references(loaded)
+		if err != nil {
+			return nil, err
+		}
+
+		spec := SchemaSpec{Subject: subjectFor(file), Schema: loaded.content, SchemaType: loaded.schemaType}
+		if loaded.schemaType == Protobuf && loader.protoOptions {
+			if spec.Metadata, err = loader.protoMetadata(file); err != nil {
+				return nil, fmt.Errorf("%s: %w", file, err)
+			}
+		}
+		for _, reference := range references {
+			spec.References = append(spec.References, Reference{Name: reference.name, Subject: subjectFor(reference.file)})
+			if !queued[reference.file] {
+				queued[reference.file] = true
+				queue = append(queue, reference.file)
+			}
+		}
+		specs = append(specs, spec)
+	}
+	sort.SliceStable(specs, func(i, j int) bool { return specs[i].Subject < specs[j].Subject })
+	return specs, nil
+}
+
+// schemaLoader loads the schema files of a directory, keyed by their
+// slash separated path relative to the directory.
+type schemaLoader struct {
+	root  string
+	files map[string]*loadedSchemaFile
+	// avroNames maps the Avro named types to the files defining them,
+	// it is nil until an Avro schema references another file
+	avroNames        map[string]string
+	protoOptions     bool
+	protoOptionNames []string
+}
+
+type loadedSchemaFile struct {
+	file       string
+	content    string
+	schemaType SchemaType
+}
+
+type fileReference struct {
+	name string
+	file string
+}
+
+// avroIDL compiles an Avro IDL file into a spec for each of its named
+// types, named after a file with the name of the type in the directory
+// of the IDL file, as idl2schemata would write it.
+func (loader *schemaLoader) avroIDL(file string, subjectFor func(file string) string) ([]SchemaSpec, error) {
+	content, err := readSchemaFile(filepath.Join(loader.root, filepath.FromSlash(file)))
+	if err != nil {
+		return nil, err
+	}
+	schemas, err := CompileAvroIDL(content, func(imported string) (string, error) {
+		return readSchemaFile(filepath.Join(loader.root, filepath.FromSlash(path.Dir(file)), filepath.FromSlash(imported)))
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	specs := make([]SchemaSpec, 0, len(schemas))
+	for _, schema := range schemas {
+		specs = append(specs, SchemaSpec{
+			Subject:    subjectFor(path.Join(path.Dir(file), schema.Name+".avsc")),
+			Schema:     schema.Schema,
+			SchemaType: Avro,
+		})
+	}
+	return specs, nil
+}
+
+func isAvroIDLFile(file string) bool {
+	return strings.EqualFold(filepath.Ext(file), ".avdl")
+}
+
+// schemaFiles lists the schema files of the directory.
+func (loader *schemaLoader) schemaFiles() ([]string, error) {
+	var files []string
+	err := filepath.Walk(loader.root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if path != loader.root && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, ok := schemaTypeOfFile(path); !ok && !isAvroIDLFile(path) {
+			return nil
+		}
+		file, err := filepath.Rel(loader.root, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(file))
+		return nil
+	})
+	return files, err
+}
+
+func (loader *schemaLoader) load(file string) (*loadedSchemaFile, error) {
+	if loaded, ok := loader.files[file]; ok {
+		return loaded, nil
+	}
+	content, err := readSchemaFile(filepath.Join(loader.root, filepath.FromSlash(file)))
+	if err != nil {
+		return nil, err
+	}
+	schemaType, _ := schemaTypeOfFile(file)
+	if schemaType == Json && isAvroSchema(content) {
+		schemaType = Avro
+	}
+	loaded := &loadedSchemaFile{file: file, content: content, schemaType: schemaType}
+	loader.files[file] = loaded
+	return loaded, nil
+}
+
+// resolve finds the file imported by another one, relative to it first
+// and then to the directory.
+func (loader *schemaLoader) resolve(from, imported string) (string, bool) {
+	for _, candidate := range []string{path.Join(path.Dir(from), imported), path.Clean(imported)} {
+		if strings.HasPrefix(candidate, "../") || candidate == ".." {
+			continue
+		}
+		if info, err := os.Stat(filepath.Join(loader.root, filepath.FromSlash(candidate))); err == nil && !info.IsDir() {
+			return candidate, true
+		}
+	}
+	return "", false
+}
+
+func (loader *schemaLoader) references(loaded *loadedSchemaFile) ([]fileReference, error) {
+	var references []fileReference
+	seen := make(map[string]bool)
+	add := func(name, file string) {
+		if !seen[name] && file != loaded.file {
+			seen[name] = true
+			references = append(references, fileReference{name: name, file: file})
+		}
+	}
+
+	switch loaded.schemaType {
+	case Protobuf:
+		content := protobufComments.ReplaceAllString(loaded.content, " ")
+		for _, match := range protobufImports.FindAllStringSubmatch(content, -1) {
+			if file, ok := loader.resolve(loaded.file, match[1]); ok {
+				add(match[1], file)
+			}
+		}
+	case Json:
+		var document interface{}
+		if err := json.Unmarshal([]byte(loaded.content), &document); err != nil {
+			return nil, err
+		}
+		for _, ref := range jsonSchemaRefs(document, nil) {
+			name := strings.SplitN(ref, "#", 2)[0]
+			if name == "" || strings.Contains(name, "://") {
+				continue
+			}
+			if file, ok := loader.resolve(loaded.file, name); ok {
+				add(name, file)
+			}
+		}
+	case Avro:
+		var schema interface{}
+		if err := json.Unmarshal([]byte(loaded.content), &schema); err != nil {
+			return nil, err
+		}
+		defined, used := make(map[string]bool), make(map[string]bool)
+		avroNamedTypes(schema, "", defined, used)
+		var external []string
+		for name := range used {
+			if !defined[name] {
+				external = append(external, name)
+			}
+		}
+		if len(external) == 0 {
+			return nil, nil
+		}
+		if err := loader.indexAvroNames(); err != nil {
+			return nil, err
+		}
+		sort.Strings(external)
+		for _, name := range external {
+			if file, ok := loader.avroNames[name]; ok {
+				add(name, file)
+			}
+		}
+	}
+	return references, nil
+}
+
+// indexAvroNames maps the named types of the Avro files of the directory
+// to their files.
+func (loader *schemaLoader) indexAvroNames() error {
+	if loader.avroNames != nil {
+		return nil
+	}
+	files, err := loader.schemaFiles()
+	if err != nil {
+		return err
+	}
+	loader.avroNames = make(map[string]string)
+	for _, file := range files {
+		if schemaType, _ := schemaTypeOfFile(file); schemaType != Avro && schemaType != Json {
+			continue
+		}
+		loaded, err := loader.load(file)
+		if err != nil {
+			return err
+		}
+		if loaded.schemaType != Avro {
+			continue
+		}
+		var schema interface{}
+		if err := json.Unmarshal([]byte(loaded.content), &schema); err != nil {
+			continue
+		}
+		defined := make(map[string]bool)
+		avroNamedTypes(schema, "", defined, make(map[string]bool))
+		for name := range defined {
+			if _, ok := loader.avroNames[name]; !ok {
+				loader.avroNames[name] = file
+			}
+		}
+	}
+	return nil
+}
+
+// isAvroSchema tells whether a JSON document is an Avro schema rather
+// than a JSON schema.
+func isAvroSchema(content string) bool {
+	var schema interface{}
+	if err := json.Unmarshal([]byte(content), &schema); err != nil {
+		return false
+	}
+	switch typed := schema.(type) {
+	case string:
+		return avroPrimitives[typed]
+	case []interface{}:
+		return true
+	case map[string]interface{}:
+		if _, ok := typed["$schema"]; ok {
+			return false
+		}
+		switch typed["type"] {
+		case "record", "error", "enum", "fixed":
+			_, named := typed["name"].(string)
+			return named
+		}
+	}
+	return false
+}
+
+// jsonSchemaRefs collects the $ref of a JSON schema.
+func jsonSchemaRefs(document interface{}, refs []string) []string {
+	switch typed := document.(type) {
+	case []interface{}:
+		for _, item := range typed {
+			refs = jsonSchemaRefs(item, refs)
+		}
+	case map[string]interface{}:
+		if ref, ok := typed["$ref"].(string); ok {
+			refs = append(refs, ref)
+		}
+		keys := make([]string, 0, len(typed))
+		for key := range typed {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			refs = jsonSchemaRefs(typed[key], refs)
+		}
+	}
+	return refs
+}
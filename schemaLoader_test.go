@@ -0,0 +1,108 @@
+package srclient
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeSchemaFiles(t *testing.T, files map[string]string) string {
+	t.Helper()
+	dir := t.TempDir()
+	for file, content := range files {
+		path := filepath.Join(dir, filepath.FromSlash(file))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+		require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	}
+	return dir
+}
+
+func TestLoadSchemaSpecs(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := writeSchemaFiles(t, map[string]string{
+		"avro/customer.avsc": `{"type": "record", "name": "Customer", "namespace": "com.shop", "fields": [{"name": "id", "type": "long"}]}`,
+		"avro/order.json":    `{"type": "record", "name": "Order", "namespace": "com.shop", "fields": [{"name": "customer", "type": "Customer"}]}`,
+		"json/address.json":  `{"$schema": "http://json-schema.org/draft-07/schema#", "type": "object"}`,
+		"json/person.json":   `{"type": "object", "properties": {"address": {"$ref": "address.json#/definitions/x"}}}`,
+		"proto/money.proto":  `syntax = "proto3"; package shop; message Money { int64 units = 1; }`,
+		"proto/pay.proto":    "syntax = \"proto3\";\r\n// import \"commented.proto\";\r\nimport \"proto/money.proto\";\r\nimport \"google/protobuf/timestamp.proto\";\r\nmessage Pay { shop.Money amount = 1; }",
+		".git/ignored.avsc":  `"string"`,
+		"README.md":          "schemas",
+	})
+
+	// Act
+	specs, err := LoadSchemaSpecs(dir, func(file string) string {
+		return strings.TrimSuffix(filepath.Base(file), filepath.Ext(file)) + "-value"
+	})
+
+	// Assert
+	require.NoError(t, err)
+	bySubject := make(map[string]SchemaSpec)
+	for _, spec := range specs {
+		bySubject[spec.Subject] = spec
+	}
+	assert.Len(t, specs, 6)
+	assert.Equal(t, Avro, bySubject["order-value"].SchemaType)
+	assert.Equal(t, []Reference{{Name: "com.shop.Customer", Subject: "customer-value"}}, bySubject["order-value"].References)
+	assert.Equal(t, Json, bySubject["person-value"].SchemaType)
+	assert.Equal(t, []Reference{{Name: "address.json", Subject: "address-value"}}, bySubject["person-value"].References)
+	assert.Equal(t, Protobuf, bySubject["pay-value"].SchemaType)
+	assert.Equal(t, []Reference{{Name: "proto/money.proto", Subject: "money-value"}}, bySubject["pay-value"].References)
+	assert.NotContains(t, bySubject["pay-value"].Schema, "\r")
+	assert.Empty(t, bySubject["customer-value"].References)
+}
+
+func TestLoadSchemaSpecs_File(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	dir := writeSchemaFiles(t, map[string]string{
+		"customer.avsc":  `{"type": "record", "name": "Customer", "fields": [{"name": "address", "type": "Address"}]}`,
+		"address.avsc":   `{"type": "record", "name": "Address", "fields": [{"name": "street", "type": "string"}]}`,
+		"unrelated.avsc": `{"type": "enum", "name": "Unrelated", "symbols": ["A"]}`,
+	})
+
+	// Act
+	specs, err := LoadSchemaSpecs(filepath.Join(dir, "customer.avsc"), nil)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, specs, 2)
+	assert.Equal(t, "address.avsc", specs[0].Subject)
+	assert.Equal(t, "customer.avsc", specs[1].Subject)
+	assert.Equal(t, []Reference{{Name: "Address", Subject: "address.avsc"}}, specs[1].References)
+	_, err = registrationLevels(specs)
+	assert.NoError(t, err)
+}
+
+func TestIsAvroSchema(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		content  string
+		expected bool
+	}{
+		"record":       {content: `{"type": "record", "name": "A", "fields": []}`, expected: true},
+		"union":        {content: `["null", "string"]`, expected: true},
+		"primitive":    {content: `"long"`, expected: true},
+		"json schema":  {content: `{"type": "object", "properties": {}}`},
+		"with $schema": {content: `{"$schema": "x", "type": "record", "name": "A"}`},
+		"invalid":      {content: `{`},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			isAvro := isAvroSchema(testData.content)
+
+			// Assert
+			assert.Equal(t, testData.expected, isAvro)
+		})
+	}
+}
@@ -7,17 +7,17 @@ import (
 	"errors"
 	"fmt"
 	"io"
-	"io/ioutil"
 	"net/http"
 	"net/url"
-	"regexp"
+	"sort"
 	"strconv"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/crxfoz/goavro/v2"
 	"github.com/santhosh-tekuri/jsonschema/v5"
-	"golang.org/x/sync/semaphore"
+	"golang.org/x/sync/singleflight"
 )
 
 // ISchemaRegistryClient provides the
@@ -26,24 +26,48 @@ import (
 type ISchemaRegistryClient interface {
 	GetGlobalCompatibilityLevel(ctx context.Context) (*CompatibilityLevel, error)
 	GetCompatibilityLevel(ctx context.Context, subject string, defaultToGlobal bool) (*CompatibilityLevel, error)
+	GetGlobalConfig(ctx context.Context) (*Config, error)
+	ResetSubjectConfig(ctx context.Context, subject string) (*Config, error)
 	GetSubjects(ctx context.Context) ([]string, error)
 	GetSubjectsIncludingDeleted(ctx context.Context) ([]string, error)
 	GetSchema(ctx context.Context, schemaID int) (*Schema, error)
+	GetSchemaBySubjectAndID(ctx context.Context, subject string, schemaID int) (*Schema, error)
+	GetSchemaVersionsByID(ctx context.Context, schemaID int) ([]SubjectVersion, error)
+	GetSubjectsBySchemaID(ctx context.Context, schemaID int, deleted bool) ([]string, error)
 	GetLatestSchema(ctx context.Context, subject string) (*Schema, error)
 	GetSchemaVersions(ctx context.Context, subject string) ([]int, error)
 	GetSchemaByVersion(ctx context.Context, subject string, version int) (*Schema, error)
+	SubjectExists(ctx context.Context, subject string) (bool, error)
+	VersionExists(ctx context.Context, subject string, version int) (bool, error)
+	ListDeletedVersions(ctx context.Context, subject string) ([]int, error)
+	IsSubjectSoftDeleted(ctx context.Context, subject string) (bool, error)
+	UndeleteSubject(ctx context.Context, subject string) ([]*Schema, error)
 	CreateSchema(ctx context.Context, subject string, schema string, schemaType SchemaType, references ...Reference) (*Schema, error)
+	CreateSchemaWithMetadata(ctx context.Context, subject string, schema string, schemaType SchemaType, metadata *SchemaMetadata, references ...Reference) (*Schema, error)
 	LookupSchema(ctx context.Context, subject string, schema string, schemaType SchemaType, references ...Reference) (*Schema, error)
 	ChangeSubjectCompatibilityLevel(ctx context.Context, subject string, compatibility CompatibilityLevel) (*CompatibilityLevel, error)
-	DeleteSubject(ctx context.Context, subject string, permanent bool) error
-	DeleteSubjectByVersion(ctx context.Context, subject string, version int, permanent bool) error
+	ChangeGlobalCompatibilityLevel(ctx context.Context, compatibility CompatibilityLevel) (*CompatibilityLevel, error)
+	DeleteSubjectCompatibilityLevel(ctx context.Context, subject string) (*CompatibilityLevel, error)
+	GetMode(ctx context.Context) (*Mode, error)
+	SetMode(ctx context.Context, mode Mode) (*Mode, error)
+	GetSubjectMode(ctx context.Context, subject string, defaultToGlobal bool) (*Mode, error)
+	SetSubjectMode(ctx context.Context, subject string, mode Mode) (*Mode, error)
+	DeleteSubject(ctx context.Context, subject string, permanent bool) ([]int, error)
+	DeleteSubjectByVersion(ctx context.Context, subject string, version int, permanent bool) (int, error)
 	SetCredentials(username string, password string)
 	SetBearerToken(token TokenProvider)
 	SetTimeout(timeout time.Duration)
 	CachingEnabled(value bool)
 	ResetCache()
+	InvalidateSubject(subject string)
+	InvalidateSchemaID(schemaID int)
+	InvalidateLatest(subject string)
+	Close(ctx context.Context) error
 	CodecCreationEnabled(value bool)
 	IsSchemaCompatible(ctx context.Context, subject, schema, version string, schemaType SchemaType, references ...Reference) (bool, error)
+	SearchSchemas(ctx context.Context, predicate SchemaPredicate) ([]SchemaMatch, error)
+	GetAllSchemas(ctx context.Context, options SchemaListOptions) ([]*Schema, error)
+	GetReferencedBy(ctx context.Context, subject string, version int) ([]int, error)
 }
 
 // SchemaRegistryClient allows interactions with
@@ -53,6 +77,7 @@ type ISchemaRegistryClient interface {
 // deserialize data.
 type SchemaRegistryClient struct {
 	schemaRegistryURL        string
+	urlErr                   error
 	credsLock                sync.RWMutex
 	credentials              *credentials
 	httpClient               *http.Client
@@ -62,11 +87,46 @@ type SchemaRegistryClient struct {
 	cacheLatestLock          sync.RWMutex
 	codecCreationEnabled     bool
 	codecCreationEnabledLock sync.RWMutex
-	idSchemaCache            map[int]*Schema
-	idSchemaCacheLock        sync.RWMutex
-	subjectSchemaCache       map[string]*Schema
-	subjectSchemaCacheLock   sync.RWMutex
-	sem                      *semaphore.Weighted
+	idSchemaCache            *schemaCache
+	subjectSchemaCache       *schemaCache
+	readSem                  *requestSemaphore
+	writeSem                 *requestSemaphore
+	featureDetection         featureDetection
+	stale                    staleStore
+	clock                    Clock
+	clockLock                sync.RWMutex
+	statusHandlers           map[int]StatusHandler
+	statusHandlersLock       sync.RWMutex
+	maxResponseBytes         int64
+	maxResponseBytesLock     sync.RWMutex
+	sharedCache              SharedCache
+	sharedCacheLock          sync.RWMutex
+	coldStart                coldStartLimiter
+	checksums                checksumPins
+	auditSink                AuditSink
+	approver                 Approver
+	subjectPrefix            string
+	budget                   *requestBudget
+	accessPolicy             *AccessPolicy
+	subjectLocker            SubjectLocker
+	headers                  http.Header
+	headerFuncs              []HeaderFunc
+	writeTimeout             time.Duration
+	inflight                 singleflight.Group
+	endpoints                *endpointSelector
+	cacheTTL                 time.Duration
+	readYourWrites           time.Duration
+	leader                   *leaderRouter
+	retry                    retryPolicy
+	maxSchemaBytes           int
+	minifySchemas            bool
+	notFound                 notFoundCache
+	streamProcessors         []StreamProcessor
+	lifecycle                *lifecycle
+	subjectVersions          bool
+	// sharedRefs counts the references taken by GetOrCreateClient, it is
+	// guarded by the lock of the shared clients
+	sharedRefs int
 }
 
 var _ ISchemaRegistryClient = new(SchemaRegistryClient)
@@ -114,6 +174,14 @@ type Reference struct {
 	Version int    `json:"version"`
 }
 
+// SchemaMetadata holds the tags, properties and sensitive properties
+// attached to a schema in the registry, used by data contracts.
+type SchemaMetadata struct {
+	Tags       map[string][]string `json:"tags,omitempty"`
+	Properties map[string]string   `json:"properties,omitempty"`
+	Sensitive  []string            `json:"sensitive,omitempty"`
+}
+
 // Schema is a data structure that holds all
 // the relevant information about schemas.
 type Schema struct {
@@ -122,8 +190,26 @@ type Schema struct {
 	schemaType *SchemaType
 	version    int
 	references []Reference
+	metadata   *SchemaMetadata
+	// registeredAt is zero when the registry doesn't tell
+	registeredAt time.Time
+	// subject is empty when the schema was fetched by ID alone
+	subject string
+	// fetchedAt and raw are zero for schemas not fetched from the registry
+	fetchedAt  time.Time
+	raw        []byte
 	codec      *goavro.Codec
 	jsonSchema *jsonschema.Schema
+	// schemaCodec is only set for custom schema types
+	schemaCodec SchemaCodec
+	// subjectVersions is nil unless fetched with WithSubjectVersions
+	subjectVersions []SubjectVersion
+	// deleted is set for the soft deleted versions
+	deleted bool
+
+	// lazyLock guards the lazy initialization of codec, jsonSchema and
+	// schemaCodec
+	lazyLock sync.Mutex
 }
 
 // credentials can have either username AND password
@@ -138,18 +224,28 @@ type credentials struct {
 }
 
 type schemaRequest struct {
-	Schema     string      `json:"schema"`
-	SchemaType string      `json:"schemaType,omitempty"`
-	References []Reference `json:"references,omitempty"`
+	Schema     string          `json:"schema"`
+	SchemaType string          `json:"schemaType,omitempty"`
+	References []Reference     `json:"references,omitempty"`
+	ID         int             `json:"id,omitempty"`
+	Version    int             `json:"version,omitempty"`
+	Metadata   *SchemaMetadata `json:"metadata,omitempty"`
 }
 
 type schemaResponse struct {
-	Subject    string      `json:"subject"`
-	Version    int         `json:"version"`
-	Schema     string      `json:"schema"`
-	SchemaType *SchemaType `json:"schemaType"`
-	ID         int         `json:"id"`
-	References []Reference `json:"references"`
+	Subject    string          `json:"subject"`
+	Version    int             `json:"version"`
+	Schema     string          `json:"schema"`
+	SchemaType *SchemaType     `json:"schemaType"`
+	ID         int             `json:"id"`
+	References []Reference     `json:"references"`
+	Metadata   *SchemaMetadata `json:"metadata,omitempty"`
+	// Timestamp is the registration time in milliseconds, returned by
+	// recent registries
+	Timestamp int64 `json:"ts,omitempty"`
+	// Deleted flags soft deleted versions, Redpanda names it is_deleted
+	Deleted   bool `json:"deleted,omitempty"`
+	IsDeleted bool `json:"is_deleted,omitempty"`
 }
 
 type isCompatibleResponse struct {
@@ -166,66 +262,153 @@ type configChangeRequest struct {
 
 type configChangeResponse configChangeRequest
 
+// Config is the full configuration object of the registry or of a
+// subject, rule sets are kept as raw JSON.
+type Config struct {
+	CompatibilityLevel CompatibilityLevel `json:"compatibilityLevel"`
+	Alias              string             `json:"alias,omitempty"`
+	Normalize          *bool              `json:"normalize,omitempty"`
+	CompatibilityGroup string             `json:"compatibilityGroup,omitempty"`
+	DefaultMetadata    *SchemaMetadata    `json:"defaultMetadata,omitempty"`
+	OverrideMetadata   *SchemaMetadata    `json:"overrideMetadata,omitempty"`
+	DefaultRuleSet     json.RawMessage    `json:"defaultRuleSet,omitempty"`
+	OverrideRuleSet    json.RawMessage    `json:"overrideRuleSet,omitempty"`
+}
+
 const (
 	schemaByID       = "/schemas/ids/%d"
 	subjectBySubject = "/subjects/%s"
 	subjectVersions  = "/subjects/%s/versions"
 	subjectByVersion = "/subjects/%s/versions/%s"
+	referencedBy     = "/subjects/%s/versions/%d/referencedby"
 	subjects         = "/subjects"
 	config           = "/config"
 	configBySubject  = "/config/%s"
 	contentType      = "application/vnd.schemaregistry.v1+json"
 )
 
+const (
+	maxErrorBodyBytes      = 64 * 1024
+	errorBodySnippetLength = 256
+	maxDrainBytes          = 1024 * 1024
+)
+
+// ErrResponseTooLarge is returned when a response of the registry is
+// larger than the limit set with SetMaxResponseBytes.
+var ErrResponseTooLarge = errors.New("schema registry response too large")
+
 // CreateSchemaRegistryClient creates a client that allows
 // interactions with Schema Registry over HTTP. Applications
 // using this client can retrieve data about schemas, which
 // in turn can be used to serialize and deserialize records.
+// NewClient creates clients configured with options instead.
 func CreateSchemaRegistryClient(schemaRegistryURL string) *SchemaRegistryClient {
-	return CreateSchemaRegistryClientWithOptions(schemaRegistryURL, &http.Client{Timeout: 5 * time.Second}, 16)
+	return NewClient(schemaRegistryURL)
 }
 
-// CreateSchemaRegistryClientWithOptions provides the ability to pass the http.Client to be used, as well as the semaphoreWeight for concurrent requests
+// CreateSchemaRegistryClientWithOptions provides the ability to pass the http.Client to be used, as well as the semaphoreWeight for concurrent requests,
+// zero meaning no limit
 func CreateSchemaRegistryClientWithOptions(schemaRegistryURL string, client *http.Client, semaphoreWeight int) *SchemaRegistryClient {
+	sem := newRequestSemaphore(semaphoreWeight)
+	return newSchemaRegistryClient(schemaRegistryURL, client, sem, sem)
+}
+
+// CreateSchemaRegistryClientWithConcurrencyBudgets works like CreateSchemaRegistryClientWithOptions but
+// uses distinct limits for read and write requests, so a flood of reads can't starve admin operations and vice versa.
+func CreateSchemaRegistryClientWithConcurrencyBudgets(schemaRegistryURL string, client *http.Client, readWeight, writeWeight int) *SchemaRegistryClient {
+	return newSchemaRegistryClient(schemaRegistryURL, client, newRequestSemaphore(readWeight), newRequestSemaphore(writeWeight))
+}
+
+func newSchemaRegistryClient(schemaRegistryURL string, client *http.Client, readSem, writeSem *requestSemaphore) *SchemaRegistryClient {
 	return &SchemaRegistryClient{
-		schemaRegistryURL:    schemaRegistryURL,
+		schemaRegistryURL:    strings.TrimSuffix(schemaRegistryURL, "/"),
+		urlErr:               ValidateSchemaRegistryURL(schemaRegistryURL),
 		httpClient:           client,
 		cachingEnabled:       true,
 		cacheLatest:          false,
 		codecCreationEnabled: false,
-		idSchemaCache:        make(map[int]*Schema),
-		subjectSchemaCache:   make(map[string]*Schema),
-		sem:                  semaphore.NewWeighted(int64(semaphoreWeight)),
+		idSchemaCache:        newSchemaCache(0),
+		subjectSchemaCache:   newSchemaCache(0),
+		readSem:              readSem,
+		writeSem:             writeSem,
+		clock:                systemClock{},
+		lifecycle:            newLifecycle(),
 	}
 }
 
 // ResetCache resets the schema caches to be able to get updated schemas.
 func (client *SchemaRegistryClient) ResetCache() {
 
-	client.idSchemaCacheLock.Lock()
-	client.subjectSchemaCacheLock.Lock()
-	client.idSchemaCache = make(map[int]*Schema)
-	client.subjectSchemaCache = make(map[string]*Schema)
-	client.idSchemaCacheLock.Unlock()
-	client.subjectSchemaCacheLock.Unlock()
+	client.idSchemaCache.reset()
+	client.subjectSchemaCache.reset()
+	client.notFound.reset()
+	client.featureDetection.reset()
+
+}
+
+// InvalidateSubject evicts the cached versions of the subject, and the
+// schemas looked up by ID under it, to see the changes made to a subject
+// without emptying the whole cache.
+func (client *SchemaRegistryClient) InvalidateSubject(subject string) {
+	client.subjectSchemaCache.removeIf(func(key interface{}) bool {
+		return key.(subjectCacheKey).subject == subject
+	})
+	prefix := fmt.Sprintf(subjectVersions, url.QueryEscape(client.prefixed(subject)))
+	client.notFound.forgetIf(func(uri string) bool {
+		return strings.HasPrefix(uri, prefix)
+	})
+}
+
+// InvalidateSchemaID evicts the schema of the ID, whether it was looked
+// up by ID alone or under a subject.
+func (client *SchemaRegistryClient) InvalidateSchemaID(schemaID int) {
+	client.idSchemaCache.removeIf(func(key interface{}) bool {
+		return key == schemaID
+	})
+	client.subjectSchemaCache.removeIf(func(key interface{}) bool {
+		cacheKey := key.(subjectCacheKey)
+		return cacheKey.version == "" && cacheKey.id == schemaID
+	})
+	uri := fmt.Sprintf(schemaByID, schemaID)
+	client.notFound.forgetIf(func(cached string) bool {
+		return cached == uri
+	})
+}
 
+// InvalidateLatest evicts the cached latest version of the subject, the
+// next GetLatestSchema fetching it from the registry.
+func (client *SchemaRegistryClient) InvalidateLatest(subject string) {
+	client.subjectSchemaCache.removeIf(func(key interface{}) bool {
+		return key == versionCacheKey(subject, "latest")
+	})
+	uri := fmt.Sprintf(subjectByVersion, url.QueryEscape(client.prefixed(subject)), "latest")
+	client.notFound.forgetIf(func(cached string) bool {
+		return cached == uri
+	})
 }
 
 // GetSchema gets the schema associated with the given id.
 func (client *SchemaRegistryClient) GetSchema(ctx context.Context, schemaID int) (*Schema, error) {
 
 	if client.getCachingEnabled() {
-		client.idSchemaCacheLock.RLock()
-		cachedSchema := client.idSchemaCache[schemaID]
-		client.idSchemaCacheLock.RUnlock()
-		if cachedSchema != nil {
-			return cachedSchema, nil
+		cached := client.idSchemaCache.get(schemaID)
+		if client.fresh(cached) {
+			return cached.schema, nil
 		}
 	}
 
-	resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(schemaByID, schemaID), nil)
+	uri := fmt.Sprintf(schemaByID, schemaID)
+	return client.coalesce(ctx, uri, func(ctx context.Context) (*Schema, error) {
+		return client.fetchSchemaByID(ctx, schemaID, "", uri)
+	})
+}
+
+// fetchSchemaByID fetches the schema with the given id, registered under
+// the subject unless it is empty, and caches it.
+func (client *SchemaRegistryClient) fetchSchemaByID(ctx context.Context, schemaID int, subject, uri string) (*Schema, error) {
+	resp, err := client.readThrough(ctx, uri)
 	if err != nil {
-		return nil, err
+		return client.stale.fallback(ctx, uri, err, client.now())
 	}
 
 	var schemaResp = new(schemaResponse)
@@ -240,24 +423,69 @@ func (client *SchemaRegistryClient) GetSchema(ctx context.Context, schemaID int)
 			return nil, err
 		}
 	}
+	schemaSubject := subject
+	if schemaSubject == "" {
+		schemaSubject, _ = client.unprefixed(schemaResp.Subject)
+	}
 	var schema = &Schema{
-		id:         schemaID,
-		schema:     schemaResp.Schema,
-		version:    schemaResp.Version,
-		schemaType: schemaResp.SchemaType,
-		references: schemaResp.References,
-		codec:      codec,
+		id:           schemaID,
+		schema:       schemaResp.Schema,
+		version:      schemaResp.Version,
+		schemaType:   schemaResp.SchemaType,
+		references:   client.unprefixedReferences(schemaResp.References),
+		metadata:     schemaResp.Metadata,
+		registeredAt: registrationTime(schemaResp.Timestamp),
+		subject:      schemaSubject,
+		fetchedAt:    client.now(),
+		raw:          resp,
+		codec:        codec,
+		deleted:      schemaResp.Deleted || schemaResp.IsDeleted,
+	}
+	if err := client.checksums.verify(subject, schema); err != nil {
+		return nil, err
+	}
+	if subject == "" && client.subjectVersions {
+		subjectVersions, err := client.GetSchemaVersionsByID(ctx, schemaID)
+		if err != nil && !isUnsupportedEndpointError(err) {
+			return nil, err
+		}
+		schema.subjectVersions = subjectVersions
 	}
 
 	if client.getCachingEnabled() {
-		client.idSchemaCacheLock.Lock()
-		client.idSchemaCache[schemaID] = schema
-		client.idSchemaCacheLock.Unlock()
+		if subject == "" {
+			client.idSchemaCache.set(schemaID, client.newSchemaCacheEntry(schema))
+		} else {
+			client.subjectSchemaCache.set(idCacheKey(subject, schemaID), client.newSchemaCacheEntry(schema))
+		}
 	}
 
+	client.stale.remember(uri, schema, client.now())
+
 	return schema, nil
 }
 
+// GetSchemaBySubjectAndID gets the schema associated with the given id,
+// making sure it is registered under the given subject. Registries with
+// strict authorization only allow fetching schemas this way.
+func (client *SchemaRegistryClient) GetSchemaBySubjectAndID(ctx context.Context, subject string, schemaID int) (*Schema, error) {
+	if err := client.authorize(subject, false); err != nil {
+		return nil, err
+	}
+	cacheKey := idCacheKey(subject, schemaID)
+	if client.getCachingEnabled() {
+		cached := client.subjectSchemaCache.get(cacheKey)
+		if client.fresh(cached) {
+			return cached.schema, nil
+		}
+	}
+
+	uri := fmt.Sprintf(schemaByID+"?subject=%s", schemaID, url.QueryEscape(client.prefixed(subject)))
+	return client.coalesce(ctx, uri, func(ctx context.Context) (*Schema, error) {
+		return client.fetchSchemaByID(ctx, schemaID, subject, uri)
+	})
+}
+
 // GetLatestSchema gets the schema associated with the given subject.
 // The schema returned contains the last version for that subject.
 func (client *SchemaRegistryClient) GetLatestSchema(ctx context.Context, subject string) (*Schema, error) {
@@ -266,7 +494,18 @@ func (client *SchemaRegistryClient) GetLatestSchema(ctx context.Context, subject
 
 // GetSchemaVersions returns a list of versions from a given subject.
 func (client *SchemaRegistryClient) GetSchemaVersions(ctx context.Context, subject string) ([]int, error) {
-	resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(subjectVersions, url.QueryEscape(subject)), nil)
+	return client.getSchemaVersions(ctx, subject, false)
+}
+
+func (client *SchemaRegistryClient) getSchemaVersions(ctx context.Context, subject string, deleted bool) ([]int, error) {
+	if err := client.authorize(subject, false); err != nil {
+		return nil, err
+	}
+	uri := fmt.Sprintf(subjectVersions, url.QueryEscape(client.prefixed(subject)))
+	if deleted {
+		uri += "?deleted=true"
+	}
+	resp, err := client.httpRequest(ctx, "GET", uri, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -280,8 +519,54 @@ func (client *SchemaRegistryClient) GetSchemaVersions(ctx context.Context, subje
 	return versions, nil
 }
 
+// GetReferencedBy returns the IDs of the schemas referencing the version
+// of the subject.
+func (client *SchemaRegistryClient) GetReferencedBy(ctx context.Context, subject string, version int) ([]int, error) {
+	if err := client.authorize(subject, false); err != nil {
+		return nil, err
+	}
+	resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(referencedBy, url.QueryEscape(client.prefixed(subject)), version), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var ids = []int{}
+	err = json.Unmarshal(resp, &ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return ids, nil
+}
+
 // ChangeSubjectCompatibilityLevel changes the compatibility level of the subject.
 func (client *SchemaRegistryClient) ChangeSubjectCompatibilityLevel(ctx context.Context, subject string, compatibility CompatibilityLevel) (*CompatibilityLevel, error) {
+	if err := client.authorize(subject, true); err != nil {
+		return nil, err
+	}
+	return client.changeCompatibilityLevel(ctx, subject, fmt.Sprintf(configBySubject, url.QueryEscape(client.prefixed(subject))), compatibility)
+}
+
+// ChangeGlobalCompatibilityLevel changes the global compatibility level of
+// the registry, the one of the subjects without their own level. Clients
+// confined to some subjects by WithSubjectPrefix or the Write list of
+// their access policy are refused with a PolicyError.
+func (client *SchemaRegistryClient) ChangeGlobalCompatibilityLevel(ctx context.Context, compatibility CompatibilityLevel) (*CompatibilityLevel, error) {
+	if err := client.authorizeGlobal(); err != nil {
+		return nil, err
+	}
+	return client.changeCompatibilityLevel(ctx, "", config, compatibility)
+}
+
+func (client *SchemaRegistryClient) changeCompatibilityLevel(ctx context.Context, subject, uri string, compatibility CompatibilityLevel) (*CompatibilityLevel, error) {
+	if compatibility == None {
+		err := client.approve(ctx, ApprovalRequest{Operation: AuditChangeCompatibility, Subject: subject, Compatibility: compatibility})
+		if err != nil {
+			client.audit(ctx, AuditEvent{Operation: AuditChangeCompatibility, Subject: subject, Compatibility: compatibility}, err)
+			return nil, err
+		}
+	}
+
 	configChangeReq := configChangeRequest{CompatibilityLevel: compatibility}
 	configChangeReqBytes, err := json.Marshal(configChangeReq)
 	if err != nil {
@@ -289,7 +574,8 @@ func (client *SchemaRegistryClient) ChangeSubjectCompatibilityLevel(ctx context.
 	}
 	payload := bytes.NewBuffer(configChangeReqBytes)
 
-	resp, err := client.httpRequest(ctx, "PUT", fmt.Sprintf(configBySubject, url.QueryEscape(subject)), payload)
+	resp, err := client.httpRequest(ctx, "PUT", uri, payload)
+	client.audit(ctx, AuditEvent{Operation: AuditChangeCompatibility, Subject: subject, Compatibility: compatibility}, err)
 	if err != nil {
 		return nil, err
 	}
@@ -303,6 +589,17 @@ func (client *SchemaRegistryClient) ChangeSubjectCompatibilityLevel(ctx context.
 	return &cfgChangeResp.CompatibilityLevel, nil
 }
 
+// DeleteSubjectCompatibilityLevel removes the compatibility level of the
+// subject, which reverts to the global one. It returns the removed level.
+func (client *SchemaRegistryClient) DeleteSubjectCompatibilityLevel(ctx context.Context, subject string) (*CompatibilityLevel, error) {
+	removedConfig, err := client.ResetSubjectConfig(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	return &removedConfig.CompatibilityLevel, nil
+}
+
 // GetGlobalCompatibilityLevel returns the global compatibility level of the registry.
 func (client *SchemaRegistryClient) GetGlobalCompatibilityLevel(ctx context.Context) (*CompatibilityLevel, error) {
 	resp, err := client.httpRequest(ctx, "GET", config, nil)
@@ -319,10 +616,51 @@ func (client *SchemaRegistryClient) GetGlobalCompatibilityLevel(ctx context.Cont
 	return &configResponse.CompatibilityLevel, nil
 }
 
+// GetGlobalConfig returns the full global configuration of the registry,
+// the defaults applied to subjects without their own configuration.
+func (client *SchemaRegistryClient) GetGlobalConfig(ctx context.Context) (*Config, error) {
+	resp, err := client.httpRequest(ctx, "GET", config, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var globalConfig = new(Config)
+	err = json.Unmarshal(resp, &globalConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return globalConfig, nil
+}
+
+// ResetSubjectConfig removes the configuration of the subject, which
+// reverts to the global defaults. It returns the removed configuration.
+func (client *SchemaRegistryClient) ResetSubjectConfig(ctx context.Context, subject string) (*Config, error) {
+	if err := client.authorize(subject, true); err != nil {
+		return nil, err
+	}
+	resp, err := client.httpRequest(ctx, "DELETE", fmt.Sprintf(configBySubject, url.QueryEscape(client.prefixed(subject))), nil)
+	client.audit(ctx, AuditEvent{Operation: AuditResetConfig, Subject: subject}, err)
+	if err != nil {
+		return nil, err
+	}
+
+	var removedConfig = new(Config)
+	err = json.Unmarshal(resp, &removedConfig)
+	if err != nil {
+		return nil, err
+	}
+
+	return removedConfig, nil
+}
+
 // GetCompatibilityLevel returns the compatibility level of the subject.
 // If defaultToGlobal is set to true and no compatibility level is set on the subject, the global compatibility level is returned.
 func (client *SchemaRegistryClient) GetCompatibilityLevel(ctx context.Context, subject string, defaultToGlobal bool) (*CompatibilityLevel, error) {
-	resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(configBySubject+"?defaultToGlobal=%t", url.QueryEscape(subject), defaultToGlobal), nil)
+	if err := client.authorize(subject, false); err != nil {
+		return nil, err
+	}
+	resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(configBySubject+"?defaultToGlobal=%t", url.QueryEscape(client.prefixed(subject)), defaultToGlobal), nil)
 	if err != nil {
 		return nil, err
 	}
@@ -347,11 +685,14 @@ func (client *SchemaRegistryClient) GetSubjects(ctx context.Context) ([]string,
 	if err != nil {
 		return nil, err
 	}
-	return allSubjects, nil
+	return client.readableSubjects(client.unprefixedSubjects(allSubjects)), nil
 }
 
 // GetSubjectsIncludingDeleted returns a list of all subjects in the registry including those which have been soft deleted
 func (client *SchemaRegistryClient) GetSubjectsIncludingDeleted(ctx context.Context) ([]string, error) {
+	if err := client.requireFeature(ctx, deletedSubjectsFeature); err != nil {
+		return nil, err
+	}
 	resp, err := client.httpRequest(ctx, "GET", subjects+"?deleted=true", nil)
 	if err != nil {
 		return nil, err
@@ -361,7 +702,7 @@ func (client *SchemaRegistryClient) GetSubjectsIncludingDeleted(ctx context.Cont
 	if err != nil {
 		return nil, err
 	}
-	return allSubjects, nil
+	return client.readableSubjects(client.unprefixedSubjects(allSubjects)), nil
 }
 
 // GetSchemaByVersion gets the schema associated with the given subject.
@@ -370,61 +711,207 @@ func (client *SchemaRegistryClient) GetSchemaByVersion(ctx context.Context, subj
 	return client.getVersion(ctx, subject, strconv.Itoa(version))
 }
 
+// SubjectExists tells if the subject is registered, a missing
+// subject is reported as false rather than as an error.
+func (client *SchemaRegistryClient) SubjectExists(ctx context.Context, subject string) (bool, error) {
+	_, err := client.GetSchemaVersions(ctx, subject)
+	if isNotFoundError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// VersionExists tells if the subject has the given version, a missing
+// subject or version is reported as false rather than as an error.
+func (client *SchemaRegistryClient) VersionExists(ctx context.Context, subject string, version int) (bool, error) {
+	_, err := client.GetSchemaByVersion(ctx, subject, version)
+	if isNotFoundError(err) {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ListDeletedVersions returns the soft deleted versions of the subject,
+// the ones only listed when deleted versions are requested.
+func (client *SchemaRegistryClient) ListDeletedVersions(ctx context.Context, subject string) ([]int, error) {
+	if err := client.requireFeature(ctx, deletedSubjectsFeature); err != nil {
+		return nil, err
+	}
+	all, err := client.getSchemaVersions(ctx, subject, true)
+	if err != nil {
+		return nil, err
+	}
+	active, err := client.GetSchemaVersions(ctx, subject)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+
+	activeVersions := make(map[int]bool, len(active))
+	for _, version := range active {
+		activeVersions[version] = true
+	}
+	var deleted = []int{}
+	for _, version := range all {
+		if !activeVersions[version] {
+			deleted = append(deleted, version)
+		}
+	}
+	return deleted, nil
+}
+
+// IsSubjectSoftDeleted tells if every version of the subject was soft
+// deleted, as opposed to a subject never registered or permanently deleted.
+func (client *SchemaRegistryClient) IsSubjectSoftDeleted(ctx context.Context, subject string) (bool, error) {
+	if err := client.requireFeature(ctx, deletedSubjectsFeature); err != nil {
+		return false, err
+	}
+	_, err := client.GetSchemaVersions(ctx, subject)
+	if err == nil || !isNotFoundError(err) {
+		return false, err
+	}
+
+	all, err := client.getSchemaVersions(ctx, subject, true)
+	if isNotFoundError(err) {
+		return false, nil
+	}
+	return len(all) > 0, err
+}
+
+// UndeleteSubject restores the soft deleted versions registered after
+// the latest active version of the subject, all of them when the whole
+// subject was soft deleted. The versions are registered again in their
+// original order and the restored schemas are returned.
+func (client *SchemaRegistryClient) UndeleteSubject(ctx context.Context, subject string) ([]*Schema, error) {
+	if err := client.authorize(subject, true); err != nil {
+		return nil, err
+	}
+	deleted, err := client.ListDeletedVersions(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+	active, err := client.GetSchemaVersions(ctx, subject)
+	if err != nil && !isNotFoundError(err) {
+		return nil, err
+	}
+	latestActive := 0
+	for _, version := range active {
+		if version > latestActive {
+			latestActive = version
+		}
+	}
+
+	sort.Ints(deleted)
+	var restored []*Schema
+	for _, version := range deleted {
+		if version < latestActive {
+			continue
+		}
+
+		resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(subjectByVersion, url.QueryEscape(client.prefixed(subject)), strconv.Itoa(version))+"?deleted=true", nil)
+		if err != nil {
+			return restored, err
+		}
+		schemaResp := new(schemaResponse)
+		if err := json.Unmarshal(resp, &schemaResp); err != nil {
+			return restored, err
+		}
+
+		schemaType := Avro
+		if schemaResp.SchemaType != nil {
+			schemaType = *schemaResp.SchemaType
+		}
+		schema, err := client.CreateSchema(ctx, subject, schemaResp.Schema, schemaType, client.unprefixedReferences(schemaResp.References)...)
+		if err != nil {
+			return restored, err
+		}
+		restored = append(restored, schema)
+	}
+	return restored, nil
+}
+
 // CreateSchema creates a new schema in Schema Registry and associates
 // with the subject provided. It returns the newly created schema with
 // all its associated information.
 func (client *SchemaRegistryClient) CreateSchema(ctx context.Context,
 	subject string, schema string,
 	schemaType SchemaType, references ...Reference) (*Schema, error) {
-	switch schemaType {
-	case Avro, Json:
-		compiledRegex := regexp.MustCompile(`\r?\n`)
-		schema = compiledRegex.ReplaceAllString(schema, " ")
-	case Protobuf:
-		break
-	default:
-		return nil, fmt.Errorf("invalid schema type. valid values are Avro, Json, or Protobuf")
-	}
+	return client.createSchema(ctx, subject, schema, schemaType, nil, references)
+}
 
-	if references == nil {
-		references = make([]Reference, 0)
-	}
+// CreateSchemaWithMetadata creates a new schema like CreateSchema, with
+// metadata attached to it. Registering the same schema with different
+// metadata creates a new version of the subject.
+func (client *SchemaRegistryClient) CreateSchemaWithMetadata(ctx context.Context,
+	subject string, schema string, schemaType SchemaType,
+	metadata *SchemaMetadata, references ...Reference) (*Schema, error) {
+	return client.createSchema(ctx, subject, schema, schemaType, metadata, references)
+}
 
-	schemaReq := schemaRequest{Schema: schema, SchemaType: schemaType.String(), References: references}
-	schemaBytes, err := json.Marshal(schemaReq)
+func (client *SchemaRegistryClient) createSchema(ctx context.Context,
+	subject string, schema string, schemaType SchemaType,
+	metadata *SchemaMetadata, references []Reference) (*Schema, error) {
+	if err := client.authorize(subject, true); err != nil {
+		return nil, err
+	}
+	request, err := newRegistrationRequest(schema, schemaType, metadata, client.prefixedReferences(references), client.minifySchemas)
 	if err != nil {
 		return nil, err
 	}
-	payload := bytes.NewBuffer(schemaBytes)
-	resp, err := client.httpRequest(ctx, "POST", fmt.Sprintf(subjectVersions, url.QueryEscape(subject)), payload)
+	if client.maxSchemaBytes > 0 {
+		size, err := requestSize(request)
+		if err != nil {
+			return nil, err
+		}
+		if size > client.maxSchemaBytes {
+			return nil, fmt.Errorf("%w: subject %s, %d bytes, more than %d", ErrSchemaTooLarge, subject, size, client.maxSchemaBytes)
+		}
+	}
+	if err := client.checkStreamProcessors(ctx, subject, schema, schemaType, references); err != nil {
+		return nil, err
+	}
+	unlock, err := client.lockSubject(ctx, subject)
 	if err != nil {
 		return nil, err
 	}
+	defer unlock()
+	payload := streamRequest(request)
+	defer payload.Close()
+	resp, err := client.httpRequest(ctx, "POST", fmt.Sprintf(subjectVersions, url.QueryEscape(client.prefixed(subject))), payload)
+	if err != nil {
+		if isSchemaTooLargeError(err) {
+			size, _ := requestSize(request)
+			err = newKindError(ErrSchemaTooLarge, fmt.Sprintf("%s: subject %s, %d bytes", ErrSchemaTooLarge, subject, size), err)
+		}
+		client.audit(ctx, AuditEvent{Operation: AuditRegisterSchema, Subject: subject}, err)
+		return nil, err
+	}
 
 	schemaResp := new(schemaResponse)
 	err = json.Unmarshal(resp, &schemaResp)
+	client.audit(ctx, AuditEvent{Operation: AuditRegisterSchema, Subject: subject, SchemaID: schemaResp.ID, Version: schemaResp.Version}, err)
 	if err != nil {
 		return nil, err
 	}
+	client.notFound.reset()
 
 	newSchema, err := client.GetSchema(ctx, schemaResp.ID)
 	if err != nil {
 		return nil, err
 	}
+	if client.readYourWrites > 0 {
+		if err := client.awaitRegistration(ctx, subject, newSchema, schema, schemaType, references); err != nil {
+			return newSchema, fmt.Errorf("schema registered but not served yet: %w", err)
+		}
+	}
 
 	if client.getCachingEnabled() {
 
 		// Update the subject-2-schema cache
-		cacheKey := cacheKey(subject,
-			strconv.Itoa(newSchema.version))
-		client.subjectSchemaCacheLock.Lock()
-		client.subjectSchemaCache[cacheKey] = newSchema
-		client.subjectSchemaCacheLock.Unlock()
+		cacheKey := versionCacheKey(subject, strconv.Itoa(newSchema.version))
+		client.subjectSchemaCache.set(cacheKey, client.newSchemaCacheEntry(newSchema))
 
 		// Update the id-2-schema cache
-		client.idSchemaCacheLock.Lock()
-		client.idSchemaCache[newSchema.id] = newSchema
-		client.idSchemaCacheLock.Unlock()
+		client.idSchemaCache.set(newSchema.id, client.newSchemaCacheEntry(newSchema))
 
 	}
 
@@ -433,27 +920,15 @@ func (client *SchemaRegistryClient) CreateSchema(ctx context.Context,
 
 // LookupSchema looks up the schema by subject and schema string. If it finds the schema it returns it with all its associated information.
 func (client *SchemaRegistryClient) LookupSchema(ctx context.Context, subject string, schema string, schemaType SchemaType, references ...Reference) (*Schema, error) {
-	switch schemaType {
-	case Avro, Json:
-		compiledRegex := regexp.MustCompile(`\r?\n`)
-		schema = compiledRegex.ReplaceAllString(schema, " ")
-	case Protobuf:
-		break
-	default:
-		return nil, fmt.Errorf("invalid schema type. valid values are Avro, Json, or Protobuf")
-	}
-
-	if references == nil {
-		references = make([]Reference, 0)
+	if err := client.authorize(subject, false); err != nil {
+		return nil, err
 	}
-
-	schemaReq := schemaRequest{Schema: schema, SchemaType: schemaType.String(), References: references}
-	schemaBytes, err := json.Marshal(schemaReq)
+	schemaBytes, err := registrationRequest(schema, schemaType, nil, client.prefixedReferences(references), client.minifySchemas)
 	if err != nil {
 		return nil, err
 	}
 	payload := bytes.NewBuffer(schemaBytes)
-	resp, err := client.httpRequest(ctx, "POST", fmt.Sprintf(subjectBySubject, url.QueryEscape(subject)), payload)
+	resp, err := client.httpRequest(ctx, "POST", fmt.Sprintf(subjectBySubject, url.QueryEscape(client.prefixed(subject))), payload)
 	if err != nil {
 		return nil, err
 	}
@@ -472,27 +947,28 @@ func (client *SchemaRegistryClient) LookupSchema(ctx context.Context, subject st
 		}
 	}
 	var gotSchema = &Schema{
-		id:         schemaResp.ID,
-		schema:     schemaResp.Schema,
-		schemaType: schemaResp.SchemaType,
-		version:    schemaResp.Version,
-		references: schemaResp.References,
-		codec:      codec,
+		id:           schemaResp.ID,
+		schema:       schemaResp.Schema,
+		schemaType:   schemaResp.SchemaType,
+		version:      schemaResp.Version,
+		references:   client.unprefixedReferences(schemaResp.References),
+		metadata:     schemaResp.Metadata,
+		registeredAt: registrationTime(schemaResp.Timestamp),
+		subject:      subject,
+		fetchedAt:    client.now(),
+		raw:          resp,
+		codec:        codec,
+		deleted:      schemaResp.Deleted || schemaResp.IsDeleted,
 	}
 
 	if client.getCachingEnabled() {
 
 		// Update the subject-2-schema cache
-		cacheKey := cacheKey(subject,
-			strconv.Itoa(gotSchema.version))
-		client.subjectSchemaCacheLock.Lock()
-		client.subjectSchemaCache[cacheKey] = gotSchema
-		client.subjectSchemaCacheLock.Unlock()
+		cacheKey := versionCacheKey(subject, strconv.Itoa(gotSchema.version))
+		client.subjectSchemaCache.set(cacheKey, client.newSchemaCacheEntry(gotSchema))
 
 		// Update the id-2-schema cache
-		client.idSchemaCacheLock.Lock()
-		client.idSchemaCache[gotSchema.id] = gotSchema
-		client.idSchemaCacheLock.Unlock()
+		client.idSchemaCache.set(gotSchema.id, client.newSchemaCacheEntry(gotSchema))
 
 	}
 
@@ -502,6 +978,10 @@ func (client *SchemaRegistryClient) LookupSchema(ctx context.Context, subject st
 // IsSchemaCompatible checks if the given schema is compatible with the given subject and version
 // valid versions are versionID and "latest"
 func (client *SchemaRegistryClient) IsSchemaCompatible(ctx context.Context, subject, schema, version string, schemaType SchemaType, references ...Reference) (bool, error) {
+	if err := client.authorize(subject, false); err != nil {
+		return false, err
+	}
+	references = client.prefixedReferences(references)
 	if references == nil {
 		references = make([]Reference, 0)
 	}
@@ -513,7 +993,7 @@ func (client *SchemaRegistryClient) IsSchemaCompatible(ctx context.Context, subj
 	}
 	payload := bytes.NewBuffer(schemaReqBytes)
 
-	url := fmt.Sprintf("/compatibility/subjects/%s/versions/%s", subject, version)
+	url := fmt.Sprintf("/compatibility/subjects/%s/versions/%s", client.prefixed(subject), version)
 	resp, err := client.httpRequest(ctx, "POST", url, payload)
 	if err != nil {
 		return false, err
@@ -528,30 +1008,58 @@ func (client *SchemaRegistryClient) IsSchemaCompatible(ctx context.Context, subj
 	return compatibilityResponse.IsCompatible, nil
 }
 
-// DeleteSubject deletes
-func (client *SchemaRegistryClient) DeleteSubject(ctx context.Context, subject string, permanent bool) error {
-	uri := "/subjects/" + subject
-	_, err := client.httpRequest(ctx, "DELETE", uri, nil)
-	if err != nil || !permanent {
-		return err
+// DeleteSubject deletes the subject, soft deleting it and then removing it
+// for good when permanent. It returns the deleted versions.
+func (client *SchemaRegistryClient) DeleteSubject(ctx context.Context, subject string, permanent bool) ([]int, error) {
+	if err := client.authorize(subject, true); err != nil {
+		return nil, err
 	}
+	var versions []int
+	err := client.approveDelete(ctx, ApprovalRequest{Operation: AuditDeleteSubject, Subject: subject, Permanent: permanent})
+	if err == nil {
+		var resp []byte
+		if resp, err = client.delete(ctx, "/subjects/"+client.prefixed(subject), permanent); err == nil {
+			err = json.Unmarshal(resp, &versions)
+		}
+	}
+	client.audit(ctx, AuditEvent{Operation: AuditDeleteSubject, Subject: subject, Permanent: permanent}, err)
+	if err != nil {
+		return nil, err
+	}
+	return versions, nil
+}
 
-	uri += "?permanent=true"
-	_, err = client.httpRequest(ctx, "DELETE", uri, nil)
-	return err
+// DeleteSubjectByVersion deletes the version of the scheme, it returns the
+// number of the deleted version.
+func (client *SchemaRegistryClient) DeleteSubjectByVersion(ctx context.Context, subject string, version int, permanent bool) (int, error) {
+	if err := client.authorize(subject, true); err != nil {
+		return 0, err
+	}
+	var deleted int
+	err := client.approveDelete(ctx, ApprovalRequest{Operation: AuditDeleteVersion, Subject: subject, Version: version, Permanent: permanent})
+	if err == nil {
+		var resp []byte
+		if resp, err = client.delete(ctx, fmt.Sprintf(subjectByVersion, client.prefixed(subject), strconv.Itoa(version)), permanent); err == nil {
+			err = json.Unmarshal(resp, &deleted)
+		}
+	}
+	client.audit(ctx, AuditEvent{Operation: AuditDeleteVersion, Subject: subject, Version: version, Permanent: permanent}, err)
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
 }
 
-// DeleteSubjectByVersion deletes the version of the scheme
-func (client *SchemaRegistryClient) DeleteSubjectByVersion(ctx context.Context, subject string, version int, permanent bool) error {
-	uri := fmt.Sprintf(subjectByVersion, subject, strconv.Itoa(version))
-	_, err := client.httpRequest(ctx, "DELETE", uri, nil)
+// delete soft deletes uri, then deletes it permanently if asked to. It
+// returns the response of the last request.
+func (client *SchemaRegistryClient) delete(ctx context.Context, uri string, permanent bool) ([]byte, error) {
+	resp, err := client.httpRequest(ctx, "DELETE", uri, nil)
 	if err != nil || !permanent {
-		return err
+		return resp, err
 	}
 
 	uri += "?permanent=true"
-	_, err = client.httpRequest(ctx, "DELETE", uri, nil)
-	return err
+	return client.httpRequest(ctx, "DELETE", uri, nil)
 }
 
 // SetCredentials allows users to set credentials to be
@@ -583,10 +1091,20 @@ func (client *SchemaRegistryClient) SetBearerToken(token TokenProvider) {
 // SetTimeout allows the client to be reconfigured about
 // how much time internal HTTP requests will take until
 // they timeout. FYI, It defaults to five seconds.
+// WithCallTimeout overrides it for a single call.
 func (client *SchemaRegistryClient) SetTimeout(timeout time.Duration) {
 	client.httpClient.Timeout = timeout
 }
 
+// SetMaxResponseBytes limits the size of the responses read from the
+// registry, larger responses fail with ErrResponseTooLarge instead of
+// being loaded in memory. Zero, the default, means no limit.
+func (client *SchemaRegistryClient) SetMaxResponseBytes(max int64) {
+	client.maxResponseBytesLock.Lock()
+	defer client.maxResponseBytesLock.Unlock()
+	client.maxResponseBytes = max
+}
+
 // CachingEnabled allows the client to cache any values
 // that have been returned, which may speed up performance
 // if these values rarely changes.
@@ -611,23 +1129,98 @@ func (client *SchemaRegistryClient) CodecCreationEnabled(value bool) {
 	client.codecCreationEnabled = value
 }
 
+// SetSemaphoreHook registers a hook called every time a request
+// waits for a slot of the concurrent requests semaphore, exposing
+// the queue depth and the time spent waiting.
+func (client *SchemaRegistryClient) SetSemaphoreHook(hook SemaphoreHook) {
+	client.readSem.setHook(hook)
+	client.writeSem.setHook(hook)
+}
+
+// SetSemaphoreWeight changes the limit of concurrent requests to the
+// registry while requests may be running. Zero disables the limit. When
+// reads and writes are limited separately, both budgets are given the
+// weight; SetReadSemaphoreWeight and SetWriteSemaphoreWeight keep them
+// apart.
+func (client *SchemaRegistryClient) SetSemaphoreWeight(weight int) {
+	client.readSem.resize(weight)
+	if client.writeSem != client.readSem {
+		client.writeSem.resize(weight)
+	}
+}
+
+// SetReadSemaphoreWeight changes the limit of concurrent read requests
+// of a client with separate budgets, leaving the write one as it is.
+// Zero disables the limit. Clients limiting reads and writes together,
+// which can't be split once created, have their single limit changed
+// like with SetSemaphoreWeight.
+func (client *SchemaRegistryClient) SetReadSemaphoreWeight(weight int) {
+	client.readSem.resize(weight)
+}
+
+// SetWriteSemaphoreWeight changes the limit of concurrent write requests
+// of a client with separate budgets, leaving the read one as it is.
+// Zero disables the limit. Clients limiting reads and writes together
+// have their single limit changed like with SetSemaphoreWeight.
+func (client *SchemaRegistryClient) SetWriteSemaphoreWeight(weight int) {
+	client.writeSem.resize(weight)
+}
+
+// SemaphoreQueueDepth returns the number of requests currently
+// waiting for a slot of the concurrent requests semaphores.
+func (client *SchemaRegistryClient) SemaphoreQueueDepth() int {
+	if client.readSem == client.writeSem {
+		return client.readSem.queueDepth()
+	}
+	return client.readSem.queueDepth() + client.writeSem.queueDepth()
+}
+
 func (client *SchemaRegistryClient) getVersion(ctx context.Context, subject string, version string) (*Schema, error) {
 
+	if err := client.authorize(subject, false); err != nil {
+		return nil, err
+	}
+	var cached schemaCacheEntry
 	if client.getCachingEnabled() {
 		if version != "latest" || (version == "latest" && client.getCacheLatest()) {
-			cacheKey := cacheKey(subject, version)
-			client.subjectSchemaCacheLock.RLock()
-			cachedResult := client.subjectSchemaCache[cacheKey]
-			client.subjectSchemaCacheLock.RUnlock()
-			if cachedResult != nil {
-				return cachedResult, nil
+			cacheKey := versionCacheKey(subject, version)
+			cached = client.subjectSchemaCache.get(cacheKey)
+			if client.fresh(cached) {
+				return cached.schema, nil
 			}
 		}
 	}
 
-	resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(subjectByVersion, url.QueryEscape(subject), version), nil)
+	uri := fmt.Sprintf(subjectByVersion, url.QueryEscape(client.prefixed(subject)), version)
+	return client.coalesce(ctx, uri, func(ctx context.Context) (*Schema, error) {
+		return client.fetchVersion(ctx, subject, version, uri, cached)
+	})
+}
+
+// fetchVersion fetches a version of the subject. The latest version is
+// fetched with a conditional request when its cached entry has an ETag,
+// a 304 Not Modified refreshing the entry instead of downloading the
+// schema again.
+func (client *SchemaRegistryClient) fetchVersion(ctx context.Context, subject, version, uri string, cached schemaCacheEntry) (*Schema, error) {
+	var resp []byte
+	var err error
+	conditional := new(conditionalRequest)
+	if version == "latest" {
+		if cached.schema != nil {
+			conditional.ifNoneMatch = cached.etag
+		}
+		resp, err = client.fetchSchema(withConditionalRequest(ctx, conditional), uri)
+	} else {
+		resp, err = client.readThrough(ctx, uri)
+	}
+	if errors.Is(err, errNotModified) {
+		refreshed := client.newSchemaCacheEntry(cached.schema)
+		refreshed.etag = cached.etag
+		client.subjectSchemaCache.set(versionCacheKey(subject, version), refreshed)
+		return cached.schema, nil
+	}
 	if err != nil {
-		return nil, err
+		return client.stale.fallback(ctx, uri, err, client.now())
 	}
 
 	schemaResp := new(schemaResponse)
@@ -643,73 +1236,143 @@ func (client *SchemaRegistryClient) getVersion(ctx context.Context, subject stri
 		}
 	}
 	var schema = &Schema{
-		id:         schemaResp.ID,
-		schema:     schemaResp.Schema,
-		schemaType: schemaResp.SchemaType,
-		version:    schemaResp.Version,
-		references: schemaResp.References,
-		codec:      codec,
+		id:           schemaResp.ID,
+		schema:       schemaResp.Schema,
+		schemaType:   schemaResp.SchemaType,
+		version:      schemaResp.Version,
+		references:   client.unprefixedReferences(schemaResp.References),
+		metadata:     schemaResp.Metadata,
+		registeredAt: registrationTime(schemaResp.Timestamp),
+		subject:      subject,
+		fetchedAt:    client.now(),
+		raw:          resp,
+		codec:        codec,
+		deleted:      schemaResp.Deleted || schemaResp.IsDeleted,
+	}
+	if err := client.checksums.verify(subject, schema); err != nil {
+		return nil, err
 	}
 
 	if client.getCachingEnabled() {
 		if version != "latest" || (version == "latest" && client.getCacheLatest()) {
 			// Update the subject-2-schema cache
-			cacheKey := cacheKey(subject, version)
-			client.subjectSchemaCacheLock.Lock()
-			client.subjectSchemaCache[cacheKey] = schema
-			client.subjectSchemaCacheLock.Unlock()
+			cacheKey := versionCacheKey(subject, version)
+			entry := client.newSchemaCacheEntry(schema)
+			entry.etag = conditional.etag
+			client.subjectSchemaCache.set(cacheKey, entry)
 		}
 
 		// Update the id-2-schema cache
-		client.idSchemaCacheLock.Lock()
-		client.idSchemaCache[schema.id] = schema
-		client.idSchemaCacheLock.Unlock()
+		client.idSchemaCache.set(schema.id, client.newSchemaCacheEntry(schema))
 
 	}
 
+	client.stale.remember(uri, schema, client.now())
+
 	return schema, nil
 }
 
 func (client *SchemaRegistryClient) httpRequest(ctx context.Context, method, uri string, payload io.Reader) ([]byte, error) {
+	if err := client.lifecycle.begin(); err != nil {
+		return nil, err
+	}
+	defer client.lifecycle.end()
+	if client.leader != nil && isWriteRequest(method, uri) {
+		return client.leaderRequest(ctx, method, uri, payload)
+	}
+	if client.retry.maxAttempts > 1 && method == http.MethodGet {
+		return client.retriedRequest(ctx, method, uri)
+	}
+	return client.httpRequestTo(ctx, client.baseURL(), method, uri, payload, &RequestAttempt{})
+}
 
-	url := fmt.Sprintf("%s%s", client.schemaRegistryURL, uri)
+// httpRequestTo sends a request to the registry at base, describing it
+// in attempt.
+func (client *SchemaRegistryClient) httpRequestTo(ctx context.Context, base, method, uri string, payload io.Reader, attempt *RequestAttempt) ([]byte, error) {
+	attempt.Time, attempt.Endpoint = client.now(), base
+	if client.urlErr != nil {
+		return nil, client.urlErr
+	}
+	if client.budget != nil {
+		if err := client.budget.spend(client.now()); err != nil {
+			return nil, err
+		}
+	}
+
+	url := fmt.Sprintf("%s%s", base, uri)
 	req, err := http.NewRequestWithContext(ctx, method, url, payload)
 	if err != nil {
 		return nil, err
 	}
+	client.setHeaders(ctx, req)
+	setConditionalHeaders(ctx, req)
 
 	client.credsLock.RLock()
-	if client.credentials != nil {
-		if len(client.credentials.username) > 0 && len(client.credentials.password) > 0 {
-			req.SetBasicAuth(client.credentials.username, client.credentials.password)
-		} else if client.credentials.bearerToken != nil {
-			token, err := client.credentials.bearerToken.ObtainToken(ctx)
+	creds := client.credentials
+	client.credsLock.RUnlock()
+	if creds != nil {
+		if len(creds.username) > 0 && len(creds.password) > 0 {
+			req.SetBasicAuth(creds.username, creds.password)
+		} else if creds.bearerToken != nil {
+			token, err := creds.bearerToken.ObtainToken(ctx)
 			if err != nil {
 				return nil, err
 			}
 
-			req.Header.Add("Authorization", "Bearer "+token)
+			req.Header.Set("Authorization", "Bearer "+token)
 		}
 	}
-	client.credsLock.RUnlock()
 
 	req.Header.Set("Content-Type", contentType)
 
-	client.sem.Acquire(context.Background(), 1)
-	defer client.sem.Release(1)
-	resp, err := client.httpClient.Do(req)
+	sem, write := client.readSem, isWriteRequest(method, uri)
+	if write {
+		sem = client.writeSem
+	}
+	release, err := sem.acquire(ctx, write)
 	if err != nil {
 		return nil, err
 	}
-
-	if resp != nil {
-		defer resp.Body.Close()
+	defer release()
+	resp, err := client.requestClient(ctx, write).Do(req)
+	if err != nil {
+		if client.endpoints != nil && ctx.Err() == nil {
+			client.endpoints.failed(base)
+		}
+		return nil, err
+	}
+	attempt.StatusCode = resp.StatusCode
+
+	body := resp.Body
+	defer func() {
+		// Drain what's left of the body so that the connection can be reused
+		_, _ = io.Copy(io.Discard, io.LimitReader(body, maxDrainBytes))
+		body.Close()
+	}()
+	if conditionalResponse(ctx, resp) {
+		return nil, errNotModified
 	}
 	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		return nil, createError(resp)
+		return nil, client.handleErrorStatus(resp)
 	}
 
-	return ioutil.ReadAll(resp.Body)
+	return readLimited(resp.Body, client.getMaxResponseBytes())
+}
+
+// readLimited reads the whole body unless it is larger than max bytes,
+// max being zero or less means no limit.
+func readLimited(body io.Reader, max int64) ([]byte, error) {
+	if max <= 0 {
+		return io.ReadAll(body)
+	}
+	data, err := io.ReadAll(io.LimitReader(body, max+1))
+	if err != nil {
+		return nil, err
+	}
+	if int64(len(data)) > max {
+		return nil, fmt.Errorf("%w: more than %d bytes", ErrResponseTooLarge, max)
+	}
+	return data, nil
 }
 
 func (client *SchemaRegistryClient) getCachingEnabled() bool {
@@ -718,6 +1381,12 @@ func (client *SchemaRegistryClient) getCachingEnabled() bool {
 	return client.cachingEnabled
 }
 
+func (client *SchemaRegistryClient) getMaxResponseBytes() int64 {
+	client.maxResponseBytesLock.RLock()
+	defer client.maxResponseBytesLock.RUnlock()
+	return client.maxResponseBytes
+}
+
 func (client *SchemaRegistryClient) getCacheLatest() bool {
 	client.cacheLatestLock.RLock()
 	defer client.cacheLatestLock.RUnlock()
@@ -769,7 +1438,8 @@ func (schema *Schema) SchemaType() *SchemaType {
 	return schema.schemaType
 }
 
-// Version ensures access to Version
+// Version ensures access to Version, it is zero for schemas fetched
+// by ID, see KnownVersion
 func (schema *Schema) Version() int {
 	return schema.version
 }
@@ -779,10 +1449,48 @@ func (schema *Schema) References() []Reference {
 	return schema.references
 }
 
+// Metadata ensures access to Metadata, it is nil when
+// the registry doesn't return any
+func (schema *Schema) Metadata() *SchemaMetadata {
+	return schema.metadata
+}
+
+// RegisteredAt returns when the version was registered, it is zero
+// when the registry doesn't return registration times
+func (schema *Schema) RegisteredAt() time.Time {
+	return schema.registeredAt
+}
+
+// Subject returns the subject the schema was fetched from, it is empty
+// for schemas fetched by ID from registries which don't tell
+func (schema *Schema) Subject() string {
+	return schema.subject
+}
+
+// Deleted tells whether the version was soft deleted, as flagged by the
+// registries returning soft deleted versions when they are requested
+func (schema *Schema) Deleted() bool {
+	return schema.deleted
+}
+
+// FetchedAt returns when the schema was fetched from the registry, it
+// is zero for schemas which weren't, such as the ones of a snapshot
+func (schema *Schema) FetchedAt() time.Time {
+	return schema.fetchedAt
+}
+
+// RawResponse returns the JSON body of the registry response the schema
+// was built from, to log or audit it. It must not be modified.
+func (schema *Schema) RawResponse() []byte {
+	return schema.raw
+}
+
 // Codec ensures access to Codec
 // Will try to initialize a new one if it hasn't been initialized before
 // Will return nil if it can't initialize a codec from the schema
 func (schema *Schema) Codec() *goavro.Codec {
+	schema.lazyLock.Lock()
+	defer schema.lazyLock.Unlock()
 	if schema.codec == nil {
 		codec, err := goavro.NewCodec(schema.Schema())
 		if err == nil {
@@ -796,6 +1504,8 @@ func (schema *Schema) Codec() *goavro.Codec {
 // Will try to initialize a new one if it hasn't been initialized before
 // Will return nil if it can't initialize a json schema from the schema
 func (schema *Schema) JsonSchema() *jsonschema.Schema {
+	schema.lazyLock.Lock()
+	defer schema.lazyLock.Unlock()
 	if schema.jsonSchema == nil {
 		jsonSchema, err := jsonschema.CompileString("schema.json", schema.Schema())
 		if err == nil {
@@ -805,28 +1515,133 @@ func (schema *Schema) JsonSchema() *jsonschema.Schema {
 	return schema.jsonSchema
 }
 
-func cacheKey(subject string, version string) string {
-	return fmt.Sprintf("%s-%s", subject, version)
+// registrationTime converts the registration timestamp of a version.
+func registrationTime(timestamp int64) time.Time {
+	if timestamp == 0 {
+		return time.Time{}
+	}
+	return time.Unix(0, timestamp*int64(time.Millisecond)).UTC()
+}
+
+// subjectCacheKey identifies a schema of the subject cache, either by
+// version or by ID. Keeping the subject in its own field, rather than
+// joining it with the version, prevents subjects containing dashes from
+// colliding with the versions of other subjects, and lets the entries of
+// a subject be found by comparing the field.
+type subjectCacheKey struct {
+	subject string
+	// version is a version number or "latest", empty for ID keys.
+	version string
+	id      int
+}
+
+// versionCacheKey is the key of a version, or "latest", of the subject.
+func versionCacheKey(subject string, version string) subjectCacheKey {
+	return subjectCacheKey{subject: subject, version: version}
+}
+
+// idCacheKey is the key of a schema ID registered under the subject.
+func idCacheKey(subject string, schemaID int) subjectCacheKey {
+	return subjectCacheKey{subject: subject, id: schemaID}
 }
 
 // Error implements error, encodes HTTP errors from Schema Registry.
 type Error struct {
 	Code    int    `json:"error_code"`
 	Message string `json:"message"`
-	str     *bytes.Buffer
+	// ContentType and Body are only set for responses which aren't
+	// registry errors, Body is a truncated snippet of the response.
+	ContentType string `json:"-"`
+	Body        string `json:"-"`
+	str         *bytes.Buffer
 }
 
 func (e Error) Error() string {
 	return e.str.String()
 }
 
+// kindError is an error of a kind, such as ErrFeatureNotSupported,
+// caused by another error: errors.Is matches both of them.
+type kindError struct {
+	kind  error
+	cause error
+	msg   string
+}
+
+// newKindError returns an error of the kind whose message is detail,
+// followed by the message of its cause.
+func newKindError(kind error, detail string, cause error) error {
+	return &kindError{kind: kind, cause: cause, msg: fmt.Sprintf("%s: %s", detail, cause)}
+}
+
+func (e *kindError) Error() string {
+	return e.msg
+}
+
+func (e *kindError) Is(target error) bool {
+	return target == e.kind
+}
+
+func (e *kindError) Unwrap() error {
+	return e.cause
+}
+
+// isNotFoundError tells if the error means that the requested
+// subject, version or schema does not exist in the registry.
+func isNotFoundError(err error) bool {
+	var registryErr Error
+	if errors.As(err, &registryErr) {
+		return registryErr.Code == http.StatusNotFound || registryErr.Code/100 == http.StatusNotFound
+	}
+	return errors.Is(err, errSchemaNotFound) || errors.Is(err, errSubjectNotFound) || errors.Is(err, errConfigNotFound) ||
+		errors.Is(err, errModeNotFound)
+}
+
 func createError(resp *http.Response) error {
-	err := Error{str: bytes.NewBuffer(make([]byte, 0))}
-	decoder := json.NewDecoder(io.TeeReader(resp.Body, err.str))
-	marshalErr := decoder.Decode(&err)
+	body, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	err := Error{str: bytes.NewBuffer(body)}
+	marshalErr := json.NewDecoder(bytes.NewReader(body)).Decode(&err)
 	if marshalErr != nil {
-		return fmt.Errorf("%s", resp.Status)
+		// Keep the status code around, registries behind proxies or alternative
+		// implementations don't always answer with a JSON error body
+		return nonJSONError(resp, body)
 	}
 
 	return err
 }
+
+// nonJSONError describes an error response which isn't a registry error,
+// a snippet of the body is kept and HTML pages, usually served by a proxy
+// or another service, are pointed out.
+func nonJSONError(resp *http.Response, body []byte) Error {
+	snippet := strings.TrimSpace(string(body))
+	if len(snippet) > errorBodySnippetLength {
+		snippet = snippet[:errorBodySnippetLength] + "..."
+	}
+	contentType := resp.Header.Get("Content-Type")
+
+	message := resp.Status
+	if snippet != "" {
+		message += ": " + snippet
+	}
+	if isHTMLBody(contentType, snippet) {
+		message += " (received an HTML page instead of a Schema Registry response, " +
+			"you are probably hitting the wrong URL or port, or a proxy in front of the registry)"
+	}
+
+	return Error{
+		Code:        resp.StatusCode,
+		Message:     resp.Status,
+		ContentType: contentType,
+		Body:        snippet,
+		str:         bytes.NewBufferString(message),
+	}
+}
+
+func isHTMLBody(contentType, body string) bool {
+	if strings.HasPrefix(contentType, "text/html") {
+		return true
+	}
+	lower := strings.ToLower(body)
+	return strings.HasPrefix(lower, "<!doctype html") || strings.HasPrefix(lower, "<html")
+}
@@ -0,0 +1,145 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"regexp"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// These tests are meant to be run with -race, they hammer the client from
+// many goroutines at once to make sure the caches, the setters and the lazy
+// initialization on Schema stay thread-safe.
+
+const (
+	concurrencyWorkers    = 16
+	concurrencyIterations = 50
+	concurrencySchema     = `{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}]}`
+)
+
+var (
+	concurrencyIDRegex      = regexp.MustCompile(`^/schemas/ids/(\d+)$`)
+	concurrencyVersionRegex = regexp.MustCompile(`^/subjects/([^/]+)/versions/([^/]+)$`)
+)
+
+// fakeConcurrencyServer answers to schema by id and schema by version lookups
+// for any id, subject or version requested.
+func fakeConcurrencyServer(t *testing.T) *httptest.Server {
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var resp schemaResponse
+		if match := concurrencyIDRegex.FindStringSubmatch(req.URL.Path); match != nil {
+			id, _ := strconv.Atoi(match[1])
+			resp = schemaResponse{ID: id, Schema: concurrencySchema}
+		} else if match := concurrencyVersionRegex.FindStringSubmatch(req.URL.Path); match != nil {
+			version, err := strconv.Atoi(match[2])
+			if err != nil {
+				version = 1
+			}
+			resp = schemaResponse{Subject: match[1], Version: version, ID: version, Schema: concurrencySchema}
+		} else {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		response, _ := json.Marshal(resp)
+		if _, err := rw.Write(response); err != nil {
+			t.Errorf("could not write response %s", err)
+		}
+	}))
+}
+
+func runConcurrently(work func(worker, iteration int)) {
+	var wg sync.WaitGroup
+	for worker := 0; worker < concurrencyWorkers; worker++ {
+		wg.Add(1)
+		go func(worker int) {
+			defer wg.Done()
+			for iteration := 0; iteration < concurrencyIterations; iteration++ {
+				work(worker, iteration)
+			}
+		}(worker)
+	}
+	wg.Wait()
+}
+
+func TestSchemaRegistryClient_Concurrency_CacheReadsAndWrites(t *testing.T) {
+	t.Parallel()
+	server := fakeConcurrencyServer(t)
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.CacheLatest(true)
+
+	runConcurrently(func(worker, iteration int) {
+		id := iteration % 5
+		schema, err := srClient.GetSchema(context.Background(), id)
+		if assert.NoError(t, err) {
+			assert.Equal(t, id, schema.ID())
+		}
+
+		subject := fmt.Sprintf("subject-%d", worker%3)
+		schema, err = srClient.GetSchemaByVersion(context.Background(), subject, id+1)
+		if assert.NoError(t, err) {
+			assert.Equal(t, id+1, schema.Version())
+		}
+
+		_, err = srClient.GetLatestSchema(context.Background(), subject)
+		assert.NoError(t, err)
+
+		if iteration%10 == 0 {
+			srClient.ResetCache()
+		}
+	})
+}
+
+func TestSchemaRegistryClient_Concurrency_SetterToggles(t *testing.T) {
+	t.Parallel()
+	server := fakeConcurrencyServer(t)
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	runConcurrently(func(worker, iteration int) {
+		switch worker % 4 {
+		case 0:
+			srClient.CachingEnabled(iteration%2 == 0)
+		case 1:
+			srClient.CacheLatest(iteration%2 == 0)
+		case 2:
+			srClient.CodecCreationEnabled(iteration%2 == 0)
+		case 3:
+			srClient.SetCredentials("user", strconv.Itoa(iteration))
+		}
+
+		_, err := srClient.GetSchema(context.Background(), iteration%5)
+		assert.NoError(t, err)
+		_, err = srClient.GetLatestSchema(context.Background(), "subject")
+		assert.NoError(t, err)
+	})
+}
+
+func TestSchemaRegistryClient_Concurrency_LazyInitialization(t *testing.T) {
+	t.Parallel()
+	server := fakeConcurrencyServer(t)
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.CodecCreationEnabled(false)
+
+	schema, err := srClient.GetSchema(context.Background(), 1)
+	if !assert.NoError(t, err) {
+		return
+	}
+
+	runConcurrently(func(worker, iteration int) {
+		assert.NotNil(t, schema.Codec())
+		_ = schema.JsonSchema()
+	})
+}
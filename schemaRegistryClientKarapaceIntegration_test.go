@@ -0,0 +1,29 @@
+//go:build integration && karapace
+// +build integration,karapace
+
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests run against Karapace's schema registry implementation,
+// point SRCLIENT_URL to it and run with -tags integration,karapace.
+
+func TestKarapaceFeatures(t *testing.T) {
+	t.Parallel()
+	features, err := client.Features(context.Background())
+	require.NoError(t, err)
+	t.Log("Detected features: ", *features)
+}
+
+func TestKarapaceCompatibilityLevel(t *testing.T) {
+	t.Parallel()
+	level, err := client.GetGlobalCompatibilityLevel(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, Full, *level)
+}
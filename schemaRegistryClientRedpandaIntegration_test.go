@@ -0,0 +1,82 @@
+//go:build integration && redpanda
+// +build integration,redpanda
+
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// These tests run against Redpanda's schema registry implementation,
+// point SRCLIENT_URL to it and run with -tags integration,redpanda.
+
+func TestRedpandaFeatures(t *testing.T) {
+	t.Parallel()
+	features, err := client.Features(context.Background())
+	require.NoError(t, err)
+	assert.True(t, features.DeletedSubjects)
+
+	_, err = client.GetMode(context.Background())
+	if features.Mode {
+		assert.NoError(t, err)
+	} else {
+		assert.ErrorIs(t, err, ErrFeatureNotSupported)
+		_, err = client.SetSubjectMode(context.Background(), "RedpandaModes", ReadOnly)
+		assert.ErrorIs(t, err, ErrFeatureNotSupported)
+	}
+}
+
+func TestRedpandaSoftDeletedSubject(t *testing.T) {
+	t.Parallel()
+	subject := "RedpandaSoftDeleted"
+	schema := `{"type": "record", "name": "RedpandaSoftDeleted", "fields": [{"name": "value", "type": "long"}]}`
+	_, err := client.CreateSchema(context.Background(), subject, schema, Avro)
+	require.NoError(t, err)
+	_, err = client.DeleteSubject(context.Background(), subject, false)
+	require.NoError(t, err)
+
+	softDeleted, err := client.IsSubjectSoftDeleted(context.Background(), subject)
+	require.NoError(t, err)
+	assert.True(t, softDeleted)
+	deleted, err := client.ListDeletedVersions(context.Background(), subject)
+	require.NoError(t, err)
+	assert.Equal(t, []int{1}, deleted)
+	subjects, err := client.GetSubjectsIncludingDeleted(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, subjects, subject)
+
+	restored, err := client.UndeleteSubject(context.Background(), subject)
+	require.NoError(t, err)
+	if assert.Len(t, restored, 1) {
+		assert.False(t, restored[0].Deleted())
+	}
+
+	_, err = client.DeleteSubject(context.Background(), subject, false)
+	require.NoError(t, err)
+	_, err = client.DeleteSubject(context.Background(), subject, true)
+	require.NoError(t, err)
+}
+
+func TestRedpandaCreateAndFetchSchema(t *testing.T) {
+	t.Parallel()
+	subject := "RedpandaLongList"
+	schema := `{"type": "record", "name": "RedpandaLongList", "fields": [{"name": "value", "type": "long"}]}`
+
+	created, err := client.CreateSchema(context.Background(), subject, schema, Avro)
+	require.NoError(t, err)
+
+	fetched, err := client.GetSchema(context.Background(), created.ID())
+	require.NoError(t, err)
+	assert.Equal(t, created.ID(), fetched.ID())
+
+	latest, err := client.GetLatestSchema(context.Background(), subject)
+	require.NoError(t, err)
+	assert.Equal(t, created.ID(), latest.ID())
+
+	_, err = client.DeleteSubject(context.Background(), subject, true)
+	require.NoError(t, err)
+}
@@ -7,9 +7,13 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/crxfoz/goavro/v2"
 	"github.com/santhosh-tekuri/jsonschema/v5"
@@ -484,6 +488,8 @@ func TestSchemaRegistryClient_GetLatestSchemaReturnsValueFromCacheNegative(t *te
 
 	srClient := CreateSchemaRegistryClient(server.URL)
 	srClient.CacheLatest(false)
+	// Both fetches happen at the same time, for the schemas to be equal
+	srClient.SetClock(NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)))
 
 	schema1, err := srClient.GetLatestSchema(context.Background(), "test1-value")
 
@@ -498,6 +504,415 @@ func TestSchemaRegistryClient_GetLatestSchemaReturnsValueFromCacheNegative(t *te
 	assert.Equal(t, schema1, schema2)
 }
 
+func TestSchemaRegistryClient_GetSchemaBySubjectAndIDReturnsValueFromCache(t *testing.T) {
+	t.Parallel()
+	server, call := mockServerWithSchemaResponse(t, "/schemas/ids/1?subject=test1-value", schemaResponse{
+		Schema: "payload",
+		ID:     1,
+	})
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	schema1, err := srClient.GetSchemaBySubjectAndID(context.Background(), "test1-value", 1)
+
+	// Test response
+	assert.NoError(t, err)
+	assert.Equal(t, 1, schema1.ID())
+	assert.Equal(t, "payload", schema1.Schema())
+
+	// When called twice
+	schema2, err := srClient.GetSchemaBySubjectAndID(context.Background(), "test1-value", 1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, *call)
+	assert.Equal(t, schema1, schema2)
+}
+
+func TestSubjectCacheKey(t *testing.T) {
+	t.Parallel()
+	assert.NotEqual(t, versionCacheKey("a-1", "2"), versionCacheKey("a", "1-2"))
+	assert.NotEqual(t, versionCacheKey("a", "1"), idCacheKey("a", 1))
+	assert.NotEqual(t, idCacheKey("a-id", 1), versionCacheKey("a", "id-1"))
+	assert.Equal(t, versionCacheKey("a-1", "latest"), versionCacheKey("a-1", "latest"))
+	assert.Equal(t, "a-1", idCacheKey("a-1", 2).subject)
+}
+
+func TestSchemaRegistryClient_SubjectAndVersionExists(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.String() {
+		case "/subjects/test1/versions":
+			_, _ = rw.Write([]byte(`[1]`))
+		case "/subjects/test1/versions/1":
+			response, _ := json.Marshal(schemaResponse{Subject: "test1", Version: 1, Schema: "payload", ID: 1})
+			_, _ = rw.Write(response)
+		case "/subjects/test1/versions/2":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code":40402,"message":"Version 2 not found."}`))
+		case "/subjects/test2/versions", "/subjects/test2/versions/1":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code":40401,"message":"Subject 'test2' not found."}`))
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	ctx := context.Background()
+
+	exists, err := srClient.SubjectExists(ctx, "test1")
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = srClient.SubjectExists(ctx, "test2")
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = srClient.VersionExists(ctx, "test1", 1)
+	assert.NoError(t, err)
+	assert.True(t, exists)
+
+	exists, err = srClient.VersionExists(ctx, "test1", 2)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	exists, err = srClient.VersionExists(ctx, "test2", 1)
+	assert.NoError(t, err)
+	assert.False(t, exists)
+
+	_, err = srClient.SubjectExists(ctx, "test3")
+	assert.Error(t, err)
+}
+
+func TestSchemaRegistryClient_SoftDeleteInspection(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.String() {
+		case "/mode":
+			_, _ = rw.Write([]byte(`{"mode": "READWRITE"}`))
+		case "/subjects?deleted=true":
+			_, _ = rw.Write([]byte(`["active", "deleted"]`))
+		case "/subjects/active/versions":
+			_, _ = rw.Write([]byte(`[2, 3]`))
+		case "/subjects/active/versions?deleted=true":
+			_, _ = rw.Write([]byte(`[1, 2, 3]`))
+		case "/subjects/deleted/versions?deleted=true":
+			_, _ = rw.Write([]byte(`[1, 2]`))
+		case "/subjects/deleted/versions", "/subjects/missing/versions", "/subjects/missing/versions?deleted=true":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code":40401,"message":"Subject not found."}`))
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	ctx := context.Background()
+
+	versions, err := srClient.ListDeletedVersions(ctx, "active")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1}, versions)
+
+	versions, err = srClient.ListDeletedVersions(ctx, "deleted")
+	assert.NoError(t, err)
+	assert.Equal(t, []int{1, 2}, versions)
+
+	_, err = srClient.ListDeletedVersions(ctx, "missing")
+	assert.True(t, isNotFoundError(err))
+
+	for subject, expected := range map[string]bool{"active": false, "deleted": true, "missing": false} {
+		softDeleted, err := srClient.IsSubjectSoftDeleted(ctx, subject)
+		assert.NoError(t, err)
+		assert.Equal(t, expected, softDeleted, subject)
+	}
+
+	_, err = srClient.IsSubjectSoftDeleted(ctx, "broken")
+	assert.Error(t, err)
+}
+
+func TestSchemaRegistryClient_UndeleteSubject(t *testing.T) {
+	t.Parallel()
+	var registered []schemaRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.String() {
+		case "GET /mode":
+			_, _ = rw.Write([]byte(`{"mode": "READWRITE"}`))
+		case "GET /subjects?deleted=true":
+			_, _ = rw.Write([]byte(`["cupcake"]`))
+		case "GET /subjects/cupcake/versions":
+			_, _ = rw.Write([]byte(`[1]`))
+		case "GET /subjects/cupcake/versions?deleted=true":
+			_, _ = rw.Write([]byte(`[3, 1, 2]`))
+		case "GET /subjects/cupcake/versions/2?deleted=true":
+			response, _ := json.Marshal(schemaResponse{Subject: "cupcake", Version: 2, Schema: testSchema1, ID: 2})
+			_, _ = rw.Write(response)
+		case "GET /subjects/cupcake/versions/3?deleted=true":
+			response, _ := json.Marshal(schemaResponse{Subject: "cupcake", Version: 3, Schema: testSchema2, ID: 3})
+			_, _ = rw.Write(response)
+		case "POST /subjects/cupcake/versions":
+			var schemaReq schemaRequest
+			_ = json.NewDecoder(req.Body).Decode(&schemaReq)
+			registered = append(registered, schemaReq)
+			_, _ = rw.Write([]byte(fmt.Sprintf(`{"id": %d}`, len(registered)+1)))
+		case "GET /schemas/ids/2", "GET /schemas/ids/3":
+			response, _ := json.Marshal(schemaResponse{Schema: registered[len(registered)-1].Schema})
+			_, _ = rw.Write(response)
+		default:
+			rw.WriteHeader(http.StatusInternalServerError)
+		}
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	restored, err := srClient.UndeleteSubject(context.Background(), "cupcake")
+
+	assert.NoError(t, err)
+	if assert.Len(t, registered, 2) {
+		assert.Equal(t, testSchema1, registered[0].Schema)
+		assert.Equal(t, testSchema2, registered[1].Schema)
+	}
+	if assert.Len(t, restored, 2) {
+		assert.Equal(t, 2, restored[0].ID())
+		assert.Equal(t, 3, restored[1].ID())
+	}
+}
+
+func TestSchemaRegistryClient_DeleteReturnsDeleted(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.String() {
+		case "DELETE /subjects/cupcake":
+			_, _ = rw.Write([]byte(`[1,2,3]`))
+		case "DELETE /subjects/cupcake?permanent=true":
+			_, _ = rw.Write([]byte(`[1,2,3,4]`))
+		case "DELETE /subjects/cupcake/versions/2", "DELETE /subjects/cupcake/versions/2?permanent=true":
+			_, _ = rw.Write([]byte(`2`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40401, "message": "Subject not found"}`))
+		}
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	softDeleted, softErr := srClient.DeleteSubject(context.Background(), "cupcake", false)
+	permanentlyDeleted, permanentErr := srClient.DeleteSubject(context.Background(), "cupcake", true)
+	version, versionErr := srClient.DeleteSubjectByVersion(context.Background(), "cupcake", 2, true)
+	_, missingErr := srClient.DeleteSubject(context.Background(), "missing", false)
+
+	// Assert
+	assert.NoError(t, softErr)
+	assert.Equal(t, []int{1, 2, 3}, softDeleted)
+	assert.NoError(t, permanentErr)
+	assert.Equal(t, []int{1, 2, 3, 4}, permanentlyDeleted)
+	assert.NoError(t, versionErr)
+	assert.Equal(t, 2, version)
+	assert.True(t, isNotFoundError(missingErr))
+}
+
+func TestSchemaRegistryClient_ChangeGlobalCompatibilityLevel(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var body configChangeRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method != http.MethodPut || req.URL.Path != "/config" {
+			rw.WriteHeader(http.StatusNotFound)
+			return
+		}
+		_ = json.NewDecoder(req.Body).Decode(&body)
+		_ = json.NewEncoder(rw).Encode(configChangeResponse{CompatibilityLevel: body.CompatibilityLevel})
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	level, err := srClient.ChangeGlobalCompatibilityLevel(context.Background(), FullTransitive)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, FullTransitive, body.CompatibilityLevel)
+	assert.Equal(t, FullTransitive, *level)
+}
+
+func TestSchemaRegistryClient_GlobalConfigAndReset(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.String() {
+		case "GET /config":
+			_, _ = rw.Write([]byte(`{"compatibilityLevel": "FULL", "normalize": true, "defaultMetadata": {"properties": {"owner": "team"}}, "defaultRuleSet": {"domainRules": []}}`))
+		case "DELETE /config/cupcake":
+			_, _ = rw.Write([]byte(`{"compatibilityLevel": "NONE"}`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40401, "message": "Subject not found"}`))
+		}
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	globalConfig, err := srClient.GetGlobalConfig(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, Full, globalConfig.CompatibilityLevel)
+	if assert.NotNil(t, globalConfig.Normalize) {
+		assert.True(t, *globalConfig.Normalize)
+	}
+	assert.Equal(t, map[string]string{"owner": "team"}, globalConfig.DefaultMetadata.Properties)
+	assert.JSONEq(t, `{"domainRules": []}`, string(globalConfig.DefaultRuleSet))
+
+	removed, err := srClient.ResetSubjectConfig(context.Background(), "cupcake")
+	assert.NoError(t, err)
+	assert.Equal(t, None, removed.CompatibilityLevel)
+
+	_, err = srClient.ResetSubjectConfig(context.Background(), "missing")
+	assert.True(t, isNotFoundError(err))
+
+	level, err := srClient.DeleteSubjectCompatibilityLevel(context.Background(), "cupcake")
+	assert.NoError(t, err)
+	assert.Equal(t, None, *level)
+
+	_, err = srClient.DeleteSubjectCompatibilityLevel(context.Background(), "missing")
+	assert.True(t, isNotFoundError(err))
+}
+
+func TestSchemaRegistryClient_GetReferencedBy(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.Method + " " + req.URL.String() {
+		case "GET /subjects/cupcake/versions/2/referencedby":
+			_, _ = rw.Write([]byte(`[4, 7]`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40402, "message": "Version not found"}`))
+		}
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	ids, err := srClient.GetReferencedBy(context.Background(), "cupcake", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, []int{4, 7}, ids)
+
+	_, err = srClient.GetReferencedBy(context.Background(), "cupcake", 3)
+	assert.True(t, isNotFoundError(err))
+}
+
+func TestCreateError(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		status      int
+		contentType string
+		body        string
+		code        int
+		snippet     string
+		message     string
+		html        bool
+	}{
+		"registry error": {
+			status:  http.StatusNotFound,
+			body:    `{"error_code":40401,"message":"Subject not found"}`,
+			code:    40401,
+			message: "Subject not found",
+		},
+		"plain text": {
+			status:      http.StatusBadGateway,
+			contentType: "text/plain",
+			body:        "upstream connect error\n",
+			code:        http.StatusBadGateway,
+			snippet:     "upstream connect error",
+			message:     "502 Bad Gateway",
+		},
+		"html page": {
+			status:      http.StatusNotFound,
+			contentType: "text/html; charset=utf-8",
+			body:        "<html><body>" + strings.Repeat("Not Found ", 100) + "</body></html>",
+			code:        http.StatusNotFound,
+			snippet:     "<html><body>" + strings.Repeat("Not Found ", 100)[:244] + "...",
+			message:     "404 Not Found",
+			html:        true,
+		},
+		"html without content type": {
+			status:  http.StatusOK,
+			body:    "<!DOCTYPE html><html></html>",
+			code:    http.StatusOK,
+			snippet: "<!DOCTYPE html><html></html>",
+			message: "200 OK",
+			html:    true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			recorder := httptest.NewRecorder()
+			if testData.contentType != "" {
+				recorder.Header().Set("Content-Type", testData.contentType)
+			}
+			recorder.WriteHeader(testData.status)
+			_, _ = recorder.WriteString(testData.body)
+
+			err := createError(recorder.Result())
+
+			var registryErr Error
+			require.True(t, errors.As(err, &registryErr))
+			assert.Equal(t, testData.code, registryErr.Code)
+			assert.Equal(t, testData.message, registryErr.Message)
+			assert.Equal(t, testData.snippet, registryErr.Body)
+			if testData.html {
+				assert.Contains(t, err.Error(), "wrong URL or port")
+			} else {
+				assert.NotContains(t, err.Error(), "wrong URL or port")
+			}
+		})
+	}
+}
+
+func TestSchemaRegistryClient_MaxResponseBytes(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_, _ = rw.Write([]byte(`["subject-1", "subject-2", "subject-3"]`))
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetMaxResponseBytes(16)
+	_, err := srClient.GetSubjects(context.Background())
+	assert.ErrorIs(t, err, ErrResponseTooLarge)
+
+	srClient.SetMaxResponseBytes(64)
+	subjects, err := srClient.GetSubjects(context.Background())
+	assert.NoError(t, err)
+	assert.Len(t, subjects, 3)
+}
+
+func TestSchemaRegistryClient_ReusesConnectionsOnErrors(t *testing.T) {
+	t.Parallel()
+	var connections int32
+	server := httptest.NewUnstartedServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusInternalServerError)
+		// A large body which isn't a registry error, only partially read by createError
+		_, _ = rw.Write([]byte(strings.Repeat("x", 512*1024)))
+	}))
+	server.Config.ConnState = func(conn net.Conn, state http.ConnState) {
+		if state == http.StateNew {
+			atomic.AddInt32(&connections, 1)
+		}
+	}
+	server.Start()
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	for i := 0; i < 3; i++ {
+		_, err := srClient.GetSubjects(context.Background())
+		assert.Error(t, err)
+	}
+
+	assert.Equal(t, int32(1), atomic.LoadInt32(&connections))
+}
+
 func TestSchemaRegistryClient_GetSchemaType(t *testing.T) {
 	t.Parallel()
 	{
@@ -538,6 +953,76 @@ func TestSchemaRegistryClient_GetSchemaType(t *testing.T) {
 	}
 }
 
+func TestSchemaRegistryClient_GetSchemaRegisteredAt(t *testing.T) {
+	t.Parallel()
+	{
+		server, call := mockServerFromSubjectVersionPairWithSchemaResponse(t, "test1-value", "latest", schemaResponse{
+			Subject:   "test1",
+			Version:   1,
+			Schema:    "payload",
+			ID:        1,
+			Timestamp: 1700000000123,
+		})
+
+		srClient := CreateSchemaRegistryClient(server.URL)
+		schema, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+
+		// Test response
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *call)
+		assert.Equal(t, time.Date(2023, 11, 14, 22, 13, 20, 123000000, time.UTC), schema.RegisteredAt())
+		exported := exportSchema("test1-value", schema)
+		if assert.NotNil(t, exported.RegisteredAt) {
+			assert.Equal(t, schema.RegisteredAt(), *exported.RegisteredAt)
+		}
+	}
+	{
+		server, call := mockServerFromSubjectVersionPairWithSchemaResponse(t, "test1-value", "latest", schemaResponse{
+			Subject: "test1",
+			Version: 1,
+			Schema:  "payload",
+			ID:      1,
+		})
+
+		srClient := CreateSchemaRegistryClient(server.URL)
+		schema, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+
+		// Test response
+		assert.NoError(t, err)
+		assert.Equal(t, 1, *call)
+		assert.True(t, schema.RegisteredAt().IsZero())
+		assert.Nil(t, exportSchema("test1-value", schema).RegisteredAt)
+	}
+}
+
+func TestSchemaRegistryClient_GetSchemaResponseMetadata(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/schemas/ids/1" {
+			_, _ = rw.Write([]byte(`{"schema":"\"string\""}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`{"subject":"test1-value","version":2,"id":2,"schema":"\"string\""}`))
+	}))
+	defer server.Close()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	srClient := NewClient(server.URL, WithClock(clock))
+
+	// Act
+	latest, err := srClient.GetLatestSchema(context.Background(), "test1-value")
+	require.NoError(t, err)
+	byID, err := srClient.GetSchema(context.Background(), 1)
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "test1-value", latest.Subject())
+	assert.Equal(t, clock.Now(), latest.FetchedAt())
+	assert.JSONEq(t, `{"subject":"test1-value","version":2,"id":2,"schema":"\"string\""}`, string(latest.RawResponse()))
+	assert.Empty(t, byID.Subject())
+	assert.Equal(t, `{"schema":"\"string\""}`, string(byID.RawResponse()))
+}
+
 func TestSchemaRegistryClient_JsonSchemaParses(t *testing.T) {
 	t.Parallel()
 	{
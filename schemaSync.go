@@ -0,0 +1,410 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// SyncConfigFile is the conventional name of the configuration file
+// describing the schemas of a repository, at the root of the directory
+// holding them.
+const SyncConfigFile = "srclient.yaml"
+
+// SyncMapping maps a schema file to its subject. File is relative to the
+// synced directory, the schema type defaults to the one of the file
+// extension. References without a version use the latest version of the
+// referenced subject, once it has been synced when it is mapped too.
+// Compatibility, when set, is applied to the subject before the schema
+// is checked, Metadata is registered along with the schema.
+type SyncMapping struct {
+	Subject       string             `yaml:"subject"`
+	File          string             `yaml:"file"`
+	SchemaType    SchemaType         `yaml:"schemaType,omitempty"`
+	References    []Reference        `yaml:"references,omitempty"`
+	Compatibility CompatibilityLevel `yaml:"compatibility,omitempty"`
+	Metadata      *SchemaMetadata    `yaml:"metadata,omitempty"`
+}
+
+// SyncConfig lists the schema files of a repository directory along
+// with the subjects they are registered under. Compatibility is the
+// level of the subjects which don't set their own.
+type SyncConfig struct {
+	Compatibility CompatibilityLevel `yaml:"compatibility,omitempty"`
+	Subjects      []SyncMapping      `yaml:"subjects"`
+}
+
+// LoadSyncConfig reads a YAML sync configuration, usually named
+// srclient.yaml, such as:
+//
+//	compatibility: BACKWARD
+//	subjects:
+//	  - subject: customers-value
+//	    file: customers/customer.avsc
+//	    metadata:
+//	      tags:
+//	        Customer.email: [PII]
+//	  - subject: orders-value
+//	    file: orders/order.avsc
+//	    compatibility: FULL
+//	    references:
+//	      - name: com.shop.Customer
+//	        subject: customers-value
+func LoadSyncConfig(path string) (*SyncConfig, error) {
+	content, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	config := new(SyncConfig)
+	if err := yaml.Unmarshal(content, config); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if err := config.Validate(); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return config, nil
+}
+
+// Validate checks that every mapping has a subject and a file, that
+// subjects are mapped once, and that the schema types and compatibility
+// levels are known.
+func (config *SyncConfig) Validate() error {
+	if !validCompatibilityLevel(config.Compatibility) {
+		return fmt.Errorf("unknown compatibility level %s", config.Compatibility)
+	}
+	subjects := make(map[string]bool, len(config.Subjects))
+	for i, mapping := range config.Subjects {
+		switch {
+		case mapping.Subject == "" || mapping.File == "":
+			return fmt.Errorf("mapping %d needs a subject and a file", i)
+		case subjects[mapping.Subject]:
+			return fmt.Errorf("subject %s is mapped more than once", mapping.Subject)
+		case mapping.SchemaType != "" && mapping.SchemaType != Avro && mapping.SchemaType != Json && mapping.SchemaType != Protobuf:
+			return fmt.Errorf("subject %s: unknown schema type %s", mapping.Subject, mapping.SchemaType)
+		case !validCompatibilityLevel(mapping.Compatibility):
+			return fmt.Errorf("subject %s: unknown compatibility level %s", mapping.Subject, mapping.Compatibility)
+		}
+		for _, reference := range mapping.References {
+			if reference.Name == "" || reference.Subject == "" {
+				return fmt.Errorf("subject %s: references need a name and a subject", mapping.Subject)
+			}
+		}
+		subjects[mapping.Subject] = true
+	}
+	return nil
+}
+
+func validCompatibilityLevel(level CompatibilityLevel) bool {
+	switch level {
+	case "", None, Backward, BackwardTransitive, Forward, ForwardTransitive, Full, FullTransitive:
+		return true
+	}
+	return false
+}
+
+// SyncAction tells what a sync did, or would do, with a schema file.
+type SyncAction string
+
+const (
+	// SyncUnchanged means the schema is already registered under the subject.
+	SyncUnchanged SyncAction = "UNCHANGED"
+	// SyncRegistered means a new version of the subject was registered.
+	SyncRegistered SyncAction = "REGISTERED"
+	// SyncPending means the schema would be registered, in a dry run.
+	SyncPending SyncAction = "PENDING"
+	// SyncIncompatible means the schema was not registered as the
+	// compatibility check of the subject failed.
+	SyncIncompatible SyncAction = "INCOMPATIBLE"
+	// SyncUnmapped means the schema file is not mapped to any subject.
+	SyncUnmapped SyncAction = "UNMAPPED"
+)
+
+// SyncResult is the outcome of the sync of a single schema file.
+type SyncResult struct {
+	File    string
+	Subject string
+	Action  SyncAction
+	// Schema is the registered schema, unless the schema is pending,
+	// incompatible or unmapped.
+	Schema *Schema
+}
+
+// SyncSummary lists the outcome of a sync, in file order.
+type SyncSummary struct {
+	Results []SyncResult
+}
+
+// Failed tells whether some schemas were left out by the compatibility checks.
+func (summary *SyncSummary) Failed() bool {
+	for _, result := range summary.Results {
+		if result.Action == SyncIncompatible {
+			return true
+		}
+	}
+	return false
+}
+
+// String prints one line per schema file followed by the count of each
+// action, as a CD step would log it.
+func (summary *SyncSummary) String() string {
+	var builder strings.Builder
+	counts := make(map[SyncAction]int)
+	for _, result := range summary.Results {
+		counts[result.Action]++
+		switch {
+		case result.Schema != nil:
+			fmt.Fprintf(&builder, "%-12s %s -> %s version %d (id %d)\n", result.Action, result.File, result.Subject, result.Schema.Version(), result.Schema.ID())
+		case result.Subject != "":
+			fmt.Fprintf(&builder, "%-12s %s -> %s\n", result.Action, result.File, result.Subject)
+		default:
+			fmt.Fprintf(&builder, "%-12s %s\n", result.Action, result.File)
+		}
+	}
+	var totals []string
+	for _, action := range []SyncAction{SyncRegistered, SyncPending, SyncUnchanged, SyncIncompatible, SyncUnmapped} {
+		if counts[action] > 0 {
+			totals = append(totals, fmt.Sprintf("%d %s", counts[action], strings.ToLower(string(action))))
+		}
+	}
+	if len(totals) == 0 {
+		totals = append(totals, "nothing to sync")
+	}
+	builder.WriteString(strings.Join(totals, ", "))
+	return builder.String()
+}
+
+// SyncSchemaDirectory walks the directory for .avsc, .json and .proto
+// files and registers the ones mapped by the configuration whose schema
+// is not registered under their subject yet, once the registry confirms
+// they are compatible with the latest version. Referenced subjects which
+// are mapped are synced first. Incompatible schemas are reported and
+// skipped along with the schemas referencing them, the other ones are
+// still synced. With dryRun nothing is registered nor configured.
+func SyncSchemaDirectory(ctx context.Context, client ISchemaRegistryClient, dir string, config *SyncConfig, dryRun bool) (*SyncSummary, error) {
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	syncer := schemaSyncer{
+		client:    client,
+		dir:       dir,
+		config:    config,
+		dryRun:    dryRun,
+		bySubject: make(map[string]SyncMapping, len(config.Subjects)),
+		results:   make(map[string]*SyncResult, len(config.Subjects)),
+	}
+	mappings := make(map[string][]SyncMapping, len(config.Subjects))
+	for _, mapping := range config.Subjects {
+		file := filepath.ToSlash(filepath.Clean(mapping.File))
+		mappings[file] = append(mappings[file], mapping)
+		syncer.bySubject[mapping.Subject] = mapping
+	}
+
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			if path != dir && strings.HasPrefix(entry.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+		if _, ok := schemaTypeOfFile(path); !ok {
+			return nil
+		}
+		relative, err := filepath.Rel(dir, path)
+		if err != nil {
+			return err
+		}
+		files = append(files, filepath.ToSlash(relative))
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	for file := range mappings {
+		if _, err := os.Stat(filepath.Join(dir, filepath.FromSlash(file))); err != nil {
+			return nil, fmt.Errorf("mapped schema file %s: %w", file, err)
+		}
+	}
+	sort.Strings(files)
+
+	summary := &SyncSummary{}
+	for _, file := range files {
+		if len(mappings[file]) == 0 {
+			summary.Results = append(summary.Results, SyncResult{File: file, Action: SyncUnmapped})
+			continue
+		}
+		for _, mapping := range mappings[file] {
+			result, err := syncer.sync(ctx, mapping.Subject, nil)
+			if err != nil {
+				return nil, err
+			}
+			summary.Results = append(summary.Results, *result)
+		}
+	}
+	return summary, nil
+}
+
+type schemaSyncer struct {
+	client    ISchemaRegistryClient
+	dir       string
+	config    *SyncConfig
+	dryRun    bool
+	bySubject map[string]SyncMapping
+	results   map[string]*SyncResult
+}
+
+// sync syncs the mapped subject once, after the mapped subjects it
+// references. visiting holds the subjects being synced to detect cycles.
+func (syncer *schemaSyncer) sync(ctx context.Context, subject string, visiting map[string]bool) (*SyncResult, error) {
+	if result, ok := syncer.results[subject]; ok {
+		return result, nil
+	}
+	if visiting[subject] {
+		return nil, fmt.Errorf("subject %s references itself", subject)
+	}
+	if visiting == nil {
+		visiting = make(map[string]bool)
+	}
+	visiting[subject] = true
+
+	mapping := syncer.bySubject[subject]
+	file := filepath.ToSlash(filepath.Clean(mapping.File))
+	result := &SyncResult{File: file, Subject: subject}
+	references, blocked, err := syncer.resolveReferences(ctx, mapping, visiting)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	if blocked != "" {
+		result.Action = blocked
+		syncer.results[subject] = result
+		return result, nil
+	}
+
+	content, err := readSchemaFile(filepath.Join(syncer.dir, filepath.FromSlash(file)))
+	if err != nil {
+		return nil, err
+	}
+	schemaType := mapping.SchemaType
+	if schemaType == "" {
+		schemaType, _ = schemaTypeOfFile(file)
+	}
+	compatibility := mapping.Compatibility
+	if compatibility == "" {
+		compatibility = syncer.config.Compatibility
+	}
+	if err := syncer.syncSchema(ctx, result, content, schemaType, compatibility, mapping.Metadata, references); err != nil {
+		return nil, fmt.Errorf("%s: %w", file, err)
+	}
+	syncer.results[subject] = result
+	return result, nil
+}
+
+// resolveReferences syncs the mapped subjects referenced by the mapping
+// and pins the references without a version to the latest version. When
+// a mapped reference is pending or incompatible, the action is returned
+// as blocked instead.
+func (syncer *schemaSyncer) resolveReferences(ctx context.Context, mapping SyncMapping, visiting map[string]bool) ([]Reference, SyncAction, error) {
+	references := make([]Reference, 0, len(mapping.References))
+	for _, reference := range mapping.References {
+		if _, mapped := syncer.bySubject[reference.Subject]; mapped {
+			referenced, err := syncer.sync(ctx, reference.Subject, visiting)
+			if err != nil {
+				return nil, "", err
+			}
+			if referenced.Action == SyncPending || referenced.Action == SyncIncompatible {
+				return nil, referenced.Action, nil
+			}
+			if reference.Version == 0 {
+				reference.Version = referenced.Schema.Version()
+			}
+		}
+		if reference.Version == 0 {
+			latest, err := syncer.client.GetLatestSchema(ctx, reference.Subject)
+			if err != nil {
+				return nil, "", fmt.Errorf("reference %s: %w", reference.Name, err)
+			}
+			reference.Version = latest.Version()
+		}
+		references = append(references, reference)
+	}
+	return references, "", nil
+}
+
+func (syncer *schemaSyncer) syncSchema(ctx context.Context, result *SyncResult, content string, schemaType SchemaType, compatibility CompatibilityLevel, metadata *SchemaMetadata, references []Reference) error {
+	client := syncer.client
+	schema, err := client.LookupSchema(ctx, result.Subject, content, schemaType, references...)
+	switch {
+	case err == nil && sameMetadata(schema.Metadata(), metadata):
+		result.Action = SyncUnchanged
+		result.Schema = schema
+		return nil
+	case err != nil && !isNotFoundError(err):
+		return err
+	}
+
+	if compatibility != "" && !syncer.dryRun {
+		current, err := client.GetCompatibilityLevel(ctx, result.Subject, false)
+		if err != nil && !isNotFoundError(err) {
+			return err
+		}
+		if current == nil || *current != compatibility {
+			if _, err := client.ChangeSubjectCompatibilityLevel(ctx, result.Subject, compatibility); err != nil {
+				return err
+			}
+		}
+	}
+
+	// The first version of a subject has nothing to be compatible with
+	compatible, err := client.IsSchemaCompatible(ctx, result.Subject, content, "latest", schemaType, references...)
+	if isNotFoundError(err) {
+		compatible = true
+	} else if err != nil {
+		return err
+	}
+	switch {
+	case !compatible:
+		result.Action = SyncIncompatible
+		return nil
+	case syncer.dryRun:
+		result.Action = SyncPending
+		return nil
+	}
+
+	if metadata != nil {
+		result.Schema, err = client.CreateSchemaWithMetadata(ctx, result.Subject, content, schemaType, metadata, references...)
+	} else {
+		result.Schema, err = client.CreateSchema(ctx, result.Subject, content, schemaType, references...)
+	}
+	if err != nil {
+		return err
+	}
+	result.Action = SyncRegistered
+	return nil
+}
+
+// sameMetadata compares metadata, a missing metadata being the same as
+// an empty one.
+func sameMetadata(registered, expected *SchemaMetadata) bool {
+	if registered == nil {
+		registered = &SchemaMetadata{}
+	}
+	if expected == nil {
+		expected = &SchemaMetadata{}
+	}
+	return len(registered.Tags) == len(expected.Tags) &&
+		len(registered.Properties) == len(expected.Properties) &&
+		len(registered.Sensitive) == len(expected.Sensitive) &&
+		(len(registered.Tags) == 0 || reflect.DeepEqual(registered.Tags, expected.Tags)) &&
+		(len(registered.Properties) == 0 || reflect.DeepEqual(registered.Properties, expected.Properties)) &&
+		(len(registered.Sensitive) == 0 || reflect.DeepEqual(registered.Sensitive, expected.Sensitive))
+}
@@ -0,0 +1,253 @@
+package srclient
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoadSyncConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	valid := filepath.Join(dir, SyncConfigFile)
+	require.NoError(t, os.WriteFile(valid, []byte(`compatibility: BACKWARD
+subjects:
+  - subject: customers-value
+    file: customers/customer.avsc
+    metadata:
+      tags:
+        Customer.email: [PII]
+      properties:
+        owner: crm
+  - subject: orders-value
+    file: orders/order.proto
+    schemaType: PROTOBUF
+    compatibility: FULL
+    references:
+      - name: customer.proto
+        subject: customers-value
+        version: 2
+`), 0600))
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, os.WriteFile(invalid, []byte("subjects:\n  - file: order.avsc\n"), 0600))
+
+	config, err := LoadSyncConfig(valid)
+	_, invalidErr := LoadSyncConfig(invalid)
+
+	require.NoError(t, err)
+	assert.Equal(t, &SyncConfig{
+		Compatibility: Backward,
+		Subjects: []SyncMapping{
+			{
+				Subject: "customers-value", File: "customers/customer.avsc",
+				Metadata: &SchemaMetadata{Tags: map[string][]string{"Customer.email": {"PII"}}, Properties: map[string]string{"owner": "crm"}},
+			},
+			{
+				Subject: "orders-value", File: "orders/order.proto", SchemaType: Protobuf, Compatibility: Full,
+				References: []Reference{{Name: "customer.proto", Subject: "customers-value", Version: 2}},
+			},
+		},
+	}, config)
+	assert.EqualError(t, invalidErr, invalid+": mapping 0 needs a subject and a file")
+}
+
+func TestSyncConfig_Validate(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		config   SyncConfig
+		expected string
+	}{
+		"duplicate subject": {
+			config:   SyncConfig{Subjects: []SyncMapping{{Subject: "a", File: "a.avsc"}, {Subject: "a", File: "b.avsc"}}},
+			expected: "subject a is mapped more than once",
+		},
+		"schema type": {
+			config:   SyncConfig{Subjects: []SyncMapping{{Subject: "a", File: "a.xml", SchemaType: "XML"}}},
+			expected: "subject a: unknown schema type XML",
+		},
+		"compatibility": {
+			config:   SyncConfig{Compatibility: "SOMETIMES"},
+			expected: "unknown compatibility level SOMETIMES",
+		},
+		"reference": {
+			config:   SyncConfig{Subjects: []SyncMapping{{Subject: "a", File: "a.avsc", References: []Reference{{Name: "b"}}}}},
+			expected: "subject a: references need a name and a subject",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.EqualError(t, testData.config.Validate(), testData.expected)
+		})
+	}
+}
+
+func TestSyncSchemaDirectory(t *testing.T) {
+	t.Parallel()
+	files := map[string]string{
+		"customer.avsc":      `{"type": "record", "name": "Customer", "fields": []}`,
+		"orders/order.avsc":  `{"type": "record", "name": "Order", "fields": []}`,
+		"refund.avsc":        `{"type": "record", "name": "Refund", "fields": []}`,
+		"notes/example.json": `{"type": "object"}`,
+		".git/ignored.json":  `{"type": "object"}`,
+	}
+	config := &SyncConfig{Subjects: []SyncMapping{
+		{Subject: "customer-value", File: "customer.avsc"},
+		{Subject: "orders-value", File: "orders/order.avsc"},
+		{Subject: "refunds-value", File: "./refund.avsc"},
+	}}
+
+	tests := map[string]struct {
+		dryRun   bool
+		expected string
+		created  int
+	}{
+		"sync": {
+			expected: "UNCHANGED    customer.avsc -> customer-value version 2 (id 1)\n" +
+				"UNMAPPED     notes/example.json\n" +
+				"REGISTERED   orders/order.avsc -> orders-value version 0 (id 7)\n" +
+				"INCOMPATIBLE refund.avsc -> refunds-value\n" +
+				"1 registered, 1 unchanged, 1 incompatible, 1 unmapped",
+			created: 1,
+		},
+		"dry run": {
+			dryRun: true,
+			expected: "UNCHANGED    customer.avsc -> customer-value version 2 (id 1)\n" +
+				"UNMAPPED     notes/example.json\n" +
+				"PENDING      orders/order.avsc -> orders-value\n" +
+				"INCOMPATIBLE refund.avsc -> refunds-value\n" +
+				"1 pending, 1 unchanged, 1 incompatible, 1 unmapped",
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			dir := t.TempDir()
+			for file, content := range files {
+				path := filepath.Join(dir, filepath.FromSlash(file))
+				require.NoError(t, os.MkdirAll(filepath.Dir(path), 0700))
+				require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+			}
+
+			var lock sync.Mutex
+			created := 0
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				switch req.Method + " " + req.URL.String() {
+				case "POST /subjects/customer-value":
+					_, _ = rw.Write([]byte(`{"subject": "customer-value", "version": 2, "id": 1, "schema": "{}"}`))
+				case "POST /subjects/orders-value", "POST /subjects/refunds-value":
+					rw.WriteHeader(http.StatusNotFound)
+					_, _ = rw.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+				case "POST /compatibility/subjects/orders-value/versions/latest":
+					rw.WriteHeader(http.StatusNotFound)
+					_, _ = rw.Write([]byte(`{"error_code": 40401, "message": "Subject not found"}`))
+				case "POST /compatibility/subjects/refunds-value/versions/latest":
+					_, _ = rw.Write([]byte(`{"is_compatible": false}`))
+				case "POST /subjects/orders-value/versions":
+					lock.Lock()
+					created++
+					lock.Unlock()
+					_, _ = rw.Write([]byte(`{"id": 7}`))
+				case "GET /schemas/ids/7":
+					_, _ = rw.Write([]byte(`{"schema": "{\"type\": \"record\", \"name\": \"Order\", \"fields\": []}"}`))
+				default:
+					t.Errorf("unexpected request %s %s", req.Method, req.URL)
+				}
+			}))
+			defer server.Close()
+			srClient := CreateSchemaRegistryClient(server.URL)
+
+			summary, err := SyncSchemaDirectory(context.Background(), srClient, dir, config, testData.dryRun)
+
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, summary.String())
+			assert.True(t, summary.Failed())
+			assert.Equal(t, testData.created, created)
+		})
+	}
+}
+
+func TestSyncSchemaDirectory_MissingMappedFile(t *testing.T) {
+	t.Parallel()
+	srClient := CreateMockSchemaRegistryClient("mock://testingUrl")
+	config := &SyncConfig{Subjects: []SyncMapping{{Subject: "orders-value", File: "order.avsc"}}}
+
+	_, err := SyncSchemaDirectory(context.Background(), srClient, t.TempDir(), config, false)
+
+	assert.ErrorIs(t, err, os.ErrNotExist)
+}
+
+func TestSyncSchemaDirectory_ReferencesAndConfig(t *testing.T) {
+	t.Parallel()
+	dir := t.TempDir()
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "address.avsc"), []byte(`{"type": "record", "name": "Address", "fields": []}`), 0600))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "customer.avsc"), []byte(`{"type": "record", "name": "Customer", "fields": [{"name": "address", "type": "Address"}]}`), 0600))
+	config := &SyncConfig{
+		Compatibility: Backward,
+		Subjects: []SyncMapping{
+			{
+				Subject: "customers-value", File: "customer.avsc", Compatibility: Full,
+				References: []Reference{{Name: "Address", Subject: "addresses-value"}},
+				Metadata:   &SchemaMetadata{Tags: map[string][]string{"Customer.address": {"PII"}}},
+			},
+			{Subject: "addresses-value", File: "address.avsc"},
+		},
+	}
+
+	var lock sync.Mutex
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		lock.Lock()
+		requests = append(requests, req.Method+" "+req.URL.String()+" "+string(body))
+		lock.Unlock()
+		switch req.Method + " " + req.URL.String() {
+		case "POST /subjects/addresses-value":
+			_, _ = rw.Write([]byte(`{"subject": "addresses-value", "version": 4, "id": 3, "schema": "{}"}`))
+		case "POST /subjects/customers-value", "POST /compatibility/subjects/customers-value/versions/latest":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40401, "message": "Subject not found"}`))
+		case "GET /config/customers-value?defaultToGlobal=false":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40408, "message": "Subject does not have subject-level compatibility configured"}`))
+		case "PUT /config/customers-value":
+			_, _ = rw.Write([]byte(`{"compatibility": "FULL"}`))
+		case "POST /subjects/customers-value/versions":
+			_, _ = rw.Write([]byte(`{"id": 5}`))
+		case "GET /schemas/ids/5":
+			_, _ = rw.Write([]byte(`{"schema": "{}"}`))
+		default:
+			t.Errorf("unexpected request %s %s", req.Method, req.URL)
+		}
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	summary, err := SyncSchemaDirectory(context.Background(), srClient, dir, config, false)
+
+	require.NoError(t, err)
+	assert.Equal(t, "UNCHANGED    address.avsc -> addresses-value version 4 (id 3)\n"+
+		"REGISTERED   customer.avsc -> customers-value version 0 (id 5)\n"+
+		"1 registered, 1 unchanged", summary.String())
+	assert.Equal(t, []string{
+		`POST /subjects/addresses-value {"schema":"{\"type\": \"record\", \"name\": \"Address\", \"fields\": []}"}`,
+		`POST /subjects/customers-value {"schema":"{\"type\": \"record\", \"name\": \"Customer\", \"fields\": [{\"name\": \"address\", \"type\": \"Address\"}]}","references":[{"name":"Address","subject":"addresses-value","version":4}]}`,
+		`GET /config/customers-value?defaultToGlobal=false `,
+		`PUT /config/customers-value {"compatibility":"FULL"}`,
+		`POST /compatibility/subjects/customers-value/versions/latest {"schema":"{\"type\": \"record\", \"name\": \"Customer\", \"fields\": [{\"name\": \"address\", \"type\": \"Address\"}]}","references":[{"name":"Address","subject":"addresses-value","version":4}]}`,
+		`POST /subjects/customers-value/versions {"schema":"{\"type\": \"record\", \"name\": \"Customer\", \"fields\": [{\"name\": \"address\", \"type\": \"Address\"}]}","references":[{"name":"Address","subject":"addresses-value","version":4}],"metadata":{"tags":{"Customer.address":["PII"]}}}`,
+		`GET /schemas/ids/5 `,
+	}, requests)
+}
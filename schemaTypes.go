@@ -0,0 +1,105 @@
+package srclient
+
+import (
+	"errors"
+	"fmt"
+	"sync"
+)
+
+// ErrSchemaTypeRegistered is returned when registering a handler for a
+// built-in schema type or for a type which already has one.
+var ErrSchemaTypeRegistered = errors.New("schema type already registered")
+
+// SchemaCodec encodes and decodes the values of a schema of a custom
+// schema type, without the wire format header.
+type SchemaCodec interface {
+	Encode(value interface{}) ([]byte, error)
+	Decode(data []byte) (interface{}, error)
+}
+
+// SchemaTypeHandler supports a schema type the client doesn't know,
+// such as the experimental types of registry forks. All its functions
+// are optional.
+type SchemaTypeHandler struct {
+	// Parse checks that a schema is well formed, it is called before
+	// registering or looking up a schema and by the pre-commit checks.
+	Parse func(schema string) error
+	// Canonicalize returns the form of the schema sent to the registry,
+	// schemas are sent as they are without it.
+	Canonicalize func(schema string) (string, error)
+	// NewCodec creates the codec the Serializer and the Deserializer
+	// use for a registered schema, the values of the type can't be
+	// serialized without it.
+	NewCodec func(schema *Schema) (SchemaCodec, error)
+}
+
+var schemaTypeHandlers = struct {
+	lock     sync.RWMutex
+	handlers map[SchemaType]SchemaTypeHandler
+}{handlers: make(map[SchemaType]SchemaTypeHandler)}
+
+// RegisterSchemaType registers the handler of a custom schema type, the
+// type is then accepted by the clients, the mock, the Serializer and the
+// Deserializer like the built-in ones. It is meant to be called once, at
+// initialization, for each custom type.
+func RegisterSchemaType(schemaType SchemaType, handler SchemaTypeHandler) error {
+	switch schemaType {
+	case "", Avro, Json, Protobuf:
+		return fmt.Errorf("%w: %s is built-in", ErrSchemaTypeRegistered, schemaType)
+	}
+
+	schemaTypeHandlers.lock.Lock()
+	defer schemaTypeHandlers.lock.Unlock()
+	if _, ok := schemaTypeHandlers.handlers[schemaType]; ok {
+		return fmt.Errorf("%w: %s", ErrSchemaTypeRegistered, schemaType)
+	}
+	schemaTypeHandlers.handlers[schemaType] = handler
+	return nil
+}
+
+// schemaTypeHandler returns the handler registered for a custom schema
+// type.
+func schemaTypeHandler(schemaType SchemaType) (SchemaTypeHandler, bool) {
+	schemaTypeHandlers.lock.RLock()
+	defer schemaTypeHandlers.lock.RUnlock()
+	handler, ok := schemaTypeHandlers.handlers[schemaType]
+	return handler, ok
+}
+
+// canonicalCustomSchema parses and canonicalizes a schema of a custom
+// schema type, it fails with errInvalidSchemaType for unknown types.
+func canonicalCustomSchema(schema string, schemaType SchemaType) (string, error) {
+	handler, ok := schemaTypeHandler(schemaType)
+	if !ok {
+		return "", errInvalidSchemaType
+	}
+	if handler.Parse != nil {
+		if err := handler.Parse(schema); err != nil {
+			return "", fmt.Errorf("invalid %s schema: %w", schemaType, err)
+		}
+	}
+	if handler.Canonicalize == nil {
+		return schema, nil
+	}
+	return handler.Canonicalize(schema)
+}
+
+// customCodec returns the codec of a schema of a custom schema type,
+// created the first time it is needed.
+func (schema *Schema) customCodec() (SchemaCodec, error) {
+	handler, ok := schemaTypeHandler(schemaTypeOf(schema))
+	if !ok || handler.NewCodec == nil {
+		return nil, errUnsupportedSchemaType
+	}
+
+	schema.lazyLock.Lock()
+	defer schema.lazyLock.Unlock()
+	if schema.schemaCodec == nil {
+		codec, err := handler.NewCodec(schema)
+		if err != nil {
+			return nil, err
+		}
+		schema.schemaCodec = codec
+	}
+	return schema.schemaCodec, nil
+}
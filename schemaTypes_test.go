@@ -0,0 +1,125 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// csvCodec encodes []string values as comma separated fields.
+type csvCodec struct{}
+
+func (csvCodec) Encode(value interface{}) ([]byte, error) {
+	fields, ok := value.([]string)
+	if !ok {
+		return nil, errors.New("values must be []string")
+	}
+	return []byte(strings.Join(fields, ",")), nil
+}
+
+func (csvCodec) Decode(data []byte) (interface{}, error) {
+	return strings.Split(string(data), ","), nil
+}
+
+// registerCsvSchemaType registers a schema type whose schemas are the
+// names of the fields of the values, one per line.
+func registerCsvSchemaType(t *testing.T, schemaType SchemaType) {
+	t.Helper()
+	require.NoError(t, RegisterSchemaType(schemaType, SchemaTypeHandler{
+		Parse: func(schema string) error {
+			if strings.TrimSpace(schema) == "" {
+				return errors.New("no fields")
+			}
+			return nil
+		},
+		Canonicalize: func(schema string) (string, error) {
+			return strings.Join(strings.Fields(schema), "\n"), nil
+		},
+		NewCodec: func(*Schema) (SchemaCodec, error) {
+			return csvCodec{}, nil
+		},
+	}))
+}
+
+func TestRegisterSchemaType(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		schemaType SchemaType
+	}{
+		"avro":     {schemaType: Avro},
+		"json":     {schemaType: Json},
+		"protobuf": {schemaType: Protobuf},
+		"default":  {schemaType: ""},
+		"twice":    {schemaType: "CSV_TWICE"},
+	}
+	registerCsvSchemaType(t, "CSV_TWICE")
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			err := RegisterSchemaType(testData.schemaType, SchemaTypeHandler{})
+
+			// Assert
+			assert.ErrorIs(t, err, ErrSchemaTypeRegistered)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_CreateSchemaCustomType(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registerCsvSchemaType(t, "CSV_CLIENT")
+	var request schemaRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		_ = json.Unmarshal(body, &request)
+		_, _ = rw.Write([]byte(`{"id": 1}`))
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	_, err := srClient.CreateSchema(context.Background(), "orders-value", "  id \n amount ", "CSV_CLIENT")
+	_, invalidErr := srClient.CreateSchema(context.Background(), "orders-value", " ", "CSV_CLIENT")
+	_, unknownErr := srClient.CreateSchema(context.Background(), "orders-value", "id", "CSV_UNKNOWN")
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "id\namount", request.Schema)
+	assert.Equal(t, "CSV_CLIENT", request.SchemaType)
+	assert.ErrorContains(t, invalidErr, "no fields")
+	assert.ErrorIs(t, unknownErr, errInvalidSchemaType)
+}
+
+func TestSerializer_CustomSchemaType(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registerCsvSchemaType(t, "CSV_SERDE")
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	orders, err := registry.CreateSchema(context.Background(), "orders-value", "id amount", "CSV_SERDE")
+	require.NoError(t, err)
+	serializer := NewSerializer(registry)
+	deserializer := NewDeserializer(registry)
+
+	// Act
+	payload, err := serializer.Serialize(context.Background(), "orders-value", []string{"42", "9.99"})
+	require.NoError(t, err)
+	value, schema, err := deserializer.Deserialize(context.Background(), payload)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "id\namount", orders.Schema())
+	assert.Equal(t, append(appendWireHeader(nil, orders.ID()), "42,9.99"...), payload)
+	assert.Equal(t, orders.ID(), schema.ID())
+	assert.Equal(t, []string{"42", "9.99"}, value)
+}
@@ -0,0 +1,223 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+)
+
+const (
+	allSchemas     = "/schemas"
+	schemasPaged   = "/schemas?offset=%d&limit=%d"
+	searchPageSize = 100
+)
+
+// SchemaPredicate selects the schemas returned by SearchSchemas.
+type SchemaPredicate func(subject string, schema *Schema) bool
+
+// SchemaMatch is a schema version matched by SearchSchemas.
+type SchemaMatch struct {
+	Subject string
+	Schema  *Schema
+}
+
+// SearchSchemas scans every schema version of the registry, page by
+// page, and returns the ones matched by the predicate. It is meant for
+// sweeps such as finding every subject holding a field named ssn.
+func (client *SchemaRegistryClient) SearchSchemas(ctx context.Context, predicate SchemaPredicate) ([]SchemaMatch, error) {
+	var matches []SchemaMatch
+	var previous schemaCoordinates
+	for offset := 0; ; offset += searchPageSize {
+		resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(schemasPaged, offset, searchPageSize), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		page, err := client.schemasPage(resp)
+		if err != nil {
+			return nil, err
+		}
+		// Registries ignoring the offset return the same page again
+		if offset > 0 && page.first == previous {
+			return matches, nil
+		}
+		previous = page.first
+		for _, schema := range page.schemas {
+			if predicate(schema.subject, schema) {
+				matches = append(matches, SchemaMatch{Subject: schema.subject, Schema: schema})
+			}
+		}
+
+		// Registries without pagination return every schema at once
+		if page.size != searchPageSize {
+			return matches, nil
+		}
+	}
+}
+
+// SchemaListOptions filter the schemas listed by GetAllSchemas.
+type SchemaListOptions struct {
+	// SubjectPrefix only lists the schemas of the subjects starting with
+	// it, the prefix of WithSubjectPrefix being added to it.
+	SubjectPrefix string
+	// Deleted lists the soft deleted versions too.
+	Deleted bool
+	// LatestOnly only lists the latest version of each subject.
+	LatestOnly bool
+	Offset     int
+	// Limit is the number of schemas listed, zero lists them all.
+	Limit int
+}
+
+// GetAllSchemas lists the schema versions of the registry with
+// GET /schemas, ordered by subject and version, for inventories of the
+// registry. The subject and the version of the schemas are set.
+func (client *SchemaRegistryClient) GetAllSchemas(ctx context.Context, options SchemaListOptions) ([]*Schema, error) {
+	query := url.Values{}
+	if prefix := client.prefixed(options.SubjectPrefix); prefix != "" {
+		query.Set("subjectPrefix", prefix)
+	}
+	if options.Deleted {
+		query.Set("deleted", "true")
+	}
+	if options.LatestOnly {
+		query.Set("latestOnly", "true")
+	}
+	if options.Offset > 0 {
+		query.Set("offset", strconv.Itoa(options.Offset))
+	}
+	if options.Limit > 0 {
+		query.Set("limit", strconv.Itoa(options.Limit))
+	}
+	uri := allSchemas
+	if len(query) > 0 {
+		uri += "?" + query.Encode()
+	}
+
+	resp, err := client.httpRequest(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	page, err := client.schemasPage(resp)
+	if err != nil {
+		return nil, err
+	}
+	return page.schemas, nil
+}
+
+// schemasPage is a page of GET /schemas, size is the number of schemas
+// of the page before the ones the client can't read are left out and
+// first the coordinates of its first schema.
+type schemasPage struct {
+	schemas []*Schema
+	size    int
+	first   schemaCoordinates
+}
+
+// schemaCoordinates identify a schema version of a listing.
+type schemaCoordinates struct {
+	subject string
+	version int
+	id      int
+}
+
+func (client *SchemaRegistryClient) schemasPage(resp []byte) (schemasPage, error) {
+	var raws []json.RawMessage
+	if err := json.Unmarshal(resp, &raws); err != nil {
+		return schemasPage{}, err
+	}
+
+	page := schemasPage{size: len(raws)}
+	for i, raw := range raws {
+		var schemaResp schemaResponse
+		if err := json.Unmarshal(raw, &schemaResp); err != nil {
+			return schemasPage{}, err
+		}
+		if i == 0 {
+			page.first = schemaCoordinates{subject: schemaResp.Subject, version: schemaResp.Version, id: schemaResp.ID}
+		}
+		subject, ok := client.unprefixed(schemaResp.Subject)
+		if !ok || !client.accessPolicy.allows(subject, false) {
+			continue
+		}
+		page.schemas = append(page.schemas, &Schema{
+			id:           schemaResp.ID,
+			schema:       schemaResp.Schema,
+			schemaType:   schemaResp.SchemaType,
+			version:      schemaResp.Version,
+			references:   client.unprefixedReferences(schemaResp.References),
+			metadata:     schemaResp.Metadata,
+			registeredAt: registrationTime(schemaResp.Timestamp),
+			subject:      subject,
+			fetchedAt:    client.now(),
+			raw:          raw,
+		})
+	}
+	return page, nil
+}
+
+// HasField matches schemas declaring a field with the given name,
+// at any depth. Names are compared case insensitively.
+func HasField(name string) SchemaPredicate {
+	return matchFields(func(field SchemaField) bool {
+		return strings.EqualFold(field.Name, name)
+	})
+}
+
+// HasFieldOfType matches schemas declaring a field of the given type,
+// such as "string" or the full name of an Avro record.
+func HasFieldOfType(typeName string) SchemaPredicate {
+	return matchFields(func(field SchemaField) bool {
+		return field.Type == typeName
+	})
+}
+
+// HasMetadataTag matches schemas whose metadata tags any path with the tag.
+func HasMetadataTag(tag string) SchemaPredicate {
+	return func(_ string, schema *Schema) bool {
+		metadata := schema.Metadata()
+		if metadata == nil {
+			return false
+		}
+		for _, tags := range metadata.Tags {
+			for _, t := range tags {
+				if t == tag {
+					return true
+				}
+			}
+		}
+		return false
+	}
+}
+
+// HasMetadataProperty matches schemas whose metadata hold the property.
+func HasMetadataProperty(key, value string) SchemaPredicate {
+	return func(_ string, schema *Schema) bool {
+		metadata := schema.Metadata()
+		if metadata == nil {
+			return false
+		}
+		actual, ok := metadata.Properties[key]
+		return ok && actual == value
+	}
+}
+
+// matchFields matches schemas holding a matching field, schemas which
+// can't be parsed never match.
+func matchFields(match func(field SchemaField) bool) SchemaPredicate {
+	return func(_ string, schema *Schema) bool {
+		fields, err := schemaFields(schema)
+		if err != nil {
+			return false
+		}
+		for _, field := range fields {
+			if match(field) {
+				return true
+			}
+		}
+		return false
+	}
+}
@@ -0,0 +1,172 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_SearchSchemasPaginates(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var all []schemaResponse
+	for i := 1; i <= searchPageSize+5; i++ {
+		all = append(all, schemaResponse{Subject: fmt.Sprintf("subject-%d", i), Version: 1, ID: i, Schema: testSchema1})
+	}
+	all[42].Schema = `{"type": "record", "name": "customer", "fields": [{"name": "SSN", "type": "string"}]}`
+	all[searchPageSize+2].Metadata = &SchemaMetadata{Tags: map[string][]string{"$.ssn": {"PII"}}}
+
+	var requests []string
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests = append(requests, req.URL.String())
+		var offset, limit int
+		_, _ = fmt.Sscanf(req.URL.RawQuery, "offset=%d&limit=%d", &offset, &limit)
+		end := offset + limit
+		if end > len(all) {
+			end = len(all)
+		}
+		_ = json.NewEncoder(rw).Encode(all[offset:end])
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	byField, err := srClient.SearchSchemas(context.Background(), HasField("ssn"))
+	require.NoError(t, err)
+	byTag, err := srClient.SearchSchemas(context.Background(), HasMetadataTag("PII"))
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, []string{"/schemas?offset=0&limit=100", "/schemas?offset=100&limit=100"}, requests[:2])
+	if assert.Len(t, byField, 1) {
+		assert.Equal(t, "subject-43", byField[0].Subject)
+		assert.Equal(t, 43, byField[0].Schema.ID())
+	}
+	if assert.Len(t, byTag, 1) {
+		assert.Equal(t, "subject-103", byTag[0].Subject)
+	}
+}
+
+func TestSchemaRegistryClient_SearchSchemasWithoutPagination(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var all []schemaResponse
+	for i := 1; i <= searchPageSize; i++ {
+		all = append(all, schemaResponse{Subject: fmt.Sprintf("subject-%d", i), Version: 1, ID: i, Schema: testSchema1})
+	}
+	requests := 0
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		requests++
+		_ = json.NewEncoder(rw).Encode(all)
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	matches, err := srClient.SearchSchemas(context.Background(), HasFieldOfType("string"))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Len(t, matches, searchPageSize)
+	assert.Equal(t, 2, requests)
+}
+
+func TestMockSchemaRegistryClient_SearchSchemas(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+	_, _ = registry.CreateSchema(context.Background(), "bakery", testSchema2, Avro)
+	_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema2, Avro)
+
+	matches, err := registry.SearchSchemas(context.Background(), HasFieldOfType("int"))
+
+	require.NoError(t, err)
+	require.Len(t, matches, 2)
+	assert.Equal(t, "bakery", matches[0].Subject)
+	assert.Equal(t, "cupcake", matches[1].Subject)
+	assert.Equal(t, 2, matches[1].Schema.Version())
+}
+
+func TestSchemaRegistryClient_GetAllSchemas(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	queries := make(chan string, 1)
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		queries <- req.URL.RawQuery
+		_ = json.NewEncoder(rw).Encode([]schemaResponse{
+			{Subject: "team-a.orders-value", Version: 3, ID: 7, Schema: testSchema1},
+			{Subject: "team-b.orders-value", Version: 1, ID: 8, Schema: testSchema2},
+		})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithSubjectPrefix("team-a."))
+
+	// Act
+	schemas, err := srClient.GetAllSchemas(context.Background(), SchemaListOptions{
+		SubjectPrefix: "orders", Deleted: true, LatestOnly: true, Offset: 10, Limit: 5,
+	})
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, "deleted=true&latestOnly=true&limit=5&offset=10&subjectPrefix=team-a.orders", <-queries)
+	require.Len(t, schemas, 1)
+	assert.Equal(t, "orders-value", schemas[0].Subject())
+	assert.Equal(t, 3, schemas[0].Version())
+	assert.Equal(t, 7, schemas[0].ID())
+}
+
+func TestMockSchemaRegistryClient_GetAllSchemas(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		options  SchemaListOptions
+		expected []string
+	}{
+		"all": {
+			expected: []string{"bakery/1", "cupcake/2", "cupcake/3"},
+		},
+		"deleted": {
+			options:  SchemaListOptions{Deleted: true},
+			expected: []string{"bakery/1", "cupcake/1", "cupcake/2", "cupcake/3"},
+		},
+		"prefix and latest only": {
+			options:  SchemaListOptions{SubjectPrefix: "cup", LatestOnly: true},
+			expected: []string{"cupcake/3"},
+		},
+		"offset and limit": {
+			options:  SchemaListOptions{Offset: 1, Limit: 1},
+			expected: []string{"cupcake/2"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+			_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema1, Avro)
+			_, _ = registry.CreateSchema(context.Background(), "cupcake", testSchema2, Avro)
+			_, _ = registry.CreateSchema(context.Background(), "cupcake", `{"type":"record","name":"cupcake","fields":[]}`, Avro)
+			_, _ = registry.CreateSchema(context.Background(), "bakery", testSchema1, Avro)
+			_, _ = registry.DeleteSubjectByVersion(context.Background(), "cupcake", 1, false)
+
+			// Act
+			schemas, err := registry.GetAllSchemas(context.Background(), testData.options)
+
+			// Assert
+			require.NoError(t, err)
+			var listed []string
+			for _, schema := range schemas {
+				listed = append(listed, fmt.Sprintf("%s/%d", schema.Subject(), schema.Version()))
+			}
+			assert.Equal(t, testData.expected, listed)
+		})
+	}
+}
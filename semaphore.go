@@ -0,0 +1,128 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"time"
+
+	"golang.org/x/sync/semaphore"
+)
+
+// SemaphoreStats describes how a request went through the
+// semaphore limiting the concurrent requests to Schema Registry.
+type SemaphoreStats struct {
+	// QueueDepth is the number of requests waiting for a slot
+	// when this request started waiting, itself included. It is
+	// zero for requests which didn't wait, because a slot was
+	// free or their context was already done.
+	QueueDepth int
+	// Wait is the time spent waiting for a slot.
+	Wait time.Duration
+	// Err is set when no slot could be acquired, because the
+	// context was cancelled or its deadline passed.
+	Err error
+	// Write tells if the request was a write (register, delete,
+	// config change) or a read operation.
+	Write bool
+}
+
+// SemaphoreHook is called every time a request acquires, or
+// gives up on acquiring, a slot of the request semaphore.
+type SemaphoreHook func(stats SemaphoreStats)
+
+// requestSemaphore wraps a weighted semaphore to keep track
+// of the requests queued on it. A nil semaphore, for a weight
+// of zero or less, doesn't limit the requests.
+type requestSemaphore struct {
+	sem      *semaphore.Weighted
+	semLock  sync.RWMutex
+	waiting  int64
+	hook     SemaphoreHook
+	hookLock sync.RWMutex
+}
+
+func newRequestSemaphore(weight int) *requestSemaphore {
+	s := &requestSemaphore{}
+	s.resize(weight)
+	return s
+}
+
+// resize replaces the semaphore by one of the given weight. The requests
+// holding or waiting for a slot of the previous one keep it, so the new
+// weight may be exceeded until they are done.
+func (s *requestSemaphore) resize(weight int) {
+	var sem *semaphore.Weighted
+	if weight > 0 {
+		sem = semaphore.NewWeighted(int64(weight))
+	}
+	s.semLock.Lock()
+	defer s.semLock.Unlock()
+	s.sem = sem
+}
+
+// acquire waits for a slot until the context is done. Requests whose
+// context is already done, deadline passed included, fail right away
+// instead of queueing, the others wait for as long as their deadline
+// allows. Only the requests finding no free slot count in the queue.
+func (s *requestSemaphore) acquire(ctx context.Context, write bool) (func(), error) {
+	start := time.Now()
+	s.semLock.RLock()
+	sem := s.sem
+	s.semLock.RUnlock()
+
+	err := ctx.Err()
+	if deadline, ok := ctx.Deadline(); err == nil && ok && !deadline.After(start) {
+		err = context.DeadlineExceeded
+	}
+	var depth int64
+	if err == nil && sem != nil && !sem.TryAcquire(1) {
+		depth = atomic.AddInt64(&s.waiting, 1)
+		err = sem.Acquire(ctx, 1)
+		atomic.AddInt64(&s.waiting, -1)
+	}
+
+	s.notify(SemaphoreStats{QueueDepth: int(depth), Wait: time.Since(start), Err: err, Write: write})
+	if err != nil {
+		return nil, err
+	}
+
+	if sem == nil {
+		return func() {}, nil
+	}
+	return func() { sem.Release(1) }, nil
+}
+
+func (s *requestSemaphore) queueDepth() int {
+	return int(atomic.LoadInt64(&s.waiting))
+}
+
+func (s *requestSemaphore) setHook(hook SemaphoreHook) {
+	s.hookLock.Lock()
+	defer s.hookLock.Unlock()
+	s.hook = hook
+}
+
+func (s *requestSemaphore) notify(stats SemaphoreStats) {
+	s.hookLock.RLock()
+	hook := s.hook
+	s.hookLock.RUnlock()
+	if hook != nil {
+		hook(stats)
+	}
+}
+
+// isWriteRequest tells if a request modifies the registry, lookups
+// and compatibility checks are POST requests but only read data.
+func isWriteRequest(method, uri string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead:
+		return false
+	case http.MethodPost:
+		return strings.HasPrefix(uri, "/subjects/") && strings.HasSuffix(strings.SplitN(uri, "?", 2)[0], "/versions")
+	default:
+		return true
+	}
+}
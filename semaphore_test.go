@@ -0,0 +1,270 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaRegistryClient_SemaphoreFailsFastOnExpiredDeadline(t *testing.T) {
+	t.Parallel()
+	var calls int
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		calls++
+	}))
+	defer server.Close()
+
+	var stats []SemaphoreStats
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetSemaphoreHook(func(s SemaphoreStats) {
+		stats = append(stats, s)
+	})
+
+	ctx, cancel := context.WithDeadline(context.Background(), time.Now().Add(-time.Second))
+	defer cancel()
+	_, err := srClient.GetSubjects(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, 0, calls)
+	if assert.Len(t, stats, 1) {
+		assert.Equal(t, 0, stats[0].QueueDepth)
+		assert.ErrorIs(t, stats[0].Err, context.DeadlineExceeded)
+	}
+}
+
+func TestSchemaRegistryClient_SemaphoreRespectsDeadlineWhenSaturated(t *testing.T) {
+	t.Parallel()
+	unblock := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		<-unblock
+		_, _ = rw.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	var statsLock sync.Mutex
+	var stats []SemaphoreStats
+	srClient := CreateSchemaRegistryClientWithOptions(server.URL, &http.Client{Timeout: 5 * time.Second}, 1)
+	srClient.SetSemaphoreHook(func(s SemaphoreStats) {
+		statsLock.Lock()
+		defer statsLock.Unlock()
+		stats = append(stats, s)
+	})
+
+	// Occupy the only slot
+	done := make(chan error)
+	go func() {
+		_, err := srClient.GetSubjects(context.Background())
+		done <- err
+	}()
+	assert.Eventually(t, func() bool {
+		statsLock.Lock()
+		defer statsLock.Unlock()
+		return len(stats) == 1
+	}, time.Second, time.Millisecond)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, 0, srClient.SemaphoreQueueDepth())
+	go func() {
+		_, err := srClient.GetSubjects(ctx)
+		done <- err
+	}()
+	assert.Eventually(t, func() bool {
+		return srClient.SemaphoreQueueDepth() == 1
+	}, time.Second, time.Millisecond)
+	assert.ErrorIs(t, <-done, context.DeadlineExceeded)
+	assert.Equal(t, 0, srClient.SemaphoreQueueDepth())
+
+	close(unblock)
+	assert.NoError(t, <-done)
+
+	statsLock.Lock()
+	defer statsLock.Unlock()
+	if assert.Len(t, stats, 2) {
+		assert.Equal(t, 0, stats[0].QueueDepth)
+		assert.Equal(t, 1, stats[1].QueueDepth)
+		assert.ErrorIs(t, stats[1].Err, context.DeadlineExceeded)
+		assert.GreaterOrEqual(t, stats[1].Wait, 50*time.Millisecond)
+	}
+}
+
+func TestSchemaRegistryClient_SeparateReadWriteBudgets(t *testing.T) {
+	t.Parallel()
+	unblock := make(chan struct{})
+	arrived := make(chan struct{})
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.Method == http.MethodDelete {
+			close(arrived)
+			<-unblock
+		}
+		_, _ = rw.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClientWithConcurrencyBudgets(server.URL, &http.Client{Timeout: 5 * time.Second}, 1, 1)
+
+	// Saturate the write budget
+	done := make(chan error)
+	go func() {
+		_, err := srClient.DeleteSubject(context.Background(), "test1", false)
+		done <- err
+	}()
+	<-arrived
+
+	// Reads are not affected
+	ctx, cancel := context.WithTimeout(context.Background(), time.Second)
+	defer cancel()
+	_, err := srClient.GetSubjects(ctx)
+	assert.NoError(t, err)
+
+	close(unblock)
+	assert.NoError(t, <-done)
+}
+
+func TestSchemaRegistryClient_SetSemaphoreWeight(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		weight      int
+		expectedErr error
+	}{
+		"disabled": {weight: 0},
+		"raised":   {weight: 2},
+		"lowered":  {weight: 1, expectedErr: context.DeadlineExceeded},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			unblock := make(chan struct{})
+			arrived := make(chan struct{}, 1)
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Query().Get("deleted") == "true" {
+					arrived <- struct{}{}
+					<-unblock
+				}
+				_, _ = rw.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+			srClient := CreateSchemaRegistryClientWithOptions(server.URL, &http.Client{Timeout: 5 * time.Second}, 4)
+			srClient.SetSemaphoreWeight(testData.weight)
+			done := make(chan error)
+			go func() {
+				_, err := srClient.GetSubjectsIncludingDeleted(context.Background())
+				done <- err
+			}()
+			<-arrived
+
+			// Act
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			_, err := srClient.GetSubjects(ctx)
+
+			// Assert
+			assert.ErrorIs(t, err, testData.expectedErr)
+			close(unblock)
+			assert.NoError(t, <-done)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_SetReadAndWriteSemaphoreWeights(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		setWeight func(client *SchemaRegistryClient)
+		write     bool
+	}{
+		"read": {
+			setWeight: func(client *SchemaRegistryClient) { client.SetReadSemaphoreWeight(1) },
+		},
+		"write": {
+			setWeight: func(client *SchemaRegistryClient) { client.SetWriteSemaphoreWeight(1) },
+			write:     true,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			unblock := make(chan struct{})
+			arrived := make(chan struct{}, 1)
+			var blocked int32
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if atomic.CompareAndSwapInt32(&blocked, 0, 1) {
+					arrived <- struct{}{}
+					<-unblock
+				}
+				if req.URL.Path == "/config" {
+					_, _ = rw.Write([]byte(`{"compatibility": "FULL"}`))
+					return
+				}
+				_, _ = rw.Write([]byte(`[]`))
+			}))
+			defer server.Close()
+			srClient := CreateSchemaRegistryClientWithConcurrencyBudgets(server.URL, &http.Client{Timeout: 5 * time.Second}, 4, 4)
+			testData.setWeight(srClient)
+			read := func(ctx context.Context) error {
+				_, err := srClient.GetSubjects(ctx)
+				return err
+			}
+			write := func(ctx context.Context) error {
+				_, err := srClient.ChangeGlobalCompatibilityLevel(ctx, Full)
+				return err
+			}
+			limited, other := read, write
+			if testData.write {
+				limited, other = write, read
+			}
+			done := make(chan error)
+			go func() {
+				done <- limited(context.Background())
+			}()
+			<-arrived
+
+			// Act
+			ctx, cancel := context.WithTimeout(context.Background(), 50*time.Millisecond)
+			defer cancel()
+			limitedErr := limited(ctx)
+			otherErr := other(context.Background())
+
+			// Assert
+			assert.ErrorIs(t, limitedErr, context.DeadlineExceeded)
+			assert.NoError(t, otherErr)
+			close(unblock)
+			assert.NoError(t, <-done)
+		})
+	}
+}
+
+func TestIsWriteRequest(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		method   string
+		uri      string
+		expected bool
+	}{
+		"get schema":     {method: "GET", uri: "/schemas/ids/1", expected: false},
+		"create schema":  {method: "POST", uri: "/subjects/test1/versions", expected: true},
+		"lookup schema":  {method: "POST", uri: "/subjects/test1", expected: false},
+		"compatibility":  {method: "POST", uri: "/compatibility/subjects/test1/versions/latest", expected: false},
+		"change config":  {method: "PUT", uri: "/config/test1", expected: true},
+		"delete subject": {method: "DELETE", uri: "/subjects/test1?permanent=true", expected: true},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			assert.Equal(t, testData.expected, isWriteRequest(testData.method, testData.uri))
+		})
+	}
+}
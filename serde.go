@@ -0,0 +1,333 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protodesc"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/descriptorpb"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// Serializer encodes values into Confluent's wire format payloads: the
+// magic byte, the 4 bytes schema ID and the encoded value. Protobuf
+// values are preceded by the indexes of their message in the schema,
+// as written by Confluent's Protobuf serializer. JSON values are
+// validated against their schema before being encoded.
+type Serializer struct {
+	client  ISchemaRegistryClient
+	schemas map[string]*Schema
+	pins    SchemaPins
+	lock    sync.RWMutex
+}
+
+// NewSerializer creates a Serializer fetching the schemas of the
+// subjects with the given client.
+func NewSerializer(client ISchemaRegistryClient) *Serializer {
+	return &Serializer{
+		client:  client,
+		schemas: make(map[string]*Schema),
+	}
+}
+
+// SetPins pins subjects to the schema they are serialized with.
+func (serializer *Serializer) SetPins(pins SchemaPins) {
+	serializer.lock.Lock()
+	defer serializer.lock.Unlock()
+	serializer.pins = pins
+	serializer.schemas = make(map[string]*Schema)
+}
+
+// Serialize encodes the value with the pinned schema of the subject, or
+// its latest schema when it isn't pinned. The schema is fetched the
+// first time the subject is serialized and kept afterwards, Reset picks
+// up the versions registered since.
+func (serializer *Serializer) Serialize(ctx context.Context, subject string, value interface{}) ([]byte, error) {
+	serializer.lock.RLock()
+	schema, ok := serializer.schemas[subject]
+	pins := serializer.pins
+	serializer.lock.RUnlock()
+
+	if !ok {
+		var err error
+		schema, err = pins.schema(ctx, serializer.client, subject)
+		if err != nil {
+			return nil, err
+		}
+		if schema == nil {
+			schema, err = serializer.client.GetLatestSchema(ctx, subject)
+			if err != nil {
+				return nil, err
+			}
+		}
+		serializer.lock.Lock()
+		serializer.schemas[subject] = schema
+		serializer.lock.Unlock()
+	}
+	return serializer.SerializeWithSchema(schema, value)
+}
+
+// SerializeWithSchema encodes the value with the given registered
+// schema. Avro values are in their native Go representation, JSON
+// values anything encoding/json marshals and Protobuf values are
+// proto.Message whose message is defined by the schema. JSON values not
+// matching the schema are rejected with a *JsonValidationError. Values
+// of custom schema types are encoded by the codec of their
+// SchemaTypeHandler.
+func (serializer *Serializer) SerializeWithSchema(schema *Schema, value interface{}) ([]byte, error) {
+	payload := appendWireHeader(nil, schema.ID())
+	switch schemaTypeOf(schema) {
+	case Avro:
+		codec := schema.Codec()
+		if codec == nil {
+			return nil, fmt.Errorf("invalid Avro schema with id %d", schema.ID())
+		}
+		return codec.BinaryFromNative(payload, value)
+	case Json:
+		body, err := json.Marshal(value)
+		if err != nil {
+			return nil, err
+		}
+		var decoded interface{}
+		if err := json.Unmarshal(body, &decoded); err != nil {
+			return nil, err
+		}
+		if err := validateJson(schema, decoded); err != nil {
+			return nil, err
+		}
+		return append(payload, body...), nil
+	case Protobuf:
+		message, ok := value.(proto.Message)
+		if !ok {
+			return nil, fmt.Errorf("Protobuf values must be proto.Message, got %T", value)
+		}
+		payload = appendMessageIndexes(payload, protobufMessageIndexes(message.ProtoReflect().Descriptor()))
+		return proto.MarshalOptions{}.MarshalAppend(payload, message)
+	default:
+		codec, err := schema.customCodec()
+		if err != nil {
+			return nil, err
+		}
+		body, err := codec.Encode(value)
+		if err != nil {
+			return nil, err
+		}
+		return append(payload, body...), nil
+	}
+}
+
+// Reset forgets the schemas of the subjects serialized so far.
+func (serializer *Serializer) Reset() {
+	serializer.lock.Lock()
+	defer serializer.lock.Unlock()
+	serializer.schemas = make(map[string]*Schema)
+}
+
+// Deserializer decodes Confluent's wire format payloads, fetching the
+// schema they were written with by its ID. The client caches schemas
+// by ID, so only the first payload of a schema hits the registry.
+type Deserializer struct {
+	client ISchemaRegistryClient
+	// files holds the parsed Protobuf schemas by ID.
+	files map[int]protoreflect.FileDescriptor
+	pins  SchemaPins
+	lock  sync.RWMutex
+	// projections reads the Avro payloads with the pinned schemas.
+	projections avroProjections
+}
+
+// NewDeserializer creates a Deserializer fetching the writer schemas
+// with the given client.
+func NewDeserializer(client ISchemaRegistryClient) *Deserializer {
+	return &Deserializer{
+		client: client,
+		files:  make(map[int]protoreflect.FileDescriptor),
+	}
+}
+
+// SetPins pins subjects to the schema their payloads are read with by
+// DeserializeSubject.
+func (deserializer *Deserializer) SetPins(pins SchemaPins) {
+	deserializer.lock.Lock()
+	defer deserializer.lock.Unlock()
+	deserializer.pins = pins
+}
+
+// Deserialize decodes the payload and returns it along with the schema
+// it was written with. Avro payloads are decoded into their native Go
+// representation, JSON ones as by encoding/json into an interface{}
+// and validated against their schema, Protobuf ones into a
+// *dynamicpb.Message of the message pointed by the message indexes and
+// the ones of custom schema types by the codec of their
+// SchemaTypeHandler.
+func (deserializer *Deserializer) Deserialize(ctx context.Context, payload []byte) (interface{}, *Schema, error) {
+	return deserializer.deserialize(ctx, payload, nil)
+}
+
+// DeserializeSubject decodes a payload of the subject like Deserialize,
+// but when the subject is pinned the payload is read with the pinned
+// schema, whichever schema it was written with, and the pinned schema
+// is returned. Avro values are resolved with the pinned schema as the
+// reader schema, JSON ones are validated against it and Protobuf ones
+// are decoded into its message at the same indexes.
+func (deserializer *Deserializer) DeserializeSubject(ctx context.Context, subject string, payload []byte) (interface{}, *Schema, error) {
+	deserializer.lock.RLock()
+	pins := deserializer.pins
+	deserializer.lock.RUnlock()
+
+	reader, err := pins.schema(ctx, deserializer.client, subject)
+	if err != nil {
+		return nil, nil, err
+	}
+	return deserializer.deserialize(ctx, payload, reader)
+}
+
+// deserialize decodes the payload with the given reader schema, or the
+// writer schema when nil.
+func (deserializer *Deserializer) deserialize(ctx context.Context, payload []byte, reader *Schema) (interface{}, *Schema, error) {
+	schemaID, body, err := parseWireFormat(payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	schema, err := deserializer.client.GetSchema(ctx, schemaID)
+	if err != nil {
+		return nil, nil, err
+	}
+	if reader == nil {
+		reader = schema
+	} else if schemaTypeOf(reader) != schemaTypeOf(schema) {
+		return nil, nil, fmt.Errorf("%s payload can't be read with %s schema id %d", schemaTypeOf(schema), schemaTypeOf(reader), reader.ID())
+	}
+
+	switch schemaTypeOf(schema) {
+	case Avro:
+		codec := schema.Codec()
+		if codec == nil {
+			return nil, nil, fmt.Errorf("invalid Avro schema with id %d", schema.ID())
+		}
+		native, _, err := codec.NativeFromBinary(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		if reader.ID() == schema.ID() {
+			return native, schema, nil
+		}
+		if reader.Codec() == nil {
+			return nil, nil, fmt.Errorf("invalid Avro schema with id %d", reader.ID())
+		}
+		native, err = deserializer.projections.project(reader, schema, native)
+		if err != nil {
+			return nil, nil, err
+		}
+		return native, reader, nil
+	case Json:
+		var value interface{}
+		if err := json.Unmarshal(body, &value); err != nil {
+			return nil, nil, err
+		}
+		if err := validateJson(reader, value); err != nil {
+			return nil, nil, err
+		}
+		return value, reader, nil
+	case Protobuf:
+		indexes, body, err := parseMessageIndexes(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		descriptor, err := deserializer.protobufMessage(ctx, reader, indexes)
+		if err != nil {
+			return nil, nil, err
+		}
+		message := dynamicpb.NewMessage(descriptor)
+		if err := proto.Unmarshal(body, message); err != nil {
+			return nil, nil, err
+		}
+		return message, reader, nil
+	default:
+		codec, err := reader.customCodec()
+		if err != nil {
+			return nil, nil, err
+		}
+		value, err := codec.Decode(body)
+		if err != nil {
+			return nil, nil, err
+		}
+		return value, reader, nil
+	}
+}
+
+// DeserializeInto decodes a Protobuf payload into the given message,
+// usually generated code, and returns the schema it was written with.
+func (deserializer *Deserializer) DeserializeInto(ctx context.Context, payload []byte, message proto.Message) (*Schema, error) {
+	schemaID, body, err := parseWireFormat(payload)
+	if err != nil {
+		return nil, err
+	}
+	schema, err := deserializer.client.GetSchema(ctx, schemaID)
+	if err != nil {
+		return nil, err
+	}
+	if schemaTypeOf(schema) != Protobuf {
+		return nil, errUnsupportedSchemaType
+	}
+
+	_, body, err = parseMessageIndexes(body)
+	if err != nil {
+		return nil, err
+	}
+	return schema, proto.Unmarshal(body, message)
+}
+
+// protobufMessage returns the descriptor of the message of the schema
+// at the given indexes.
+func (deserializer *Deserializer) protobufMessage(ctx context.Context, schema *Schema, indexes []int) (protoreflect.MessageDescriptor, error) {
+	deserializer.lock.RLock()
+	file, ok := deserializer.files[schema.ID()]
+	deserializer.lock.RUnlock()
+
+	if !ok {
+		parsed, err := protobufFileDescriptor(ctx, deserializer.client, fmt.Sprintf("schema-%d", schema.ID()), schema)
+		if err != nil {
+			return nil, err
+		}
+		set := &descriptorpb.FileDescriptorSet{}
+		appendFileDescriptor(set, parsed, make(map[string]bool))
+		files, err := protodesc.NewFiles(set)
+		if err != nil {
+			return nil, err
+		}
+		file, err = files.FindFileByPath(parsed.GetName())
+		if err != nil {
+			return nil, err
+		}
+
+		deserializer.lock.Lock()
+		deserializer.files[schema.ID()] = file
+		deserializer.lock.Unlock()
+	}
+
+	messages := file.Messages()
+	var message protoreflect.MessageDescriptor
+	for _, index := range indexes {
+		if index >= messages.Len() {
+			return nil, fmt.Errorf("schema id %d has no message at indexes %v", schema.ID(), indexes)
+		}
+		message = messages.Get(index)
+		messages = message.Messages()
+	}
+	return message, nil
+}
+
+// protobufMessageIndexes returns the path to the message within its
+// file, from the top level message to the message itself.
+func protobufMessageIndexes(message protoreflect.MessageDescriptor) []int {
+	indexes := []int{message.Index()}
+	for parent, ok := message.Parent().(protoreflect.MessageDescriptor); ok; parent, ok = parent.Parent().(protoreflect.MessageDescriptor) {
+		indexes = append([]int{parent.Index()}, indexes...)
+	}
+	return indexes
+}
@@ -0,0 +1,224 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+	"google.golang.org/protobuf/types/known/durationpb"
+)
+
+func TestSerializer_RoundTrip(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	cupcake, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	serializer := NewSerializer(registry)
+	deserializer := NewDeserializer(registry)
+
+	// Act
+	payload, err := serializer.Serialize(context.Background(), "cupcakes-value", map[string]interface{}{"flavor": "vanilla"})
+	require.NoError(t, err)
+	value, schema, err := deserializer.Deserialize(context.Background(), payload)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, encodeAvroTestRecord(t, cupcake, map[string]interface{}{"flavor": "vanilla"}), payload)
+	assert.Equal(t, cupcake.ID(), schema.ID())
+	assert.Equal(t, map[string]interface{}{"flavor": "vanilla"}, value)
+}
+
+func TestSerializer_KeepsSchemaUntilReset(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	first, err := registry.CreateSchema(context.Background(), "cupcakes-value", testSchema1, Avro)
+	require.NoError(t, err)
+	serializer := NewSerializer(registry)
+	_, err = serializer.Serialize(context.Background(), "cupcakes-value", map[string]interface{}{"flavor": "vanilla"})
+	require.NoError(t, err)
+	second, err := registry.CreateSchema(context.Background(), "cupcakes-value",
+		`{"type": "record", "name": "cupcake", "fields": [{"name": "flavor", "type": "string"}, {"name": "size", "type": "int", "default": 1}]}`, Avro)
+	require.NoError(t, err)
+
+	// Act
+	kept, keptErr := serializer.Serialize(context.Background(), "cupcakes-value", map[string]interface{}{"flavor": "vanilla"})
+	serializer.Reset()
+	refreshed, refreshedErr := serializer.Serialize(context.Background(), "cupcakes-value", map[string]interface{}{"flavor": "vanilla", "size": 2})
+
+	// Assert
+	require.NoError(t, keptErr)
+	require.NoError(t, refreshedErr)
+	keptID, _, _ := parseWireFormat(kept)
+	refreshedID, _, _ := parseWireFormat(refreshed)
+	assert.Equal(t, first.ID(), keptID)
+	assert.Equal(t, second.ID(), refreshedID)
+}
+
+func TestSerializer_Errors(t *testing.T) {
+	t.Parallel()
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	jsonSchema, err := registry.CreateSchema(context.Background(), "pies-value", `{"type": "object"}`, Json)
+	require.NoError(t, err)
+	serializer := NewSerializer(registry)
+	deserializer := NewDeserializer(registry)
+
+	_, err = serializer.Serialize(context.Background(), "unknown-value", map[string]interface{}{})
+	assert.True(t, isNotFoundError(err))
+
+	_, _, err = deserializer.Deserialize(context.Background(), []byte{1, 2})
+	assert.ErrorIs(t, err, ErrInvalidWireFormat)
+	_, _, err = deserializer.Deserialize(context.Background(), appendWireHeader(nil, jsonSchema.ID()))
+	assert.Error(t, err)
+}
+
+func TestSerializer_Protobuf(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, err := registry.CreateSchema(context.Background(), "orders-value", `syntax = "proto3";
+package shop;
+message Bakery {
+  string name = 1;
+}
+message Order {
+  message Line {
+    string flavor = 1;
+    int32 quantity = 2;
+  }
+  Line line = 1;
+}`, Protobuf)
+	require.NoError(t, err)
+	serializer := NewSerializer(registry)
+	deserializer := NewDeserializer(registry)
+
+	descriptor, err := deserializer.protobufMessage(context.Background(), schema, []int{1, 0})
+	require.NoError(t, err)
+	require.Equal(t, "shop.Order.Line", string(descriptor.FullName()))
+	line := dynamicpb.NewMessage(descriptor)
+	line.Set(descriptor.Fields().ByName("flavor"), protoreflect.ValueOfString("vanilla"))
+
+	// Act
+	payload, err := serializer.Serialize(context.Background(), "orders-value", line)
+	require.NoError(t, err)
+	value, writer, err := deserializer.Deserialize(context.Background(), payload)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []byte{4, 2, 0}, payload[wireHeaderLength:wireHeaderLength+3])
+	assert.Equal(t, schema.ID(), writer.ID())
+	assert.True(t, proto.Equal(line, value.(proto.Message)))
+}
+
+func TestSerializer_ProtobufGeneratedMessage(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	_, err := registry.CreateSchema(context.Background(), "durations-value", `syntax = "proto3";
+package google.protobuf;
+message Duration {
+  int64 seconds = 1;
+  int32 nanos = 2;
+}`, Protobuf)
+	require.NoError(t, err)
+	serializer := NewSerializer(registry)
+	deserializer := NewDeserializer(registry)
+
+	// Act
+	payload, err := serializer.Serialize(context.Background(), "durations-value", durationpb.New(90*time.Second))
+	require.NoError(t, err)
+	var duration durationpb.Duration
+	_, intoErr := deserializer.DeserializeInto(context.Background(), payload, &duration)
+	value, _, err := deserializer.Deserialize(context.Background(), payload)
+
+	// Assert
+	assert.Equal(t, byte(0), payload[wireHeaderLength])
+	require.NoError(t, intoErr)
+	assert.Equal(t, int64(90), duration.Seconds)
+	require.NoError(t, err)
+	message := value.(*dynamicpb.Message)
+	assert.Equal(t, int64(90), message.Get(message.Descriptor().Fields().ByName("seconds")).Int())
+
+	_, err = serializer.Serialize(context.Background(), "durations-value", map[string]interface{}{})
+	assert.Error(t, err)
+	_, err = deserializer.DeserializeInto(context.Background(), []byte{0, 0, 0, 0, 1}, &duration)
+	assert.Error(t, err)
+}
+
+func TestMessageIndexes(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		indexes []int
+		encoded []byte
+	}{
+		"first message":  {indexes: []int{0}, encoded: []byte{0}},
+		"second message": {indexes: []int{1}, encoded: []byte{2, 2}},
+		"nested message": {indexes: []int{1, 0, 3}, encoded: []byte{6, 2, 0, 6}},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			encoded := appendMessageIndexes(nil, testData.indexes)
+			assert.Equal(t, testData.encoded, encoded)
+
+			indexes, body, err := parseMessageIndexes(append(encoded, 42))
+			require.NoError(t, err)
+			assert.Equal(t, testData.indexes, indexes)
+			assert.Equal(t, []byte{42}, body)
+		})
+	}
+
+	_, _, err := parseMessageIndexes([]byte{8, 2})
+	assert.ErrorIs(t, err, ErrInvalidWireFormat)
+	_, _, err = parseMessageIndexes(nil)
+	assert.ErrorIs(t, err, ErrInvalidWireFormat)
+}
+
+func TestSerializer_Json(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, err := registry.CreateSchema(context.Background(), "pies-value", `{
+  "type": "object",
+  "properties": {
+    "flavor": {"type": "string"},
+    "slices": {"type": "integer", "minimum": 1}
+  },
+  "required": ["flavor"]
+}`, Json)
+	require.NoError(t, err)
+	serializer := NewSerializer(registry)
+	deserializer := NewDeserializer(registry)
+	type pie struct {
+		Flavor string `json:"flavor,omitempty"`
+		Slices int    `json:"slices"`
+	}
+
+	// Act
+	payload, err := serializer.Serialize(context.Background(), "pies-value", pie{Flavor: "apple", Slices: 8})
+	require.NoError(t, err)
+	value, writer, err := deserializer.Deserialize(context.Background(), payload)
+	_, invalidErr := serializer.Serialize(context.Background(), "pies-value", pie{Slices: 0})
+	_, _, invalidPayloadErr := deserializer.Deserialize(context.Background(),
+		append(appendWireHeader(nil, schema.ID()), `{"flavor": 3}`...))
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, schema.ID(), writer.ID())
+	assert.Equal(t, map[string]interface{}{"flavor": "apple", "slices": float64(8)}, value)
+
+	var validationErr *JsonValidationError
+	require.ErrorAs(t, invalidErr, &validationErr)
+	assert.Equal(t, schema.ID(), validationErr.SchemaID)
+	assert.ElementsMatch(t, []string{"", "/slices"}, []string{validationErr.Violations[0].Path, validationErr.Violations[1].Path})
+	require.ErrorAs(t, invalidPayloadErr, &validationErr)
+	assert.Equal(t, []JsonViolation{{Path: "/flavor", Message: "expected string, but got number"}}, validationErr.Violations)
+}
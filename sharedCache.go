@@ -0,0 +1,52 @@
+package srclient
+
+import "context"
+
+// SharedCache is an external cache shared by many clients, such as Redis
+// or memcached, backing their in-process caches. Values are the raw
+// registry responses of immutable reads: schemas by ID and subject
+// versions by number, never the latest version.
+type SharedCache interface {
+	// Get returns the value of the key and whether it was found.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+	Set(ctx context.Context, key string, value []byte) error
+}
+
+// SetSharedCache backs the in-process caches with a shared tier, so a
+// fleet of consumers starting together fetches each schema from the
+// registry once rather than once per consumer. Reads missing the
+// in-process cache go through the shared cache before the registry and
+// the registry responses are written to both. Keys are the registry URL
+// followed by the request path. The shared cache is only used when
+// caching is enabled, its errors are ignored and the registry is read
+// instead. A nil cache removes the shared tier.
+func (client *SchemaRegistryClient) SetSharedCache(cache SharedCache) {
+	client.sharedCacheLock.Lock()
+	defer client.sharedCacheLock.Unlock()
+	client.sharedCache = cache
+}
+
+func (client *SchemaRegistryClient) getSharedCache() SharedCache {
+	client.sharedCacheLock.RLock()
+	defer client.sharedCacheLock.RUnlock()
+	return client.sharedCache
+}
+
+// readThrough reads an immutable registry path through the shared cache.
+func (client *SchemaRegistryClient) readThrough(ctx context.Context, uri string) ([]byte, error) {
+	cache := client.getSharedCache()
+	if cache == nil || !client.getCachingEnabled() {
+		return client.fetchSchema(ctx, uri)
+	}
+
+	key := client.schemaRegistryURL + uri
+	if cached, ok, err := cache.Get(ctx, key); err == nil && ok {
+		return cached, nil
+	}
+	resp, err := client.fetchSchema(ctx, uri)
+	if err != nil {
+		return nil, err
+	}
+	_ = cache.Set(ctx, key, resp)
+	return resp, nil
+}
@@ -0,0 +1,101 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type mapSharedCache struct {
+	lock   sync.Mutex
+	values map[string][]byte
+	err    error
+}
+
+func (cache *mapSharedCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	value, ok := cache.values[key]
+	return value, ok, cache.err
+}
+
+func (cache *mapSharedCache) Set(_ context.Context, key string, value []byte) error {
+	cache.lock.Lock()
+	defer cache.lock.Unlock()
+	if cache.values == nil {
+		cache.values = make(map[string][]byte)
+	}
+	cache.values[key] = value
+	return cache.err
+}
+
+func TestSchemaRegistryClient_SharedCache(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+		switch req.URL.String() {
+		case "/schemas/ids/1", "/subjects/cupcakes-value/versions/1", "/subjects/cupcakes-value/versions/latest":
+			_ = json.NewEncoder(rw).Encode(schemaResponse{Subject: "cupcakes-value", Version: 1, ID: 1, Schema: testSchema1})
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	shared := &mapSharedCache{}
+	first := CreateSchemaRegistryClient(server.URL)
+	first.SetSharedCache(shared)
+	second := CreateSchemaRegistryClient(server.URL)
+	second.SetSharedCache(shared)
+
+	// Act
+	_, err := first.GetSchema(context.Background(), 1)
+	require.NoError(t, err)
+	_, err = first.GetSchemaByVersion(context.Background(), "cupcakes-value", 1)
+	require.NoError(t, err)
+	schema, idErr := second.GetSchema(context.Background(), 1)
+	_, versionErr := second.GetSchemaByVersion(context.Background(), "cupcakes-value", 1)
+
+	// Assert
+	require.NoError(t, idErr)
+	require.NoError(t, versionErr)
+	assert.Equal(t, int32(2), atomic.LoadInt32(&calls))
+	assert.Equal(t, testSchema1, schema.Schema())
+	assert.NotNil(t, schema.Codec())
+	assert.Contains(t, shared.values, server.URL+"/schemas/ids/1")
+
+	// The latest version is never shared
+	_, err = first.GetLatestSchema(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	_, err = second.GetLatestSchema(context.Background(), "cupcakes-value")
+	require.NoError(t, err)
+	assert.Equal(t, int32(4), atomic.LoadInt32(&calls))
+	assert.Len(t, shared.values, 2)
+}
+
+func TestSchemaRegistryClient_SharedCacheErrorsAreIgnored(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetSharedCache(&mapSharedCache{err: errors.New("connection refused")})
+
+	// Act
+	schema, err := srClient.GetSchema(context.Background(), 1)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, 1, schema.ID())
+}
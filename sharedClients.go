@@ -0,0 +1,160 @@
+package srclient
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// sharedClients holds the clients of GetOrCreateClient by the URL and the
+// fingerprint of their options.
+var sharedClients = struct {
+	lock    sync.Mutex
+	clients map[string]*SchemaRegistryClient
+}{clients: make(map[string]*SchemaRegistryClient)}
+
+// GetOrCreateClient returns the client of the process configured with the
+// same URL and options, creating it with NewClient the first time, so the
+// libraries of a process share caches and connection pools rather than
+// each creating their own client. Options are compared by value, such as
+// credentials or headers, except for the HTTP clients and the values
+// given as interfaces, such as audit sinks, compared by identity. Options
+// holding functions, such as WithHeaderFunc or WithLeaderWrites with a
+// detector, can't be compared: such clients are created anew each time
+// and not shared.
+//
+// Shared clients should be configured with options only, a setter called
+// by one of the libraries changes the client of all of them. Each call
+// takes a reference on the client which Close releases, the client being
+// only closed by the Close releasing the last one, so each library must
+// close the client it got once, and not use it afterwards.
+func GetOrCreateClient(schemaRegistryURL string, opts ...Option) *SchemaRegistryClient {
+	var options clientOptions
+	for _, opt := range opts {
+		opt(&options)
+	}
+	var fingerprint strings.Builder
+	if !optionsFingerprint(reflect.ValueOf(options), &fingerprint) {
+		return NewClient(schemaRegistryURL, opts...)
+	}
+	key := schemaRegistryURL + "\x00" + fingerprint.String()
+
+	sharedClients.lock.Lock()
+	defer sharedClients.lock.Unlock()
+	if client, ok := sharedClients.clients[key]; ok {
+		client.sharedRefs++
+		return client
+	}
+	client := NewClient(schemaRegistryURL, opts...)
+	client.sharedRefs = 1
+	sharedClients.clients[key] = client
+	return client
+}
+
+// ForgetSharedClients removes the clients of GetOrCreateClient, the next
+// calls creating new ones. The clients already returned keep working,
+// and are closed by the Close releasing their last reference.
+func ForgetSharedClients() {
+	sharedClients.lock.Lock()
+	defer sharedClients.lock.Unlock()
+	sharedClients.clients = make(map[string]*SchemaRegistryClient)
+}
+
+// releaseSharedClient releases a reference on a client returned by
+// GetOrCreateClient, it tells true when the client must be closed: when
+// that was its last reference, or when it isn't shared. The client is
+// no longer shared then.
+func releaseSharedClient(client *SchemaRegistryClient) bool {
+	sharedClients.lock.Lock()
+	defer sharedClients.lock.Unlock()
+	if client.sharedRefs > 1 {
+		client.sharedRefs--
+		return false
+	}
+	client.sharedRefs = 0
+	for key, shared := range sharedClients.clients {
+		if shared == client {
+			delete(sharedClients.clients, key)
+		}
+	}
+	return true
+}
+
+// valueOptions are the options made anew by each option, which are
+// compared by value rather than by identity.
+var valueOptions = map[reflect.Type]bool{
+	reflect.TypeOf(&credentials{}):   true,
+	reflect.TypeOf(&RequestBudget{}): true,
+	reflect.TypeOf(&AccessPolicy{}):  true,
+}
+
+// optionsFingerprint writes a description of the value which is the same
+// for equal options, it tells false when the value holds functions.
+func optionsFingerprint(value reflect.Value, fingerprint *strings.Builder) bool {
+	switch value.Kind() {
+	case reflect.Invalid:
+		fingerprint.WriteString("nil")
+	case reflect.Func:
+		if !value.IsNil() {
+			return false
+		}
+		fingerprint.WriteString("nil")
+	case reflect.Ptr:
+		switch {
+		case value.IsNil():
+			fingerprint.WriteString("nil")
+		case valueOptions[value.Type()]:
+			fingerprint.WriteString("&")
+			return optionsFingerprint(value.Elem(), fingerprint)
+		default:
+			fmt.Fprintf(fingerprint, "%s@%x", value.Type(), value.Pointer())
+		}
+	case reflect.Interface:
+		if value.IsNil() {
+			fingerprint.WriteString("nil")
+			return true
+		}
+		fmt.Fprintf(fingerprint, "%s:", value.Elem().Type())
+		return optionsFingerprint(value.Elem(), fingerprint)
+	case reflect.Struct:
+		fingerprint.WriteString("{")
+		for i := 0; i < value.NumField(); i++ {
+			fmt.Fprintf(fingerprint, "%s:", value.Type().Field(i).Name)
+			if !optionsFingerprint(value.Field(i), fingerprint) {
+				return false
+			}
+			fingerprint.WriteString(",")
+		}
+		fingerprint.WriteString("}")
+	case reflect.Slice, reflect.Array:
+		fingerprint.WriteString("[")
+		for i := 0; i < value.Len(); i++ {
+			if !optionsFingerprint(value.Index(i), fingerprint) {
+				return false
+			}
+			fingerprint.WriteString(",")
+		}
+		fingerprint.WriteString("]")
+	case reflect.Map:
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i]) < fmt.Sprint(keys[j]) })
+		fingerprint.WriteString("map[")
+		for _, key := range keys {
+			fmt.Fprintf(fingerprint, "%q:", fmt.Sprint(key))
+			if !optionsFingerprint(value.MapIndex(key), fingerprint) {
+				return false
+			}
+			fingerprint.WriteString(",")
+		}
+		fingerprint.WriteString("]")
+	case reflect.String:
+		fmt.Fprintf(fingerprint, "%q", value.String())
+	case reflect.Chan, reflect.UnsafePointer:
+		fmt.Fprintf(fingerprint, "%x", value.Pointer())
+	default:
+		fmt.Fprint(fingerprint, value)
+	}
+	return true
+}
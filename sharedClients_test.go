@@ -0,0 +1,90 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGetOrCreateClient(t *testing.T) {
+	t.Parallel()
+
+	httpClient := &http.Client{}
+	tests := map[string]struct {
+		first  []Option
+		second []Option
+		shared bool
+	}{
+		"no options": {
+			shared: true,
+		},
+		"same options": {
+			first:  []Option{WithBasicAuth("user", "secret"), WithHeaders(http.Header{"X-Tenant-Id": {"a"}}), WithCacheTTL(time.Minute)},
+			second: []Option{WithBasicAuth("user", "secret"), WithHeaders(http.Header{"X-Tenant-Id": {"a"}}), WithCacheTTL(time.Minute)},
+			shared: true,
+		},
+		"same http client": {
+			first:  []Option{WithHTTPClient(httpClient)},
+			second: []Option{WithHTTPClient(httpClient)},
+			shared: true,
+		},
+		"different credentials": {
+			first:  []Option{WithBasicAuth("user", "secret")},
+			second: []Option{WithBasicAuth("user", "other")},
+		},
+		"different headers": {
+			first:  []Option{WithHeaders(http.Header{"X-Tenant-Id": {"a"}})},
+			second: []Option{WithHeaders(http.Header{"X-Tenant-Id": {"b"}})},
+		},
+		"different http clients": {
+			first:  []Option{WithHTTPClient(&http.Client{})},
+			second: []Option{WithHTTPClient(&http.Client{})},
+		},
+		"header function": {
+			first:  []Option{WithHeaderFunc(func(context.Context) http.Header { return nil })},
+			second: []Option{WithHeaderFunc(func(context.Context) http.Header { return nil })},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		url := "http://" + strings.ReplaceAll(name, " ", "-")
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			first := GetOrCreateClient(url, testData.first...)
+			second := GetOrCreateClient(url, testData.second...)
+
+			// Assert
+			assert.Equal(t, testData.shared, first == second)
+		})
+	}
+}
+
+func TestGetOrCreateClient_DifferentURLs(t *testing.T) {
+	t.Parallel()
+	// Act
+	first := GetOrCreateClient("http://first-registry")
+	second := GetOrCreateClient("http://second-registry")
+
+	// Assert
+	assert.NotSame(t, first, second)
+}
+
+// Not parallel, forgetting the shared clients would break the other tests
+func TestForgetSharedClients(t *testing.T) {
+	// Arrange
+	first := GetOrCreateClient("http://forgotten-registry")
+
+	// Act
+	ForgetSharedClients()
+	second := GetOrCreateClient("http://forgotten-registry")
+
+	// Assert
+	assert.NotSame(t, first, second)
+	assert.Same(t, second, GetOrCreateClient("http://forgotten-registry"))
+}
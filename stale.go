@@ -0,0 +1,132 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// StaleFallbackEvent is emitted when a read failed against the registry
+// and a previously fetched, possibly stale, schema was returned instead.
+type StaleFallbackEvent struct {
+	// URI is the registry path which failed
+	URI string
+	// Err is the error returned by the registry call
+	Err error
+	// Schema is the stale schema returned to the caller
+	Schema *Schema
+	// Age is the time elapsed since the schema was fetched
+	Age time.Duration
+}
+
+// StaleFallbackHook is called every time a stale schema is served.
+type StaleFallbackHook func(event StaleFallbackEvent)
+
+// staleStore keeps the last schema successfully read for each registry
+// path. It survives ResetCache on purpose, as it's only used on errors,
+// and is bounded by the capacity of the schema caches.
+type staleStore struct {
+	lock     sync.RWMutex
+	enabled  bool
+	capacity int
+	schemas  *schemaCache
+	hook     StaleFallbackHook
+}
+
+// StaleOnErrorEnabled makes read methods return the last schema fetched
+// for the same request when the registry can't be reached or fails,
+// so consumers keep working through brief registry outages. The schemas
+// kept are bounded by WithCacheCapacity like the schema caches, but kept
+// past the TTL of WithCacheTTL as they are only served on errors.
+func (client *SchemaRegistryClient) StaleOnErrorEnabled(value bool) {
+	client.stale.lock.Lock()
+	defer client.stale.lock.Unlock()
+	client.stale.enabled = value
+	if !value {
+		client.stale.schemas = nil
+	}
+}
+
+// SetStaleFallbackHook registers a hook called every time a stale schema is served.
+func (client *SchemaRegistryClient) SetStaleFallbackHook(hook StaleFallbackHook) {
+	client.stale.lock.Lock()
+	defer client.stale.lock.Unlock()
+	client.stale.hook = hook
+}
+
+// remember records the schema read for uri at the given time, when the
+// fallback is enabled.
+func (s *staleStore) remember(uri string, schema *Schema, now time.Time) {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if !s.enabled {
+		return
+	}
+	if s.schemas == nil {
+		s.schemas = newSchemaCache(s.capacity)
+	}
+	s.schemas.set(uri, schemaCacheEntry{schema: schema, cachedAt: now})
+}
+
+// fallback returns the stale schema for uri if there is one and the error
+// is worth hiding, otherwise the original error is returned.
+func (s *staleStore) fallback(ctx context.Context, uri string, err error, now time.Time) (*Schema, error) {
+	if !isTransientError(ctx, err) {
+		return nil, err
+	}
+
+	s.lock.RLock()
+	schemas := s.schemas
+	hook := s.hook
+	s.lock.RUnlock()
+	if schemas == nil {
+		return nil, err
+	}
+	stale := schemas.get(uri)
+	if stale.schema == nil {
+		return nil, err
+	}
+
+	if hook != nil {
+		hook(StaleFallbackEvent{URI: uri, Err: err, Schema: stale.schema, Age: now.Sub(stale.cachedAt)})
+	}
+	return stale.schema, nil
+}
+
+// isTransientError tells if the error may be caused by an outage: a
+// network error, a timeout which isn't the one of the caller's context,
+// or a 5xx or 429 response. Client errors such as a missing schema are
+// authoritative, and the errors of the caller or of the client itself,
+// such as a cancelled context or a closed client, are never hidden.
+func isTransientError(ctx context.Context, err error) bool {
+	if ctx.Err() != nil {
+		return false
+	}
+	var registryErr Error
+	if errors.As(err, &registryErr) {
+		code := registryErr.Code
+		if code >= 10000 {
+			// Registry error codes are the HTTP status followed by two digits
+			code /= 100
+		}
+		return code >= http.StatusInternalServerError || code == http.StatusTooManyRequests
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		// The deadline of the request, the caller's one is checked above
+		return true
+	}
+	if errors.Is(err, context.Canceled) {
+		return false
+	}
+	// url.Error is a net.Error itself, the error it wraps tells what failed
+	var urlErr *url.Error
+	for errors.As(err, &urlErr) {
+		err = urlErr.Err
+	}
+	var netErr net.Error
+	return errors.As(err, &netErr)
+}
@@ -0,0 +1,203 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaRegistryClient_StaleOnErrorReturnsLastKnownSchema(t *testing.T) {
+	t.Parallel()
+	var down int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		response, _ := json.Marshal(schemaResponse{Subject: "test1", Version: 1, ID: 1, Schema: "payload"})
+		_, _ = rw.Write(response)
+	}))
+	defer server.Close()
+
+	var events []StaleFallbackEvent
+	clock := NewManualClock(time.Unix(0, 0))
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetClock(clock)
+	srClient.StaleOnErrorEnabled(true)
+	srClient.SetStaleFallbackHook(func(event StaleFallbackEvent) {
+		events = append(events, event)
+	})
+
+	schema1, err := srClient.GetLatestSchema(context.Background(), "test1")
+	assert.NoError(t, err)
+
+	atomic.StoreInt32(&down, 1)
+	clock.Advance(time.Minute)
+	schema2, err := srClient.GetLatestSchema(context.Background(), "test1")
+
+	assert.NoError(t, err)
+	assert.Same(t, schema1, schema2)
+	if assert.Len(t, events, 1) {
+		assert.Equal(t, "/subjects/test1/versions/latest", events[0].URI)
+		assert.Error(t, events[0].Err)
+		assert.Equal(t, time.Minute, events[0].Age)
+	}
+
+	// Nothing to fall back to
+	_, err = srClient.GetLatestSchema(context.Background(), "test2")
+	assert.Error(t, err)
+}
+
+func TestSchemaRegistryClient_StaleOnErrorKeepsNotFoundErrors(t *testing.T) {
+	t.Parallel()
+	var gone int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&gone) == 1 {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+			return
+		}
+		response, _ := json.Marshal(schemaResponse{Subject: "test1", Version: 1, ID: 1, Schema: "payload"})
+		_, _ = rw.Write(response)
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.StaleOnErrorEnabled(true)
+
+	_, err := srClient.GetLatestSchema(context.Background(), "test1")
+	assert.NoError(t, err)
+
+	atomic.StoreInt32(&gone, 1)
+	_, err = srClient.GetLatestSchema(context.Background(), "test1")
+	assert.True(t, isNotFoundError(err))
+}
+
+func TestIsTransientError(t *testing.T) {
+	t.Parallel()
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	tests := map[string]struct {
+		ctx       context.Context
+		err       error
+		transient bool
+	}{
+		"unavailable": {
+			err:       Error{Code: 50301, Message: "unavailable"},
+			transient: true,
+		},
+		"too many requests": {
+			err:       Error{Code: http.StatusTooManyRequests},
+			transient: true,
+		},
+		"not found": {
+			err: Error{Code: 40401, Message: "Subject not found"},
+		},
+		"connection refused": {
+			err:       &url.Error{Op: "Get", URL: "http://registry", Err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}},
+			transient: true,
+		},
+		"request timeout": {
+			err:       &url.Error{Op: "Get", URL: "http://registry", Err: context.DeadlineExceeded},
+			transient: true,
+		},
+		"caller cancelled": {
+			ctx: cancelled,
+			err: &url.Error{Op: "Get", URL: "http://registry", Err: context.Canceled},
+		},
+		"cancelled": {
+			err: &url.Error{Op: "Get", URL: "http://registry", Err: context.Canceled},
+		},
+		"closed client": {
+			err: ErrClientClosed,
+		},
+		"budget exceeded": {
+			err: ErrBudgetExceeded,
+		},
+		"invalid url": {
+			err: &url.Error{Op: "parse", URL: "http://registry\n", Err: errors.New("invalid control character in URL")},
+		},
+		"policy": {
+			err: &PolicyError{Subject: "orders-value"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			ctx := testData.ctx
+			if ctx == nil {
+				ctx = context.Background()
+			}
+
+			// Act
+			transient := isTransientError(ctx, testData.err)
+
+			// Assert
+			assert.Equal(t, testData.transient, transient)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_StaleOnErrorBoundedByCacheCapacity(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var down int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if atomic.LoadInt32(&down) == 1 {
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		response, _ := json.Marshal(schemaResponse{Version: 1, ID: 1, Schema: "payload"})
+		_, _ = rw.Write(response)
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithCacheCapacity(1))
+	srClient.CachingEnabled(false)
+	srClient.StaleOnErrorEnabled(true)
+	_, err := srClient.GetLatestSchema(context.Background(), "test1")
+	assert.NoError(t, err)
+	_, err = srClient.GetLatestSchema(context.Background(), "test2")
+	assert.NoError(t, err)
+	atomic.StoreInt32(&down, 1)
+
+	// Act
+	_, evictedErr := srClient.GetLatestSchema(context.Background(), "test1")
+	_, keptErr := srClient.GetLatestSchema(context.Background(), "test2")
+
+	// Assert
+	assert.Error(t, evictedErr)
+	assert.NoError(t, keptErr)
+}
+
+func TestSchemaRegistryClient_StaleOnErrorKeepsClosedClientErrors(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		response, _ := json.Marshal(schemaResponse{Version: 1, ID: 1, Schema: "payload"})
+		_, _ = rw.Write(response)
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.CachingEnabled(false)
+	srClient.StaleOnErrorEnabled(true)
+	_, err := srClient.GetLatestSchema(context.Background(), "test1")
+	assert.NoError(t, err)
+	assert.NoError(t, srClient.Close(context.Background()))
+
+	// Act
+	_, err = srClient.GetLatestSchema(context.Background(), "test1")
+
+	// Assert
+	assert.ErrorIs(t, err, ErrClientClosed)
+}
@@ -0,0 +1,94 @@
+package srclient
+
+import (
+	"context"
+	"sort"
+)
+
+// RegistryStats summarizes the content of a registry, to trend its
+// hygiene over time.
+type RegistryStats struct {
+	Subjects int
+	// Versions counts the versions of every subject, Schemas the
+	// distinct schema IDs they use.
+	Versions int
+	Schemas  int
+	// SchemasByType counts the distinct schemas of each type.
+	SchemasByType map[SchemaType]int
+	// AverageSchemaSize is the average length in bytes of the distinct schemas.
+	AverageSchemaSize float64
+	// NoneCompatibility lists the subjects whose effective
+	// compatibility level is NONE, sorted.
+	NoneCompatibility []string
+	// WithReferences lists the subjects whose latest version has
+	// references, sorted.
+	WithReferences []string
+}
+
+// Stats walks every version of every subject of the registry and
+// summarizes them. The compatibility level of each subject is the
+// effective one, falling back to the global level.
+func (client *SchemaRegistryClient) Stats(ctx context.Context) (*RegistryStats, error) {
+	return registryStats(ctx, client)
+}
+
+func registryStats(ctx context.Context, client ISchemaRegistryClient) (*RegistryStats, error) {
+	subjects, err := client.GetSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(subjects)
+
+	stats := &RegistryStats{SchemasByType: make(map[SchemaType]int)}
+	sizes := 0
+	seen := make(map[int]bool)
+	for _, subject := range subjects {
+		versions, err := client.GetSchemaVersions(ctx, subject)
+		if isNotFoundError(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		var latest *Schema
+		for _, version := range versions {
+			schema, err := client.GetSchemaByVersion(ctx, subject, version)
+			if isNotFoundError(err) {
+				continue
+			} else if err != nil {
+				return nil, err
+			}
+			stats.Versions++
+			if latest == nil || schema.Version() > latest.Version() {
+				latest = schema
+			}
+			if seen[schema.ID()] {
+				continue
+			}
+			seen[schema.ID()] = true
+			stats.Schemas++
+			stats.SchemasByType[schemaTypeOf(schema)]++
+			sizes += len(schema.Schema())
+		}
+		if latest == nil {
+			continue
+		}
+		stats.Subjects++
+		if len(latest.References()) > 0 {
+			stats.WithReferences = append(stats.WithReferences, subject)
+		}
+
+		level, err := client.GetCompatibilityLevel(ctx, subject, true)
+		if err != nil {
+			return nil, err
+		}
+		if *level == None {
+			stats.NoneCompatibility = append(stats.NoneCompatibility, subject)
+		}
+	}
+
+	if stats.Schemas > 0 {
+		stats.AverageSchemaSize = float64(sizes) / float64(stats.Schemas)
+	}
+	return stats, nil
+}
@@ -0,0 +1,50 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_Stats(t *testing.T) {
+	t.Parallel()
+	jsonType := Json
+	responses := map[string]interface{}{
+		"/subjects":                                    []string{"orders-value", "customers-value", "shared-value"},
+		"/subjects/orders-value/versions":              []int{1, 2},
+		"/subjects/orders-value/versions/1":            schemaResponse{Version: 1, ID: 1, Schema: `"int"`},
+		"/subjects/orders-value/versions/2":            schemaResponse{Version: 2, ID: 2, Schema: `"long"`},
+		"/subjects/shared-value/versions":              []int{1},
+		"/subjects/shared-value/versions/1":            schemaResponse{Version: 1, ID: 2, Schema: `"long"`},
+		"/subjects/customers-value/versions":           []int{1},
+		"/subjects/customers-value/versions/1":         schemaResponse{Version: 1, ID: 3, Schema: `{"$ref": "address.json"}`, SchemaType: &jsonType, References: []Reference{{Name: "address.json", Subject: "address", Version: 1}}},
+		"/config/orders-value?defaultToGlobal=true":    configResponse{CompatibilityLevel: None},
+		"/config/shared-value?defaultToGlobal=true":    configResponse{CompatibilityLevel: Backward},
+		"/config/customers-value?defaultToGlobal=true": configResponse{CompatibilityLevel: Full},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		response, ok := responses[req.URL.String()]
+		require.True(t, ok, req.URL.String())
+		_ = json.NewEncoder(rw).Encode(response)
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	stats, err := srClient.Stats(context.Background())
+
+	require.NoError(t, err)
+	assert.Equal(t, &RegistryStats{
+		Subjects:          3,
+		Versions:          4,
+		Schemas:           3,
+		SchemasByType:     map[SchemaType]int{Avro: 2, Json: 1},
+		AverageSchemaSize: float64(len(`"int"`)+len(`"long"`)+len(`{"$ref": "address.json"}`)) / 3,
+		NoneCompatibility: []string{"orders-value"},
+		WithReferences:    []string{"customers-value"},
+	}, stats)
+}
@@ -0,0 +1,54 @@
+package srclient
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+)
+
+// StatusHandler is called for error responses with the status code it
+// was registered for, before the default error handling. The request is
+// available through resp.Request. Returning an error replaces the
+// default error, returning nil falls back to it. The body can be read
+// freely, the default error handling gets it back untouched.
+type StatusHandler func(resp *http.Response) error
+
+// SetStatusHandler registers a handler for the status code, for example
+// to turn a 404 into a typed error that triggers a registration, or to
+// map the HTML pages of a gateway answering 503 to typed errors.
+// A nil handler removes the handler registered for the code.
+func (client *SchemaRegistryClient) SetStatusHandler(code int, handler StatusHandler) {
+	client.statusHandlersLock.Lock()
+	defer client.statusHandlersLock.Unlock()
+	if handler == nil {
+		delete(client.statusHandlers, code)
+		return
+	}
+	if client.statusHandlers == nil {
+		client.statusHandlers = make(map[int]StatusHandler)
+	}
+	client.statusHandlers[code] = handler
+}
+
+// handleErrorStatus runs the handler of the response status code, if
+// any, and falls back to createError.
+func (client *SchemaRegistryClient) handleErrorStatus(resp *http.Response) error {
+	client.statusHandlersLock.RLock()
+	handler := client.statusHandlers[resp.StatusCode]
+	client.statusHandlersLock.RUnlock()
+	if handler == nil {
+		return createError(resp)
+	}
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyBytes))
+	if err != nil {
+		return err
+	}
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	if err := handler(resp); err != nil {
+		return err
+	}
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return createError(resp)
+}
@@ -0,0 +1,68 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+var errRegisterNow = errors.New("register now")
+
+func TestSchemaRegistryClient_StatusHandlers(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		switch req.URL.Path {
+		case "/subjects/missing/versions/latest":
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+		case "/subjects/gateway/versions/latest":
+			rw.WriteHeader(http.StatusServiceUnavailable)
+			_, _ = rw.Write([]byte(`<html><body>Service Unavailable</body></html>`))
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code":40403,"message":"Schema not found"}`))
+		}
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	srClient.SetStatusHandler(http.StatusNotFound, func(resp *http.Response) error {
+		if resp.Request.Method == "GET" && strings.HasSuffix(resp.Request.URL.Path, "/versions/latest") {
+			return errRegisterNow
+		}
+		// Reading the body doesn't prevent the default error
+		_, _ = io.ReadAll(resp.Body)
+		return nil
+	})
+	var gatewayBody string
+	srClient.SetStatusHandler(http.StatusServiceUnavailable, func(resp *http.Response) error {
+		body, _ := io.ReadAll(resp.Body)
+		gatewayBody = string(body)
+		return nil
+	})
+
+	_, err := srClient.GetLatestSchema(context.Background(), "missing")
+	assert.ErrorIs(t, err, errRegisterNow)
+
+	_, err = srClient.GetSchema(context.Background(), 1)
+	var registryErr Error
+	if assert.True(t, errors.As(err, &registryErr)) {
+		assert.Equal(t, 40403, registryErr.Code)
+	}
+
+	_, err = srClient.GetLatestSchema(context.Background(), "gateway")
+	assert.Contains(t, gatewayBody, "Service Unavailable")
+	if assert.True(t, errors.As(err, &registryErr)) {
+		assert.Equal(t, http.StatusServiceUnavailable, registryErr.Code)
+	}
+
+	srClient.SetStatusHandler(http.StatusNotFound, nil)
+	_, err = srClient.GetLatestSchema(context.Background(), "missing")
+	assert.True(t, isNotFoundError(err))
+}
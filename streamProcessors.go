@@ -0,0 +1,400 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/jhump/protoreflect/desc/protoparse"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+// StreamProcessor is a stream processing engine reading the schemas of
+// the registry, such as ksqlDB or Flink.
+type StreamProcessor string
+
+const (
+	KsqlDB StreamProcessor = "ksqlDB"
+	Flink  StreamProcessor = "Flink"
+)
+
+// ErrStreamProcessorUnsupported is returned when registering a schema
+// using features the stream processors checked with
+// WithStreamProcessorChecks don't support.
+var ErrStreamProcessorUnsupported = errors.New("schema not supported by stream processors")
+
+// StreamProcessorIssue is a feature of a schema which a stream processor
+// can't map to its own types.
+type StreamProcessorIssue struct {
+	Processor StreamProcessor
+	// Path is the dotted path of the field using the feature.
+	Path    string
+	Problem string
+}
+
+func (issue StreamProcessorIssue) String() string {
+	return fmt.Sprintf("%s: %s: %s", issue.Processor, issue.Path, issue.Problem)
+}
+
+// streamProcessorLogicalTypes are the Avro logical types each stream
+// processor maps to its own types, the other ones being read as their
+// underlying type at best.
+var streamProcessorLogicalTypes = map[StreamProcessor]map[string]bool{
+	KsqlDB: {"decimal": true, "date": true, "time-millis": true, "timestamp-millis": true},
+	Flink: {"decimal": true, "date": true, "time-millis": true, "time-micros": true, "timestamp-millis": true,
+		"timestamp-micros": true, "local-timestamp-millis": true, "local-timestamp-micros": true},
+}
+
+// CheckStreamProcessors reports the features of a schema which ksqlDB or
+// Flink don't support: unions of several types other than null, JSON
+// Schema oneOf and anyOf alike, recursive types and Avro logical types they
+// don't know of. processors defaults to all of them. Referenced types are
+// not checked, flatten the schema with FlattenSchema to check them too.
+func CheckStreamProcessors(schema string, schemaType SchemaType, processors ...StreamProcessor) ([]StreamProcessorIssue, error) {
+	if len(processors) == 0 {
+		processors = []StreamProcessor{KsqlDB, Flink}
+	}
+
+	var problems []streamProcessorProblem
+	switch schemaType {
+	case Protobuf:
+		parser := protoparse.Parser{Accessor: protoparse.FileContentsFromMap(map[string]string{"schema.proto": schema})}
+		files, err := parser.ParseFilesButDoNotLink("schema.proto")
+		if err != nil {
+			return nil, err
+		}
+		problems = protobufStreamProblems(files[0])
+	case Json:
+		document, err := decodeSchemaDocument(schema)
+		if err != nil {
+			return nil, err
+		}
+		checker := &jsonStreamChecker{root: document, visiting: map[string]bool{"": true}}
+		checker.check(document, "")
+		problems = checker.problems
+	default:
+		document, err := decodeSchemaDocument(schema)
+		if err != nil {
+			return nil, err
+		}
+		checker := &avroStreamChecker{named: avroDefinitions(document)}
+		checker.check(document, "", "")
+		problems = checker.problems
+	}
+
+	var issues []StreamProcessorIssue
+	for _, processor := range processors {
+		for _, problem := range problems {
+			if problem.logicalType != "" && streamProcessorLogicalTypes[processor][problem.logicalType] {
+				continue
+			}
+			issues = append(issues, StreamProcessorIssue{Processor: processor, Path: problem.path, Problem: problem.problem})
+		}
+	}
+	return issues, nil
+}
+
+// WithStreamProcessorChecks makes the client refuse to register schemas
+// which CheckStreamProcessors finds the processors don't support, with
+// ErrStreamProcessorUnsupported. The references of Avro and JSON schemas
+// are flattened to be checked too.
+func WithStreamProcessorChecks(processors ...StreamProcessor) Option {
+	return func(options *clientOptions) {
+		options.streamProcessors = processors
+		if len(processors) == 0 {
+			options.streamProcessors = []StreamProcessor{KsqlDB, Flink}
+		}
+	}
+}
+
+// checkStreamProcessors checks a schema before registering it.
+func (client *SchemaRegistryClient) checkStreamProcessors(ctx context.Context, subject string, schema string, schemaType SchemaType, references []Reference) error {
+	if len(client.streamProcessors) == 0 {
+		return nil
+	}
+	if len(references) > 0 && schemaType != Protobuf {
+		flattened, err := FlattenSchema(ctx, client, &Schema{schema: schema, schemaType: &schemaType, references: references})
+		if err != nil {
+			return err
+		}
+		schema = flattened
+	}
+	issues, err := CheckStreamProcessors(schema, schemaType, client.streamProcessors...)
+	if err != nil {
+		return err
+	}
+	if len(issues) == 0 {
+		return nil
+	}
+	descriptions := make([]string, len(issues))
+	for i, issue := range issues {
+		descriptions[i] = issue.String()
+	}
+	return fmt.Errorf("%w: subject %s: %s", ErrStreamProcessorUnsupported, subject, strings.Join(descriptions, "; "))
+}
+
+// streamProcessorProblem is a problem for every processor, unless it is
+// a logical type the processor knows of.
+type streamProcessorProblem struct {
+	path        string
+	problem     string
+	logicalType string
+}
+
+// avroStreamChecker walks an Avro schema, named maps the full names of
+// its named types to their definitions.
+type avroStreamChecker struct {
+	named    map[string]map[string]interface{}
+	problems []streamProcessorProblem
+}
+
+func (checker *avroStreamChecker) report(path, problem string) {
+	checker.problems = append(checker.problems, streamProcessorProblem{path: path, problem: problem})
+}
+
+func (checker *avroStreamChecker) check(schema interface{}, namespace, path string) {
+	switch typed := schema.(type) {
+	case []interface{}:
+		var types int
+		for _, member := range typed {
+			if member != "null" {
+				types++
+			}
+		}
+		if types > 1 {
+			checker.report(path, "union of several types other than null")
+		}
+		for _, member := range typed {
+			checker.check(member, namespace, path)
+		}
+	case map[string]interface{}:
+		if logicalType, ok := typed["logicalType"].(string); ok {
+			checker.problems = append(checker.problems, streamProcessorProblem{
+				path:        path,
+				problem:     fmt.Sprintf("unsupported logical type %s", logicalType),
+				logicalType: logicalType,
+			})
+		}
+		typeName, ok := typed["type"].(string)
+		if !ok {
+			checker.check(typed["type"], namespace, path)
+			return
+		}
+		switch typeName {
+		case "array":
+			checker.check(typed["items"], namespace, path)
+		case "map":
+			checker.check(typed["values"], namespace, path)
+		case "record", "error":
+			fullName := avroDefinitionName(typed, namespace)
+			if checker.reaches(fullName, fullName, make(map[string]bool)) {
+				checker.report(path, fmt.Sprintf("recursive type %s", fullName))
+			}
+			fields, _ := typed["fields"].([]interface{})
+			for _, field := range fields {
+				if fieldMap, ok := field.(map[string]interface{}); ok {
+					fieldName, _ := fieldMap["name"].(string)
+					checker.check(fieldMap["type"], avroNamespaceOf(fullName), joinFieldPath(path, fieldName))
+				}
+			}
+		}
+	}
+}
+
+// reaches tells whether the fields of the named type from use the named
+// type to, directly or not.
+func (checker *avroStreamChecker) reaches(from, to string, seen map[string]bool) bool {
+	seen[from] = true
+	used := make(map[string]bool)
+	fields, _ := checker.named[from]["fields"].([]interface{})
+	for _, field := range fields {
+		if fieldMap, ok := field.(map[string]interface{}); ok {
+			checker.usedNames(fieldMap["type"], avroNamespaceOf(from), used)
+		}
+	}
+	for name := range used {
+		if name == to || (!seen[name] && checker.reaches(name, to, seen)) {
+			return true
+		}
+	}
+	return false
+}
+
+// usedNames collects the named types a type uses, without looking into
+// the fields of the records.
+func (checker *avroStreamChecker) usedNames(schema interface{}, namespace string, used map[string]bool) {
+	switch typed := schema.(type) {
+	case string:
+		if fullName, ok := avroLookupName(checker.named, typed, namespace); ok {
+			used[fullName] = true
+		}
+	case []interface{}:
+		for _, member := range typed {
+			checker.usedNames(member, namespace, used)
+		}
+	case map[string]interface{}:
+		switch typed["type"] {
+		case "record", "error", "enum", "fixed":
+			used[avroDefinitionName(typed, namespace)] = true
+		case "array":
+			checker.usedNames(typed["items"], namespace, used)
+		case "map":
+			checker.usedNames(typed["values"], namespace, used)
+		default:
+			checker.usedNames(typed["type"], namespace, used)
+		}
+	}
+}
+
+// jsonStreamChecker walks a JSON schema following its local $ref,
+// visiting holds the JSON pointers of the definitions being walked.
+type jsonStreamChecker struct {
+	root     interface{}
+	visiting map[string]bool
+	problems []streamProcessorProblem
+}
+
+func (checker *jsonStreamChecker) report(path, problem string) {
+	checker.problems = append(checker.problems, streamProcessorProblem{path: path, problem: problem})
+}
+
+func (checker *jsonStreamChecker) check(schema interface{}, path string) {
+	typed, ok := schema.(map[string]interface{})
+	if !ok {
+		return
+	}
+	if ref, ok := typed["$ref"].(string); ok && strings.HasPrefix(ref, "#") {
+		pointer := strings.TrimPrefix(ref, "#")
+		if checker.visiting[pointer] {
+			checker.report(path, fmt.Sprintf("recursive type %s", ref))
+		} else if target, ok := jsonPointerTarget(checker.root, pointer); ok {
+			checker.visiting[pointer] = true
+			checker.check(target, path)
+			delete(checker.visiting, pointer)
+		}
+	}
+	for _, keyword := range []string{"oneOf", "anyOf"} {
+		alternatives, _ := typed[keyword].([]interface{})
+		var types int
+		for _, alternative := range alternatives {
+			if alternativeMap, ok := alternative.(map[string]interface{}); !ok || alternativeMap["type"] != "null" {
+				types++
+			}
+		}
+		if types > 1 {
+			checker.report(path, fmt.Sprintf("%s of several types other than null", keyword))
+		}
+		for _, alternative := range alternatives {
+			checker.check(alternative, path)
+		}
+	}
+	if types, ok := typed["type"].([]interface{}); ok {
+		var nonNull int
+		for _, t := range types {
+			if t != "null" {
+				nonNull++
+			}
+		}
+		if nonNull > 1 {
+			checker.report(path, "union of several types other than null")
+		}
+	}
+	properties, _ := typed["properties"].(map[string]interface{})
+	for _, name := range sortedKeys(properties) {
+		checker.check(properties[name], joinFieldPath(path, name))
+	}
+	checker.check(typed["items"], path)
+	checker.check(typed["additionalProperties"], path)
+}
+
+// jsonPointerTarget returns the part of the document a JSON pointer
+// points to.
+func jsonPointerTarget(document interface{}, pointer string) (interface{}, bool) {
+	if pointer == "" {
+		return document, true
+	}
+	unescape := strings.NewReplacer("~1", "/", "~0", "~")
+	for _, token := range strings.Split(strings.TrimPrefix(pointer, "/"), "/") {
+		object, ok := document.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		if document, ok = object[unescape.Replace(token)]; !ok {
+			return nil, false
+		}
+	}
+	return document, true
+}
+
+// protobufStreamProblems reports the recursive messages of a Protobuf
+// file, the types of the fields are resolved within the file only.
+func protobufStreamProblems(file *descriptorpb.FileDescriptorProto) []streamProcessorProblem {
+	messages := make(map[string]*descriptorpb.DescriptorProto)
+	var index func(scope string, message *descriptorpb.DescriptorProto)
+	index = func(scope string, message *descriptorpb.DescriptorProto) {
+		name := joinFieldPath(scope, message.GetName())
+		messages[name] = message
+		for _, nested := range message.GetNestedType() {
+			index(name, nested)
+		}
+	}
+	for _, message := range file.GetMessageType() {
+		index(file.GetPackage(), message)
+	}
+
+	resolve := func(scope, typeName string) (string, bool) {
+		if strings.HasPrefix(typeName, ".") {
+			_, ok := messages[typeName[1:]]
+			return typeName[1:], ok
+		}
+		for {
+			candidate := joinFieldPath(scope, typeName)
+			if _, ok := messages[candidate]; ok {
+				return candidate, true
+			}
+			if scope == "" {
+				return "", false
+			}
+			idx := strings.LastIndex(scope, ".")
+			if idx < 0 {
+				scope = ""
+			} else {
+				scope = scope[:idx]
+			}
+		}
+	}
+
+	var problems []streamProcessorProblem
+	visiting := make(map[string]bool)
+	var check func(name, path string)
+	check = func(name, path string) {
+		visiting[name] = true
+		for _, field := range messages[name].GetField() {
+			if field.GetTypeName() == "" {
+				continue
+			}
+			fieldType, ok := resolve(name, field.GetTypeName())
+			if !ok {
+				continue
+			}
+			fieldPath := joinFieldPath(path, field.GetName())
+			if visiting[fieldType] {
+				problems = append(problems, streamProcessorProblem{path: fieldPath, problem: fmt.Sprintf("recursive type %s", fieldType)})
+				continue
+			}
+			check(fieldType, fieldPath)
+		}
+		delete(visiting, name)
+	}
+	names := make([]string, 0, len(file.GetMessageType()))
+	for _, message := range file.GetMessageType() {
+		names = append(names, joinFieldPath(file.GetPackage(), message.GetName()))
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		check(name, strings.TrimPrefix(strings.TrimPrefix(name, file.GetPackage()), "."))
+	}
+	return problems
+}
@@ -0,0 +1,122 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckStreamProcessors(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		schema     string
+		schemaType SchemaType
+		processors []StreamProcessor
+		expected   []StreamProcessorIssue
+	}{
+		"supported avro": {
+			schema: `{"type":"record","name":"Order","fields":[{"name":"id","type":"string"},` +
+				`{"name":"note","type":["null","string"]},{"name":"amount","type":{"type":"bytes","logicalType":"decimal","precision":4}}]}`,
+			schemaType: Avro,
+		},
+		"avro union": {
+			schema:     `{"type":"record","name":"Order","fields":[{"name":"id","type":["null","string","long"]}]}`,
+			schemaType: Avro,
+			processors: []StreamProcessor{KsqlDB},
+			expected:   []StreamProcessorIssue{{Processor: KsqlDB, Path: "id", Problem: "union of several types other than null"}},
+		},
+		"avro recursive type": {
+			schema: `{"type":"record","name":"Node","namespace":"com.example","fields":[` +
+				`{"name":"child","type":{"type":"record","name":"Child","fields":[{"name":"parent","type":["null","Node"]}]}}]}`,
+			schemaType: Avro,
+			processors: []StreamProcessor{Flink},
+			expected: []StreamProcessorIssue{
+				{Processor: Flink, Path: "", Problem: "recursive type com.example.Node"},
+				{Processor: Flink, Path: "child", Problem: "recursive type com.example.Child"},
+			},
+		},
+		"avro recursion through a type defined elsewhere": {
+			schema: `{"type":"record","name":"Root","fields":[` +
+				`{"name":"a","type":{"type":"record","name":"A","fields":[{"name":"c","type":["null","C"]}]}},` +
+				`{"name":"c","type":{"type":"record","name":"C","fields":[{"name":"a","type":"A"}]}}]}`,
+			schemaType: Avro,
+			processors: []StreamProcessor{KsqlDB},
+			expected: []StreamProcessorIssue{
+				{Processor: KsqlDB, Path: "a", Problem: "recursive type A"},
+				{Processor: KsqlDB, Path: "c", Problem: "recursive type C"},
+			},
+		},
+		"avro logical types": {
+			schema:     `{"type":"record","name":"Event","fields":[{"name":"at","type":{"type":"long","logicalType":"timestamp-micros"}}]}`,
+			schemaType: Avro,
+			expected:   []StreamProcessorIssue{{Processor: KsqlDB, Path: "at", Problem: "unsupported logical type timestamp-micros"}},
+		},
+		"json oneOf": {
+			schema:     `{"type":"object","properties":{"id":{"oneOf":[{"type":"string"},{"type":"integer"}]},"note":{"anyOf":[{"type":"null"},{"type":"string"}]}}}`,
+			schemaType: Json,
+			processors: []StreamProcessor{KsqlDB},
+			expected:   []StreamProcessorIssue{{Processor: KsqlDB, Path: "id", Problem: "oneOf of several types other than null"}},
+		},
+		"json recursive type": {
+			schema: `{"type":"object","properties":{"tree":{"$ref":"#/definitions/node"}},` +
+				`"definitions":{"node":{"type":"object","properties":{"children":{"type":"array","items":{"$ref":"#/definitions/node"}}}}}}`,
+			schemaType: Json,
+			processors: []StreamProcessor{Flink},
+			expected:   []StreamProcessorIssue{{Processor: Flink, Path: "tree.children", Problem: "recursive type #/definitions/node"}},
+		},
+		"protobuf recursive type": {
+			schema:     `syntax = "proto3"; package tree; message Node { repeated Node children = 1; string name = 2; }`,
+			schemaType: Protobuf,
+			processors: []StreamProcessor{KsqlDB},
+			expected:   []StreamProcessorIssue{{Processor: KsqlDB, Path: "Node.children", Problem: "recursive type tree.Node"}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			issues, err := CheckStreamProcessors(testData.schema, testData.schemaType, testData.processors...)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, issues)
+		})
+	}
+}
+
+func TestCheckStreamProcessors_InvalidSchema(t *testing.T) {
+	t.Parallel()
+	// Act
+	_, err := CheckStreamProcessors(`{"type":`, Avro)
+
+	// Assert
+	assert.Error(t, err)
+}
+
+func TestWithStreamProcessorChecks(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		atomic.AddInt32(&calls, 1)
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithStreamProcessorChecks(KsqlDB))
+
+	// Act
+	_, err := srClient.CreateSchema(context.Background(), "test1-value",
+		`{"type":"record","name":"Order","fields":[{"name":"id","type":["string","long"]}]}`, Avro)
+
+	// Assert
+	assert.ErrorIs(t, err, ErrStreamProcessorUnsupported)
+	assert.Contains(t, err.Error(), "ksqlDB: id: union")
+	assert.Equal(t, int32(0), atomic.LoadInt32(&calls))
+}
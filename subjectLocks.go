@@ -0,0 +1,72 @@
+package srclient
+
+import (
+	"context"
+	"sync"
+)
+
+// SubjectLocker locks subjects across the replicas of a service, so
+// replicas deploying at the same time register their schemas one after
+// the other instead of racing on the order of the versions. The
+// lockers/redislocker and lockers/zklocker modules lock them with Redis
+// and ZooKeeper, LocalSubjectLocker within the process.
+type SubjectLocker interface {
+	// Lock blocks until the subject is locked or the context is done,
+	// the returned function releases the lock.
+	Lock(ctx context.Context, subject string) (unlock func(), err error)
+}
+
+// WithSubjectLocker makes CreateSchema hold the lock of the subject
+// while registering a schema. Subjects are locked by their name in the
+// registry, prefix included.
+func WithSubjectLocker(locker SubjectLocker) Option {
+	return func(options *clientOptions) {
+		options.subjectLocker = locker
+	}
+}
+
+// LocalSubjectLocker locks subjects within the process, for clients
+// sharing a registry in a single service and for tests.
+type LocalSubjectLocker struct {
+	lock  sync.Mutex
+	locks map[string]chan struct{}
+}
+
+// NewLocalSubjectLocker returns a locker of subjects within the process.
+func NewLocalSubjectLocker() *LocalSubjectLocker {
+	return &LocalSubjectLocker{locks: make(map[string]chan struct{})}
+}
+
+func (locker *LocalSubjectLocker) Lock(ctx context.Context, subject string) (func(), error) {
+	for {
+		locker.lock.Lock()
+		held, ok := locker.locks[subject]
+		if !ok {
+			released := make(chan struct{})
+			locker.locks[subject] = released
+			locker.lock.Unlock()
+			return func() {
+				locker.lock.Lock()
+				delete(locker.locks, subject)
+				locker.lock.Unlock()
+				close(released)
+			}, nil
+		}
+		locker.lock.Unlock()
+
+		select {
+		case <-held:
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// lockSubject locks the subject if the client has a locker, the
+// returned function releases it.
+func (client *SchemaRegistryClient) lockSubject(ctx context.Context, subject string) (func(), error) {
+	if client.subjectLocker == nil {
+		return func() {}, nil
+	}
+	return client.subjectLocker.Lock(ctx, client.prefixed(subject))
+}
@@ -0,0 +1,79 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type recordingLocker struct {
+	lock   sync.Mutex
+	events []string
+}
+
+func (locker *recordingLocker) Lock(ctx context.Context, subject string) (func(), error) {
+	locker.record("lock " + subject)
+	return func() { locker.record("unlock " + subject) }, nil
+}
+
+func (locker *recordingLocker) record(event string) {
+	locker.lock.Lock()
+	defer locker.lock.Unlock()
+	locker.events = append(locker.events, event)
+}
+
+func TestLocalSubjectLocker(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	locker := NewLocalSubjectLocker()
+	unlock, err := locker.Lock(context.Background(), "orders-value")
+	require.NoError(t, err)
+
+	// Act
+	otherUnlock, otherErr := locker.Lock(context.Background(), "payments-value")
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	_, blockedErr := locker.Lock(ctx, "orders-value")
+	acquired := make(chan struct{})
+	go func() {
+		unlock, err := locker.Lock(context.Background(), "orders-value")
+		assert.NoError(t, err)
+		unlock()
+		close(acquired)
+	}()
+	unlock()
+
+	// Assert
+	require.NoError(t, otherErr)
+	otherUnlock()
+	assert.ErrorIs(t, blockedErr, context.DeadlineExceeded)
+	select {
+	case <-acquired:
+	case <-time.After(5 * time.Second):
+		t.Fatal("lock wasn't acquired after being released")
+	}
+}
+
+func TestSchemaRegistryClient_SubjectLocker(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 1, Schema: testSchema1})
+	}))
+	defer server.Close()
+	locker := &recordingLocker{}
+	srClient := NewClient(server.URL, WithSubjectLocker(locker), WithSubjectPrefix("team-a."))
+
+	_, err := srClient.CreateSchema(context.Background(), "orders-value", testSchema1, Avro)
+	require.NoError(t, err)
+	_, err = srClient.GetLatestSchema(context.Background(), "orders-value")
+	require.NoError(t, err)
+
+	assert.Equal(t, []string{"lock team-a.orders-value", "unlock team-a.orders-value"}, locker.events)
+}
@@ -0,0 +1,25 @@
+package srclient
+
+// SubjectNameStrategy derives the subject a schema is registered
+// under from the topic, whether it's used for the record key or
+// value, and the fully qualified name of the record.
+type SubjectNameStrategy func(topic string, key bool, recordName string) string
+
+// TopicNameStrategy uses "<topic>-key" or "<topic>-value" as subject,
+// it is the default strategy of Confluent's serializers.
+func TopicNameStrategy(topic string, key bool, _ string) string {
+	if key {
+		return topic + "-key"
+	}
+	return topic + "-value"
+}
+
+// RecordNameStrategy uses the fully qualified record name as subject.
+func RecordNameStrategy(_ string, _ bool, recordName string) string {
+	return recordName
+}
+
+// TopicRecordNameStrategy uses "<topic>-<fully qualified record name>" as subject.
+func TopicRecordNameStrategy(topic string, _ bool, recordName string) string {
+	return topic + "-" + recordName
+}
@@ -0,0 +1,73 @@
+package srclient
+
+import "strings"
+
+// WithSubjectPrefix confines the client to the subjects starting with
+// the prefix, so a multi-tenant platform can hand it to a team without
+// giving it access to the other teams' subjects. The prefix is added to
+// every subject given to the client, including the subjects of schema
+// references, and removed from the subjects it returns. Listings only
+// return the subjects of the prefix. References to subjects outside
+// the prefix are returned unchanged, but can't be resolved through the
+// client.
+func WithSubjectPrefix(prefix string) Option {
+	return func(options *clientOptions) {
+		options.subjectPrefix = prefix
+	}
+}
+
+// prefixed returns the name of the subject in the registry.
+func (client *SchemaRegistryClient) prefixed(subject string) string {
+	return client.subjectPrefix + subject
+}
+
+// prefixedReferences returns the references with the names of their
+// subjects in the registry.
+func (client *SchemaRegistryClient) prefixedReferences(references []Reference) []Reference {
+	if client.subjectPrefix == "" || len(references) == 0 {
+		return references
+	}
+	prefixed := make([]Reference, len(references))
+	for i, reference := range references {
+		reference.Subject = client.prefixed(reference.Subject)
+		prefixed[i] = reference
+	}
+	return prefixed
+}
+
+// unprefixedReferences removes the prefix from the subjects of the
+// references read from the registry.
+func (client *SchemaRegistryClient) unprefixedReferences(references []Reference) []Reference {
+	if client.subjectPrefix == "" || len(references) == 0 {
+		return references
+	}
+	unprefixed := make([]Reference, len(references))
+	for i, reference := range references {
+		reference.Subject = strings.TrimPrefix(reference.Subject, client.subjectPrefix)
+		unprefixed[i] = reference
+	}
+	return unprefixed
+}
+
+// unprefixedSubjects keeps the subjects of the prefix, without it.
+func (client *SchemaRegistryClient) unprefixedSubjects(subjects []string) []string {
+	if client.subjectPrefix == "" {
+		return subjects
+	}
+	unprefixed := make([]string, 0, len(subjects))
+	for _, subject := range subjects {
+		if subject, ok := client.unprefixed(subject); ok {
+			unprefixed = append(unprefixed, subject)
+		}
+	}
+	return unprefixed
+}
+
+// unprefixed returns the name of a registry subject for the client and
+// whether it has the prefix.
+func (client *SchemaRegistryClient) unprefixed(subject string) (string, bool) {
+	if !strings.HasPrefix(subject, client.subjectPrefix) {
+		return subject, false
+	}
+	return strings.TrimPrefix(subject, client.subjectPrefix), true
+}
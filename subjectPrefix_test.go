@@ -0,0 +1,88 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaRegistryClient_SubjectPrefix(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	var lock sync.Mutex
+	var paths []string
+	var registered schemaRequest
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		lock.Lock()
+		paths = append(paths, req.Method+" "+req.URL.Path)
+		lock.Unlock()
+		switch {
+		case req.Method == http.MethodDelete:
+			_ = json.NewEncoder(rw).Encode(1)
+		case req.URL.Path == "/subjects":
+			_ = json.NewEncoder(rw).Encode([]string{"team-a.orders-value", "team-b.orders-value", "team-a.payments-value"})
+		case req.URL.Path == "/subjects/team-a.orders-value/versions":
+			body, _ := io.ReadAll(req.Body)
+			_ = json.Unmarshal(body, &registered)
+			_ = json.NewEncoder(rw).Encode(schemaResponse{ID: 2})
+		default:
+			_ = json.NewEncoder(rw).Encode(schemaResponse{
+				Subject: "team-a.orders-value", Version: 1, ID: 2, Schema: testSchema1,
+				References: []Reference{{Name: "customer", Subject: "team-a.customer-value", Version: 1}},
+			})
+		}
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithSubjectPrefix("team-a."), WithCaching(false))
+
+	// Act
+	subjects, subjectsErr := srClient.GetSubjects(context.Background())
+	_, createErr := srClient.CreateSchema(context.Background(), "orders-value", testSchema1, Avro,
+		Reference{Name: "customer", Subject: "customer-value", Version: 1})
+	latest, latestErr := srClient.GetLatestSchema(context.Background(), "orders-value")
+	_, deleteErr := srClient.DeleteSubjectByVersion(context.Background(), "orders-value", 1, false)
+
+	// Assert
+	require.NoError(t, subjectsErr)
+	require.NoError(t, createErr)
+	require.NoError(t, latestErr)
+	require.NoError(t, deleteErr)
+	assert.Equal(t, []string{"orders-value", "payments-value"}, subjects)
+	assert.Equal(t, []Reference{{Name: "customer", Subject: "team-a.customer-value", Version: 1}}, registered.References)
+	assert.Equal(t, []Reference{{Name: "customer", Subject: "customer-value", Version: 1}}, latest.References())
+	assert.Equal(t, []string{
+		"GET /subjects",
+		"POST /subjects/team-a.orders-value/versions",
+		"GET /schemas/ids/2",
+		"GET /subjects/team-a.orders-value/versions/latest",
+		"DELETE /subjects/team-a.orders-value/versions/1",
+	}, paths)
+}
+
+func TestSchemaRegistryClient_SubjectPrefixSearch(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		_ = json.NewEncoder(rw).Encode([]schemaResponse{
+			{Subject: "team-a.orders-value", Version: 1, ID: 1, Schema: testSchema1},
+			{Subject: "team-b.orders-value", Version: 1, ID: 2, Schema: testSchema1},
+		})
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithSubjectPrefix("team-a."))
+
+	matches, err := srClient.SearchSchemas(context.Background(), func(subject string, schema *Schema) bool {
+		return true
+	})
+
+	require.NoError(t, err)
+	require.Len(t, matches, 1)
+	assert.Equal(t, "orders-value", matches[0].Subject)
+	assert.Equal(t, 1, matches[0].Schema.ID())
+}
@@ -0,0 +1,100 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+)
+
+const (
+	schemaVersionsByID = "/schemas/ids/%d/versions"
+	schemaSubjectsByID = "/schemas/ids/%d/subjects"
+)
+
+// SubjectVersion is a version of a subject registered with a schema ID.
+type SubjectVersion struct {
+	Subject string `json:"subject"`
+	Version int    `json:"version"`
+}
+
+// WithSubjectVersions makes GetSchema fetch the subject versions using
+// the schema ID from /schemas/ids/{id}/versions, the registry answering
+// GET /schemas/ids/{id} without a version. They are returned by
+// SubjectVersions and tell KnownVersion the version of the schema.
+// Registries without the endpoint leave them unset.
+func WithSubjectVersions(enabled bool) Option {
+	return func(options *clientOptions) {
+		options.subjectVersions = enabled
+	}
+}
+
+// SubjectVersions returns the subject versions registered with the ID of
+// the schema, they are nil unless the schema was fetched by ID with
+// WithSubjectVersions.
+func (schema *Schema) SubjectVersions() []SubjectVersion {
+	return schema.subjectVersions
+}
+
+// KnownVersion returns the version of the schema and whether it is
+// known. Schemas fetched by ID have no version, Version returning zero,
+// unless the subject versions of the ID tell the version of its subject,
+// or of its only subject version when it has no subject.
+func (schema *Schema) KnownVersion() (int, bool) {
+	if schema.version > 0 {
+		return schema.version, true
+	}
+	for _, subjectVersion := range schema.subjectVersions {
+		if subjectVersion.Subject == schema.subject {
+			return subjectVersion.Version, true
+		}
+	}
+	if schema.subject == "" && len(schema.subjectVersions) == 1 {
+		return schema.subjectVersions[0].Version, true
+	}
+	return 0, false
+}
+
+// GetSchemaVersionsByID returns the subject versions registered with the
+// schema ID, to find out what a consumer only seeing the ID on the wire
+// is reading. The ones the client can't read are left out.
+func (client *SchemaRegistryClient) GetSchemaVersionsByID(ctx context.Context, schemaID int) ([]SubjectVersion, error) {
+	resp, err := client.httpRequest(ctx, "GET", fmt.Sprintf(schemaVersionsByID, schemaID), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subjectVersions []SubjectVersion
+	if err := json.Unmarshal(resp, &subjectVersions); err != nil {
+		return nil, err
+	}
+	readable := make([]SubjectVersion, 0, len(subjectVersions))
+	for _, subjectVersion := range subjectVersions {
+		subject, ok := client.unprefixed(subjectVersion.Subject)
+		if !ok || !client.accessPolicy.allows(subject, false) {
+			continue
+		}
+		readable = append(readable, SubjectVersion{Subject: subject, Version: subjectVersion.Version})
+	}
+	return readable, nil
+}
+
+// GetSubjectsBySchemaID returns the subjects registered with the schema
+// ID, to find every subject still using a schema before deleting it.
+// The soft deleted ones are included when deleted is set, and the ones
+// the client can't read are left out.
+func (client *SchemaRegistryClient) GetSubjectsBySchemaID(ctx context.Context, schemaID int, deleted bool) ([]string, error) {
+	uri := fmt.Sprintf(schemaSubjectsByID, schemaID)
+	if deleted {
+		uri += "?deleted=true"
+	}
+	resp, err := client.httpRequest(ctx, "GET", uri, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var subjects = []string{}
+	if err := json.Unmarshal(resp, &subjects); err != nil {
+		return nil, err
+	}
+	return client.readableSubjects(client.unprefixedSubjects(subjects)), nil
+}
@@ -0,0 +1,238 @@
+package srclient
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWithSubjectVersions(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		status   int
+		body     string
+		expected []SubjectVersion
+		version  int
+		known    bool
+	}{
+		"subject versions": {
+			status:   http.StatusOK,
+			body:     `[{"subject": "team-a.orders-value", "version": 3}, {"subject": "team-b.orders-value", "version": 1}]`,
+			expected: []SubjectVersion{{Subject: "orders-value", Version: 3}},
+			version:  3,
+			known:    true,
+		},
+		"registry without the endpoint": {
+			status: http.StatusNotFound,
+			body:   `<html>Not Found</html>`,
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				switch req.URL.Path {
+				case "/schemas/ids/7":
+					_, _ = rw.Write([]byte(`{"schema": "\"string\""}`))
+				case "/schemas/ids/7/versions":
+					rw.WriteHeader(testData.status)
+					_, _ = rw.Write([]byte(testData.body))
+				default:
+					rw.WriteHeader(http.StatusNotFound)
+				}
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL, WithSubjectPrefix("team-a."), WithSubjectVersions(true))
+
+			// Act
+			schema, err := srClient.GetSchema(context.Background(), 7)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, 0, schema.Version())
+			assert.Equal(t, testData.expected, schema.SubjectVersions())
+			version, known := schema.KnownVersion()
+			assert.Equal(t, testData.version, version)
+			assert.Equal(t, testData.known, known)
+		})
+	}
+}
+
+func TestSchema_KnownVersion(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]struct {
+		schema  *Schema
+		version int
+		known   bool
+	}{
+		"version": {
+			schema:  &Schema{version: 2},
+			version: 2,
+			known:   true,
+		},
+		"fetched by id": {
+			schema: &Schema{},
+		},
+		"version of the subject": {
+			schema:  &Schema{subject: "b", subjectVersions: []SubjectVersion{{Subject: "a", Version: 1}, {Subject: "b", Version: 4}}},
+			version: 4,
+			known:   true,
+		},
+		"single subject version": {
+			schema:  &Schema{subjectVersions: []SubjectVersion{{Subject: "a", Version: 5}}},
+			version: 5,
+			known:   true,
+		},
+		"several subject versions": {
+			schema: &Schema{subjectVersions: []SubjectVersion{{Subject: "a", Version: 1}, {Subject: "b", Version: 4}}},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Act
+			version, known := testData.schema.KnownVersion()
+
+			// Assert
+			assert.Equal(t, testData.version, version)
+			assert.Equal(t, testData.known, known)
+		})
+	}
+}
+
+func TestSchemaRegistryClient_GetSchemaVersionsByID(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path != "/schemas/ids/7/versions" {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+			return
+		}
+		_, _ = rw.Write([]byte(`[{"subject": "orders-value", "version": 3}, {"subject": "payments-value", "version": 1}]`))
+	}))
+	defer server.Close()
+	srClient := NewClient(server.URL, WithAccessPolicy(AccessPolicy{Deny: []string{"payments-value"}}))
+
+	// Act
+	subjectVersions, err := srClient.GetSchemaVersionsByID(context.Background(), 7)
+	_, missingErr := srClient.GetSchemaVersionsByID(context.Background(), 8)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []SubjectVersion{{Subject: "orders-value", Version: 3}}, subjectVersions)
+	assert.True(t, isNotFoundError(missingErr))
+}
+
+func TestMockSchemaRegistryClient_GetSchemaVersionsByID(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+	schema, _ := registry.CreateSchema(context.Background(), "orders-value", testSchema1, Avro)
+	_, _ = registry.SetSchema(context.Background(), schema.ID(), "archive-value", testSchema1, Avro, 4)
+
+	// Act
+	subjectVersions, err := registry.GetSchemaVersionsByID(context.Background(), schema.ID())
+	_, missingErr := registry.GetSchemaVersionsByID(context.Background(), 42)
+
+	// Assert
+	require.NoError(t, err)
+	assert.Equal(t, []SubjectVersion{{Subject: "archive-value", Version: 4}, {Subject: "orders-value", Version: 1}}, subjectVersions)
+	assert.ErrorIs(t, missingErr, errSchemaNotFound)
+}
+
+func TestSchemaRegistryClient_GetSubjectsBySchemaID(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		deleted  bool
+		expected []string
+	}{
+		"subjects": {
+			expected: []string{"orders-value"},
+		},
+		"including deleted": {
+			deleted:  true,
+			expected: []string{"archive-value", "orders-value"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				if req.URL.Path != "/schemas/ids/7/subjects" {
+					rw.WriteHeader(http.StatusNotFound)
+					_, _ = rw.Write([]byte(`{"error_code": 40403, "message": "Schema not found"}`))
+					return
+				}
+				if req.URL.Query().Get("deleted") == "true" {
+					_, _ = rw.Write([]byte(`["team-a.archive-value", "team-a.orders-value", "team-b.orders-value"]`))
+					return
+				}
+				_, _ = rw.Write([]byte(`["team-a.orders-value", "team-b.orders-value"]`))
+			}))
+			defer server.Close()
+			srClient := NewClient(server.URL, WithSubjectPrefix("team-a."))
+
+			// Act
+			subjects, err := srClient.GetSubjectsBySchemaID(context.Background(), 7, testData.deleted)
+			_, missingErr := srClient.GetSubjectsBySchemaID(context.Background(), 8, testData.deleted)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, subjects)
+			assert.True(t, isNotFoundError(missingErr))
+		})
+	}
+}
+
+func TestMockSchemaRegistryClient_GetSubjectsBySchemaID(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		deleted  bool
+		expected []string
+	}{
+		"subjects": {
+			expected: []string{"orders-value"},
+		},
+		"including deleted": {
+			deleted:  true,
+			expected: []string{"archive-value", "orders-value"},
+		},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			registry := CreateMockSchemaRegistryClient("http://localhost:8081")
+			schema, _ := registry.CreateSchema(context.Background(), "orders-value", testSchema1, Avro)
+			_, _ = registry.SetSchema(context.Background(), schema.ID(), "archive-value", testSchema1, Avro, 1)
+			_, _ = registry.CreateSchema(context.Background(), "payments-value", testSchema2, Avro)
+			_, _ = registry.DeleteSubject(context.Background(), "archive-value", false)
+
+			// Act
+			subjects, err := registry.GetSubjectsBySchemaID(context.Background(), schema.ID(), testData.deleted)
+			_, missingErr := registry.GetSubjectsBySchemaID(context.Background(), 42, testData.deleted)
+
+			// Assert
+			require.NoError(t, err)
+			assert.Equal(t, testData.expected, subjects)
+			assert.ErrorIs(t, missingErr, errSchemaNotFound)
+		})
+	}
+}
@@ -0,0 +1,146 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+)
+
+// confluentTags is the property holding inline tags in Avro and JSON schemas.
+const confluentTags = "confluent:tags"
+
+// TagViolation reports a tag declared on a referenced schema, directly
+// or through its own references, which the referencing schema drops.
+type TagViolation struct {
+	Subject   string
+	Version   int
+	Reference Reference
+	Tag       string
+}
+
+func (v TagViolation) String() string {
+	return fmt.Sprintf("%s version %d drops tag %s of reference %s (%s version %d)",
+		v.Subject, v.Version, v.Tag, v.Reference.Name, v.Reference.Subject, v.Reference.Version)
+}
+
+// CheckTagPropagation verifies that the latest schema of the subject,
+// and every schema it references, declare the tags of the schemas they
+// reference, so that governance tags such as PII are not lost in
+// composite schemas. Tags are read from the schema metadata and from
+// the confluent:tags properties of Avro and JSON schemas. When tags are
+// given only those are checked.
+func CheckTagPropagation(ctx context.Context, client ISchemaRegistryClient, subject string, tags ...string) ([]TagViolation, error) {
+	schema, err := client.GetLatestSchema(ctx, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	checker := tagChecker{
+		client:    client,
+		effective: make(map[Reference]map[string]bool),
+	}
+	if len(tags) > 0 {
+		checker.only = make(map[string]bool, len(tags))
+		for _, tag := range tags {
+			checker.only[tag] = true
+		}
+	}
+	if _, err := checker.check(ctx, subject, schema); err != nil {
+		return nil, err
+	}
+	return checker.violations, nil
+}
+
+type tagChecker struct {
+	client     ISchemaRegistryClient
+	only       map[string]bool
+	effective  map[Reference]map[string]bool
+	violations []TagViolation
+}
+
+// check reports the violations of the schema and returns its effective
+// tags, the ones it declares along with the ones of its references.
+func (checker *tagChecker) check(ctx context.Context, subject string, schema *Schema) (map[string]bool, error) {
+	own := schemaTags(schema)
+	effective := make(map[string]bool, len(own))
+	for tag := range own {
+		effective[tag] = true
+	}
+
+	for _, reference := range schema.References() {
+		referenced, ok := checker.effective[reference]
+		if !ok {
+			referencedSchema, err := checker.client.GetSchemaByVersion(ctx, reference.Subject, reference.Version)
+			if err != nil {
+				return nil, fmt.Errorf("reference %s: %w", reference.Name, err)
+			}
+			if referenced, err = checker.check(ctx, reference.Subject, referencedSchema); err != nil {
+				return nil, err
+			}
+			checker.effective[reference] = referenced
+		}
+
+		var dropped []string
+		for tag := range referenced {
+			effective[tag] = true
+			if !own[tag] && (checker.only == nil || checker.only[tag]) {
+				dropped = append(dropped, tag)
+			}
+		}
+		sort.Strings(dropped)
+		for _, tag := range dropped {
+			checker.violations = append(checker.violations, TagViolation{
+				Subject:   subject,
+				Version:   schema.Version(),
+				Reference: reference,
+				Tag:       tag,
+			})
+		}
+	}
+	return effective, nil
+}
+
+// schemaTags collects the tags declared by the schema metadata and
+// inline by Avro and JSON schemas.
+func schemaTags(schema *Schema) map[string]bool {
+	tags := make(map[string]bool)
+	if metadata := schema.Metadata(); metadata != nil {
+		for _, pathTags := range metadata.Tags {
+			for _, tag := range pathTags {
+				tags[tag] = true
+			}
+		}
+	}
+
+	if schemaTypeOf(schema) == Protobuf {
+		return tags
+	}
+	var parsed interface{}
+	if err := json.Unmarshal([]byte(schema.Schema()), &parsed); err == nil {
+		collectInlineTags(parsed, tags)
+	}
+	return tags
+}
+
+func collectInlineTags(node interface{}, tags map[string]bool) {
+	switch typed := node.(type) {
+	case []interface{}:
+		for _, item := range typed {
+			collectInlineTags(item, tags)
+		}
+	case map[string]interface{}:
+		for key, value := range typed {
+			if key == confluentTags {
+				inline, _ := value.([]interface{})
+				for _, tag := range inline {
+					if name, ok := tag.(string); ok {
+						tags[name] = true
+					}
+				}
+				continue
+			}
+			collectInlineTags(value, tags)
+		}
+	}
+}
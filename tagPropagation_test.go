@@ -0,0 +1,68 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckTagPropagation(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	addressRef := Reference{Name: "com.shop.Address", Subject: "address", Version: 1}
+	customerRef := Reference{Name: "com.shop.Customer", Subject: "customer", Version: 2}
+	productRef := Reference{Name: "com.shop.Product", Subject: "product", Version: 1}
+	responses := map[string]schemaResponse{
+		"/subjects/order/versions/latest": {
+			Subject: "order", Version: 3, ID: 4,
+			Schema:     `{"type": "record", "name": "Order", "fields": [{"name": "customer", "type": "com.shop.Customer"}, {"name": "product", "type": "com.shop.Product"}]}`,
+			References: []Reference{customerRef, productRef},
+		},
+		"/subjects/customer/versions/2": {
+			Subject: "customer", Version: 2, ID: 2,
+			Schema:     `{"type": "record", "name": "Customer", "fields": [{"name": "address", "type": "com.shop.Address"}]}`,
+			References: []Reference{addressRef},
+			Metadata:   &SchemaMetadata{Tags: map[string][]string{"Customer.address": {"PII"}}},
+		},
+		"/subjects/address/versions/1": {
+			Subject: "address", Version: 1, ID: 1,
+			Schema: `{"type": "record", "name": "Address", "fields": [{"name": "street", "type": "string", "confluent:tags": ["PII"]}]}`,
+		},
+		"/subjects/product/versions/1": {
+			Subject: "product", Version: 1, ID: 3,
+			Schema: `{"type": "record", "name": "Product", "fields": [{"name": "cost", "type": "double", "confluent:tags": ["INTERNAL"]}]}`,
+		},
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		uri := req.URL.String()
+		if uri == "/subjects/customer/versions/latest" {
+			uri = "/subjects/customer/versions/2"
+		}
+		_ = json.NewEncoder(rw).Encode(responses[uri])
+	}))
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	// Act
+	all, err := CheckTagPropagation(context.Background(), srClient, "order")
+	require.NoError(t, err)
+	pii, err := CheckTagPropagation(context.Background(), srClient, "order", "PII")
+	require.NoError(t, err)
+	require.NoError(t, err)
+	clean, err := CheckTagPropagation(context.Background(), srClient, "customer")
+
+	// Assert
+	assert.Equal(t, []TagViolation{
+		{Subject: "order", Version: 3, Reference: customerRef, Tag: "PII"},
+		{Subject: "order", Version: 3, Reference: productRef, Tag: "INTERNAL"},
+	}, all)
+	assert.Equal(t, []TagViolation{{Subject: "order", Version: 3, Reference: customerRef, Tag: "PII"}}, pii)
+	assert.Equal(t, "order version 3 drops tag PII of reference com.shop.Customer (customer version 2)", pii[0].String())
+	assert.NoError(t, err)
+	assert.Empty(t, clean)
+}
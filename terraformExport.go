@@ -0,0 +1,221 @@
+package srclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// TerraformFormat selects the syntax of the Terraform export.
+type TerraformFormat string
+
+const (
+	TerraformHCL  TerraformFormat = "HCL"
+	TerraformJSON TerraformFormat = "JSON"
+)
+
+var terraformNameRegex = regexp.MustCompile(`[^A-Za-z0-9_-]`)
+
+// TerraformExportOptions configures ExportTerraform.
+type TerraformExportOptions struct {
+	// Format is the output syntax, HCL by default.
+	Format TerraformFormat
+	// ClusterID is the Schema Registry cluster ID, when set import
+	// blocks are generated so that the resources are adopted by
+	// Terraform instead of being created again.
+	ClusterID string
+	// Subjects restricts the export, all the subjects are exported
+	// when it is empty.
+	Subjects []string
+}
+
+type terraformSchemaReference struct {
+	Name        string `json:"name"`
+	SubjectName string `json:"subject_name"`
+	Version     int    `json:"version"`
+}
+
+type terraformSchema struct {
+	SubjectName     string                     `json:"subject_name"`
+	Format          string                     `json:"format"`
+	Schema          string                     `json:"schema"`
+	SchemaReference []terraformSchemaReference `json:"schema_reference,omitempty"`
+}
+
+type terraformSubjectConfig struct {
+	SubjectName        string `json:"subject_name"`
+	CompatibilityLevel string `json:"compatibility_level"`
+}
+
+type terraformImport struct {
+	To string `json:"to"`
+	ID string `json:"id"`
+}
+
+type terraformState struct {
+	names          []string
+	schemas        map[string]terraformSchema
+	subjectConfigs map[string]terraformSubjectConfig
+	imports        []terraformImport
+}
+
+// ExportTerraform renders the live registry state, the latest schema of
+// every subject and the subject compatibility levels, as confluent_schema
+// and confluent_subject_config resources of the Confluent Terraform
+// provider. The registry endpoint and credentials are expected to be
+// configured on the provider.
+func ExportTerraform(ctx context.Context, client ISchemaRegistryClient, options TerraformExportOptions) ([]byte, error) {
+	subjectList := options.Subjects
+	if len(subjectList) == 0 {
+		var err error
+		if subjectList, err = client.GetSubjects(ctx); err != nil {
+			return nil, err
+		}
+	}
+	subjectList = append([]string(nil), subjectList...)
+	sort.Strings(subjectList)
+
+	state := terraformState{
+		schemas:        make(map[string]terraformSchema),
+		subjectConfigs: make(map[string]terraformSubjectConfig),
+	}
+	for _, subject := range subjectList {
+		schema, err := client.GetLatestSchema(ctx, subject)
+		if err != nil {
+			return nil, err
+		}
+		name := state.uniqueName(terraformName(subject))
+		state.names = append(state.names, name)
+
+		resource := terraformSchema{SubjectName: subject, Format: string(schemaTypeOf(schema)), Schema: schema.Schema()}
+		for _, reference := range schema.References() {
+			resource.SchemaReference = append(resource.SchemaReference, terraformSchemaReference{
+				Name:        reference.Name,
+				SubjectName: reference.Subject,
+				Version:     reference.Version,
+			})
+		}
+		state.schemas[name] = resource
+		if options.ClusterID != "" {
+			state.imports = append(state.imports, terraformImport{
+				To: "confluent_schema." + name,
+				ID: fmt.Sprintf("%s/%s/%d", options.ClusterID, subject, schema.ID()),
+			})
+		}
+
+		level, err := client.GetCompatibilityLevel(ctx, subject, false)
+		if isNotFoundError(err) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		state.subjectConfigs[name] = terraformSubjectConfig{SubjectName: subject, CompatibilityLevel: level.String()}
+		if options.ClusterID != "" {
+			state.imports = append(state.imports, terraformImport{
+				To: "confluent_subject_config." + name,
+				ID: fmt.Sprintf("%s/%s", options.ClusterID, subject),
+			})
+		}
+	}
+
+	switch options.Format {
+	case TerraformHCL, "":
+		return state.hcl(), nil
+	case TerraformJSON:
+		return state.json()
+	default:
+		return nil, fmt.Errorf("invalid Terraform format %q, valid values are HCL or JSON", options.Format)
+	}
+}
+
+func (state terraformState) hcl() []byte {
+	var buf bytes.Buffer
+	for _, name := range state.names {
+		schema := state.schemas[name]
+		fmt.Fprintf(&buf, "resource \"confluent_schema\" %q {\n", name)
+		fmt.Fprintf(&buf, "  subject_name = %s\n", terraformString(schema.SubjectName))
+		fmt.Fprintf(&buf, "  format       = %s\n", terraformString(schema.Format))
+		fmt.Fprintf(&buf, "  schema       = %s\n", terraformString(schema.Schema))
+		for _, reference := range schema.SchemaReference {
+			buf.WriteString("\n  schema_reference {\n")
+			fmt.Fprintf(&buf, "    name         = %s\n", terraformString(reference.Name))
+			fmt.Fprintf(&buf, "    subject_name = %s\n", terraformString(reference.SubjectName))
+			fmt.Fprintf(&buf, "    version      = %d\n", reference.Version)
+			buf.WriteString("  }\n")
+		}
+		buf.WriteString("}\n\n")
+
+		if config, ok := state.subjectConfigs[name]; ok {
+			fmt.Fprintf(&buf, "resource \"confluent_subject_config\" %q {\n", name)
+			fmt.Fprintf(&buf, "  subject_name        = %s\n", terraformString(config.SubjectName))
+			fmt.Fprintf(&buf, "  compatibility_level = %s\n", terraformString(config.CompatibilityLevel))
+			buf.WriteString("}\n\n")
+		}
+	}
+	for _, imported := range state.imports {
+		buf.WriteString("import {\n")
+		fmt.Fprintf(&buf, "  to = %s\n", imported.To)
+		fmt.Fprintf(&buf, "  id = %s\n", terraformString(imported.ID))
+		buf.WriteString("}\n\n")
+	}
+	return bytes.TrimSuffix(buf.Bytes(), []byte("\n"))
+}
+
+func (state terraformState) json() ([]byte, error) {
+	resources := make(map[string]interface{})
+	schemas := make(map[string]terraformSchema, len(state.schemas))
+	for name, schema := range state.schemas {
+		schema.SubjectName = terraformEscape(schema.SubjectName)
+		schema.Schema = terraformEscape(schema.Schema)
+		schemas[name] = schema
+	}
+	resources["confluent_schema"] = schemas
+	if len(state.subjectConfigs) > 0 {
+		resources["confluent_subject_config"] = state.subjectConfigs
+	}
+
+	document := map[string]interface{}{"resource": resources}
+	if len(state.imports) > 0 {
+		document["import"] = state.imports
+	}
+	return json.MarshalIndent(document, "", "  ")
+}
+
+// terraformString quotes a string for HCL, template sequences are
+// escaped so that schemas are taken literally.
+func terraformString(value string) string {
+	var buf bytes.Buffer
+	encoder := json.NewEncoder(&buf)
+	encoder.SetEscapeHTML(false)
+	_ = encoder.Encode(terraformEscape(value))
+	return strings.TrimSuffix(buf.String(), "\n")
+}
+
+func terraformEscape(value string) string {
+	return strings.NewReplacer("${", "$${", "%{", "%%{").Replace(value)
+}
+
+// uniqueName suffixes the name of a resource with a number when another
+// subject was already given it, such as a.b and a_b.
+func (state terraformState) uniqueName(name string) string {
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := state.schemas[unique]; !ok {
+			return unique
+		}
+		unique = fmt.Sprintf("%s_%d", name, i)
+	}
+}
+
+// terraformName turns a subject into a valid resource name.
+func terraformName(subject string) string {
+	name := terraformNameRegex.ReplaceAllString(subject, "_")
+	if name == "" || (name[0] >= '0' && name[0] <= '9') || name[0] == '-' {
+		name = "_" + name
+	}
+	return name
+}
@@ -0,0 +1,141 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func terraformTestServer(t *testing.T) *httptest.Server {
+	protobuf := Protobuf
+	return httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		var response interface{}
+		switch req.URL.String() {
+		case "/subjects":
+			response = []string{"orders-value", "1cupcake"}
+		case "/subjects/1cupcake/versions/latest":
+			response = schemaResponse{Subject: "1cupcake", Version: 1, ID: 1, Schema: `{"type":"string","doc":"${not a template}"}`}
+		case "/subjects/orders-value/versions/latest":
+			response = schemaResponse{
+				Subject: "orders-value", Version: 2, ID: 5, SchemaType: &protobuf,
+				Schema:     `import "cupcake.proto";`,
+				References: []Reference{{Name: "cupcake.proto", Subject: "1cupcake", Version: 1}},
+			}
+		case "/config/orders-value?defaultToGlobal=false":
+			response = configResponse{CompatibilityLevel: Full}
+		default:
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40408, "message": "Subject does not have subject-level compatibility configured"}`))
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(response)
+	}))
+}
+
+func TestExportTerraform_HCL(t *testing.T) {
+	t.Parallel()
+	server := terraformTestServer(t)
+	defer server.Close()
+
+	hcl, err := ExportTerraform(context.Background(), CreateSchemaRegistryClient(server.URL), TerraformExportOptions{ClusterID: "lsrc-123"})
+
+	require.NoError(t, err)
+	assert.Equal(t, `resource "confluent_schema" "_1cupcake" {
+  subject_name = "1cupcake"
+  format       = "AVRO"
+  schema       = "{\"type\":\"string\",\"doc\":\"$${not a template}\"}"
+}
+
+resource "confluent_schema" "orders-value" {
+  subject_name = "orders-value"
+  format       = "PROTOBUF"
+  schema       = "import \"cupcake.proto\";"
+
+  schema_reference {
+    name         = "cupcake.proto"
+    subject_name = "1cupcake"
+    version      = 1
+  }
+}
+
+resource "confluent_subject_config" "orders-value" {
+  subject_name        = "orders-value"
+  compatibility_level = "FULL"
+}
+
+import {
+  to = confluent_schema._1cupcake
+  id = "lsrc-123/1cupcake/1"
+}
+
+import {
+  to = confluent_schema.orders-value
+  id = "lsrc-123/orders-value/5"
+}
+
+import {
+  to = confluent_subject_config.orders-value
+  id = "lsrc-123/orders-value"
+}
+`, string(hcl))
+}
+
+func TestExportTerraform_JSON(t *testing.T) {
+	t.Parallel()
+	server := terraformTestServer(t)
+	defer server.Close()
+	srClient := CreateSchemaRegistryClient(server.URL)
+
+	output, err := ExportTerraform(context.Background(), srClient, TerraformExportOptions{Format: TerraformJSON, Subjects: []string{"orders-value"}})
+	require.NoError(t, err)
+
+	var document map[string]map[string]map[string]map[string]interface{}
+	require.NoError(t, json.Unmarshal(output, &document))
+	resources := document["resource"]
+	assert.Len(t, resources["confluent_schema"], 1)
+	assert.Equal(t, "PROTOBUF", resources["confluent_schema"]["orders-value"]["format"])
+	assert.Equal(t, "FULL", resources["confluent_subject_config"]["orders-value"]["compatibility_level"])
+	assert.NotContains(t, string(output), `"import"`)
+
+	_, err = ExportTerraform(context.Background(), srClient, TerraformExportOptions{Format: "YAML"})
+	assert.Error(t, err)
+}
+
+func TestExportTerraform_NameCollisions(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		if req.URL.Path == "/config/a.b" || req.URL.Path == "/config/a_b" || req.URL.Path == "/config/a_b_2" {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code": 40408, "message": "Subject does not have subject-level compatibility configured"}`))
+			return
+		}
+		_ = json.NewEncoder(rw).Encode(schemaResponse{Version: 1, ID: 1, Schema: `"string"`})
+	}))
+	defer server.Close()
+
+	// Act
+	document, err := ExportTerraform(context.Background(), CreateSchemaRegistryClient(server.URL), TerraformExportOptions{
+		Format:   TerraformJSON,
+		Subjects: []string{"a_b", "a.b", "a_b_2"},
+	})
+
+	// Assert
+	require.NoError(t, err)
+	var decoded struct {
+		Resource struct {
+			Schemas map[string]terraformSchema `json:"confluent_schema"`
+		} `json:"resource"`
+	}
+	require.NoError(t, json.Unmarshal(document, &decoded))
+	subjects := make(map[string]string)
+	for name, schema := range decoded.Resource.Schemas {
+		subjects[name] = schema.SubjectName
+	}
+	assert.Equal(t, map[string]string{"a_b": "a.b", "a_b_2": "a_b", "a_b_2_2": "a_b_2"}, subjects)
+}
@@ -0,0 +1,119 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"net/http"
+	"time"
+)
+
+type callTimeoutKey struct{}
+
+// WithCallTimeout returns a context making the calls of the client given
+// it time out after timeout instead of the timeout of the client, which
+// it may exceed, unlike a deadline set on the context.
+func WithCallTimeout(ctx context.Context, timeout time.Duration) context.Context {
+	return context.WithValue(ctx, callTimeoutKey{}, timeout)
+}
+
+func callTimeout(ctx context.Context) (time.Duration, bool) {
+	timeout, ok := ctx.Value(callTimeoutKey{}).(time.Duration)
+	return timeout, ok
+}
+
+// WithWriteTimeout sets the timeout of the requests changing the
+// registry, such as schema registrations, so that they can be given
+// longer than the lookups bound by the timeout of the client.
+func WithWriteTimeout(timeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.writeTimeout = timeout
+	}
+}
+
+// WithDialTimeout sets how long connecting to the registry can take.
+func WithDialTimeout(timeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.dialTimeout = timeout
+	}
+}
+
+// WithTLSHandshakeTimeout sets how long the TLS handshake with the
+// registry can take.
+func WithTLSHandshakeTimeout(timeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.tlsHandshakeTimeout = timeout
+	}
+}
+
+// WithResponseHeaderTimeout sets how long the registry can take to
+// answer a request once it is sent, reading the body is not included.
+func WithResponseHeaderTimeout(timeout time.Duration) Option {
+	return func(options *clientOptions) {
+		options.responseHeaderTimeout = timeout
+	}
+}
+
+// configureTransport returns a copy of the client using a copy of its
+// transport with the proxy, timeouts and limits of the options, or the
+// client itself when they are not set. The transport must be an
+// http.Transport then.
+func configureTransport(httpClient *http.Client, options *clientOptions) (*http.Client, error) {
+	if options.proxyURL == "" && options.dialTimeout <= 0 && options.tlsHandshakeTimeout <= 0 && options.responseHeaderTimeout <= 0 &&
+		options.maxResponseHeaderBytes <= 0 && options.writeBufferSize <= 0 && options.readBufferSize <= 0 {
+		return httpClient, nil
+	}
+
+	base := httpClient.Transport
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	transport, ok := base.(*http.Transport)
+	if !ok {
+		return httpClient, fmt.Errorf("can't configure a %T transport", base)
+	}
+	transport = transport.Clone()
+	if options.proxyURL != "" {
+		if err := setProxy(transport, options.proxyURL); err != nil {
+			return httpClient, err
+		}
+	}
+	if options.dialTimeout > 0 {
+		dialer := &net.Dialer{Timeout: options.dialTimeout, KeepAlive: 30 * time.Second}
+		transport.DialContext = dialer.DialContext
+	}
+	if options.tlsHandshakeTimeout > 0 {
+		transport.TLSHandshakeTimeout = options.tlsHandshakeTimeout
+	}
+	if options.responseHeaderTimeout > 0 {
+		transport.ResponseHeaderTimeout = options.responseHeaderTimeout
+	}
+	if options.maxResponseHeaderBytes > 0 {
+		transport.MaxResponseHeaderBytes = options.maxResponseHeaderBytes
+	}
+	if options.writeBufferSize > 0 {
+		transport.WriteBufferSize = options.writeBufferSize
+	}
+	if options.readBufferSize > 0 {
+		transport.ReadBufferSize = options.readBufferSize
+	}
+
+	configured := *httpClient
+	configured.Transport = transport
+	return &configured, nil
+}
+
+// requestClient returns the http.Client sending a request, a copy of the
+// one of the client when the call or the request has its own timeout.
+func (client *SchemaRegistryClient) requestClient(ctx context.Context, write bool) *http.Client {
+	timeout, ok := callTimeout(ctx)
+	if !ok && write && client.writeTimeout > 0 {
+		timeout, ok = client.writeTimeout, true
+	}
+	if !ok {
+		return client.httpClient
+	}
+	withTimeout := *client.httpClient
+	withTimeout.Timeout = timeout
+	return &withTimeout
+}
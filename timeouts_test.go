@@ -0,0 +1,109 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func slowRegistry(t *testing.T, delay time.Duration) *httptest.Server {
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		time.Sleep(delay)
+		rw.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+		fmt.Fprint(rw, `{"schema": "\"string\""}`)
+	}))
+	t.Cleanup(server.Close)
+	return server
+}
+
+func TestWithCallTimeout(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		timeout     time.Duration
+		expectedErr bool
+	}{
+		"longer than the client timeout":  {timeout: time.Second},
+		"shorter than the server latency": {timeout: 10 * time.Millisecond, expectedErr: true},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			server := slowRegistry(t, 100*time.Millisecond)
+			client := NewClient(server.URL, WithTimeout(50*time.Millisecond), WithCaching(false))
+
+			// Act
+			_, err := client.GetSchema(WithCallTimeout(context.Background(), testData.timeout), 1)
+
+			// Assert
+			if testData.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestWithWriteTimeout(t *testing.T) {
+	t.Parallel()
+	tests := map[string]struct {
+		writeTimeout time.Duration
+		expectedErr  bool
+	}{
+		"longer than the registration": {writeTimeout: time.Second},
+		"unset":                        {expectedErr: true},
+	}
+
+	for name, testData := range tests {
+		testData := testData
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+			// Arrange
+			server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+				rw.Header().Set("Content-Type", "application/vnd.schemaregistry.v1+json")
+				if req.Method == http.MethodPost {
+					time.Sleep(100 * time.Millisecond)
+					fmt.Fprint(rw, `{"id": 1}`)
+					return
+				}
+				fmt.Fprint(rw, `{"schema": "\"string\""}`)
+			}))
+			t.Cleanup(server.Close)
+			client := NewClient(server.URL, WithTimeout(50*time.Millisecond), WithWriteTimeout(testData.writeTimeout))
+
+			// Act
+			_, err := client.CreateSchema(context.Background(), "cupcakes-value", `"string"`, Avro)
+
+			// Assert
+			if testData.expectedErr {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+			}
+		})
+	}
+}
+
+func TestConfigureTransport(t *testing.T) {
+	t.Parallel()
+	shared := &http.Client{Transport: &http.Transport{}}
+	options := clientOptions{tlsHandshakeTimeout: time.Second, responseHeaderTimeout: 2 * time.Second, dialTimeout: time.Second}
+
+	configured, err := configureTransport(shared, &options)
+
+	require.NoError(t, err)
+	transport := configured.Transport.(*http.Transport)
+	assert.Equal(t, time.Second, transport.TLSHandshakeTimeout)
+	assert.Equal(t, 2*time.Second, transport.ResponseHeaderTimeout)
+	assert.NotNil(t, transport.DialContext)
+	assert.Zero(t, shared.Transport.(*http.Transport).TLSHandshakeTimeout)
+}
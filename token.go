@@ -1,7 +1,107 @@
 package srclient
 
-import "context"
+import (
+	"context"
+	"errors"
+	"os"
+	"strings"
+	"sync"
+	"time"
+)
 
 type TokenProvider interface {
 	ObtainToken(ctx context.Context) (string, error)
 }
+
+// ExpiringTokenProvider is a source of tokens valid until a known time,
+// such as an OAuth token endpoint.
+type ExpiringTokenProvider interface {
+	ObtainExpiringToken(ctx context.Context) (token string, expiresAt time.Time, err error)
+}
+
+// StaticToken is a token which never changes.
+type StaticToken string
+
+func (token StaticToken) ObtainToken(ctx context.Context) (string, error) {
+	return string(token), nil
+}
+
+// FileTokenProvider reads the token from a file, reading it again
+// whenever the file changes. It suits the projected service account
+// tokens Kubernetes rotates in place.
+type FileTokenProvider struct {
+	path    string
+	lock    sync.Mutex
+	token   string
+	modTime time.Time
+	size    int64
+}
+
+// NewFileTokenProvider returns a provider of the token in the file at path.
+func NewFileTokenProvider(path string) *FileTokenProvider {
+	return &FileTokenProvider{path: path}
+}
+
+// ObtainToken returns the content of the file without surrounding
+// whitespace, it fails if the file is missing or empty.
+func (provider *FileTokenProvider) ObtainToken(ctx context.Context) (string, error) {
+	info, err := os.Stat(provider.path)
+	if err != nil {
+		return "", err
+	}
+
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+	if provider.token != "" && info.ModTime().Equal(provider.modTime) && info.Size() == provider.size {
+		return provider.token, nil
+	}
+	content, err := os.ReadFile(provider.path)
+	if err != nil {
+		return "", err
+	}
+	token := strings.TrimSpace(string(content))
+	if token == "" {
+		return "", errors.New("empty token file " + provider.path)
+	}
+	provider.token, provider.modTime, provider.size = token, info.ModTime(), info.Size()
+	return token, nil
+}
+
+// CachingTokenProvider caches the tokens of an ExpiringTokenProvider,
+// obtaining a new one when the cached token is about to expire.
+type CachingTokenProvider struct {
+	source       ExpiringTokenProvider
+	refreshAhead time.Duration
+	clock        Clock
+	lock         sync.Mutex
+	token        string
+	expiresAt    time.Time
+}
+
+// NewCachingTokenProvider returns a provider caching the tokens of the
+// source, refreshing them refreshAhead before they expire.
+func NewCachingTokenProvider(source ExpiringTokenProvider, refreshAhead time.Duration) *CachingTokenProvider {
+	return &CachingTokenProvider{source: source, refreshAhead: refreshAhead, clock: systemClock{}}
+}
+
+// ObtainToken returns the cached token until it is due for a refresh.
+// If the refresh fails, the cached token is returned for as long as it
+// hasn't expired.
+func (provider *CachingTokenProvider) ObtainToken(ctx context.Context) (string, error) {
+	provider.lock.Lock()
+	defer provider.lock.Unlock()
+
+	now := provider.clock.Now()
+	if provider.token != "" && now.Before(provider.expiresAt.Add(-provider.refreshAhead)) {
+		return provider.token, nil
+	}
+	token, expiresAt, err := provider.source.ObtainExpiringToken(ctx)
+	if err != nil {
+		if provider.token != "" && now.Before(provider.expiresAt) {
+			return provider.token, nil
+		}
+		return "", err
+	}
+	provider.token, provider.expiresAt = token, expiresAt
+	return token, nil
+}
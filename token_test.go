@@ -0,0 +1,141 @@
+package srclient
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type fakeTokenSource struct {
+	tokens []string
+	ttl    time.Duration
+	clock  Clock
+	err    error
+	calls  int
+}
+
+func (source *fakeTokenSource) ObtainExpiringToken(ctx context.Context) (string, time.Time, error) {
+	source.calls++
+	if source.err != nil {
+		return "", time.Time{}, source.err
+	}
+	token := source.tokens[0]
+	source.tokens = source.tokens[1:]
+	return token, source.clock.Now().Add(source.ttl), nil
+}
+
+func TestStaticToken(t *testing.T) {
+	t.Parallel()
+	token, err := StaticToken("secret").ObtainToken(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, "secret", token)
+}
+
+func TestFileTokenProvider(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("first\n"), 0o600))
+	provider := NewFileTokenProvider(path)
+
+	// Act
+	first, firstErr := provider.ObtainToken(context.Background())
+	require.NoError(t, os.WriteFile(path, []byte("rotated-token\n"), 0o600))
+	rotated, rotatedErr := provider.ObtainToken(context.Background())
+	require.NoError(t, os.Remove(path))
+	_, missingErr := provider.ObtainToken(context.Background())
+
+	// Assert
+	assert.NoError(t, firstErr)
+	assert.Equal(t, "first", first)
+	assert.NoError(t, rotatedErr)
+	assert.Equal(t, "rotated-token", rotated)
+	assert.Error(t, missingErr)
+}
+
+func TestFileTokenProvider_EmptyFile(t *testing.T) {
+	t.Parallel()
+	path := filepath.Join(t.TempDir(), "token")
+	require.NoError(t, os.WriteFile(path, []byte("\n"), 0o600))
+
+	_, err := NewFileTokenProvider(path).ObtainToken(context.Background())
+
+	assert.Error(t, err)
+}
+
+func TestCachingTokenProvider(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	source := &fakeTokenSource{tokens: []string{"first", "second"}, ttl: 10 * time.Minute, clock: clock}
+	provider := NewCachingTokenProvider(source, time.Minute)
+	provider.clock = clock
+
+	// Act
+	first, err := provider.ObtainToken(context.Background())
+	require.NoError(t, err)
+	clock.Advance(8 * time.Minute)
+	cached, err := provider.ObtainToken(context.Background())
+	require.NoError(t, err)
+	clock.Advance(time.Minute)
+	refreshed, err := provider.ObtainToken(context.Background())
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, "first", first)
+	assert.Equal(t, "first", cached)
+	assert.Equal(t, "second", refreshed)
+	assert.Equal(t, 2, source.calls)
+}
+
+func TestCachingTokenProvider_RefreshFailure(t *testing.T) {
+	t.Parallel()
+	clock := NewManualClock(time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC))
+	source := &fakeTokenSource{tokens: []string{"first"}, ttl: 10 * time.Minute, clock: clock}
+	provider := NewCachingTokenProvider(source, time.Minute)
+	provider.clock = clock
+	_, err := provider.ObtainToken(context.Background())
+	require.NoError(t, err)
+	source.err = errors.New("token endpoint unavailable")
+
+	clock.Advance(9 * time.Minute)
+	beforeExpiry, beforeExpiryErr := provider.ObtainToken(context.Background())
+	clock.Advance(time.Minute)
+	_, expiredErr := provider.ObtainToken(context.Background())
+
+	assert.NoError(t, beforeExpiryErr)
+	assert.Equal(t, "first", beforeExpiry)
+	assert.Error(t, expiredErr)
+}
+
+type failingTokenProvider struct{}
+
+func (failingTokenProvider) ObtainToken(ctx context.Context) (string, error) {
+	return "", errors.New("no token")
+}
+
+func TestSchemaRegistryClient_TokenErrorReleasesCredentials(t *testing.T) {
+	t.Parallel()
+	srClient := NewClient("http://localhost:8081", WithBearerToken(failingTokenProvider{}))
+
+	_, err := srClient.GetSchema(context.Background(), 1)
+	require.Error(t, err)
+
+	done := make(chan struct{})
+	go func() {
+		srClient.SetCredentials("user", "secret")
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(5 * time.Second):
+		t.Fatal("SetCredentials blocked after a token error")
+	}
+}
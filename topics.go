@@ -0,0 +1,72 @@
+package srclient
+
+import (
+	"context"
+	"sort"
+	"strings"
+)
+
+// TopicSchemas pairs the key and value subjects of a topic, following
+// the TopicNameStrategy. Subjects and schemas are empty when the topic
+// has no schema for its key or value.
+type TopicSchemas struct {
+	Topic        string
+	KeySubject   string
+	Key          *Schema
+	ValueSubject string
+	Value        *Schema
+}
+
+// ListTopicsWithSchemas groups the "<topic>-key" and "<topic>-value"
+// subjects of the registry into topics along with their latest schemas,
+// sorted by topic, for topic-centric views. Subjects which don't follow
+// the TopicNameStrategy are left out.
+func ListTopicsWithSchemas(ctx context.Context, client ISchemaRegistryClient) ([]TopicSchemas, error) {
+	subjects, err := client.GetSubjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(subjects)
+
+	topics := make(map[string]*TopicSchemas)
+	var names []string
+	for _, subject := range subjects {
+		var topic string
+		key := strings.HasSuffix(subject, "-key")
+		switch {
+		case key:
+			topic = strings.TrimSuffix(subject, "-key")
+		case strings.HasSuffix(subject, "-value"):
+			topic = strings.TrimSuffix(subject, "-value")
+		default:
+			continue
+		}
+
+		schema, err := client.GetLatestSchema(ctx, subject)
+		if isNotFoundError(err) {
+			// Deleted since the subjects were listed
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+
+		entry, ok := topics[topic]
+		if !ok {
+			entry = &TopicSchemas{Topic: topic}
+			topics[topic] = entry
+			names = append(names, topic)
+		}
+		if key {
+			entry.KeySubject, entry.Key = subject, schema
+		} else {
+			entry.ValueSubject, entry.Value = subject, schema
+		}
+	}
+
+	sort.Strings(names)
+	result := make([]TopicSchemas, 0, len(names))
+	for _, name := range names {
+		result = append(result, *topics[name])
+	}
+	return result, nil
+}
@@ -0,0 +1,35 @@
+package srclient
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListTopicsWithSchemas(t *testing.T) {
+	t.Parallel()
+	// Arrange
+	srClient := CreateMockSchemaRegistryClient("mock://testingUrl")
+	for _, subject := range []string{"orders-value", "orders-key", "payments-value", "users-key", "com.shop.Customer"} {
+		_, err := srClient.CreateSchema(context.Background(), subject, `{"type": "record", "name": "`+subject[:3]+`", "fields": []}`, Avro)
+		require.NoError(t, err)
+	}
+
+	// Act
+	topics, err := ListTopicsWithSchemas(context.Background(), srClient)
+
+	// Assert
+	require.NoError(t, err)
+	require.Len(t, topics, 3)
+	assert.Equal(t, "orders", topics[0].Topic)
+	assert.Equal(t, "orders-key", topics[0].KeySubject)
+	assert.Equal(t, 2, topics[0].Key.ID())
+	assert.Equal(t, "orders-value", topics[0].ValueSubject)
+	assert.Equal(t, 1, topics[0].Value.ID())
+	assert.Equal(t, TopicSchemas{Topic: "payments", ValueSubject: "payments-value", Value: topics[1].Value}, topics[1])
+	assert.Equal(t, 3, topics[1].Value.ID())
+	assert.Equal(t, TopicSchemas{Topic: "users", KeySubject: "users-key", Key: topics[2].Key}, topics[2])
+	assert.Equal(t, 4, topics[2].Key.ID())
+}
@@ -0,0 +1,71 @@
+package srclient
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+const (
+	waitInitialBackoff = 100 * time.Millisecond
+	waitMaxBackoff     = 2 * time.Second
+)
+
+// WaitForSubject polls the registry until the subject is visible or the
+// timeout expires. Registries are eventually consistent behind the Kafka
+// topic storing schemas, so a subject registered on one node may take a
+// while to show up on the others.
+func (client *SchemaRegistryClient) WaitForSubject(ctx context.Context, subject string, timeout time.Duration) error {
+	return waitFor(ctx, client.getClock(), timeout, fmt.Sprintf("subject %s", subject), func(ctx context.Context) (bool, error) {
+		return client.SubjectExists(ctx, subject)
+	})
+}
+
+// WaitForSchemaID polls the registry until the schema ID is visible or the timeout expires.
+func (client *SchemaRegistryClient) WaitForSchemaID(ctx context.Context, schemaID int, timeout time.Duration) error {
+	return waitFor(ctx, client.getClock(), timeout, fmt.Sprintf("schema id %d", schemaID), func(ctx context.Context) (bool, error) {
+		_, err := client.GetSchema(ctx, schemaID)
+		if isNotFoundError(err) {
+			return false, nil
+		}
+		return err == nil, err
+	})
+}
+
+// waitFor calls check with an exponential backoff until it reports
+// true, returns an error or the timeout expires. The backoff and the
+// timeout follow the clock, the context deadline only bounds requests.
+func waitFor(ctx context.Context, clock Clock, timeout time.Duration, what string, check func(ctx context.Context) (bool, error)) error {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	deadline := clock.Now().Add(timeout)
+	backoff := waitInitialBackoff
+	for {
+		found, err := check(ctx)
+		if err != nil {
+			return err
+		}
+		if found {
+			return nil
+		}
+
+		remaining := deadline.Sub(clock.Now())
+		if remaining <= 0 {
+			return fmt.Errorf("waiting for %s: %w", what, context.DeadlineExceeded)
+		}
+		if backoff > remaining {
+			backoff = remaining
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("waiting for %s: %w", what, ctx.Err())
+		case <-clock.After(backoff):
+		}
+
+		backoff *= 2
+		if backoff > waitMaxBackoff {
+			backoff = waitMaxBackoff
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package srclient
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSchemaRegistryClient_WaitForSchemaID(t *testing.T) {
+	t.Parallel()
+	var calls int32
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		// The schema only becomes visible on the third call
+		if atomic.AddInt32(&calls, 1) < 3 {
+			rw.WriteHeader(http.StatusNotFound)
+			_, _ = rw.Write([]byte(`{"error_code":40403,"message":"Schema not found"}`))
+			return
+		}
+		response, _ := json.Marshal(schemaResponse{ID: 1, Schema: "payload"})
+		_, _ = rw.Write(response)
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	err := srClient.WaitForSchemaID(context.Background(), 1, 5*time.Second)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int32(3), atomic.LoadInt32(&calls))
+}
+
+func TestSchemaRegistryClient_WaitForSubjectTimesOut(t *testing.T) {
+	t.Parallel()
+	server := httptest.NewServer(http.HandlerFunc(func(rw http.ResponseWriter, req *http.Request) {
+		rw.WriteHeader(http.StatusNotFound)
+		_, _ = rw.Write([]byte(`{"error_code":40401,"message":"Subject not found"}`))
+	}))
+	defer server.Close()
+
+	srClient := CreateSchemaRegistryClient(server.URL)
+	err := srClient.WaitForSubject(context.Background(), "test1", 150*time.Millisecond)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
@@ -0,0 +1,71 @@
+package srclient
+
+import (
+	"encoding/binary"
+	"errors"
+)
+
+// magicByte prefixes every payload in Confluent's wire format,
+// it is followed by the 4 bytes big endian schema ID.
+const (
+	magicByte        = byte(0)
+	wireHeaderLength = 5
+)
+
+// ErrInvalidWireFormat is returned when a payload doesn't start with
+// the magic byte and schema ID of Confluent's wire format.
+var ErrInvalidWireFormat = errors.New("payload is not in schema registry wire format")
+
+// parseWireFormat splits a payload into its schema ID and body.
+func parseWireFormat(payload []byte) (int, []byte, error) {
+	if len(payload) < wireHeaderLength || payload[0] != magicByte {
+		return 0, nil, ErrInvalidWireFormat
+	}
+	return int(binary.BigEndian.Uint32(payload[1:wireHeaderLength])), payload[wireHeaderLength:], nil
+}
+
+// appendWireHeader appends the magic byte and schema ID to buf.
+func appendWireHeader(buf []byte, schemaID int) []byte {
+	header := [wireHeaderLength]byte{magicByte}
+	binary.BigEndian.PutUint32(header[1:], uint32(schemaID))
+	return append(buf, header[:]...)
+}
+
+// appendMessageIndexes appends the path to a Protobuf message within
+// its file, written after the schema ID of Protobuf payloads: the
+// zigzag varint count of indexes followed by the indexes. The common
+// path to the first message of the file is written as a single 0.
+func appendMessageIndexes(buf []byte, indexes []int) []byte {
+	if len(indexes) == 1 && indexes[0] == 0 {
+		return append(buf, 0)
+	}
+	var varint [binary.MaxVarintLen64]byte
+	buf = append(buf, varint[:binary.PutVarint(varint[:], int64(len(indexes)))]...)
+	for _, index := range indexes {
+		buf = append(buf, varint[:binary.PutVarint(varint[:], int64(index))]...)
+	}
+	return buf
+}
+
+// parseMessageIndexes splits the body of a Protobuf payload into its
+// message indexes and the serialized message.
+func parseMessageIndexes(body []byte) ([]int, []byte, error) {
+	count, read := binary.Varint(body)
+	if read <= 0 || count < 0 || count > int64(len(body)) {
+		return nil, nil, ErrInvalidWireFormat
+	}
+	body = body[read:]
+	if count == 0 {
+		return []int{0}, body, nil
+	}
+
+	indexes := make([]int, count)
+	for i := range indexes {
+		index, read := binary.Varint(body)
+		if read <= 0 || index < 0 {
+			return nil, nil, ErrInvalidWireFormat
+		}
+		indexes[i], body = int(index), body[read:]
+	}
+	return indexes, body, nil
+}